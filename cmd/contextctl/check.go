@@ -0,0 +1,117 @@
+package main
+
+import (
+	stdcontext "context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/kbrockhoff/terraform-provider-context/internal/datasource"
+	ctx "github.com/kbrockhoff/terraform-provider-context/pkg/context"
+)
+
+// Severities of findings
+const (
+	severityError   = "error"
+	severityWarning = "warning"
+)
+
+// contextOptions are a context and the provider settings it is generated with
+type contextOptions struct {
+	CloudProvider string
+	TagPrefix     string
+	// StrictMode turns the sensitivity requirements into errors, like the
+	// strict_mode of the provider
+	StrictMode bool
+	Config     *ctx.DataSourceConfig
+}
+
+// finding is an error or warning about a context, with the summary the
+// brockhoff_context data source reports it with
+type finding struct {
+	File     string `json:"file,omitempty"`
+	Field    string `json:"field,omitempty"`
+	Severity string `json:"severity"`
+	Summary  string `json:"summary"`
+	Message  string `json:"message"`
+}
+
+// diagnosticFields are the attributes of the ResolveContext diagnostics
+// that are not attribute checks
+var diagnosticFields = map[string]string{
+	"Failed to generate name prefix":  "name",
+	"Reserved word in name prefix":    "reserved_words",
+	"Invalid list_join_delimiter":     "list_join_delimiter",
+	"Large additional_tags":           "additional_tags",
+	"Large additional_data_tags":      "additional_data_tags",
+	"Compliance profile violation":    "compliance_profile",
+	"Sensitivity requirement not met": "sensitivity",
+}
+
+// evaluateContext resolves the context of opts with datasource.ResolveContext,
+// like the brockhoff_context data source does, and generates its name prefix
+// and tags. Unlike the data source, it reports every failed attribute check
+// instead of the first. The result is only valid when no finding is an
+// error.
+func evaluateContext(opts contextOptions) (ciResult, []finding) {
+	config := opts.Config
+	if err := ctx.ValidateCloudProvider(opts.CloudProvider); err != nil {
+		return ciResult{}, []finding{{Field: "cloud_provider", Severity: severityError, Summary: "Invalid cloud_provider", Message: err.Error()}}
+	}
+
+	// The provider settings other than cloud_provider, tag_prefix and
+	// strict_mode take their defaults
+	providerConfig := &datasource.ProviderConfig{
+		CloudProvider: opts.CloudProvider,
+		TagPrefix:     opts.TagPrefix,
+		StrictMode:    opts.StrictMode,
+	}
+
+	var findings []finding
+	datasource.ApplyContextDefaults(providerConfig, config)
+	for _, check := range datasource.ContextChecks(providerConfig) {
+		if err := check.Check(config); err != nil {
+			findings = append(findings, finding{Field: check.Field, Severity: severityError, Summary: check.ErrorSummary(), Message: err.Error()})
+		}
+	}
+	if len(findings) > 0 {
+		return ciResult{}, findings
+	}
+
+	resolved, diags := datasource.ResolveContext(stdcontext.Background(), providerConfig, config)
+	for _, d := range diags {
+		severity := severityWarning
+		if d.Severity() == diag.SeverityError {
+			severity = severityError
+		}
+		findings = append(findings, finding{Field: diagnosticFields[d.Summary()], Severity: severity, Summary: d.Summary(), Message: d.Detail()})
+	}
+	if diags.HasError() {
+		return ciResult{}, findings
+	}
+
+	for _, warning := range resolved.TagProcessor.Warnings {
+		findings = append(findings, finding{Severity: severityWarning, Summary: "Tag value sanitized", Message: warning})
+	}
+	if len(config.LegacyTagMap) > 0 && !resolved.TagProcessor.LegacyTagsActive() {
+		findings = append(findings, finding{Field: "legacy_tags_until", Severity: severityWarning, Summary: "Legacy tag migration ended",
+			Message: fmt.Sprintf("legacy_tags_until %s has passed, so the legacy_tag_map keys are no longer emitted. Remove legacy_tag_map and legacy_tags_until.", config.LegacyTagsUntil)})
+	}
+	for _, key := range slices.Sorted(maps.Keys(config.RawTags)) {
+		findings = append(findings, finding{Field: "raw_tags", Severity: severityWarning, Summary: "Unsanitized raw tag",
+			Message: fmt.Sprintf("raw_tags sets %s = %q verbatim, without the tag prefix, sanitization or validation, so the cloud provider may reject it at apply time. Keep raw_tags for vendor-mandated tags that additional_tags would change", key, config.RawTags[key])})
+	}
+
+	return ciResult{NamePrefix: resolved.NamePrefix, Tags: resolved.Tags, DataTags: resolved.DataTags}, findings
+}
+
+// firstError returns the first finding that is an error, if any
+func firstError(findings []finding) (finding, bool) {
+	for _, f := range findings {
+		if f.Severity == severityError {
+			return f, true
+		}
+	}
+	return finding{}, false
+}
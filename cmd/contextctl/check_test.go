@@ -0,0 +1,125 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	ctx "github.com/kbrockhoff/terraform-provider-context/pkg/context"
+)
+
+func TestEvaluateContext(t *testing.T) {
+	tests := []struct {
+		name       string
+		cloud      string
+		strict     bool
+		config     func(c *ctx.DataSourceConfig)
+		wantPrefix string
+		want       []finding
+	}{
+		{
+			name:       "valid",
+			wantPrefix: "ex-api-dev",
+		},
+		{
+			name:  "every invalid attribute",
+			cloud: "aws",
+			config: func(c *ctx.DataSourceConfig) {
+				c.Namespace = "Bad_NS"
+				c.Availability = "always"
+				c.ProductOwners = []string{"not-an-email"}
+			},
+			want: []finding{
+				{Field: "namespace", Severity: severityError, Summary: "Invalid namespace"},
+				{Field: "availability", Severity: severityError, Summary: "Invalid availability"},
+				{Field: "product_owners", Severity: severityError, Summary: "Invalid product_owners"},
+			},
+		},
+		{
+			name:   "missing lifecycle action",
+			config: func(c *ctx.DataSourceConfig) { c.EnvironmentType = "Ephemeral" },
+			want: []finding{
+				{Field: "lifecycle_action", Severity: severityError, Summary: "Missing lifecycle_action"},
+			},
+		},
+		{
+			name:   "tokenize fields without a key",
+			config: func(c *ctx.DataSourceConfig) { c.TokenizeFields = []string{"customer"} },
+			want: []finding{
+				{Field: "tokenize_fields", Severity: severityError, Summary: "Missing tokenization key"},
+			},
+		},
+		{
+			name:   "invalid cloud provider",
+			cloud:  "azure",
+			config: func(c *ctx.DataSourceConfig) { c.Namespace = "Bad_NS" },
+			want: []finding{
+				{Field: "cloud_provider", Severity: severityError, Summary: "Invalid cloud_provider"},
+			},
+		},
+		{
+			name:       "sensitivity warning",
+			config:     func(c *ctx.DataSourceConfig) { c.Sensitivity = "restricted" },
+			wantPrefix: "ex-api-dev",
+			want: []finding{
+				{Field: "sensitivity", Severity: severityWarning, Summary: "Sensitivity requirement not met"},
+			},
+		},
+		{
+			name:   "sensitivity error in strict mode",
+			strict: true,
+			config: func(c *ctx.DataSourceConfig) { c.Sensitivity = "restricted" },
+			want: []finding{
+				{Field: "sensitivity", Severity: severityError, Summary: "Sensitivity requirement not met"},
+			},
+		},
+		{
+			name:   "compliance profile",
+			config: func(c *ctx.DataSourceConfig) { c.ComplianceProfile = "pci"; c.CostCenter = "cc-100" },
+			want: []finding{
+				{Field: "compliance_profile", Severity: severityError, Summary: "Compliance profile violation"},
+				{Field: "compliance_profile", Severity: severityError, Summary: "Compliance profile violation"},
+				{Field: "compliance_profile", Severity: severityError, Summary: "Compliance profile violation"},
+				{Field: "compliance_profile", Severity: severityError, Summary: "Compliance profile violation"},
+			},
+		},
+		{
+			name:       "raw tags",
+			config:     func(c *ctx.DataSourceConfig) { c.RawTags = map[string]string{"Vendor": "acme"} },
+			wantPrefix: "ex-api-dev",
+			want: []finding{
+				{Field: "raw_tags", Severity: severityWarning, Summary: "Unsanitized raw tag"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := ctx.NewDataSourceConfig()
+			config.Namespace = "ex"
+			config.Name = "api"
+			config.Environment = "dev"
+			config.SourceRepoTagsEnabled = false
+			if tt.config != nil {
+				tt.config(config)
+			}
+			cloud := tt.cloud
+			if cloud == "" {
+				cloud = "dc"
+			}
+
+			result, findings := evaluateContext(contextOptions{CloudProvider: cloud, TagPrefix: "bc-", StrictMode: tt.strict, Config: config})
+			for i := range findings {
+				if findings[i].Message == "" {
+					t.Errorf("finding %d has no message", i)
+				}
+				findings[i].Message = ""
+			}
+			if !slices.Equal(findings, tt.want) {
+				t.Errorf("evaluateContext() findings = %+v, want %+v", findings, tt.want)
+			}
+			if result.NamePrefix != tt.wantPrefix {
+				t.Errorf("evaluateContext() name prefix = %q, want %q", result.NamePrefix, tt.wantPrefix)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	ctx "github.com/kbrockhoff/terraform-provider-context/pkg/context"
+)
+
+// ciResult is what the ci command exports, keyed by output name
+type ciResult struct {
+	NamePrefix string            `json:"name_prefix"`
+	Tags       map[string]string `json:"tags"`
+	DataTags   map[string]string `json:"data_tags"`
+}
+
+// outputs returns the job outputs of the result in export order. labels
+// holds the tags as key=value lines, the format of docker/metadata-action
+// and docker build --label.
+func (r ciResult) outputs() ([][2]string, error) {
+	tags, err := json.Marshal(r.Tags)
+	if err != nil {
+		return nil, err
+	}
+	dataTags, err := json.Marshal(r.DataTags)
+	if err != nil {
+		return nil, err
+	}
+	return [][2]string{
+		{"name_prefix", r.NamePrefix},
+		{"tags", string(tags)},
+		{"data_tags", string(dataTags)},
+		{"labels", strings.Join(ctx.ConvertTagsToKVPList(r.Tags), "\n")},
+	}, nil
+}
+
+// ci implements the ci command
+func ci(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("ci", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), `Usage: contextctl ci [flags]
+
+Generates the name prefix, tags and data tags of a context the way the
+brockhoff_context data source does, for pipelines that build artifacts before
+Terraform runs. They are written to standard output as JSON, appended to the
+GitHub Actions job outputs name_prefix, tags, data_tags and labels, and
+exported as the environment variables <env-prefix>NAME_PREFIX, TAGS,
+DATA_TAGS and LABELS. tags and data_tags are JSON objects; labels lists the
+tags as key=value lines.
+
+Flags:
+`)
+		fs.PrintDefaults()
+	}
+
+	var (
+		contextFile  string
+		opts         contextOptions
+		namespace    string
+		name         string
+		environment  string
+		githubOutput string
+		githubEnv    string
+		envPrefix    string
+	)
+	fs.StringVar(&contextFile, "context", "", "JSON, YAML or tfvars `file` of context values")
+	fs.StringVar(&opts.CloudProvider, "cloud", "dc", "cloud provider: dc, aws, az, gcp, oci, ibm, do, vul, ali or cv")
+	fs.StringVar(&opts.TagPrefix, "tag-prefix", defaultTagPrefix, "tag prefix of the generated tags")
+	fs.StringVar(&namespace, "namespace", "", "namespace of the context")
+	fs.StringVar(&name, "name", "", "name of the context")
+	fs.StringVar(&environment, "environment", "", "environment of the context")
+	fs.StringVar(&githubOutput, "github-output", os.Getenv("GITHUB_OUTPUT"), "`file` to append the job outputs to, empty to skip")
+	fs.StringVar(&githubEnv, "github-env", os.Getenv("GITHUB_ENV"), "`file` to append the environment variables to, empty to skip")
+	fs.StringVar(&envPrefix, "env-prefix", "CONTEXT_", "prefix of the environment variable names")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+
+	opts.Config = ctx.NewDataSourceConfig()
+	if contextFile != "" {
+		config, err := loadContextFile(contextFile)
+		if err != nil {
+			return err
+		}
+		opts.Config = config
+	}
+	if namespace != "" {
+		opts.Config.Namespace = namespace
+	}
+	if name != "" {
+		opts.Config.Name = name
+	}
+	if environment != "" {
+		opts.Config.Environment = environment
+	}
+
+	result, findings := evaluateContext(opts)
+	if f, ok := firstError(findings); ok {
+		return fmt.Errorf("%s: %s", f.Summary, f.Message)
+	}
+	for _, f := range findings {
+		fmt.Fprintf(stderr, "warning: %s: %s\n", f.Summary, f.Message)
+	}
+	outputs, err := result.outputs()
+	if err != nil {
+		return err
+	}
+
+	if githubOutput != "" {
+		if err := appendGitHubFile(githubOutput, outputs); err != nil {
+			return err
+		}
+	}
+	if githubEnv != "" {
+		envVars := make([][2]string, len(outputs))
+		for i, output := range outputs {
+			envVars[i] = [2]string{envPrefix + strings.ToUpper(output[0]), output[1]}
+		}
+		if err := appendGitHubFile(githubEnv, envVars); err != nil {
+			return err
+		}
+	}
+
+	enc := json.NewEncoder(stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(result)
+}
+
+// appendGitHubFile appends the name and value pairs of values to a GitHub
+// Actions environment file, such as the one named by GITHUB_OUTPUT. Values
+// are written with a random delimiter, so they may span lines.
+func appendGitHubFile(path string, values [][2]string) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	for _, v := range values {
+		name, value := v[0], v[1]
+		delimiter, err := newDelimiter()
+		if err != nil {
+			f.Close()
+			return err
+		}
+		fmt.Fprintf(&b, "%s<<%s\n%s\n%s\n", name, delimiter, value, delimiter)
+	}
+
+	if _, err := io.WriteString(f, b.String()); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// newDelimiter returns a random heredoc delimiter, which values do not
+// contain in practice
+func newDelimiter() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return "ghadelimiter_" + hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// readGitHubFile parses a GitHub Actions environment file written with
+// heredoc delimiters
+func readGitHubFile(t *testing.T, path string) map[string]string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	values := map[string]string{}
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		name, delimiter, ok := strings.Cut(lines[i], "<<")
+		if !ok {
+			t.Fatalf("line %q is not a heredoc header", lines[i])
+		}
+		var value []string
+		for i++; i < len(lines) && lines[i] != delimiter; i++ {
+			value = append(value, lines[i])
+		}
+		values[name] = strings.Join(value, "\n")
+	}
+	return values
+}
+
+func TestCI(t *testing.T) {
+	dir := t.TempDir()
+	contextFile := filepath.Join(dir, "context.yaml")
+	err := os.WriteFile(contextFile, []byte(`
+namespace: myorg
+name: webapp
+environment: prod
+cost_center: cc-100
+source_repo_tags_enabled: false
+additional_tags:
+  team: core
+`), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	outputFile := filepath.Join(dir, "output")
+	envFile := filepath.Join(dir, "env")
+
+	var stdout, stderr bytes.Buffer
+	args := []string{"-context", contextFile, "-environment", "dev", "-cloud", "aws", "-github-output", outputFile, "-github-env", envFile}
+	if err := ci(args, &stdout, &stderr); err != nil {
+		t.Fatalf("ci() error = %v, stderr = %s", err, stderr.String())
+	}
+
+	var result ciResult
+	if err := json.Unmarshal(stdout.Bytes(), &result); err != nil {
+		t.Fatalf("ci() wrote invalid JSON: %v\n%s", err, stdout.String())
+	}
+	if result.NamePrefix != "myorg-webapp-dev" {
+		t.Errorf("name_prefix = %q, want myorg-webapp-dev", result.NamePrefix)
+	}
+	if result.Tags["bc-costcenter"] != "cc-100" || result.Tags["bc-team"] != "core" {
+		t.Errorf("tags = %v, want the cost center and additional tags", result.Tags)
+	}
+	if result.DataTags["bc-sensitivity"] != "confidential" {
+		t.Errorf("data_tags = %v, want the default sensitivity", result.DataTags)
+	}
+
+	outputs := readGitHubFile(t, outputFile)
+	if outputs["name_prefix"] != "myorg-webapp-dev" {
+		t.Errorf("name_prefix output = %q", outputs["name_prefix"])
+	}
+	var tags map[string]string
+	if err := json.Unmarshal([]byte(outputs["tags"]), &tags); err != nil || tags["bc-costcenter"] != "cc-100" {
+		t.Errorf("tags output = %q, want the tags as JSON", outputs["tags"])
+	}
+	if labels := strings.Split(outputs["labels"], "\n"); len(labels) != len(result.Tags) || !strings.Contains(outputs["labels"], "bc-team=core") {
+		t.Errorf("labels output = %q, want a key=value line per tag", outputs["labels"])
+	}
+
+	env := readGitHubFile(t, envFile)
+	for _, name := range []string{"CONTEXT_NAME_PREFIX", "CONTEXT_TAGS", "CONTEXT_DATA_TAGS", "CONTEXT_LABELS"} {
+		if _, ok := env[name]; !ok {
+			t.Errorf("environment file does not set %s: %v", name, env)
+		}
+	}
+}
+
+func TestCI_appends(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "output")
+	if err := os.WriteFile(outputFile, []byte("previous=step\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	args := []string{"-name", "api", "-environment", "dev", "-github-output", outputFile, "-github-env", ""}
+	if err := ci(args, &stdout, &stderr); err != nil {
+		t.Fatalf("ci() error = %v", err)
+	}
+	data, err := os.ReadFile(outputFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), "previous=step\nname_prefix<<") {
+		t.Errorf("ci() did not append to the output file:\n%s", data)
+	}
+}
+
+func TestCI_errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{
+			name:    "invalid cloud provider",
+			args:    []string{"-name", "api", "-environment", "dev", "-cloud", "azure"},
+			wantErr: "invalid cloud provider 'azure'",
+		},
+		{
+			name:    "invalid environment",
+			args:    []string{"-name", "api", "-environment", "Production"},
+			wantErr: "environment",
+		},
+		{
+			name:    "missing context file",
+			args:    []string{"-context", "missing.yaml"},
+			wantErr: "missing.yaml",
+		},
+		{
+			name:    "unexpected arguments",
+			args:    []string{"extra"},
+			wantErr: "unexpected arguments: extra",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			args := append([]string{"-github-output", "", "-github-env", ""}, tt.args...)
+			err := ci(args, &stdout, &stderr)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("ci() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+	"gopkg.in/yaml.v3"
+
+	ctx "github.com/kbrockhoff/terraform-provider-context/pkg/context"
+)
+
+// contextVariable is the tfvars variable that holds a whole context as an
+// object, as an alternative to one variable per attribute
+const contextVariable = "context"
+
+// loadContextFile reads a context from a YAML file, a JSON file when its
+// extension is .json, or a Terraform variables file when it is .tfvars or
+// .tfvars.json. The variables of a variables file are the context
+// attributes, unless its context variable holds the context as an object.
+func loadContextFile(path string) (*ctx.DataSourceConfig, error) {
+	config, _, err := decodeContextFile(path)
+	return config, err
+}
+
+// decodeContextFile reads a context file like loadContextFile and also
+// returns the attribute names set in it, sorted. Variables files without a
+// context variable also hold the other variables of a stack, so no names are
+// returned for them.
+func decodeContextFile(path string) (*ctx.DataSourceConfig, []string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var keys map[string]any
+	wholeFile := true
+	config := ctx.NewDataSourceConfig()
+	lowerPath := strings.ToLower(path)
+	switch {
+	case strings.HasSuffix(lowerPath, ".tfvars"):
+		if data, wholeFile, err = tfvarsJSON(path, data); err == nil {
+			err = decodeJSON(data, &keys, config)
+		}
+	case strings.HasSuffix(lowerPath, ".tfvars.json"):
+		if data, wholeFile, err = unwrapContextVariable(data); err == nil {
+			err = decodeJSON(data, &keys, config)
+		}
+	case strings.HasSuffix(lowerPath, ".json"):
+		err = decodeJSON(data, &keys, config)
+	default:
+		if err = yaml.Unmarshal(data, &keys); err == nil {
+			err = yaml.Unmarshal(data, config)
+		}
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("decoding %s: %w", path, err)
+	}
+	if !wholeFile {
+		return config, nil, nil
+	}
+	return config, slices.Sorted(maps.Keys(keys)), nil
+}
+
+// decodeJSON decodes data into the generic keys and the config
+func decodeJSON(data []byte, keys *map[string]any, config *ctx.DataSourceConfig) error {
+	if err := json.Unmarshal(data, keys); err != nil {
+		return err
+	}
+	return json.Unmarshal(data, config)
+}
+
+// tfvarsJSON converts the variables of a Terraform variables file to a JSON
+// object. When the context variable is set to an object, that object is
+// returned and wrapped reports true.
+func tfvarsJSON(path string, data []byte) (object []byte, wrapped bool, err error) {
+	file, diags := hclsyntax.ParseConfig(data, path, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, false, diags
+	}
+	attrs, diags := file.Body.JustAttributes()
+	if diags.HasErrors() {
+		return nil, false, diags
+	}
+
+	values := make(map[string]cty.Value, len(attrs))
+	for name, attr := range attrs {
+		value, diags := attr.Expr.Value(nil)
+		if diags.HasErrors() {
+			return nil, false, diags
+		}
+		values[name] = value
+	}
+
+	value := cty.ObjectVal(values)
+	if context, ok := values[contextVariable]; ok && (context.Type().IsObjectType() || context.Type().IsMapType()) {
+		value, wrapped = context, true
+	}
+	object, err = ctyjson.Marshal(value, value.Type())
+	return object, wrapped, err
+}
+
+// unwrapContextVariable returns the object of the context variable of a
+// JSON Terraform variables file, or data when it has none, like tfvarsJSON
+func unwrapContextVariable(data []byte) (object []byte, wrapped bool, err error) {
+	var variables map[string]json.RawMessage
+	if err := json.Unmarshal(data, &variables); err != nil {
+		return nil, false, err
+	}
+	if context, ok := variables[contextVariable]; ok && strings.HasPrefix(strings.TrimSpace(string(context)), "{") {
+		return context, true, nil
+	}
+	return data, false, nil
+}
+
+// contextAttributes returns the attribute names a context file may set: the
+// JSON names of the DataSourceConfig fields
+func contextAttributes() map[string]bool {
+	attributes := map[string]bool{}
+	for _, field := range reflect.VisibleFields(reflect.TypeFor[ctx.DataSourceConfig]()) {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			attributes[name] = true
+		}
+	}
+	return attributes
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestDecodeContextFile(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		content  string
+		wantName string
+		wantPR   int
+		wantTags map[string]string
+		wantKeys []string
+	}{
+		{
+			name:     "yaml",
+			file:     "context.yaml",
+			content:  "name: api\ncost_centre: cc-100\n",
+			wantName: "api",
+			wantKeys: []string{"cost_centre", "name"},
+		},
+		{
+			name:     "json",
+			file:     "context.json",
+			content:  `{"name": "api", "pr_number": 42}`,
+			wantName: "api",
+			wantPR:   42,
+			wantKeys: []string{"name", "pr_number"},
+		},
+		{
+			name:     "tfvars variables",
+			file:     "dev.tfvars",
+			content:  "name = \"api\"\npr_number = 42\ninstance_type = \"t3.micro\"\nadditional_tags = { team = \"core\" }\n",
+			wantName: "api",
+			wantPR:   42,
+			wantTags: map[string]string{"team": "core"},
+		},
+		{
+			name:     "tfvars context variable",
+			file:     "dev.tfvars",
+			content:  "region = \"us-east-1\"\ncontext = {\n  name = \"api\"\n  cost_centre = \"cc-100\"\n}\n",
+			wantName: "api",
+			wantKeys: []string{"cost_centre", "name"},
+		},
+		{
+			name:     "tfvars json variables",
+			file:     "dev.tfvars.json",
+			content:  `{"name": "api", "instance_type": "t3.micro"}`,
+			wantName: "api",
+		},
+		{
+			name:     "tfvars json context variable",
+			file:     "dev.tfvars.json",
+			content:  `{"region": "us-east-1", "context": {"name": "api"}}`,
+			wantName: "api",
+			wantKeys: []string{"name"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.file)
+			if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			config, keys, err := decodeContextFile(path)
+			if err != nil {
+				t.Fatalf("decodeContextFile() error = %v", err)
+			}
+			if config.Name != tt.wantName || config.PRNumber != tt.wantPR {
+				t.Errorf("name, pr_number = %q, %d, want %q, %d", config.Name, config.PRNumber, tt.wantName, tt.wantPR)
+			}
+			if tt.wantTags != nil && config.AdditionalTags["team"] != tt.wantTags["team"] {
+				t.Errorf("additional_tags = %v, want %v", config.AdditionalTags, tt.wantTags)
+			}
+			if !config.SourceRepoTagsEnabled {
+				t.Error("source_repo_tags_enabled = false, want the default true")
+			}
+			if !slices.Equal(keys, tt.wantKeys) {
+				t.Errorf("keys = %v, want %v", keys, tt.wantKeys)
+			}
+		})
+	}
+}
+
+func TestDecodeContextFile_errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+	}{
+		{name: "invalid yaml", file: "context.yaml", content: "name: [api"},
+		{name: "invalid json", file: "context.json", content: `{"name": `},
+		{name: "invalid tfvars", file: "dev.tfvars", content: `name = `},
+		{name: "tfvars expression", file: "dev.tfvars", content: `name = var.name`},
+		{name: "tfvars block", file: "dev.tfvars", content: "context {\n  name = \"api\"\n}\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.file)
+			if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			if _, _, err := decodeContextFile(path); err == nil {
+				t.Error("decodeContextFile() error = nil, want an error")
+			}
+		})
+	}
+}
+
+func TestContextAttributes(t *testing.T) {
+	attributes := contextAttributes()
+	for _, name := range []string{"namespace", "additional_typed_tags", "raw_tags", "list_join_delimiter"} {
+		if !attributes[name] {
+			t.Errorf("contextAttributes() is missing %s", name)
+		}
+	}
+	if attributes["cost_centre"] {
+		t.Error("contextAttributes() contains cost_centre")
+	}
+}
@@ -0,0 +1,62 @@
+// Command contextctl helps stacks adopt the brockhoff provider outside of
+// Terraform runs.
+//
+// Usage:
+//
+//	contextctl scaffold [flags]
+//	contextctl ci [flags]
+//	contextctl validate [flags] <path>...
+//	contextctl schema [flags]
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+const usage = `Usage: contextctl <command> [flags]
+
+Commands:
+  scaffold  generate a starter Terraform module wired to the context data source
+  ci        export the name prefix and tags of a context to CI job outputs and environment variables
+  validate  check context files against the rules of the context data source
+  schema    write the JSON Schema of context files
+
+Run 'contextctl <command> -h' for the flags of a command.
+`
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run dispatches args to a command and returns the exit status
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+
+	var err error
+	switch args[0] {
+	case "scaffold":
+		err = scaffold(args[1:], stdin, stdout, stderr)
+	case "ci":
+		err = ci(args[1:], stdout, stderr)
+	case "validate":
+		err = validate(args[1:], stdout, stderr)
+	case "schema":
+		err = schema(args[1:], stdout, stderr)
+	case "help", "-h", "-help", "--help":
+		fmt.Fprint(stdout, usage)
+		return 0
+	default:
+		fmt.Fprintf(stderr, "unknown command %q\n\n%s", args[0], usage)
+		return 2
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "contextctl %s: %s\n", args[0], err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,377 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+
+	ctx "github.com/kbrockhoff/terraform-provider-context/pkg/context"
+)
+
+// providerSource is the registry address of the provider
+const providerSource = "kbrockhoff/context"
+
+// defaultTagPrefix is the tag_prefix written to the provider block
+const defaultTagPrefix = "bc-"
+
+// scaffoldOptions are the answers a module is generated from
+type scaffoldOptions struct {
+	Dir           string
+	CloudProvider string
+	TagPrefix     string
+	Config        *ctx.DataSourceConfig
+	Force         bool
+}
+
+// exampleResource is the resource of a cloud provider wired to the context
+// in the generated module
+type exampleResource struct {
+	// Provider is the local name of the Terraform provider of the resource,
+	// empty for built-in resources
+	Provider string
+	Source   string
+	Type     string
+	// NameAttribute and TagsAttribute receive the name prefix and tags
+	NameAttribute string
+	TagsAttribute string
+	// Attributes are the other required arguments of the resource
+	Attributes map[string]string
+}
+
+// exampleResources are keyed by cloud provider; other cloud providers use a
+// terraform_data resource
+var exampleResources = map[string]exampleResource{
+	"aws": {
+		Provider:      "aws",
+		Source:        "hashicorp/aws",
+		Type:          "aws_s3_bucket",
+		NameAttribute: "bucket",
+		TagsAttribute: "tags",
+	},
+	"az": {
+		Provider:      "azurerm",
+		Source:        "hashicorp/azurerm",
+		Type:          "azurerm_resource_group",
+		NameAttribute: "name",
+		TagsAttribute: "tags",
+		Attributes:    map[string]string{"location": "eastus"},
+	},
+	"gcp": {
+		Provider:      "google",
+		Source:        "hashicorp/google",
+		Type:          "google_storage_bucket",
+		NameAttribute: "name",
+		TagsAttribute: "labels",
+		Attributes:    map[string]string{"location": "US"},
+	},
+}
+
+// scaffold implements the scaffold command
+func scaffold(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("scaffold", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), `Usage: contextctl scaffold [flags]
+
+Generates versions.tf, main.tf and outputs.tf of a starter module with the
+provider block, a brockhoff_context data source and an example resource using
+its name_prefix and tags. Values not given by flags or the context file are
+prompted for on standard input.
+
+Flags:
+`)
+		fs.PrintDefaults()
+	}
+
+	var (
+		contextFile string
+		opts        scaffoldOptions
+		namespace   string
+		name        string
+		environment string
+	)
+	fs.StringVar(&contextFile, "context", "", "JSON, YAML or tfvars `file` of context values")
+	fs.StringVar(&opts.Dir, "dir", ".", "`directory` to write the module to")
+	fs.StringVar(&opts.CloudProvider, "cloud", "", "cloud provider: dc, aws, az, gcp, oci, ibm, do, vul, ali or cv")
+	fs.StringVar(&opts.TagPrefix, "tag-prefix", defaultTagPrefix, "tag prefix of the provider block")
+	fs.StringVar(&namespace, "namespace", "", "namespace of the context")
+	fs.StringVar(&name, "name", "", "name of the context")
+	fs.StringVar(&environment, "environment", "", "environment of the context")
+	fs.BoolVar(&opts.Force, "force", false, "overwrite existing files")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+
+	opts.Config = ctx.NewDataSourceConfig()
+	if contextFile != "" {
+		config, err := loadContextFile(contextFile)
+		if err != nil {
+			return err
+		}
+		opts.Config = config
+	}
+	if namespace != "" {
+		opts.Config.Namespace = namespace
+	}
+	if name != "" {
+		opts.Config.Name = name
+	}
+	if environment != "" {
+		opts.Config.Environment = environment
+	}
+
+	prompt := newPrompter(stdin, stdout)
+	if err := prompt.ask(&opts.Config.Namespace, "Namespace", "", ctx.ValidateNamespace); err != nil {
+		return err
+	}
+	if err := prompt.ask(&opts.Config.Name, "Name", "", validateName); err != nil {
+		return err
+	}
+	if err := prompt.ask(&opts.Config.Environment, "Environment", "", ctx.ValidateEnvironment); err != nil {
+		return err
+	}
+	if err := prompt.ask(&opts.CloudProvider, "Cloud provider (dc, aws, az, gcp, oci, ibm, do, vul, ali, cv)", "dc", ctx.ValidateCloudProvider); err != nil {
+		return err
+	}
+
+	return writeModule(opts.Dir, renderModule(opts), opts.Force, stdout)
+}
+
+// validateName requires a name, which the context data source needs to
+// generate a name prefix
+func validateName(name string) error {
+	if name == "" {
+		return errors.New("name is required")
+	}
+	return nil
+}
+
+// prompter asks for values missing from the flags and context file
+type prompter struct {
+	in  *bufio.Scanner
+	out io.Writer
+}
+
+func newPrompter(stdin io.Reader, stdout io.Writer) *prompter {
+	return &prompter{in: bufio.NewScanner(stdin), out: stdout}
+}
+
+// ask prompts for value when it is empty, using def for an empty answer, and
+// validates the result. Invalid answers are asked again until the input ends.
+func (p *prompter) ask(value *string, label, def string, validate func(string) error) error {
+	if *value != "" {
+		return validate(*value)
+	}
+
+	for {
+		if def != "" {
+			fmt.Fprintf(p.out, "%s [%s]: ", label, def)
+		} else {
+			fmt.Fprintf(p.out, "%s: ", label)
+		}
+		if !p.in.Scan() {
+			if err := p.in.Err(); err != nil {
+				return err
+			}
+			fmt.Fprintln(p.out)
+			*value = def
+			return validate(*value)
+		}
+
+		answer := strings.TrimSpace(p.in.Text())
+		if answer == "" {
+			answer = def
+		}
+		if err := validate(answer); err != nil {
+			fmt.Fprintf(p.out, "  %s\n", err)
+			continue
+		}
+		*value = answer
+		return nil
+	}
+}
+
+// renderModule returns the files of the module keyed by name
+func renderModule(opts scaffoldOptions) map[string][]byte {
+	cloudProvider := opts.CloudProvider
+	if cloudProvider == "" {
+		cloudProvider = "dc"
+	}
+	example, ok := exampleResources[cloudProvider]
+	if !ok {
+		example = exampleResource{Type: "terraform_data"}
+	}
+
+	versions := hclwrite.NewEmptyFile()
+	terraform := versions.Body().AppendNewBlock("terraform", nil).Body()
+	requiredProviders := terraform.AppendNewBlock("required_providers", nil).Body()
+	requiredProviders.SetAttributeValue("brockhoff", cty.ObjectVal(map[string]cty.Value{
+		"source": cty.StringVal(providerSource),
+	}))
+	if example.Provider != "" {
+		requiredProviders.SetAttributeValue(example.Provider, cty.ObjectVal(map[string]cty.Value{
+			"source": cty.StringVal(example.Source),
+		}))
+	}
+
+	module := hclwrite.NewEmptyFile()
+	body := module.Body()
+	provider := body.AppendNewBlock("provider", []string{"brockhoff"}).Body()
+	provider.SetAttributeValue("cloud_provider", cty.StringVal(cloudProvider))
+	provider.SetAttributeValue("tag_prefix", cty.StringVal(opts.TagPrefix))
+	if example.Provider == "azurerm" {
+		body.AppendNewline()
+		body.AppendNewBlock("provider", []string{"azurerm"}).Body().AppendNewBlock("features", nil)
+	}
+
+	body.AppendNewline()
+	appendContextBlock(body, opts.Config)
+
+	body.AppendNewline()
+	resource := body.AppendNewBlock("resource", []string{example.Type, "example"}).Body()
+	namePrefix := contextTraversal("name_prefix")
+	tags := contextTraversal("tags")
+	if example.Type == "terraform_data" {
+		resource.SetAttributeRaw("input", hclwrite.TokensForObject([]hclwrite.ObjectAttrTokens{
+			{Name: hclwrite.TokensForIdentifier("name"), Value: hclwrite.TokensForTraversal(namePrefix)},
+			{Name: hclwrite.TokensForIdentifier("tags"), Value: hclwrite.TokensForTraversal(tags)},
+		}))
+	} else {
+		resource.SetAttributeTraversal(example.NameAttribute, namePrefix)
+		for _, key := range slices.Sorted(maps.Keys(example.Attributes)) {
+			resource.SetAttributeValue(key, cty.StringVal(example.Attributes[key]))
+		}
+		resource.SetAttributeTraversal(example.TagsAttribute, tags)
+	}
+
+	outputs := hclwrite.NewEmptyFile()
+	for i, output := range []string{"name_prefix", "tags"} {
+		if i > 0 {
+			outputs.Body().AppendNewline()
+		}
+		outputs.Body().AppendNewBlock("output", []string{output}).Body().
+			SetAttributeTraversal("value", contextTraversal(output))
+	}
+
+	return map[string][]byte{
+		"versions.tf": hclwrite.Format(versions.Bytes()),
+		"main.tf":     hclwrite.Format(module.Bytes()),
+		"outputs.tf":  hclwrite.Format(outputs.Bytes()),
+	}
+}
+
+// appendContextBlock appends the brockhoff_context data source with the
+// values of config commonly set by stacks; empty values are left out
+func appendContextBlock(body *hclwrite.Body, config *ctx.DataSourceConfig) {
+	block := body.AppendNewBlock("data", []string{"brockhoff_context", "this"}).Body()
+
+	for _, attr := range []struct {
+		name  string
+		value string
+	}{
+		{"namespace", config.Namespace},
+		{"tenant", config.Tenant},
+		{"name", config.Name},
+		{"environment", config.Environment},
+		{"environment_name", config.EnvironmentName},
+		{"environment_type", config.EnvironmentType},
+		{"availability", config.Availability},
+		{"managed_by", config.ManagedBy},
+		{"deletion_date", config.DeletionDate},
+		{"cost_center", config.CostCenter},
+		{"sensitivity", config.Sensitivity},
+		{"pm_platform", config.PMPlatform},
+		{"pm_project_code", config.PMProjectCode},
+		{"itsm_platform", config.ITSMPlatform},
+		{"itsm_system_id", config.ITSMSystemID},
+		{"itsm_component_id", config.ITSMComponentID},
+		{"itsm_instance_id", config.ITSMInstanceID},
+	} {
+		if attr.value != "" {
+			block.SetAttributeValue(attr.name, cty.StringVal(attr.value))
+		}
+	}
+
+	for _, attr := range []struct {
+		name   string
+		values []string
+	}{
+		{"product_owners", config.ProductOwners},
+		{"code_owners", config.CodeOwners},
+		{"data_owners", config.DataOwners},
+		{"data_regs", config.DataRegs},
+	} {
+		if len(attr.values) == 0 {
+			continue
+		}
+		values := make([]cty.Value, len(attr.values))
+		for i, v := range attr.values {
+			values[i] = cty.StringVal(v)
+		}
+		block.SetAttributeValue(attr.name, cty.ListVal(values))
+	}
+
+	if len(config.AdditionalTags) > 0 {
+		tags := make(map[string]cty.Value, len(config.AdditionalTags))
+		for key, value := range config.AdditionalTags {
+			tags[key] = cty.StringVal(value)
+		}
+		block.SetAttributeValue("additional_tags", cty.MapVal(tags))
+	}
+}
+
+// contextTraversal returns the reference to an attribute of the generated
+// data source
+func contextTraversal(attribute string) hcl.Traversal {
+	return hcl.Traversal{
+		hcl.TraverseRoot{Name: "data"},
+		hcl.TraverseAttr{Name: "brockhoff_context"},
+		hcl.TraverseAttr{Name: "this"},
+		hcl.TraverseAttr{Name: attribute},
+	}
+}
+
+// writeModule writes files to dir, refusing to overwrite existing files
+// unless force is set
+func writeModule(dir string, files map[string][]byte, force bool, stdout io.Writer) error {
+	names := slices.Sorted(maps.Keys(files))
+	if !force {
+		for _, name := range names {
+			path := filepath.Join(dir, name)
+			if _, err := os.Stat(path); err == nil {
+				return fmt.Errorf("%s already exists, use -force to overwrite", path)
+			} else if !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+		}
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, files[name], 0o644); err != nil {
+			return err
+		}
+		fmt.Fprintf(stdout, "wrote %s\n", path)
+	}
+	return nil
+}
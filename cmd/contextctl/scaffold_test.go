@@ -0,0 +1,202 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+func TestScaffold(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		stdin     string
+		wantFiles map[string][]string
+	}{
+		{
+			name:  "prompted aws",
+			stdin: "myorg\nwebapp\nprod\naws\n",
+			wantFiles: map[string][]string{
+				"versions.tf": {`source = "kbrockhoff/context"`, `source = "hashicorp/aws"`},
+				"main.tf": {
+					`cloud_provider = "aws"`,
+					`namespace   = "myorg"`,
+					`resource "aws_s3_bucket" "example"`,
+					`bucket = data.brockhoff_context.this.name_prefix`,
+					`tags   = data.brockhoff_context.this.tags`,
+				},
+				"outputs.tf": {`output "name_prefix"`, `output "tags"`},
+			},
+		},
+		{
+			name: "flags gcp",
+			args: []string{"-name", "api", "-environment", "dev", "-cloud", "gcp", "-tag-prefix", "ops-"},
+			wantFiles: map[string][]string{
+				"versions.tf": {`source = "hashicorp/google"`},
+				"main.tf":     {`tag_prefix     = "ops-"`, `resource "google_storage_bucket" "example"`, `labels   = data.brockhoff_context.this.tags`},
+			},
+		},
+		{
+			name:  "invalid answer asked again",
+			args:  []string{"-name", "api", "-environment", "dev"},
+			stdin: "\nazure\naz\n",
+			wantFiles: map[string][]string{
+				"main.tf": {`cloud_provider = "az"`, `provider "azurerm"`, `location = "eastus"`},
+			},
+		},
+		{
+			name: "default cloud provider",
+			args: []string{"-name", "api", "-environment", "dev"},
+			wantFiles: map[string][]string{
+				"main.tf": {`cloud_provider = "dc"`, `resource "terraform_data" "example"`},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			var stdout, stderr bytes.Buffer
+			args := append([]string{"-dir", dir}, tt.args...)
+			if err := scaffold(args, strings.NewReader(tt.stdin), &stdout, &stderr); err != nil {
+				t.Fatalf("scaffold() error = %v, stderr = %s", err, stderr.String())
+			}
+
+			for name, wants := range tt.wantFiles {
+				src, err := os.ReadFile(filepath.Join(dir, name))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if _, diags := hclsyntax.ParseConfig(src, name, hcl.Pos{Line: 1, Column: 1}); diags.HasErrors() {
+					t.Errorf("%s is not valid HCL: %s", name, diags)
+				}
+				for _, want := range wants {
+					if !containsHCL(src, want) {
+						t.Errorf("%s does not contain %q:\n%s", name, want, src)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestScaffold_contextFile(t *testing.T) {
+	dir := t.TempDir()
+	contextFile := filepath.Join(dir, "context.json")
+	err := os.WriteFile(contextFile, []byte(`{
+		"namespace": "myorg",
+		"name": "webapp",
+		"environment": "prod",
+		"managedby": "platform",
+		"product_owners": ["owner@example.com"],
+		"additional_tags": {"team": "core"}
+	}`), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	args := []string{"-context", contextFile, "-environment", "dev", "-cloud", "aws", "-dir", dir}
+	if err := scaffold(args, strings.NewReader(""), &stdout, &stderr); err != nil {
+		t.Fatalf("scaffold() error = %v", err)
+	}
+	if strings.Contains(stdout.String(), ": ") {
+		t.Errorf("scaffold() prompted for values of the context file: %s", stdout.String())
+	}
+
+	src, err := os.ReadFile(filepath.Join(dir, "main.tf"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`environment     = "dev"`,
+		`managed_by      = "platform"`,
+		`product_owners  = ["owner@example.com"]`,
+		`team = "core"`,
+	} {
+		if !containsHCL(src, want) {
+			t.Errorf("main.tf does not contain %q:\n%s", want, src)
+		}
+	}
+}
+
+func TestScaffold_errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		stdin    string
+		existing bool
+		wantErr  string
+	}{
+		{
+			name:    "name required",
+			args:    []string{"-environment", "dev", "-cloud", "aws"},
+			wantErr: "name is required",
+		},
+		{
+			name:    "invalid cloud provider flag",
+			args:    []string{"-name", "api", "-environment", "dev", "-cloud", "azure"},
+			wantErr: "invalid cloud provider 'azure'",
+		},
+		{
+			name:     "existing files",
+			args:     []string{"-name", "api", "-environment", "dev", "-cloud", "aws"},
+			existing: true,
+			wantErr:  "already exists, use -force to overwrite",
+		},
+		{
+			name:    "missing context file",
+			args:    []string{"-context", "missing.yaml"},
+			wantErr: "missing.yaml",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if tt.existing {
+				if err := os.WriteFile(filepath.Join(dir, "main.tf"), nil, 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var stdout, stderr bytes.Buffer
+			args := append([]string{"-dir", dir}, tt.args...)
+			err := scaffold(args, strings.NewReader(tt.stdin), &stdout, &stderr)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("scaffold() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestScaffold_force(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "main.tf"), []byte("# old\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	args := []string{"-dir", dir, "-name", "api", "-environment", "dev", "-cloud", "aws", "-force"}
+	if err := scaffold(args, strings.NewReader(""), &stdout, &stderr); err != nil {
+		t.Fatalf("scaffold() error = %v", err)
+	}
+	src, err := os.ReadFile(filepath.Join(dir, "main.tf"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(src), "# old") {
+		t.Errorf("main.tf was not overwritten:\n%s", src)
+	}
+}
+
+// containsHCL reports whether src contains want, ignoring the alignment of
+// attributes by hclwrite.Format
+func containsHCL(src []byte, want string) bool {
+	return strings.Contains(strings.Join(strings.Fields(string(src)), " "), strings.Join(strings.Fields(want), " "))
+}
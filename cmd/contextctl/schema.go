@@ -0,0 +1,47 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	ctx "github.com/kbrockhoff/terraform-provider-context/pkg/context"
+)
+
+// schema implements the schema command
+func schema(args []string, stdout, stderr io.Writer) error {
+	flags := flag.NewFlagSet("schema", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), `Usage: contextctl schema [flags]
+
+Writes the JSON Schema (draft 2020-12) of context documents, the
+brockhoff_context inputs as JSON or YAML, so tooling in other languages can
+validate contexts with the types, enums and patterns of the data source.
+
+Flags:
+`)
+		flags.PrintDefaults()
+	}
+
+	var output string
+	flags.StringVar(&output, "o", "", "`file` to write the schema to instead of standard output")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if flags.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments: %s", strings.Join(flags.Args(), " "))
+	}
+
+	if output != "" {
+		return os.WriteFile(output, ctx.ContextSchema(), 0o644)
+	}
+	_, err := stdout.Write(ctx.ContextSchema())
+	return err
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	ctx "github.com/kbrockhoff/terraform-provider-context/pkg/context"
+)
+
+func TestSchema(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if err := schema(nil, &stdout, &stderr); err != nil {
+		t.Fatalf("schema() error = %v", err)
+	}
+	if !bytes.Equal(stdout.Bytes(), ctx.ContextSchema()) {
+		t.Error("schema() did not write the context schema")
+	}
+
+	output := filepath.Join(t.TempDir(), "context.schema.json")
+	stdout.Reset()
+	if err := schema([]string{"-o", output}, &stdout, &stderr); err != nil {
+		t.Fatalf("schema() error = %v", err)
+	}
+	data, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, ctx.ContextSchema()) || stdout.Len() != 0 {
+		t.Error("schema() -o did not write the context schema to the file only")
+	}
+
+	if err := schema([]string{"extra"}, &stdout, &stderr); err == nil || !strings.Contains(err.Error(), "unexpected arguments") {
+		t.Errorf("schema() error = %v, want unexpected arguments", err)
+	}
+}
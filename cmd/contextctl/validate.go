@@ -0,0 +1,212 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	ctx "github.com/kbrockhoff/terraform-provider-context/pkg/context"
+)
+
+// Formats of the validate findings
+const (
+	formatJSON   = "json"
+	formatText   = "text"
+	formatGitHub = "github"
+)
+
+// contextFileNames are the files validate finds in directories
+var contextFileNames = []string{"context.yaml", "context.yml", "context.json", "context.tfvars", "context.tfvars.json"}
+
+// workflowDataEscaper and workflowPropertyEscaper escape the message and the
+// properties of GitHub Actions workflow commands
+var (
+	workflowDataEscaper     = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A")
+	workflowPropertyEscaper = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C")
+)
+
+// validateReport is the JSON output of the validate command
+type validateReport struct {
+	Valid    bool      `json:"valid"`
+	Files    []string  `json:"files"`
+	Findings []finding `json:"findings"`
+}
+
+// validate implements the validate command
+func validate(args []string, stdout, stderr io.Writer) error {
+	flags := flag.NewFlagSet("validate", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), `Usage: contextctl validate [flags] <path>...
+
+Checks context files against the rules of the brockhoff_context data source,
+so mistakes surface in pre-commit hooks and pull request checks before a plan
+runs. Paths are YAML, JSON or Terraform variables (.tfvars, .tfvars.json)
+files, or directories searched for `+strings.Join(contextFileNames, ", ")+`.
+Attributes a context does not have are reported as warnings.
+
+The findings are written as JSON, text lines or GitHub Actions workflow
+commands, and the exit status is 1 when any is an error.
+
+Flags:
+`)
+		flags.PrintDefaults()
+	}
+
+	var (
+		opts   contextOptions
+		format string
+	)
+	flags.StringVar(&opts.CloudProvider, "cloud", "dc", "cloud provider: dc, aws, az, gcp, oci, ibm, do, vul, ali or cv")
+	flags.StringVar(&opts.TagPrefix, "tag-prefix", defaultTagPrefix, "tag prefix of the generated tags")
+	flags.BoolVar(&opts.StrictMode, "strict", false, "report unmet sensitivity requirements as errors, like the strict_mode of the provider")
+	flags.StringVar(&format, "format", formatJSON, "output format: json, text or github")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if !slices.Contains([]string{formatJSON, formatText, formatGitHub}, format) {
+		return fmt.Errorf("invalid format '%s': must be json, text or github", format)
+	}
+	if err := ctx.ValidateCloudProvider(opts.CloudProvider); err != nil {
+		return err
+	}
+	if flags.NArg() == 0 {
+		return errors.New("at least one path is required")
+	}
+
+	files, err := findContextFiles(flags.Args())
+	if err != nil {
+		return err
+	}
+
+	report := validateReport{Files: files, Findings: []finding{}}
+	for _, file := range files {
+		report.Findings = append(report.Findings, validateFile(file, opts)...)
+	}
+	_, hasError := firstError(report.Findings)
+	report.Valid = !hasError
+
+	if err := writeFindings(stdout, format, report); err != nil {
+		return err
+	}
+	if hasError {
+		return fmt.Errorf("%d of %d context files are invalid", countInvalidFiles(report.Findings), len(files))
+	}
+	return nil
+}
+
+// findContextFiles returns the files of paths, replacing directories with
+// the contextFileNames files below them. .git and .terraform directories are
+// skipped.
+func findContextFiles(paths []string) ([]string, error) {
+	files := []string{}
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+
+		err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				if p != path && (d.Name() == ".git" || d.Name() == ".terraform") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if slices.Contains(contextFileNames, d.Name()) {
+				files = append(files, p)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+// validateFile returns the findings of a context file
+func validateFile(path string, opts contextOptions) []finding {
+	config, keys, err := decodeContextFile(path)
+	if err != nil {
+		return []finding{{File: path, Severity: severityError, Summary: "Invalid context file", Message: err.Error()}}
+	}
+
+	var findings []finding
+	attributes := contextAttributes()
+	for _, key := range keys {
+		if !attributes[key] {
+			findings = append(findings, finding{
+				Field:    key,
+				Severity: severityWarning,
+				Summary:  "Unknown attribute",
+				Message:  fmt.Sprintf("%s is not an attribute of brockhoff_context and is ignored", key),
+			})
+		}
+	}
+
+	opts.Config = config
+	_, contextFindings := evaluateContext(opts)
+	findings = append(findings, contextFindings...)
+	for i := range findings {
+		findings[i].File = path
+	}
+	return findings
+}
+
+// countInvalidFiles returns the number of files with error findings
+func countInvalidFiles(findings []finding) int {
+	invalid := map[string]bool{}
+	for _, f := range findings {
+		if f.Severity == severityError {
+			invalid[f.File] = true
+		}
+	}
+	return len(invalid)
+}
+
+// writeFindings writes the report in a format
+func writeFindings(w io.Writer, format string, report validateReport) error {
+	switch format {
+	case formatText:
+		for _, f := range report.Findings {
+			location := f.File
+			if f.Field != "" {
+				location += ": " + f.Field
+			}
+			if _, err := fmt.Fprintf(w, "%s: %s: %s: %s\n", location, f.Severity, f.Summary, f.Message); err != nil {
+				return err
+			}
+		}
+		return nil
+	case formatGitHub:
+		for _, f := range report.Findings {
+			if _, err := fmt.Fprintf(w, "::%s file=%s,title=%s::%s\n", f.Severity,
+				workflowPropertyEscaper.Replace(f.File), workflowPropertyEscaper.Replace(f.Summary), workflowDataEscaper.Replace(f.Message)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeContextFiles writes files, keyed by path relative to dir
+func writeContextFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	dir := t.TempDir()
+	writeContextFiles(t, dir, map[string]string{
+		"stacks/api/context.yaml":            "namespace: ex\nname: api\nenvironment: dev\nsource_repo_tags_enabled: false\n",
+		"stacks/web/context.tfvars":          "context = {\n  namespace = \"Bad_NS\"\n  name = \"web\"\n  environment = \"dev\"\n  cost_centre = \"cc-100\"\n}\n",
+		"stacks/web/.terraform/context.yaml": "namespace: Bad_NS\n",
+		"stacks/web/main.tf":                 "",
+	})
+
+	var stdout, stderr bytes.Buffer
+	err := validate([]string{filepath.Join(dir, "stacks")}, &stdout, &stderr)
+	if err == nil || err.Error() != "1 of 2 context files are invalid" {
+		t.Fatalf("validate() error = %v, want 1 of 2 context files are invalid", err)
+	}
+
+	var report validateReport
+	if err := json.Unmarshal(stdout.Bytes(), &report); err != nil {
+		t.Fatalf("validate() wrote invalid JSON: %v\n%s", err, stdout.String())
+	}
+	if report.Valid || len(report.Files) != 2 {
+		t.Errorf("valid, files = %v, %v, want false and the two context files", report.Valid, report.Files)
+	}
+	webFile := filepath.Join(dir, "stacks", "web", "context.tfvars")
+	want := []finding{
+		{File: webFile, Field: "cost_centre", Severity: severityWarning, Summary: "Unknown attribute"},
+		{File: webFile, Field: "namespace", Severity: severityError, Summary: "Invalid namespace"},
+	}
+	if len(report.Findings) != len(want) {
+		t.Fatalf("findings = %+v, want %+v", report.Findings, want)
+	}
+	for i, f := range report.Findings {
+		f.Message = ""
+		if f != want[i] {
+			t.Errorf("finding %d = %+v, want %+v", i, f, want[i])
+		}
+	}
+}
+
+func TestValidate_valid(t *testing.T) {
+	dir := t.TempDir()
+	writeContextFiles(t, dir, map[string]string{
+		"context.json": `{"namespace": "ex", "name": "api", "environment": "dev", "sensitivity": "restricted", "source_repo_tags_enabled": false}`,
+	})
+
+	var stdout, stderr bytes.Buffer
+	if err := validate([]string{"-format", "text", filepath.Join(dir, "context.json")}, &stdout, &stderr); err != nil {
+		t.Fatalf("validate() error = %v", err)
+	}
+	want := filepath.Join(dir, "context.json") + ": sensitivity: warning: Sensitivity requirement not met: data_retention is required for restricted data\n"
+	if stdout.String() != want {
+		t.Errorf("validate() output = %q, want %q", stdout.String(), want)
+	}
+
+	stdout.Reset()
+	err := validate([]string{"-format", "github", "-strict", filepath.Join(dir, "context.json")}, &stdout, &stderr)
+	if err == nil {
+		t.Fatal("validate() with -strict error = nil, want an error")
+	}
+	if !strings.HasPrefix(stdout.String(), "::error file=") || !strings.Contains(stdout.String(), ",title=Sensitivity requirement not met::") {
+		t.Errorf("validate() output = %q, want a workflow error command", stdout.String())
+	}
+}
+
+func TestValidate_invalidFile(t *testing.T) {
+	dir := t.TempDir()
+	writeContextFiles(t, dir, map[string]string{"context.yaml": "name: [api"})
+
+	var stdout, stderr bytes.Buffer
+	err := validate([]string{"-format", "text", dir}, &stdout, &stderr)
+	if err == nil {
+		t.Fatal("validate() error = nil, want an error")
+	}
+	if !strings.Contains(stdout.String(), "error: Invalid context file: decoding") {
+		t.Errorf("validate() output = %q, want the decoding error", stdout.String())
+	}
+}
+
+func TestValidate_errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{name: "no paths", args: nil, wantErr: "at least one path is required"},
+		{name: "missing path", args: []string{"missing.yaml"}, wantErr: "missing.yaml"},
+		{name: "invalid format", args: []string{"-format", "xml", "."}, wantErr: "invalid format 'xml'"},
+		{name: "invalid cloud provider", args: []string{"-cloud", "azure", "."}, wantErr: "invalid cloud provider 'azure'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			err := validate(tt.args, &stdout, &stderr)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("validate() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+# Pin the naming and tagging contract of a stack in its test configuration
+provider "brockhoff" {
+  cloud_provider = "aws"
+  tag_prefix     = "bc-"
+}
+
+data "brockhoff_context" "this" {
+  namespace        = "myorg"
+  name             = "webapp"
+  environment      = "prod"
+  environment_name = "Production"
+  cost_center      = "cc-100"
+}
+
+data "brockhoff_assert" "contract" {
+  name_prefix          = data.brockhoff_context.this.name_prefix
+  expected_name_prefix = "myorg-webapp-prod"
+
+  tags = data.brockhoff_context.this.tags
+  expected_tags = {
+    "bc-environment" = "Production"
+    "bc-costcenter"  = "cc-100"
+  }
+}
@@ -0,0 +1,22 @@
+# Preview the fields a change to the organization context would touch
+data "brockhoff_context" "org" {
+  namespace   = "myorg"
+  environment = "prod"
+  cost_center = "cc-100"
+}
+
+data "brockhoff_context" "org_proposed" {
+  namespace   = "myorg"
+  environment = "prod"
+  cost_center = "cc-200"
+  sensitivity = "confidential"
+}
+
+data "brockhoff_context_diff" "org" {
+  current  = data.brockhoff_context.org.context_output
+  proposed = data.brockhoff_context.org_proposed.context_output
+}
+
+output "org_context_changes" {
+  value = data.brockhoff_context_diff.org.changes
+}
@@ -0,0 +1,15 @@
+# Adopt the tagging of a bucket created by earlier tooling
+data "aws_s3_bucket" "legacy" {
+  bucket = "myorg-legacy-data"
+}
+
+data "brockhoff_context_from_tags" "legacy" {
+  tags = data.aws_s3_bucket.legacy.tags
+}
+
+data "brockhoff_context" "app" {
+  parent_context = data.brockhoff_context_from_tags.legacy.context_output
+  namespace      = "myorg"
+  name           = "legacy"
+  environment    = "prod"
+}
@@ -0,0 +1,24 @@
+data "brockhoff_context" "org" {
+  namespace   = "myorg"
+  environment = "prod"
+  cost_center = "cc-100"
+}
+
+data "brockhoff_merge" "team" {
+  contexts = [
+    data.brockhoff_context.org.context_output,
+    {
+      availability    = "dedicated"
+      additional_tags = { platform = "payments" }
+    },
+    {
+      cost_center     = "cc-200"
+      additional_tags = { team = "checkout" }
+    },
+  ]
+}
+
+data "brockhoff_context" "app" {
+  parent_context = data.brockhoff_merge.team.context_output
+  name           = "api"
+}
@@ -0,0 +1,18 @@
+# Keep the tagging policies of a policy-as-code repository in sync with the
+# provider configuration
+provider "brockhoff" {
+  cloud_provider = "aws"
+}
+
+data "brockhoff_policy_bundle" "opa" {
+  format = "opa"
+}
+
+resource "local_file" "opa" {
+  for_each = data.brockhoff_policy_bundle.opa.files
+
+  filename = "${path.module}/policy/${each.key}"
+  content  = each.value
+}
+
+# conftest test --policy policy --namespace brockhoff.tagging plan.json
@@ -16,6 +16,7 @@ data "brockhoff_context" "ephemeral" {
   name             = "feature-branch"
   environment      = "eph"
   environment_type = "Ephemeral" # This triggers auto-calculation of deletion_date
+  lifecycle_action = "delete"    # Required for Ephemeral; emitted as bc-expiryaction
 
   availability = "spot"
   
@@ -25,6 +26,26 @@ data "brockhoff_context" "ephemeral" {
   }
 }
 
+# Pull request preview environment - environment becomes "eph-pr42"
+variable "pr_number" {
+  description = "Pull request number set by the CI pipeline"
+  type        = number
+  default     = 42
+}
+
+data "brockhoff_context" "preview" {
+  namespace        = "test"
+  name             = "webapp"
+  environment      = "eph"
+  environment_type = "Ephemeral"
+  lifecycle_action = "delete"
+  pr_number        = var.pr_number # Appended to environment as pr<number>
+}
+
+output "preview_name" {
+  value = data.brockhoff_context.preview.name_prefix
+}
+
 output "ephemeral_name" {
   value = data.brockhoff_context.ephemeral.name_prefix
 }
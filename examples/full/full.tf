@@ -24,7 +24,7 @@ data "brockhoff_context" "full" {
   # Resource Management
   enabled       = true
   availability  = "dedicated"
-  managedby     = "terraform"
+  managed_by    = "terraform"
   deletion_date = "2024-12-31"
 
   # Project Management Integration
@@ -0,0 +1,22 @@
+locals {
+  services = toset(["api", "worker", "scheduler"])
+
+  contexts = {
+    for service in local.services : service => provider::brockhoff::context({
+      namespace        = "myorg"
+      name             = service
+      environment      = "prod"
+      environment_type = "Production"
+      availability     = "dedicated"
+      cost_center      = "cc-100"
+      cloud_provider   = "aws"
+    })
+  }
+}
+
+resource "aws_sqs_queue" "queue" {
+  for_each = local.services
+
+  name = local.contexts[each.key].name_prefix
+  tags = local.contexts[each.key].tags
+}
@@ -0,0 +1,9 @@
+# Publish the schema for editors and CI jobs validating context files
+resource "local_file" "context_schema" {
+  filename = "${path.root}/context.schema.json"
+  content  = provider::brockhoff::context_schema()
+}
+
+output "sensitivity_levels" {
+  value = jsondecode(provider::brockhoff::context_schema()).properties.sensitivity.enum
+}
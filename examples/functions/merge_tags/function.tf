@@ -0,0 +1,14 @@
+data "brockhoff_context" "app" {
+  namespace   = "myorg"
+  name        = "api"
+  environment = "prod"
+}
+
+resource "google_storage_bucket" "assets" {
+  name     = "${data.brockhoff_context.app.name_prefix}-assets"
+  location = "US"
+
+  labels = provider::brockhoff::merge_tags(data.brockhoff_context.app.tags, {
+    purpose = "Static Assets"
+  }, "gcp")
+}
@@ -0,0 +1,19 @@
+data "brockhoff_context" "app" {
+  namespace   = "myorg"
+  environment = "prod"
+}
+
+locals {
+  namespace   = data.brockhoff_context.app.context_output.namespace
+  environment = data.brockhoff_context.app.context_output.environment
+}
+
+resource "aws_db_instance" "db" {
+  identifier = provider::brockhoff::name(local.namespace, "db", local.environment, null)
+  # ...
+}
+
+resource "aws_elasticache_cluster" "cache" {
+  cluster_id = provider::brockhoff::name(local.namespace, "cache", local.environment, 40)
+  # ...
+}
@@ -0,0 +1,18 @@
+data "brockhoff_context" "app" {
+  namespace   = "myorg"
+  name        = "api"
+  environment = "prod"
+}
+
+locals {
+  object_tag_support = provider::brockhoff::tag_support("aws_s3_object")
+}
+
+resource "aws_s3_object" "config" {
+  bucket  = "${data.brockhoff_context.app.name_prefix}-config"
+  key     = "config.json"
+  content = "{}"
+
+  # S3 objects allow at most 10 tags, so only the required tags are set
+  tags = local.object_tag_support.supported ? data.brockhoff_context.app.required_tags : null
+}
@@ -0,0 +1,9 @@
+variable "availability" {
+  type    = string
+  default = "standard"
+
+  validation {
+    condition     = provider::brockhoff::validate_availability(var.availability)
+    error_message = "Must be a valid availability level."
+  }
+}
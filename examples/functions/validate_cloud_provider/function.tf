@@ -0,0 +1,9 @@
+variable "cloud_provider" {
+  type    = string
+  default = "aws"
+
+  validation {
+    condition     = provider::brockhoff::validate_cloud_provider(var.cloud_provider)
+    error_message = "Must be a valid cloud provider identifier."
+  }
+}
@@ -0,0 +1,12 @@
+data "brockhoff_merge" "team" {
+  contexts = [var.org_context, var.team_context]
+}
+
+output "context" {
+  value = data.brockhoff_merge.team.context_output
+
+  precondition {
+    condition     = provider::brockhoff::validate_context(data.brockhoff_merge.team.context_output)
+    error_message = "The merged context contains invalid values."
+  }
+}
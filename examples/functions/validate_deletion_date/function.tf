@@ -0,0 +1,9 @@
+variable "deletion_date" {
+  type    = string
+  default = null
+
+  validation {
+    condition     = provider::brockhoff::validate_deletion_date(var.deletion_date)
+    error_message = "Must be a valid deletion date."
+  }
+}
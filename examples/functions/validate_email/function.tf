@@ -0,0 +1,9 @@
+variable "owner_email" {
+  type    = string
+  default = "owner@example.com"
+
+  validation {
+    condition     = provider::brockhoff::validate_email(var.owner_email)
+    error_message = "Must be a valid email address."
+  }
+}
@@ -0,0 +1,9 @@
+variable "environment" {
+  type    = string
+  default = "prod"
+
+  validation {
+    condition     = provider::brockhoff::validate_environment(var.environment)
+    error_message = "Must be a valid environment abbreviation."
+  }
+}
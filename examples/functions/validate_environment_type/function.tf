@@ -0,0 +1,9 @@
+variable "environment_type" {
+  type    = string
+  default = "Production"
+
+  validation {
+    condition     = provider::brockhoff::validate_environment_type(var.environment_type)
+    error_message = "Must be a valid environment type."
+  }
+}
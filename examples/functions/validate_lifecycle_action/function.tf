@@ -0,0 +1,9 @@
+variable "lifecycle_action" {
+  type    = string
+  default = "delete"
+
+  validation {
+    condition     = provider::brockhoff::validate_lifecycle_action(var.lifecycle_action)
+    error_message = "Must be a valid lifecycle action."
+  }
+}
@@ -0,0 +1,9 @@
+variable "namespace" {
+  type    = string
+  default = "myorg"
+
+  validation {
+    condition     = provider::brockhoff::validate_namespace(var.namespace)
+    error_message = "Must be a valid namespace."
+  }
+}
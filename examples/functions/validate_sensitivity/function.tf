@@ -0,0 +1,9 @@
+variable "sensitivity" {
+  type    = string
+  default = "confidential"
+
+  validation {
+    condition     = provider::brockhoff::validate_sensitivity(var.sensitivity)
+    error_message = "Must be a valid data sensitivity level."
+  }
+}
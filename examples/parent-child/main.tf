@@ -20,7 +20,7 @@ data "brockhoff_context" "parent" {
 
   # Common settings for all components
   availability  = "dedicated"
-  managedby     = "terraform"
+  managed_by    = "terraform"
   cost_center   = "engineering"
   product_owners = ["product@example.com"]
   code_owners    = ["platform-team@example.com"]
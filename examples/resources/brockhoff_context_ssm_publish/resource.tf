@@ -0,0 +1,24 @@
+# Publish the team context for other stacks and applications.
+# AWS credentials and region are resolved like the AWS CLI, such as from
+# AWS_PROFILE or AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_REGION.
+data "brockhoff_context" "team" {
+  namespace   = "myorg"
+  name        = "payments"
+  environment = "prod"
+  cost_center = "cc-100"
+}
+
+resource "brockhoff_context_ssm_publish" "team" {
+  name    = "/contexts/payments/prod"
+  context = data.brockhoff_context.team.context_output
+}
+
+# In a consuming stack, with the AWS provider
+data "aws_ssm_parameter" "team" {
+  name = "/contexts/payments/prod"
+}
+
+data "brockhoff_context" "service" {
+  parent_context = jsondecode(data.aws_ssm_parameter.team.value)
+  name           = "api"
+}
@@ -0,0 +1,24 @@
+# Publish the team context for other stacks and applications.
+# Set VAULT_ADDR and VAULT_TOKEN in the environment of terraform.
+data "brockhoff_context" "team" {
+  namespace   = "myorg"
+  name        = "payments"
+  environment = "prod"
+  cost_center = "cc-100"
+}
+
+resource "brockhoff_context_vault_publish" "team" {
+  path    = "contexts/payments/prod"
+  context = data.brockhoff_context.team.context_output
+}
+
+# In a consuming stack, with the Vault provider
+data "vault_kv_secret_v2" "team" {
+  mount = "secret"
+  name  = "contexts/payments/prod"
+}
+
+data "brockhoff_context" "service" {
+  parent_context = jsondecode(data.vault_kv_secret_v2.team.data_json)
+  name           = "api"
+}
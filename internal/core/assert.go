@@ -0,0 +1,11 @@
+package core
+
+// This package re-exports from pkg/context for backward compatibility
+// New code should import from github.com/kbrockhoff/terraform-provider-context/pkg/context directly
+
+import (
+	ctx "github.com/kbrockhoff/terraform-provider-context/pkg/context"
+)
+
+// Expectation is a naming and tagging contract checked against generated values
+type Expectation = ctx.Expectation
@@ -0,0 +1,26 @@
+package core
+
+// This package re-exports from pkg/context for backward compatibility
+// New code should import from github.com/kbrockhoff/terraform-provider-context/pkg/context directly
+
+import (
+	"crypto"
+
+	ctx "github.com/kbrockhoff/terraform-provider-context/pkg/context"
+)
+
+// Environment variables holding the signing key and its cosign password
+const (
+	SigningKeyEnvVar         = ctx.SigningKeyEnvVar
+	SigningKeyPasswordEnvVar = ctx.SigningKeyPasswordEnvVar
+)
+
+// ParseSigningKey parses a cosign or unencrypted PEM private key
+func ParseSigningKey(pemData, password []byte) (crypto.Signer, error) {
+	return ctx.ParseSigningKey(pemData, password)
+}
+
+// SignDigest returns the cosign-compatible base64 signature of digest
+func SignDigest(signer crypto.Signer, digest string) (string, error) {
+	return ctx.SignDigest(signer, digest)
+}
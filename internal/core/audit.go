@@ -0,0 +1,23 @@
+package core
+
+// This package re-exports from pkg/context for backward compatibility
+// New code should import from github.com/kbrockhoff/terraform-provider-context/pkg/context directly
+
+import (
+	"context"
+
+	ctx "github.com/kbrockhoff/terraform-provider-context/pkg/context"
+)
+
+// AuditRecord describes one resolved context for the audit trail of governance teams
+type AuditRecord = ctx.AuditRecord
+
+// NewAuditRecord returns the audit record of a resolved context
+func NewAuditRecord(namePrefix string, tags map[string]string) AuditRecord {
+	return ctx.NewAuditRecord(namePrefix, tags)
+}
+
+// PostAuditRecord sends record as JSON to webhookURL
+func PostAuditRecord(c context.Context, webhookURL string, record AuditRecord) error {
+	return ctx.PostAuditRecord(c, webhookURL, record)
+}
@@ -0,0 +1,23 @@
+package core
+
+// This package re-exports from pkg/context for backward compatibility
+// New code should import from github.com/kbrockhoff/terraform-provider-context/pkg/context directly
+
+import (
+	ctx "github.com/kbrockhoff/terraform-provider-context/pkg/context"
+)
+
+// AvailabilityMatrix returns the built-in compatibility matrix with overrides applied
+func AvailabilityMatrix(overrides map[string][]string) map[string][]string {
+	return ctx.AvailabilityMatrix(overrides)
+}
+
+// ValidateAvailabilityMatrix checks the keys and levels of matrix
+func ValidateAvailabilityMatrix(matrix map[string][]string) error {
+	return ctx.ValidateAvailabilityMatrix(matrix)
+}
+
+// ValidateEnvironmentAvailability checks that availability is allowed for envType
+func ValidateEnvironmentAvailability(envType, availability string, matrix map[string][]string) error {
+	return ctx.ValidateEnvironmentAvailability(envType, availability, matrix)
+}
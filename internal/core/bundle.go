@@ -0,0 +1,25 @@
+package core
+
+// This package re-exports from pkg/context for backward compatibility
+// New code should import from github.com/kbrockhoff/terraform-provider-context/pkg/context directly
+
+import (
+	ctx "github.com/kbrockhoff/terraform-provider-context/pkg/context"
+)
+
+// Policy bundle formats
+const (
+	PolicyBundleOPA      = ctx.PolicyBundleOPA
+	PolicyBundleSentinel = ctx.PolicyBundleSentinel
+)
+
+// ValidPolicyBundleFormats contains the list of valid policy bundle formats
+var ValidPolicyBundleFormats = ctx.ValidPolicyBundleFormats
+
+// TaggingStandard is the tagging standard enforced by the provider
+type TaggingStandard = ctx.TaggingStandard
+
+// NewTaggingStandard returns the tagging standard for a cloud provider
+func NewTaggingStandard(tagPrefix string, cp CloudProvider) TaggingStandard {
+	return ctx.NewTaggingStandard(tagPrefix, cp)
+}
@@ -18,7 +18,36 @@ type (
 	DefaultProvider = ctx.DefaultProvider
 )
 
+// Optional CloudProvider interfaces
+type (
+	TagCountLimiter       = ctx.TagCountLimiter
+	ReservedTagKeyChecker = ctx.ReservedTagKeyChecker
+)
+
+// DefaultMaxTagCount is the tag count limit of cloud providers that do not implement TagCountLimiter
+const DefaultMaxTagCount = ctx.DefaultMaxTagCount
+
+// MaxTagCount returns the maximum number of tags on one resource of cp
+func MaxTagCount(cp CloudProvider) int {
+	return ctx.MaxTagCount(cp)
+}
+
+// ReservedTagKeyPrefix returns the prefix reserved by cp that key uses, or ""
+func ReservedTagKeyPrefix(cp CloudProvider, key string) string {
+	return ctx.ReservedTagKeyPrefix(cp, key)
+}
+
 // GetCloudProvider returns the appropriate CloudProvider implementation
 func GetCloudProvider(provider string) CloudProvider {
 	return ctx.GetCloudProvider(provider)
 }
+
+// ClearSanitizeCache clears the sanitized value caches of the cloud providers
+func ClearSanitizeCache() {
+	ctx.ClearSanitizeCache()
+}
+
+// SanitizeStats returns the number of tag values sanitized and cache hits
+func SanitizeStats() (lookups, cacheHits int64) {
+	return ctx.SanitizeStats()
+}
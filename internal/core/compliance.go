@@ -0,0 +1,24 @@
+package core
+
+// This package re-exports from pkg/context for backward compatibility
+// New code should import from github.com/kbrockhoff/terraform-provider-context/pkg/context directly
+
+import (
+	ctx "github.com/kbrockhoff/terraform-provider-context/pkg/context"
+)
+
+// ComplianceProfile is the rule set of a compliance framework
+type ComplianceProfile = ctx.ComplianceProfile
+
+// ComplianceProfiles are the bundled compliance profiles by name
+var ComplianceProfiles = ctx.ComplianceProfiles
+
+// ComplianceProfileNames returns the names of ComplianceProfiles, sorted
+func ComplianceProfileNames() []string {
+	return ctx.ComplianceProfileNames()
+}
+
+// ApplyComplianceProfile sets the defaults of the compliance profile of config
+func ApplyComplianceProfile(config *DataSourceConfig) {
+	ctx.ApplyComplianceProfile(config)
+}
@@ -0,0 +1,22 @@
+package core
+
+// This package re-exports from pkg/context for backward compatibility
+// New code should import from github.com/kbrockhoff/terraform-provider-context/pkg/context directly
+
+import (
+	ctx "github.com/kbrockhoff/terraform-provider-context/pkg/context"
+)
+
+// Component length limits
+const (
+	DefaultComponentMaxLength = ctx.DefaultComponentMaxLength
+	MaxComponentLength        = ctx.MaxComponentLength
+)
+
+// NamingConstraints sets the namespace and environment length limits
+type NamingConstraints = ctx.NamingConstraints
+
+// DefaultNamingConstraints returns the standard Brockhoff limits
+func DefaultNamingConstraints() NamingConstraints {
+	return ctx.DefaultNamingConstraints()
+}
@@ -0,0 +1,13 @@
+package core
+
+// This package re-exports from pkg/context for backward compatibility
+// New code should import from github.com/kbrockhoff/terraform-provider-context/pkg/context directly
+
+import (
+	ctx "github.com/kbrockhoff/terraform-provider-context/pkg/context"
+)
+
+// ContextSchema returns the JSON Schema of context documents
+func ContextSchema() []byte {
+	return ctx.ContextSchema()
+}
@@ -0,0 +1,16 @@
+package core
+
+// This package re-exports from pkg/context for backward compatibility
+// New code should import from github.com/kbrockhoff/terraform-provider-context/pkg/context directly
+
+import (
+	ctx "github.com/kbrockhoff/terraform-provider-context/pkg/context"
+)
+
+// FieldDiff lists the fields that differ between two flattened contexts
+type FieldDiff = ctx.FieldDiff
+
+// DiffFields compares two flattened contexts keyed by field name
+func DiffFields(current, proposed map[string]string) FieldDiff {
+	return ctx.DiffFields(current, proposed)
+}
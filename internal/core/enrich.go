@@ -0,0 +1,18 @@
+package core
+
+// This package re-exports from pkg/context for backward compatibility
+// New code should import from github.com/kbrockhoff/terraform-provider-context/pkg/context directly
+
+import (
+	"context"
+
+	ctx "github.com/kbrockhoff/terraform-provider-context/pkg/context"
+)
+
+// EnrichmentInput is the JSON object written to an enrichment program
+type EnrichmentInput = ctx.EnrichmentInput
+
+// RunEnrichmentProgram runs program and returns the tags it writes
+func RunEnrichmentProgram(c context.Context, program []string, input EnrichmentInput) (map[string]string, error) {
+	return ctx.RunEnrichmentProgram(c, program, input)
+}
@@ -0,0 +1,21 @@
+package core
+
+// This package re-exports from pkg/context for backward compatibility
+// New code should import from github.com/kbrockhoff/terraform-provider-context/pkg/context directly
+
+import (
+	ctx "github.com/kbrockhoff/terraform-provider-context/pkg/context"
+)
+
+// EnvironmentTypeDefaults holds the defaults of the contexts of one environment type
+type EnvironmentTypeDefaults = ctx.EnvironmentTypeDefaults
+
+// ValidateEnvironmentTypeDefaults checks the keys and values of defaults
+func ValidateEnvironmentTypeDefaults(defaults map[string]EnvironmentTypeDefaults) error {
+	return ctx.ValidateEnvironmentTypeDefaults(defaults)
+}
+
+// ApplyEnvironmentTypeDefaults sets the unset defaults of the environment type of config
+func ApplyEnvironmentTypeDefaults(config *DataSourceConfig, defaults map[string]EnvironmentTypeDefaults) {
+	ctx.ApplyEnvironmentTypeDefaults(config, defaults)
+}
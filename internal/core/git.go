@@ -4,18 +4,40 @@ package core
 // New code should import from github.com/kbrockhoff/terraform-provider-context/pkg/context directly
 
 import (
+	"time"
+
 	ctx "github.com/kbrockhoff/terraform-provider-context/pkg/context"
 )
 
 // GitInfo contains repository information
 type GitInfo = ctx.GitInfo
 
+// DefaultGitTimeout bounds the git commands run by GetGitInfo
+const DefaultGitTimeout = ctx.DefaultGitTimeout
+
 // GetGitInfo retrieves git repository information with caching
 func GetGitInfo() (*GitInfo, error) {
 	return ctx.GetGitInfo()
 }
 
+// GetGitInfoWithTimeout retrieves git repository information with caching,
+// bounding the git commands with timeout
+func GetGitInfoWithTimeout(timeout time.Duration) (*GitInfo, error) {
+	return ctx.GetGitInfoWithTimeout(timeout)
+}
+
+// GetGitInfoInDir retrieves git repository information of the repository
+// containing dir with caching, bounding the git commands with timeout
+func GetGitInfoInDir(dir string, timeout time.Duration) (*GitInfo, error) {
+	return ctx.GetGitInfoInDir(dir, timeout)
+}
+
 // ClearGitCache clears the git information cache
 func ClearGitCache() {
 	ctx.ClearGitCache()
 }
+
+// GitStats returns the number of git lookups and cache hits
+func GitStats() (lookups, cacheHits int64) {
+	return ctx.GitStats()
+}
@@ -0,0 +1,53 @@
+package core
+
+// This package re-exports from pkg/context for backward compatibility
+// New code should import from github.com/kbrockhoff/terraform-provider-context/pkg/context directly
+
+import (
+	"context"
+
+	ctx "github.com/kbrockhoff/terraform-provider-context/pkg/context"
+)
+
+// Group directory types and owner group tag modes
+const (
+	GroupDirectoryMicrosoftGraph = ctx.GroupDirectoryMicrosoftGraph
+	GroupDirectoryGoogle         = ctx.GroupDirectoryGoogle
+
+	OwnerGroupTagsDisplayName = ctx.OwnerGroupTagsDisplayName
+	OwnerGroupTagsMemberCount = ctx.OwnerGroupTagsMemberCount
+)
+
+// GroupDirectoryTokenEnvVars are the access token variables of each directory type
+var GroupDirectoryTokenEnvVars = ctx.GroupDirectoryTokenEnvVars
+
+// Group is a distribution list or security group found in a directory
+type Group = ctx.Group
+
+// GroupDirectory looks up groups by email address
+type GroupDirectory = ctx.GroupDirectory
+
+// NewGroupDirectory returns the directory of directoryType
+func NewGroupDirectory(directoryType, token, baseURL string) (GroupDirectory, error) {
+	return ctx.NewGroupDirectory(directoryType, token, baseURL)
+}
+
+// CacheGroupDirectory wraps directory so that each address is looked up once
+func CacheGroupDirectory(directory GroupDirectory) GroupDirectory {
+	return ctx.CacheGroupDirectory(directory)
+}
+
+// LookupOwnerGroups returns the owner addresses found as groups in directory
+func LookupOwnerGroups(c context.Context, directory GroupDirectory, owners ...[]string) (map[string]Group, error) {
+	return ctx.LookupOwnerGroups(c, directory, owners...)
+}
+
+// ValidateOwnerGroupTags validates an owner group tag mode
+func ValidateOwnerGroupTags(mode string) error {
+	return ctx.ValidateOwnerGroupTags(mode)
+}
+
+// GroupDirectoryStats returns the number of group directory lookups and cache hits
+func GroupDirectoryStats() (lookups, cacheHits int64) {
+	return ctx.GroupDirectoryStats()
+}
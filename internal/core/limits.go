@@ -0,0 +1,19 @@
+package core
+
+// This package re-exports from pkg/context for backward compatibility
+// New code should import from github.com/kbrockhoff/terraform-provider-context/pkg/context directly
+
+import (
+	ctx "github.com/kbrockhoff/terraform-provider-context/pkg/context"
+)
+
+// Soft limits of additional tags
+const (
+	AdditionalTagsSoftLimit      = ctx.AdditionalTagsSoftLimit
+	AdditionalTagsSoftLimitBytes = ctx.AdditionalTagsSoftLimitBytes
+)
+
+// CheckAdditionalTagLimits returns warnings when tags exceed the soft limits
+func CheckAdditionalTagLimits(field string, tags map[string]string) []string {
+	return ctx.CheckAdditionalTagLimits(field, tags)
+}
@@ -0,0 +1,43 @@
+package core
+
+// This package re-exports from pkg/context for backward compatibility
+// New code should import from github.com/kbrockhoff/terraform-provider-context/pkg/context directly
+
+import (
+	ctx "github.com/kbrockhoff/terraform-provider-context/pkg/context"
+)
+
+// Name availability check actions
+const (
+	NameAvailabilityCheckWarn  = ctx.NameAvailabilityCheckWarn
+	NameAvailabilityCheckError = ctx.NameAvailabilityCheckError
+)
+
+// NameAvailability is the result of a name availability check
+type NameAvailability = ctx.NameAvailability
+
+// Name availability check results
+const (
+	NameAvailable = ctx.NameAvailable
+	NameOwned     = ctx.NameOwned
+	NameTaken     = ctx.NameTaken
+)
+
+// NameChecker checks whether a globally unique resource name is available
+type NameChecker = ctx.NameChecker
+
+// NameValidator is implemented by name checkers that know the naming rules of their resource
+type NameValidator = ctx.NameValidator
+
+// ValidCheckName reports whether name can be the name of the resource of checker
+func ValidCheckName(checker NameChecker, name string) bool {
+	return ctx.ValidCheckName(checker, name)
+}
+
+// ErrNameCheckNotConfigured is returned when the cloud credentials of name availability checks are not set
+var ErrNameCheckNotConfigured = ctx.ErrNameCheckNotConfigured
+
+// ValidateNameAvailabilityCheck validates a name availability check action
+func ValidateNameAvailabilityCheck(action string) error {
+	return ctx.ValidateNameAvailabilityCheck(action)
+}
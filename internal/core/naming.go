@@ -11,7 +11,44 @@ import (
 const (
 	MaxNamePrefixLength = ctx.MaxNamePrefixLength
 	MinNamePrefixLength = ctx.MinNamePrefixLength
+
+	DefaultShortNameLength = ctx.DefaultShortNameLength
+	MinShortNameLength     = ctx.MinShortNameLength
 )
 
+// DefaultReservedWords are the built-in reserved words checked in name prefixes
+var DefaultReservedWords = ctx.DefaultReservedWords
+
 // NameGenerator handles name prefix generation
 type NameGenerator = ctx.NameGenerator
+
+// NameComponent identifies one component of a generated name prefix
+type NameComponent = ctx.NameComponent
+
+// NameOptions customizes name prefix generation
+type NameOptions = ctx.NameOptions
+
+// DefaultNameOptions returns the options for the standard Brockhoff format
+func DefaultNameOptions() NameOptions {
+	return ctx.DefaultNameOptions()
+}
+
+// ShortenNamePrefix fits a name prefix into maxLength using a hash suffix
+func ShortenNamePrefix(namePrefix, delimiter string, maxLength int) (string, error) {
+	return ctx.ShortenNamePrefix(namePrefix, delimiter, maxLength)
+}
+
+// SurrogateID returns the deterministic ID of a context without a name
+func SurrogateID(namePrefix string, config *DataSourceConfig) (string, error) {
+	return ctx.SurrogateID(namePrefix, config)
+}
+
+// FindReservedWords returns the reserved words contained in name
+func FindReservedWords(name string, additional []string) []string {
+	return ctx.FindReservedWords(name, additional)
+}
+
+// RemoveReservedWords removes the reserved words from name
+func RemoveReservedWords(name, delimiter string, additional []string) string {
+	return ctx.RemoveReservedWords(name, delimiter, additional)
+}
@@ -0,0 +1,26 @@
+package core
+
+// This package re-exports from pkg/context for backward compatibility
+// New code should import from github.com/kbrockhoff/terraform-provider-context/pkg/context directly
+
+import (
+	ctx "github.com/kbrockhoff/terraform-provider-context/pkg/context"
+)
+
+// ConvertTagsToDatadog converts tags to normalized Datadog key:value tags
+func ConvertTagsToDatadog(tags map[string]string) []string {
+	return ctx.ConvertTagsToDatadog(tags)
+}
+
+// ConvertTagsToNewRelic converts tags to New Relic entity tags within its length limits
+func ConvertTagsToNewRelic(tags map[string]string) map[string]string {
+	return ctx.ConvertTagsToNewRelic(tags)
+}
+
+// EventFieldNamespace is the field namespace of the event_fields output
+const EventFieldNamespace = ctx.EventFieldNamespace
+
+// ConvertToEventFields converts fields to namespaced Splunk HEC or Elastic Common Schema custom fields
+func ConvertToEventFields(fields map[string]string, namespace string) map[string]string {
+	return ctx.ConvertToEventFields(fields, namespace)
+}
@@ -0,0 +1,27 @@
+package core
+
+// This package re-exports from pkg/context for backward compatibility
+// New code should import from github.com/kbrockhoff/terraform-provider-context/pkg/context directly
+
+import (
+	ctx "github.com/kbrockhoff/terraform-provider-context/pkg/context"
+)
+
+// Management platforms reported by DetectManagedBy
+const (
+	ManagedByTerraform    = ctx.ManagedByTerraform
+	ManagedByHCPTerraform = ctx.ManagedByHCPTerraform
+	ManagedBySpacelift    = ctx.ManagedBySpacelift
+	ManagedByAtlantis     = ctx.ManagedByAtlantis
+)
+
+// DetectManagedBy returns the platform running Terraform
+func DetectManagedBy() string {
+	return ctx.DetectManagedBy()
+}
+
+// DetectTerragruntSourceDir returns the directory of the Terragrunt unit
+// whose copy in the Terragrunt download directory is wd, or ""
+func DetectTerragruntSourceDir(wd string) string {
+	return ctx.DetectTerragruntSourceDir(wd)
+}
@@ -0,0 +1,27 @@
+package core
+
+// This package re-exports from pkg/context for backward compatibility
+// New code should import from github.com/kbrockhoff/terraform-provider-context/pkg/context directly
+
+import (
+	"context"
+
+	ctx "github.com/kbrockhoff/terraform-provider-context/pkg/context"
+)
+
+// PolicyPackage is the package of the rego rules evaluated by a Policy
+const PolicyPackage = ctx.PolicyPackage
+
+// Policy evaluates rego rules against resolved contexts
+type Policy = ctx.Policy
+
+// PolicyInput is the input document of the rules of a Policy
+type PolicyInput = ctx.PolicyInput
+
+// PolicyDeniedError reports the deny messages of a Policy
+type PolicyDeniedError = ctx.PolicyDeniedError
+
+// LoadPolicy compiles the .rego files at path
+func LoadPolicy(c context.Context, path string) (*Policy, error) {
+	return ctx.LoadPolicy(c, path)
+}
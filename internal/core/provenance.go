@@ -0,0 +1,21 @@
+package core
+
+// This package re-exports from pkg/context for backward compatibility
+// New code should import from github.com/kbrockhoff/terraform-provider-context/pkg/context directly
+
+import (
+	ctx "github.com/kbrockhoff/terraform-provider-context/pkg/context"
+)
+
+// ProvenanceInfo identifies the builder that ran Terraform
+type ProvenanceInfo = ctx.ProvenanceInfo
+
+// GetProvenanceInfo returns the builder identity detected from the environment
+func GetProvenanceInfo() *ProvenanceInfo {
+	return ctx.GetProvenanceInfo()
+}
+
+// ClearProvenanceCache clears the builder identity cache
+func ClearProvenanceCache() {
+	ctx.ClearProvenanceCache()
+}
@@ -0,0 +1,15 @@
+package core
+
+// This package re-exports from pkg/context for backward compatibility
+// New code should import from github.com/kbrockhoff/terraform-provider-context/pkg/context directly
+
+import (
+	"context"
+
+	ctx "github.com/kbrockhoff/terraform-provider-context/pkg/context"
+)
+
+// LoadNamespaceRegistry reads the allowed namespaces from a file or URL
+func LoadNamespaceRegistry(c context.Context, source string, constraints NamingConstraints) ([]string, error) {
+	return ctx.LoadNamespaceRegistry(c, source, constraints)
+}
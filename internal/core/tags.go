@@ -10,14 +10,48 @@ import (
 // TagProcessor handles tag generation and processing
 type TagProcessor = ctx.TagProcessor
 
+// IAM condition keys for attribute-based access control on tags
+const (
+	IAMResourceTag = ctx.IAMResourceTag
+	IAMRequestTag  = ctx.IAMRequestTag
+)
+
+// Tag keys checked by TagProcessor.HasTags for ownership and source repository tags
+var (
+	OwnerTagKeys  = ctx.OwnerTagKeys
+	SourceTagKeys = ctx.SourceTagKeys
+)
+
+// TagCompliance summarizes whether tags meet common tagging policies
+type TagCompliance = ctx.TagCompliance
+
+// TagKeyCollision is a set of tag keys a cloud provider treats as the same key
+type TagKeyCollision = ctx.TagKeyCollision
+
 // DataSourceConfig contains all configuration fields from the data source
 type DataSourceConfig = ctx.DataSourceConfig
 
+// NewDataSourceConfig returns a config with the data source defaults for boolean fields
+func NewDataSourceConfig() *DataSourceConfig {
+	return ctx.NewDataSourceConfig()
+}
+
 // ProcessEphemeralEnvironment handles ephemeral environment special logic
 func ProcessEphemeralEnvironment(config *DataSourceConfig) {
 	ctx.ProcessEphemeralEnvironment(config)
 }
 
+// MergeTags merges override into base and sanitizes the values for the cloud provider
+func MergeTags(base, override map[string]string, cp CloudProvider) map[string]string {
+	return ctx.MergeTags(base, override, cp)
+}
+
+// MergeAdditionalTags overlays parent additional tags with child additional
+// tags, optionally matching keys case-insensitively
+func MergeAdditionalTags(parent, child map[string]string, caseInsensitiveKeys bool) map[string]string {
+	return ctx.MergeAdditionalTags(parent, child, caseInsensitiveKeys)
+}
+
 // ConvertTagsToListOfMaps converts tags map to list of maps for AWS
 func ConvertTagsToListOfMaps(tags map[string]string) []map[string]string {
 	return ctx.ConvertTagsToListOfMaps(tags)
@@ -32,3 +66,16 @@ func ConvertTagsToKVPList(tags map[string]string) []string {
 func ConvertTagsToCommaSeparated(tags map[string]string) string {
 	return ctx.ConvertTagsToCommaSeparated(tags)
 }
+
+// ConfigFromTags reverse-maps generated tags into a config
+func ConfigFromTags(tags map[string]string, tagPrefix string, cp CloudProvider) *DataSourceConfig {
+	return ctx.ConfigFromTags(tags, tagPrefix, cp)
+}
+
+// TagSupport describes how a Terraform resource type accepts tags
+type TagSupport = ctx.TagSupport
+
+// LookupTagSupport returns the tag support of a Terraform resource type
+func LookupTagSupport(resourceType string) (TagSupport, bool) {
+	return ctx.LookupTagSupport(resourceType)
+}
@@ -0,0 +1,19 @@
+package core
+
+// This package re-exports from pkg/context for backward compatibility
+// New code should import from github.com/kbrockhoff/terraform-provider-context/pkg/context directly
+
+import (
+	ctx "github.com/kbrockhoff/terraform-provider-context/pkg/context"
+)
+
+// LatestTagSchemaVersion is the tag schema version generated when none is pinned
+const LatestTagSchemaVersion = ctx.LatestTagSchemaVersion
+
+// TagSchema describes how a tag schema version differs from the latest version
+type TagSchema = ctx.TagSchema
+
+// GetTagSchema returns the behavior of a tag schema version
+func GetTagSchema(version int) (TagSchema, error) {
+	return ctx.GetTagSchema(version)
+}
@@ -0,0 +1,28 @@
+package core
+
+// This package re-exports from pkg/context for backward compatibility
+// New code should import from github.com/kbrockhoff/terraform-provider-context/pkg/context directly
+
+import (
+	ctx "github.com/kbrockhoff/terraform-provider-context/pkg/context"
+)
+
+// Outputs of the context data source holding generated tags
+const (
+	TagOutputTags     = ctx.TagOutputTags
+	TagOutputDataTags = ctx.TagOutputDataTags
+)
+
+// TagSpec describes a tag generated by TagProcessor
+type TagSpec = ctx.TagSpec
+
+// TagSpecs are the tags generated by Process and ProcessDataTags
+var TagSpecs = ctx.TagSpecs
+
+// CloudTagRules are the rules a cloud provider applies to tag values
+type CloudTagRules = ctx.CloudTagRules
+
+// TagRules returns the tag value rules of a cloud provider
+func TagRules(cloudProvider string) CloudTagRules {
+	return ctx.TagRules(cloudProvider)
+}
@@ -0,0 +1,24 @@
+package core
+
+// This package re-exports from pkg/context for backward compatibility
+// New code should import from github.com/kbrockhoff/terraform-provider-context/pkg/context directly
+
+import (
+	ctx "github.com/kbrockhoff/terraform-provider-context/pkg/context"
+)
+
+// TokenizationKeyEnvVar is the environment variable holding the tokenization key
+const TokenizationKeyEnvVar = ctx.TokenizationKeyEnvVar
+
+// TokenizableTagKeys are the tag keys whose values can be tokenized
+var TokenizableTagKeys = ctx.TokenizableTagKeys
+
+// TokenizeValue returns the HMAC token of value
+func TokenizeValue(key []byte, value string) string {
+	return ctx.TokenizeValue(key, value)
+}
+
+// ValidateTokenizeFields validates tokenize field list entries
+func ValidateTokenizeFields(fields []string) error {
+	return ctx.ValidateTokenizeFields(fields)
+}
@@ -0,0 +1,24 @@
+package core
+
+// This package re-exports from pkg/context for backward compatibility
+// New code should import from github.com/kbrockhoff/terraform-provider-context/pkg/context directly
+
+import (
+	ctx "github.com/kbrockhoff/terraform-provider-context/pkg/context"
+)
+
+// TypedTag is an additional tag whose value is a string, a number or a bool
+type TypedTag = ctx.TypedTag
+
+// Types of TypedTag values
+const (
+	TypedTagString = ctx.TypedTagString
+	TypedTagNumber = ctx.TypedTagNumber
+	TypedTagBool   = ctx.TypedTagBool
+)
+
+// MergeAdditionalTypedTags overlays parent typed tags with child typed tags,
+// optionally matching keys case-insensitively
+func MergeAdditionalTypedTags(parent, child map[string]TypedTag, caseInsensitiveKeys bool) map[string]TypedTag {
+	return ctx.MergeAdditionalTags(parent, child, caseInsensitiveKeys)
+}
@@ -9,10 +9,17 @@ import (
 
 // Exported validation constants
 var (
-	ValidCloudProviders     = ctx.ValidCloudProviders
-	ValidEnvironmentTypes   = ctx.ValidEnvironmentTypes
-	ValidAvailabilityLevels = ctx.ValidAvailabilityLevels
-	ValidSensitivityLevels  = ctx.ValidSensitivityLevels
+	ValidCloudProviders      = ctx.ValidCloudProviders
+	ValidEnvironmentTypes    = ctx.ValidEnvironmentTypes
+	ValidAvailabilityLevels  = ctx.ValidAvailabilityLevels
+	ValidSensitivityLevels   = ctx.ValidSensitivityLevels
+	ValidTiers               = ctx.ValidTiers
+	ValidLifecycleActions    = ctx.ValidLifecycleActions
+	ValidNameDelimiters      = ctx.ValidNameDelimiters
+	ValidReservedWordActions = ctx.ValidReservedWordActions
+	ValidSanitizationModes   = ctx.ValidSanitizationModes
+
+	ValidLengthOverflowPolicies = ctx.ValidLengthOverflowPolicies
 )
 
 // Validation functions
@@ -20,10 +27,55 @@ func ValidateNamespace(namespace string) error {
 	return ctx.ValidateNamespace(namespace)
 }
 
+// ValidateAllowedNamespace checks namespace against the allowed namespaces
+func ValidateAllowedNamespace(namespace string, allowed []string, registry string) error {
+	return ctx.ValidateAllowedNamespace(namespace, allowed, registry)
+}
+
 func ValidateEnvironment(environment string) error {
 	return ctx.ValidateEnvironment(environment)
 }
 
+func ValidateTenant(tenant string) error {
+	return ctx.ValidateTenant(tenant)
+}
+
+func ValidateMaintenanceWindow(window string) error {
+	return ctx.ValidateMaintenanceWindow(window)
+}
+
+func ValidateTier(tier string) error {
+	return ctx.ValidateTier(tier)
+}
+
+func ValidateTenantID(tenantID string) error {
+	return ctx.ValidateTenantID(tenantID)
+}
+
+func ValidateCustomer(customer string) error {
+	return ctx.ValidateCustomer(customer)
+}
+
+func ValidateBusinessUnit(businessUnit string) error {
+	return ctx.ValidateBusinessUnit(businessUnit)
+}
+
+func ValidateDivision(division string) error {
+	return ctx.ValidateDivision(division)
+}
+
+func ValidatePortfolio(portfolio string) error {
+	return ctx.ValidatePortfolio(portfolio)
+}
+
+func ValidateAttributes(attributes []string) error {
+	return ctx.ValidateAttributes(attributes)
+}
+
+func ValidateLabelOrder(order []string) error {
+	return ctx.ValidateLabelOrder(order)
+}
+
 func ValidateCloudProvider(provider string) error {
 	return ctx.ValidateCloudProvider(provider)
 }
@@ -40,10 +92,94 @@ func ValidateSensitivity(sensitivity string) error {
 	return ctx.ValidateSensitivity(sensitivity)
 }
 
+func ValidateDataRetention(retention string) error {
+	return ctx.ValidateDataRetention(retention)
+}
+
+func ValidateDataResidency(codes []string) error {
+	return ctx.ValidateDataResidency(codes)
+}
+
+func ValidateRegion(region string) error {
+	return ctx.ValidateRegion(region)
+}
+
+func ValidateRegionResidency(region string, residency []string) error {
+	return ctx.ValidateRegionResidency(region, residency)
+}
+
+func ValidateComplianceProfile(profile string) error {
+	return ctx.ValidateComplianceProfile(profile)
+}
+
 func ValidateDeletionDate(date string) error {
 	return ctx.ValidateDeletionDate(date)
 }
 
+func ValidateDecommission(envType, deletionDate string, approved bool) error {
+	return ctx.ValidateDecommission(envType, deletionDate, approved)
+}
+
+func ValidateLifecycleAction(action string) error {
+	return ctx.ValidateLifecycleAction(action)
+}
+
+func ValidateNameDelimiter(delimiter string) error {
+	return ctx.ValidateNameDelimiter(delimiter)
+}
+
+func ValidateReservedWordAction(action string) error {
+	return ctx.ValidateReservedWordAction(action)
+}
+
+func ValidateSanitizationMode(mode string) error {
+	return ctx.ValidateSanitizationMode(mode)
+}
+
+func ValidateLengthOverflow(policy string) error {
+	return ctx.ValidateLengthOverflow(policy)
+}
+
+func ValidateNAFields(fields []string) error {
+	return ctx.ValidateNAFields(fields)
+}
+
+func ValidateListDelimiter(delimiter string, cp CloudProvider) error {
+	return ctx.ValidateListDelimiter(delimiter, cp)
+}
+
+func ValidatePRNumber(prNumber int) error {
+	return ctx.ValidatePRNumber(prNumber)
+}
+
+func ValidateEphemeralSuffix(suffix string) error {
+	return ctx.ValidateEphemeralSuffix(suffix)
+}
+
+func ValidateMonthlyBudget(budget float64) error {
+	return ctx.ValidateMonthlyBudget(budget)
+}
+
+func ValidateBudgetCurrency(currency string) error {
+	return ctx.ValidateBudgetCurrency(currency)
+}
+
+func ValidateTagSchemaVersion(version int) error {
+	return ctx.ValidateTagSchemaVersion(version)
+}
+
+func ValidateLegacyTagMap(legacyTagMap map[string]string) error {
+	return ctx.ValidateLegacyTagMap(legacyTagMap)
+}
+
+func ValidateAdditionalTypedTags(tags map[string]TypedTag) error {
+	return ctx.ValidateAdditionalTypedTags(tags)
+}
+
+func ValidateLegacyTagsUntil(date string) error {
+	return ctx.ValidateLegacyTagsUntil(date)
+}
+
 func ValidateEmail(email string) error {
 	return ctx.ValidateEmail(email)
 }
@@ -51,3 +187,18 @@ func ValidateEmail(email string) error {
 func ValidateEmails(emails []string) error {
 	return ctx.ValidateEmails(emails)
 }
+
+// ValidateEmailsStrict validates email addresses against the ASCII-only pattern
+func ValidateEmailsStrict(emails []string) error {
+	return ctx.ValidateEmailsStrict(emails)
+}
+
+// NormalizeOwners lowercases, trims, deduplicates and sorts owner emails
+func NormalizeOwners(owners []string) []string {
+	return ctx.NormalizeOwners(owners)
+}
+
+// NormalizeEmails converts the domains of email addresses to punycode
+func NormalizeEmails(emails []string) ([]string, error) {
+	return ctx.NormalizeEmails(emails)
+}
@@ -0,0 +1,147 @@
+package datasource
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/kbrockhoff/terraform-provider-context/internal/core"
+	"github.com/kbrockhoff/terraform-provider-context/internal/metrics"
+	"github.com/kbrockhoff/terraform-provider-context/internal/tracing"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &AssertDataSource{}
+
+func NewAssertDataSource() datasource.DataSource {
+	return &AssertDataSource{}
+}
+
+// AssertDataSource fails when generated names and tags do not match
+// expected values.
+type AssertDataSource struct{}
+
+// AssertDataSourceModel describes the data source data model.
+type AssertDataSourceModel struct {
+	NamePrefix         types.String `tfsdk:"name_prefix"`
+	ExpectedNamePrefix types.String `tfsdk:"expected_name_prefix"`
+	Tags               types.Map    `tfsdk:"tags"`
+	ExpectedTags       types.Map    `tfsdk:"expected_tags"`
+	ExactTags          types.Bool   `tfsdk:"exact_tags"`
+
+	// Computed Outputs
+	ID types.String `tfsdk:"id"`
+}
+
+func (d *AssertDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_assert"
+}
+
+func (d *AssertDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Fails with a diff when a generated name prefix or tags do not match expected values, so test stacks can pin their naming and tagging contracts.",
+
+		Attributes: map[string]schema.Attribute{
+			"name_prefix": schema.StringAttribute{
+				Description: "Generated name prefix to check, such as data.brockhoff_context.this.name_prefix",
+				Optional:    true,
+			},
+			"expected_name_prefix": schema.StringAttribute{
+				Description: "Expected name prefix; requires name_prefix",
+				Optional:    true,
+			},
+			"tags": schema.MapAttribute{
+				Description: "Generated tags to check, such as data.brockhoff_context.this.tags",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"expected_tags": schema.MapAttribute{
+				Description: "Expected tag values keyed with the tag prefix; other tags are not checked unless exact_tags is set. Requires tags",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"exact_tags": schema.BoolAttribute{
+				Description: "Also fail on tags not in expected_tags (default: false)",
+				Optional:    true,
+			},
+
+			// Computed Outputs
+			"id": schema.StringAttribute{
+				Description: "Unique identifier for this data source instance",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *AssertDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := tracing.StartSpan(ctx, "AssertDataSource.Read")
+	start := time.Now()
+	defer func() {
+		tracing.EndSpan(span, resp.Diagnostics)
+		metrics.RecordRead("brockhoff_assert", time.Since(start), resp.Diagnostics)
+	}()
+
+	if deferUnknownConfig(ctx, req, resp) {
+		return
+	}
+
+	var data AssertDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.ExpectedNamePrefix.IsNull() && data.NamePrefix.IsNull() {
+		resp.Diagnostics.AddError("Missing name_prefix", "name_prefix is required when expected_name_prefix is set")
+	}
+	if !data.ExpectedTags.IsNull() && data.Tags.IsNull() {
+		resp.Diagnostics.AddError("Missing tags", "tags is required when expected_tags is set")
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	expectation := core.Expectation{ExactTags: data.ExactTags.ValueBool()}
+	if !data.ExpectedNamePrefix.IsNull() {
+		expectation.NamePrefix = data.ExpectedNamePrefix.ValueStringPointer()
+	}
+	resp.Diagnostics.Append(data.ExpectedTags.ElementsAs(ctx, &expectation.Tags, false)...)
+	var tags map[string]string
+	resp.Diagnostics.Append(data.Tags.ElementsAs(ctx, &tags, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if diff := expectation.Diff(data.NamePrefix.ValueString(), tags); len(diff) > 0 {
+		resp.Diagnostics.AddError("Assertion failed",
+			fmt.Sprintf("The generated values do not match the expected values:\n\n  %s", strings.Join(diff, "\n  ")))
+		return
+	}
+
+	var contents strings.Builder
+	fmt.Fprintf(&contents, "%s\n", data.ExpectedNamePrefix.ValueString())
+	for _, key := range slices.Sorted(maps.Keys(expectation.Tags)) {
+		fmt.Fprintf(&contents, "%s=%s\n", key, expectation.Tags[key])
+	}
+	data.ID = types.StringValue(fmt.Sprintf("%x", sha256.Sum256([]byte(contents.String())))[:16])
+
+	tflog.Debug(ctx, "Assert data source read", map[string]interface{}{
+		"expected_tags": len(expectation.Tags),
+		"exact_tags":    expectation.ExactTags,
+	})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -2,15 +2,29 @@ package datasource
 
 import (
 	"context"
+	"crypto"
+	"errors"
 	"fmt"
+	"maps"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/kbrockhoff/terraform-provider-context/internal/core"
+	"github.com/kbrockhoff/terraform-provider-context/internal/metrics"
+	"github.com/kbrockhoff/terraform-provider-context/internal/tracing"
+	"github.com/kbrockhoff/terraform-provider-context/pkg/integrations"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -19,8 +33,152 @@ var _ datasource.DataSourceWithConfigure = &ContextDataSource{}
 
 // ProviderConfig holds provider-level configuration
 type ProviderConfig struct {
-	CloudProvider string
-	TagPrefix     string
+	CloudProvider    string
+	TagPrefix        string
+	TerraformVersion string
+	ProviderVersion  string
+	DetectManagedBy  bool
+
+	// TagPrefixByNamespace overrides TagPrefix for the namespaces it holds
+	TagPrefixByNamespace map[string]string
+
+	// DefaultsByEnvironmentType holds the availability, sensitivity and
+	// data retention defaults of environment types
+	DefaultsByEnvironmentType map[string]core.EnvironmentTypeDefaults
+
+	// AllowedNamespaces restricts namespaces to a registry when not empty
+	AllowedNamespaces    []string
+	NamespaceRegistryURL string
+
+	// NamingConstraints sets the namespace and environment length limits
+	NamingConstraints core.NamingConstraints
+
+	// StrictMode turns the sensitivity requirements, such as a data
+	// retention period for restricted data, from warnings into errors
+	StrictMode bool
+
+	// AvailabilityMatrix restricts the availability levels of the
+	// environment types it holds. It is only set under StrictMode.
+	AvailabilityMatrix map[string][]string
+
+	// StrictEmailValidation checks owners against the ASCII-only pattern
+	StrictEmailValidation bool
+	PunycodeEmailDomains  bool
+
+	// GroupDirectory, when set, looks up owner addresses that are groups,
+	// shown in the owner tags as selected by OwnerGroupTags
+	GroupDirectory core.GroupDirectory
+	OwnerGroupTags string
+
+	// TokenizationKey is the HMAC key of the tokenize_fields tokens, read
+	// from CONTEXT_PROVIDER_TOKENIZATION_KEY
+	TokenizationKey []byte
+
+	// Signer, when set, signs context_digest into context_signature
+	Signer crypto.Signer
+
+	// AuditWebhookURL, when set, receives an audit record from each publish
+	// resource create and update
+	AuditWebhookURL string
+
+	// EnrichmentProgram, when set, is run with the resolved context and
+	// returns tags to merge
+	EnrichmentProgram []string
+
+	// Policy, when set, can reject the final tags and add derived tags
+	Policy *core.Policy
+
+	// GitTimeout bounds the git commands of the source repository tags
+	GitTimeout time.Duration
+
+	// GitRoot, when set, is the directory of the repository of the source
+	// repository tags instead of the working directory
+	GitRoot string
+
+	// SourceDir, when set, is the directory relative paths refer to instead
+	// of the working directory
+	SourceDir string
+}
+
+// ResolvePath returns path relative to SourceDir, unless it is absolute
+func (c *ProviderConfig) ResolvePath(path string) string {
+	if c.SourceDir == "" || filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(c.SourceDir, path)
+}
+
+// GitRootFor returns the directory of the repository of the source
+// repository tags of a context with gitRoot, which overrides GitRoot when set
+func (c *ProviderConfig) GitRootFor(gitRoot string) string {
+	if gitRoot == "" {
+		return c.GitRoot
+	}
+	return c.ResolvePath(gitRoot)
+}
+
+// TagPrefixFor returns the tag prefix of the contexts of namespace
+func (c *ProviderConfig) TagPrefixFor(namespace string) string {
+	if prefix, ok := c.TagPrefixByNamespace[namespace]; ok {
+		return prefix
+	}
+	return c.TagPrefix
+}
+
+// maxSanitizationWarnings is the number of sanitized tag values reported
+// individually; the others are counted in one warning
+const maxSanitizationWarnings = 10
+
+// ownerGroupModel describes an element of owner_groups.
+type ownerGroupModel struct {
+	DisplayName types.String `tfsdk:"display_name"`
+	MemberCount types.Int64  `tfsdk:"member_count"`
+}
+
+// ownerGroupType is the element type of owner_groups
+var ownerGroupType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"display_name": types.StringType,
+	"member_count": types.Int64Type,
+}}
+
+// namedOutputModel describes an element of named_outputs.
+type namedOutputModel struct {
+	NamePrefix      types.String `tfsdk:"name_prefix"`
+	NamePrefixShort types.String `tfsdk:"name_prefix_short"`
+}
+
+// namedOutputType is the element type of named_outputs
+var namedOutputType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"name_prefix":       types.StringType,
+	"name_prefix_short": types.StringType,
+}}
+
+// typedTagModel describes an element of additional_typed_tags.
+type typedTagModel struct {
+	Value types.String `tfsdk:"value"`
+	Type  types.String `tfsdk:"type"`
+}
+
+// typedTagType is the element type of additional_typed_tags
+var typedTagType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"value": types.StringType,
+	"type":  types.StringType,
+}}
+
+// complianceModel describes the compliance output.
+type complianceModel struct {
+	OwnersPresent         types.Bool `tfsdk:"owners_present"`
+	CostCenterPresent     types.Bool `tfsdk:"cost_center_present"`
+	ExpirySetForEphemeral types.Bool `tfsdk:"expiry_set_for_ephemeral"`
+	WithinTagLimits       types.Bool `tfsdk:"within_tag_limits"`
+}
+
+// complianceTypes are the attribute types of the compliance output
+var complianceTypes = map[string]attr.Type{
+	"owners_present":           types.BoolType,
+	"cost_center_present":      types.BoolType,
+	"expiry_set_for_ephemeral": types.BoolType,
+	"within_tag_limits":        types.BoolType,
 }
 
 func NewContextDataSource() datasource.DataSource {
@@ -36,16 +194,48 @@ type ContextDataSource struct {
 type ContextInputModel struct {
 	// Naming Configuration
 	Namespace       types.String `tfsdk:"namespace"`
+	Tenant          types.String `tfsdk:"tenant"`
 	Environment     types.String `tfsdk:"environment"`
+	Attributes      types.List   `tfsdk:"attributes"`
 	EnvironmentName types.String `tfsdk:"environment_name"`
 	EnvironmentType types.String `tfsdk:"environment_type"`
+	NameDelimiter   types.String `tfsdk:"name_delimiter"`
+	LabelOrder      types.List   `tfsdk:"label_order"`
+	ListDelimiter   types.String `tfsdk:"list_join_delimiter"`
+
+	ReservedWords      types.List   `tfsdk:"reserved_words"`
+	ReservedWordAction types.String `tfsdk:"reserved_word_action"`
+
+	// Stack Identity
+	StackName types.String `tfsdk:"stack_name"`
+
+	// Application
+	Application types.String `tfsdk:"application"`
+	Service     types.String `tfsdk:"service"`
+	Tier        types.String `tfsdk:"tier"`
+
+	// Organization Hierarchy
+	BusinessUnit types.String `tfsdk:"business_unit"`
+	Division     types.String `tfsdk:"division"`
+	Portfolio    types.String `tfsdk:"portfolio"`
+
+	// SaaS Tenancy
+	TenantID types.String `tfsdk:"tenant_id"`
+	Customer types.String `tfsdk:"customer"`
 
 	// Resource Management
 	Enabled      types.Bool   `tfsdk:"enabled"`
 	Availability types.String `tfsdk:"availability"`
-	ManagedBy    types.String `tfsdk:"managedby"`
+	ManagedBy    types.String `tfsdk:"managed_by"`
 	DeletionDate types.String `tfsdk:"deletion_date"`
 
+	LifecycleAction types.String `tfsdk:"lifecycle_action"`
+
+	// Operations Automation
+	MaintenanceWindow types.String `tfsdk:"maintenance_window"`
+	PatchGroup        types.String `tfsdk:"patch_group"`
+	Region            types.String `tfsdk:"region"`
+
 	// Project Management Integration
 	PMPlatform    types.String `tfsdk:"pm_platform"`
 	PMProjectCode types.String `tfsdk:"pm_project_code"`
@@ -62,21 +252,59 @@ type ContextInputModel struct {
 	CodeOwners    types.List   `tfsdk:"code_owners"`
 	DataOwners    types.List   `tfsdk:"data_owners"`
 
+	MonthlyBudget  types.Float64 `tfsdk:"monthly_budget"`
+	BudgetCurrency types.String  `tfsdk:"budget_currency"`
+
 	// Data Classification
 	Sensitivity    types.String `tfsdk:"sensitivity"`
 	DataRegs       types.List   `tfsdk:"data_regs"`
 	SecurityReview types.String `tfsdk:"security_review"`
 	PrivacyReview  types.String `tfsdk:"privacy_review"`
+	DataRetention  types.String `tfsdk:"data_retention"`
+
+	// Data Residency
+	DataResidency           types.List `tfsdk:"data_residency"`
+	SovereigntyRequirements types.List `tfsdk:"sovereignty_requirements"`
+
+	// Compliance
+	ComplianceProfile types.String `tfsdk:"compliance_profile"`
 
 	// Feature Toggles
 	SourceRepoTagsEnabled types.Bool `tfsdk:"source_repo_tags_enabled"`
 	SystemPrefixesEnabled types.Bool `tfsdk:"system_prefixes_enabled"`
 	NotApplicableEnabled  types.Bool `tfsdk:"not_applicable_enabled"`
 	OwnerTagsEnabled      types.Bool `tfsdk:"owner_tags_enabled"`
+	ToolingTagsEnabled    types.Bool `tfsdk:"tooling_tags_enabled"`
+	RegulationTagsEnabled types.Bool `tfsdk:"regulation_tags_enabled"`
+	DigestTagEnabled      types.Bool `tfsdk:"digest_tag_enabled"`
+	ProvenanceTagsEnabled types.Bool `tfsdk:"provenance_tags_enabled"`
+
+	GitRoot types.String `tfsdk:"git_root"`
+
+	SanitizationMode types.String `tfsdk:"sanitization_mode"`
+	LengthOverflow   types.String `tfsdk:"length_overflow"`
+	TagSchemaVersion types.Int64  `tfsdk:"tag_schema_version"`
+
+	NAValueOverride types.String `tfsdk:"na_value_override"`
+	NAFields        types.List   `tfsdk:"na_fields"`
+
+	TokenizeFields types.List `tfsdk:"tokenize_fields"`
+
+	AzurePolicyInheritanceEnabled types.Bool `tfsdk:"azure_policy_inheritance_enabled"`
+	AzurePolicyInheritedTags      types.List `tfsdk:"azure_policy_inherited_tags"`
+
+	// Deprecated Aliases
+	ManagedByAlias types.String `tfsdk:"managedby"`
 
 	// Additional Tags
-	AdditionalTags     types.Map `tfsdk:"additional_tags"`
-	AdditionalDataTags types.Map `tfsdk:"additional_data_tags"`
+	AdditionalTags      types.Map  `tfsdk:"additional_tags"`
+	AdditionalDataTags  types.Map  `tfsdk:"additional_data_tags"`
+	AdditionalTypedTags types.Map  `tfsdk:"additional_typed_tags"`
+	RawTags             types.Map  `tfsdk:"raw_tags"`
+	CaseInsensitiveKeys types.Bool `tfsdk:"case_insensitive_keys"`
+
+	LegacyTagMap    types.Map    `tfsdk:"legacy_tag_map"`
+	LegacyTagsUntil types.String `tfsdk:"legacy_tags_until"`
 }
 
 // ContextDataSourceModel describes the data source data model.
@@ -86,16 +314,63 @@ type ContextDataSourceModel struct {
 
 	// Naming Configuration
 	Namespace       types.String `tfsdk:"namespace"`
+	Tenant          types.String `tfsdk:"tenant"`
 	Name            types.String `tfsdk:"name"`
 	Environment     types.String `tfsdk:"environment"`
+	Attributes      types.List   `tfsdk:"attributes"`
 	EnvironmentName types.String `tfsdk:"environment_name"`
 	EnvironmentType types.String `tfsdk:"environment_type"`
+	NameDelimiter   types.String `tfsdk:"name_delimiter"`
+	LabelOrder      types.List   `tfsdk:"label_order"`
+	ListDelimiter   types.String `tfsdk:"list_join_delimiter"`
+
+	ReservedWords      types.List   `tfsdk:"reserved_words"`
+	ReservedWordAction types.String `tfsdk:"reserved_word_action"`
+
+	NamePrefixShortLength types.Int64  `tfsdk:"name_prefix_short_length"`
+	Names                 types.Map    `tfsdk:"names"`
+	NamesAdditionalTags   types.Map    `tfsdk:"names_additional_tags"`
+	NameAvailabilityCheck types.String `tfsdk:"name_availability_check"`
+	NameAvailabilityNames types.List   `tfsdk:"name_availability_names"`
+
+	// Ephemeral Environments
+	PRNumber        types.Int64  `tfsdk:"pr_number"`
+	EphemeralSuffix types.String `tfsdk:"ephemeral_suffix"`
+
+	// Stack Identity
+	StackName  types.String `tfsdk:"stack_name"`
+	Component  types.String `tfsdk:"component"`
+	ModulePath types.String `tfsdk:"module_path"`
+
+	// Application
+	Application types.String `tfsdk:"application"`
+	Service     types.String `tfsdk:"service"`
+	Tier        types.String `tfsdk:"tier"`
+
+	// Organization Hierarchy
+	BusinessUnit types.String `tfsdk:"business_unit"`
+	Division     types.String `tfsdk:"division"`
+	Portfolio    types.String `tfsdk:"portfolio"`
+
+	// SaaS Tenancy
+	TenantID types.String `tfsdk:"tenant_id"`
+	Customer types.String `tfsdk:"customer"`
 
 	// Resource Management
 	Enabled      types.Bool   `tfsdk:"enabled"`
 	Availability types.String `tfsdk:"availability"`
-	ManagedBy    types.String `tfsdk:"managedby"`
+	ManagedBy    types.String `tfsdk:"managed_by"`
 	DeletionDate types.String `tfsdk:"deletion_date"`
+	// DecommissionApproved is not part of parent_context, so each stack
+	// approves its own decommissioning
+	DecommissionApproved types.Bool `tfsdk:"decommission_approved"`
+
+	LifecycleAction types.String `tfsdk:"lifecycle_action"`
+
+	// Operations Automation
+	MaintenanceWindow types.String `tfsdk:"maintenance_window"`
+	PatchGroup        types.String `tfsdk:"patch_group"`
+	Region            types.String `tfsdk:"region"`
 
 	// Project Management Integration
 	PMPlatform    types.String `tfsdk:"pm_platform"`
@@ -113,34 +388,102 @@ type ContextDataSourceModel struct {
 	CodeOwners    types.List   `tfsdk:"code_owners"`
 	DataOwners    types.List   `tfsdk:"data_owners"`
 
+	MonthlyBudget  types.Float64 `tfsdk:"monthly_budget"`
+	BudgetCurrency types.String  `tfsdk:"budget_currency"`
+
 	// Data Classification
 	Sensitivity    types.String `tfsdk:"sensitivity"`
 	DataRegs       types.List   `tfsdk:"data_regs"`
 	SecurityReview types.String `tfsdk:"security_review"`
 	PrivacyReview  types.String `tfsdk:"privacy_review"`
+	DataRetention  types.String `tfsdk:"data_retention"`
+
+	// Data Residency
+	DataResidency           types.List `tfsdk:"data_residency"`
+	SovereigntyRequirements types.List `tfsdk:"sovereignty_requirements"`
+
+	// Compliance
+	ComplianceProfile types.String `tfsdk:"compliance_profile"`
 
 	// Feature Toggles
 	SourceRepoTagsEnabled types.Bool `tfsdk:"source_repo_tags_enabled"`
 	SystemPrefixesEnabled types.Bool `tfsdk:"system_prefixes_enabled"`
 	NotApplicableEnabled  types.Bool `tfsdk:"not_applicable_enabled"`
 	OwnerTagsEnabled      types.Bool `tfsdk:"owner_tags_enabled"`
+	ToolingTagsEnabled    types.Bool `tfsdk:"tooling_tags_enabled"`
+	RegulationTagsEnabled types.Bool `tfsdk:"regulation_tags_enabled"`
+	DigestTagEnabled      types.Bool `tfsdk:"digest_tag_enabled"`
+	ProvenanceTagsEnabled types.Bool `tfsdk:"provenance_tags_enabled"`
+
+	GitRoot types.String `tfsdk:"git_root"`
+
+	SanitizationMode types.String `tfsdk:"sanitization_mode"`
+	LengthOverflow   types.String `tfsdk:"length_overflow"`
+	TagSchemaVersion types.Int64  `tfsdk:"tag_schema_version"`
+
+	NAValueOverride types.String `tfsdk:"na_value_override"`
+	NAFields        types.List   `tfsdk:"na_fields"`
+
+	TokenizeFields types.List `tfsdk:"tokenize_fields"`
+
+	AzurePolicyInheritanceEnabled types.Bool `tfsdk:"azure_policy_inheritance_enabled"`
+	AzurePolicyInheritedTags      types.List `tfsdk:"azure_policy_inherited_tags"`
+
+	// Deprecated Aliases
+	ManagedByAlias types.String `tfsdk:"managedby"`
 
 	// Additional Tags
-	AdditionalTags     types.Map `tfsdk:"additional_tags"`
-	AdditionalDataTags types.Map `tfsdk:"additional_data_tags"`
+	AdditionalTags      types.Map  `tfsdk:"additional_tags"`
+	AdditionalDataTags  types.Map  `tfsdk:"additional_data_tags"`
+	AdditionalTypedTags types.Map  `tfsdk:"additional_typed_tags"`
+	RawTags             types.Map  `tfsdk:"raw_tags"`
+	CaseInsensitiveKeys types.Bool `tfsdk:"case_insensitive_keys"`
+
+	LegacyTagMap    types.Map    `tfsdk:"legacy_tag_map"`
+	LegacyTagsUntil types.String `tfsdk:"legacy_tags_until"`
+
+	TagFilter types.List `tfsdk:"tag_filter"`
 
 	// Computed Outputs
 	ID                             types.String `tfsdk:"id"`
 	NamePrefix                     types.String `tfsdk:"name_prefix"`
+	NamePrefixShort                types.String `tfsdk:"name_prefix_short"`
+	NamedOutputs                   types.Map    `tfsdk:"named_outputs"`
+	TagsByName                     types.Map    `tfsdk:"tags_by_name"`
+	ListDelimiterOutput            types.String `tfsdk:"list_delimiter"`
 	Tags                           types.Map    `tfsdk:"tags"`
 	DataTags                       types.Map    `tfsdk:"data_tags"`
+	RequiredTags                   types.Map    `tfsdk:"required_tags"`
+	OptionalTags                   types.Map    `tfsdk:"optional_tags"`
+	TagsUnprefixed                 types.Map    `tfsdk:"tags_unprefixed"`
+	TagsFiltered                   types.Map    `tfsdk:"tags_filtered"`
+	TagCount                       types.Int64  `tfsdk:"tag_count"`
+	DataTagCount                   types.Int64  `tfsdk:"data_tag_count"`
+	HasOwnerTags                   types.Bool   `tfsdk:"has_owner_tags"`
+	HasSourceTags                  types.Bool   `tfsdk:"has_source_tags"`
+	Compliance                     types.Object `tfsdk:"compliance"`
+	ContextDigest                  types.String `tfsdk:"context_digest"`
+	ContextSignature               types.String `tfsdk:"context_signature"`
+	InheritableTags                types.Map    `tfsdk:"inheritable_tags"`
+	ProviderDefaultTags            types.Map    `tfsdk:"provider_default_tags"`
+	OwnerGroups                    types.Map    `tfsdk:"owner_groups"`
 	TagsAsListOfMaps               types.List   `tfsdk:"tags_as_list_of_maps"`
 	TagsAsKVPList                  types.List   `tfsdk:"tags_as_kvp_list"`
 	TagsAsCommaSeparatedString     types.String `tfsdk:"tags_as_comma_separated_string"`
 	DataTagsAsListOfMaps           types.List   `tfsdk:"data_tags_as_list_of_maps"`
 	DataTagsAsKVPList              types.List   `tfsdk:"data_tags_as_kvp_list"`
 	DataTagsAsCommaSeparatedString types.String `tfsdk:"data_tags_as_comma_separated_string"`
+	TagsAsDDTags                   types.List   `tfsdk:"tags_as_dd_tags"`
+	TagsAsNumbers                  types.Map    `tfsdk:"tags_as_numbers"`
+	TagsAsBools                    types.Map    `tfsdk:"tags_as_bools"`
+	TagsAsNewRelicTags             types.Map    `tfsdk:"tags_as_newrelic_tags"`
+	AWSBudgetsFilter               types.Map    `tfsdk:"aws_budgets_filter"`
+	FOCUSTags                      types.Map    `tfsdk:"focus_tags"`
+	IAMResourceTagCondition        types.String `tfsdk:"iam_resource_tag_condition"`
+	IAMRequestTagCondition         types.String `tfsdk:"iam_request_tag_condition"`
 	ContextOutput                  types.Object `tfsdk:"context_output"`
+	ContextOutputMap               types.Map    `tfsdk:"context_output_map"`
+	EventFields                    types.Map    `tfsdk:"event_fields"`
 }
 
 func (d *ContextDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -149,11 +492,25 @@ func (d *ContextDataSource) Metadata(ctx context.Context, req datasource.Metadat
 
 // getContextAttributes returns the schema attributes for the context object
 func getContextAttributes() map[string]schema.Attribute {
-	return map[string]schema.Attribute{
+	attributes := map[string]schema.Attribute{
 		"namespace": schema.StringAttribute{
 			Description: "Organization or business unit identifier (1-8 chars, lowercase alphanumeric with hyphens)",
 			Optional:    true,
 		},
+		"tenant": schema.StringAttribute{
+			Description: "Tenant identifier (1-8 chars, lowercase alphanumeric with hyphens)",
+			Optional:    true,
+		},
+		"attributes": schema.ListAttribute{
+			Description: "Additional name tokens (1-8 chars each, lowercase alphanumeric with hyphens)",
+			ElementType: types.StringType,
+			Optional:    true,
+		},
+		"label_order": schema.ListAttribute{
+			Description: "Order of the name prefix components: namespace, tenant, name, environment, attributes, customer",
+			ElementType: types.StringType,
+			Optional:    true,
+		},
 		"environment": schema.StringAttribute{
 			Description: "Environment abbreviation (1-8 chars, lowercase alphanumeric with hyphens)",
 			Optional:    true,
@@ -166,6 +523,59 @@ func getContextAttributes() map[string]schema.Attribute {
 			Description: "One of: None, Ephemeral, Development, Testing, UAT, Production, MissionCritical",
 			Optional:    true,
 		},
+		"name_delimiter": schema.StringAttribute{
+			Description: "Delimiter joining the name prefix components: \"-\", \"_\" or \"\"",
+			Optional:    true,
+		},
+		"list_join_delimiter": schema.StringAttribute{
+			Description: "Delimiter joining list values in tags",
+			Optional:    true,
+		},
+		"reserved_words": schema.ListAttribute{
+			Description: "Words rejected in the name prefix in addition to the built-in cloud reserved words",
+			ElementType: types.StringType,
+			Optional:    true,
+		},
+		"reserved_word_action": schema.StringAttribute{
+			Description: "Action when the name prefix contains a reserved word: error or remove",
+			Optional:    true,
+		},
+		"stack_name": schema.StringAttribute{
+			Description: "Name of the Terraform stack that owns the resources",
+			Optional:    true,
+		},
+		"application": schema.StringAttribute{
+			Description: "Application the resources belong to, as recorded in the CMDB",
+			Optional:    true,
+		},
+		"service": schema.StringAttribute{
+			Description: "Service of the application the resources belong to",
+			Optional:    true,
+		},
+		"tier": schema.StringAttribute{
+			Description: "Application tier of the resources: web, app or data",
+			Optional:    true,
+		},
+		"business_unit": schema.StringAttribute{
+			Description: "Business unit owning the resources, the widest level of the organization hierarchy",
+			Optional:    true,
+		},
+		"division": schema.StringAttribute{
+			Description: "Division of the business unit owning the resources",
+			Optional:    true,
+		},
+		"portfolio": schema.StringAttribute{
+			Description: "Portfolio of the division owning the resources, the narrowest level of the organization hierarchy",
+			Optional:    true,
+		},
+		"tenant_id": schema.StringAttribute{
+			Description: "Identifier of the SaaS tenant the resources serve, such as a UUID or account number",
+			Optional:    true,
+		},
+		"customer": schema.StringAttribute{
+			Description: "Customer the resources serve (1-16 chars, lowercase alphanumeric with hyphens); included in the name prefix when listed in label_order",
+			Optional:    true,
+		},
 		"enabled": schema.BoolAttribute{
 			Description: "Enable/disable resource creation",
 			Optional:    true,
@@ -174,7 +584,7 @@ func getContextAttributes() map[string]schema.Attribute {
 			Description: "Availability requirement from predefined list",
 			Optional:    true,
 		},
-		"managedby": schema.StringAttribute{
+		"managed_by": schema.StringAttribute{
 			Description: "Management platform identifier",
 			Optional:    true,
 		},
@@ -182,6 +592,22 @@ func getContextAttributes() map[string]schema.Attribute {
 			Description: "Resource deletion date (YYYY-MM-DD format)",
 			Optional:    true,
 		},
+		"lifecycle_action": schema.StringAttribute{
+			Description: "Action taken at the deletion date: delete, stop, notify",
+			Optional:    true,
+		},
+		"maintenance_window": schema.StringAttribute{
+			Description: "Maintenance window of the resources: a five field cron expression, such as 0 3 * * SUN, or a day/time range, such as sun:03:00-sun:05:00",
+			Optional:    true,
+		},
+		"patch_group": schema.StringAttribute{
+			Description: "Patch group of the resources, for AWS Systems Manager Patch Manager and Azure Update Manager",
+			Optional:    true,
+		},
+		"region": schema.StringAttribute{
+			Description: "Cloud region of the resources",
+			Optional:    true,
+		},
 		"pm_platform": schema.StringAttribute{
 			Description: "Project management platform (e.g., JIRA, SNOW)",
 			Optional:    true,
@@ -210,6 +636,14 @@ func getContextAttributes() map[string]schema.Attribute {
 			Description: "Cost center for billing",
 			Optional:    true,
 		},
+		"monthly_budget": schema.Float64Attribute{
+			Description: "Monthly budget amount in budget_currency",
+			Optional:    true,
+		},
+		"budget_currency": schema.StringAttribute{
+			Description: "ISO 4217 currency code of monthly_budget (default: USD)",
+			Optional:    true,
+		},
 		"product_owners": schema.ListAttribute{
 			Description: "Product owner email addresses",
 			Optional:    true,
@@ -242,10 +676,32 @@ func getContextAttributes() map[string]schema.Attribute {
 			Description: "Privacy review identifier/date",
 			Optional:    true,
 		},
+		"data_retention": schema.StringAttribute{
+			Description: "Data retention period as an ISO 8601 duration, such as P7Y",
+			Optional:    true,
+		},
+		"data_residency": schema.ListAttribute{
+			Description: "ISO 3166 country or subdivision codes, or EU, where the data may reside",
+			Optional:    true,
+			ElementType: types.StringType,
+		},
+		"sovereignty_requirements": schema.ListAttribute{
+			Description: "Data sovereignty frameworks, such as EUCS or SecNumCloud",
+			Optional:    true,
+			ElementType: types.StringType,
+		},
+		"compliance_profile": schema.StringAttribute{
+			Description: "Compliance profile: pci, hipaa or fedramp-moderate",
+			Optional:    true,
+		},
 		"source_repo_tags_enabled": schema.BoolAttribute{
 			Description: "Include git repository tags",
 			Optional:    true,
 		},
+		"git_root": schema.StringAttribute{
+			Description: "Directory of the repository of the git repository tags",
+			Optional:    true,
+		},
 		"system_prefixes_enabled": schema.BoolAttribute{
 			Description: "Add platform prefixes to system IDs",
 			Optional:    true,
@@ -258,6 +714,57 @@ func getContextAttributes() map[string]schema.Attribute {
 			Description: "Include owner tags",
 			Optional:    true,
 		},
+		"tooling_tags_enabled": schema.BoolAttribute{
+			Description: "Include Terraform and provider version tags",
+			Optional:    true,
+		},
+		"regulation_tags_enabled": schema.BoolAttribute{
+			Description: "Include a reg-<regulation> data tag for each data regulation",
+			Optional:    true,
+		},
+		"digest_tag_enabled": schema.BoolAttribute{
+			Description: "Include a contextdigest tag holding context_digest",
+			Optional:    true,
+		},
+		"provenance_tags_enabled": schema.BoolAttribute{
+			Description: "Include the builder identity and source repository tags",
+			Optional:    true,
+		},
+		"sanitization_mode": schema.StringAttribute{
+			Description: "Handling of tag values changed by sanitization, and of additional tag keys with reserved prefixes: fix, warn or error",
+			Optional:    true,
+		},
+		"length_overflow": schema.StringAttribute{
+			Description: "Handling of tag values over the cloud provider length limit: truncate, truncate_with_ellipsis_hash or error",
+			Optional:    true,
+		},
+		"tag_schema_version": schema.Int64Attribute{
+			Description: "Tag schema version pinning generated tag names and defaults; 0 uses the latest version",
+			Optional:    true,
+		},
+		"na_value_override": schema.StringAttribute{
+			Description: "Placeholder used instead of the cloud provider N/A value",
+			Optional:    true,
+		},
+		"na_fields": schema.ListAttribute{
+			Description: "Tag keys that get the N/A placeholder; keys prefixed with ! are excluded",
+			ElementType: types.StringType,
+			Optional:    true,
+		},
+		"tokenize_fields": schema.ListAttribute{
+			Description: "Tag keys whose values are replaced with an HMAC token",
+			ElementType: types.StringType,
+			Optional:    true,
+		},
+		"azure_policy_inheritance_enabled": schema.BoolAttribute{
+			Description: "Omit tags applied by resource group Azure Policy inheritance from Azure tags",
+			Optional:    true,
+		},
+		"azure_policy_inherited_tags": schema.ListAttribute{
+			Description: "Tag keys applied by Azure Policy inheritance",
+			ElementType: types.StringType,
+			Optional:    true,
+		},
 		"additional_tags": schema.MapAttribute{
 			Description: "Custom tags to merge",
 			Optional:    true,
@@ -268,7 +775,115 @@ func getContextAttributes() map[string]schema.Attribute {
 			Optional:    true,
 			ElementType: types.StringType,
 		},
+		"additional_typed_tags": schema.MapNestedAttribute{
+			Description: "Custom tags with a string, number or bool value to merge",
+			Optional:    true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: typedTagAttributes(),
+			},
+		},
+		"raw_tags": schema.MapAttribute{
+			Description: "Tags added verbatim, without the tag prefix, sanitization or validation",
+			Optional:    true,
+			ElementType: types.StringType,
+		},
+		"case_insensitive_keys": schema.BoolAttribute{
+			Description: "Merge parent additional tag keys differing only in case from a child key into the child entry",
+			Optional:    true,
+		},
+		"legacy_tag_map": schema.MapAttribute{
+			Description: "Tag keys, without the tag prefix, mapped to legacy keys that get a copy of the tag",
+			Optional:    true,
+			ElementType: types.StringType,
+		},
+		"legacy_tags_until": schema.StringAttribute{
+			Description: "Last day the legacy tags are emitted (YYYY-MM-DD)",
+			Optional:    true,
+		},
+	}
+	addRenamedAliases(attributes, false)
+	return attributes
+}
+
+// ContextAttributeTypes returns the attribute types of the context object
+func ContextAttributeTypes() map[string]attr.Type {
+	attributeTypes := map[string]attr.Type{
+		"namespace":                types.StringType,
+		"tenant":                   types.StringType,
+		"attributes":               types.ListType{ElemType: types.StringType},
+		"label_order":              types.ListType{ElemType: types.StringType},
+		"environment":              types.StringType,
+		"environment_name":         types.StringType,
+		"environment_type":         types.StringType,
+		"name_delimiter":           types.StringType,
+		"list_join_delimiter":      types.StringType,
+		"reserved_words":           types.ListType{ElemType: types.StringType},
+		"reserved_word_action":     types.StringType,
+		"stack_name":               types.StringType,
+		"application":              types.StringType,
+		"service":                  types.StringType,
+		"tier":                     types.StringType,
+		"business_unit":            types.StringType,
+		"division":                 types.StringType,
+		"portfolio":                types.StringType,
+		"tenant_id":                types.StringType,
+		"customer":                 types.StringType,
+		"enabled":                  types.BoolType,
+		"availability":             types.StringType,
+		"managed_by":               types.StringType,
+		"deletion_date":            types.StringType,
+		"lifecycle_action":         types.StringType,
+		"maintenance_window":       types.StringType,
+		"patch_group":              types.StringType,
+		"region":                   types.StringType,
+		"pm_platform":              types.StringType,
+		"pm_project_code":          types.StringType,
+		"itsm_platform":            types.StringType,
+		"itsm_system_id":           types.StringType,
+		"itsm_component_id":        types.StringType,
+		"itsm_instance_id":         types.StringType,
+		"cost_center":              types.StringType,
+		"monthly_budget":           types.Float64Type,
+		"budget_currency":          types.StringType,
+		"product_owners":           types.ListType{ElemType: types.StringType},
+		"code_owners":              types.ListType{ElemType: types.StringType},
+		"data_owners":              types.ListType{ElemType: types.StringType},
+		"sensitivity":              types.StringType,
+		"data_regs":                types.ListType{ElemType: types.StringType},
+		"security_review":          types.StringType,
+		"privacy_review":           types.StringType,
+		"data_retention":           types.StringType,
+		"data_residency":           types.ListType{ElemType: types.StringType},
+		"sovereignty_requirements": types.ListType{ElemType: types.StringType},
+		"compliance_profile":       types.StringType,
+		"source_repo_tags_enabled": types.BoolType,
+		"git_root":                 types.StringType,
+		"system_prefixes_enabled":  types.BoolType,
+		"not_applicable_enabled":   types.BoolType,
+		"owner_tags_enabled":       types.BoolType,
+		"tooling_tags_enabled":     types.BoolType,
+		"regulation_tags_enabled":  types.BoolType,
+		"digest_tag_enabled":       types.BoolType,
+		"provenance_tags_enabled":  types.BoolType,
+		"sanitization_mode":        types.StringType,
+		"length_overflow":          types.StringType,
+		"tag_schema_version":       types.Int64Type,
+		"na_value_override":        types.StringType,
+		"na_fields":                types.ListType{ElemType: types.StringType},
+		"tokenize_fields":          types.ListType{ElemType: types.StringType},
+		"additional_tags":          types.MapType{ElemType: types.StringType},
+		"additional_data_tags":     types.MapType{ElemType: types.StringType},
+		"additional_typed_tags":    types.MapType{ElemType: typedTagType},
+		"raw_tags":                 types.MapType{ElemType: types.StringType},
+		"case_insensitive_keys":    types.BoolType,
+		"legacy_tag_map":           types.MapType{ElemType: types.StringType},
+		"legacy_tags_until":        types.StringType,
+
+		"azure_policy_inheritance_enabled": types.BoolType,
+		"azure_policy_inherited_tags":      types.ListType{ElemType: types.StringType},
 	}
+	addRenamedAliasTypes(attributeTypes)
+	return attributeTypes
 }
 
 func (d *ContextDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
@@ -288,6 +903,20 @@ func (d *ContextDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 				Description: "Organization or business unit identifier (1-8 chars, lowercase alphanumeric with hyphens)",
 				Optional:    true,
 			},
+			"tenant": schema.StringAttribute{
+				Description: "Tenant identifier (1-8 chars, lowercase alphanumeric with hyphens)",
+				Optional:    true,
+			},
+			"attributes": schema.ListAttribute{
+				Description: "Additional name tokens appended to the name prefix (1-8 chars each, lowercase alphanumeric with hyphens)",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"label_order": schema.ListAttribute{
+				Description: "Order of the name prefix components: namespace, tenant, name, environment, attributes, customer (default: namespace, tenant, name, environment, attributes)",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
 			"name": schema.StringAttribute{
 				Description: "Unique resource name (combined name_prefix must be 2-24 chars)",
 				Optional:    true,
@@ -304,98 +933,256 @@ func (d *ContextDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 				Description: "One of: None, Ephemeral, Development, Testing, UAT, Production, MissionCritical",
 				Optional:    true,
 			},
-
-			// Resource Management
-			"enabled": schema.BoolAttribute{
-				Description: "Enable/disable resource creation",
+			"name_delimiter": schema.StringAttribute{
+				Description: "Delimiter joining the name prefix components: \"-\" (default), \"_\" or \"\"",
 				Optional:    true,
 			},
-			"availability": schema.StringAttribute{
-				Description: "Availability requirement from predefined list",
+			"list_join_delimiter": schema.StringAttribute{
+				Description: "Delimiter joining list values such as owners and data_regs in tags (default: the cloud provider delimiter). Must not contain letters or digits and must be allowed in the cloud provider's tag values",
 				Optional:    true,
 			},
-			"managedby": schema.StringAttribute{
-				Description: "Management platform identifier",
+			"reserved_words": schema.ListAttribute{
+				Description: "Words rejected in the name prefix in addition to the built-in cloud reserved words (aws, amazon, azure, google, login, microsoft, windows, xbox)",
+				ElementType: types.StringType,
 				Optional:    true,
 			},
-			"deletion_date": schema.StringAttribute{
-				Description: "Resource deletion date (YYYY-MM-DD format)",
+			"reserved_word_action": schema.StringAttribute{
+				Description: "Action when the name prefix contains a reserved word: error or remove (default: no check)",
 				Optional:    true,
 			},
-
-			// Project Management Integration
-			"pm_platform": schema.StringAttribute{
-				Description: "Project management platform (e.g., JIRA, SNOW)",
+			"name_prefix_short_length": schema.Int64Attribute{
+				Description: "Maximum length of name_prefix_short (min: 8, default: 12)",
 				Optional:    true,
 			},
-			"pm_project_code": schema.StringAttribute{
-				Description: "Project code/prefix",
+			"names": schema.MapAttribute{
+				Description: "Logical keys mapped to the name each replaces the name component with in named_outputs, so many resources of a stack share one context",
+				ElementType: types.StringType,
 				Optional:    true,
 			},
-
-			// ITSM Integration
-			"itsm_platform": schema.StringAttribute{
-				Description: "IT Service Management platform",
+			"name_availability_check": schema.StringAttribute{
+				Description: "Check that name_prefix and the named_outputs name prefixes, or the name_availability_names, are free as globally unique names, S3 buckets on aws, storage accounts on az and Cloud Storage buckets on gcp, when the cloud credentials are set: warn or error reports names used by other accounts. Unset disables the check",
 				Optional:    true,
 			},
-			"itsm_system_id": schema.StringAttribute{
-				Description: "ITSM system identifier",
+			"name_availability_names": schema.ListAttribute{
+				Description: "Names checked by name_availability_check instead of name_prefix and the named_outputs name prefixes, such as the storage account names built from them. Name prefixes that are not valid names of the resource, such as hyphenated ones for storage accounts, are skipped, while names listed here are reported",
+				ElementType: types.StringType,
 				Optional:    true,
 			},
-			"itsm_component_id": schema.StringAttribute{
-				Description: "ITSM component identifier",
+			"names_additional_tags": schema.MapAttribute{
+				Description: "Tags merged onto additional_tags for single names entries in tags_by_name, keyed like names",
+				ElementType: types.MapType{ElemType: types.StringType},
 				Optional:    true,
 			},
-			"itsm_instance_id": schema.StringAttribute{
-				Description: "ITSM instance identifier",
+
+			// Ephemeral Environments
+			"pr_number": schema.Int64Attribute{
+				Description: "Pull request number appended to environment as pr<number> when environment_type is Ephemeral",
 				Optional:    true,
 			},
-
-			// Ownership and Billing
-			"cost_center": schema.StringAttribute{
-				Description: "Cost center for billing",
+			"ephemeral_suffix": schema.StringAttribute{
+				Description: "Branch or other identifier appended to environment when environment_type is Ephemeral and pr_number is not set",
 				Optional:    true,
 			},
-			"product_owners": schema.ListAttribute{
-				Description: "Product owner email addresses",
+
+			// Stack Identity
+			"stack_name": schema.StringAttribute{
+				Description: "Name of the Terraform stack that owns the resources",
 				Optional:    true,
-				ElementType: types.StringType,
 			},
-			"code_owners": schema.ListAttribute{
-				Description: "Code owner email addresses",
+			"component": schema.StringAttribute{
+				Description: "Stack component that owns the resources (defaults to the last element of module_path)",
 				Optional:    true,
-				ElementType: types.StringType,
 			},
-			"data_owners": schema.ListAttribute{
-				Description: "Data owner email addresses",
+			"module_path": schema.StringAttribute{
+				Description: "Path of the calling module, typically path.module, used to derive component",
 				Optional:    true,
-				ElementType: types.StringType,
 			},
 
-			// Data Classification
-			"sensitivity": schema.StringAttribute{
-				Description: "Data sensitivity level from predefined list",
+			// Application
+			"application": schema.StringAttribute{
+				Description: "Application the resources belong to, as recorded in the CMDB",
 				Optional:    true,
 			},
-			"data_regs": schema.ListAttribute{
-				Description: "Data compliance regulations",
+			"service": schema.StringAttribute{
+				Description: "Service of the application the resources belong to",
 				Optional:    true,
-				ElementType: types.StringType,
 			},
-			"security_review": schema.StringAttribute{
-				Description: "Security review identifier/date",
+			"tier": schema.StringAttribute{
+				Description: "Application tier of the resources: web, app or data",
+				Optional:    true,
+			},
+
+			// Organization Hierarchy
+			"business_unit": schema.StringAttribute{
+				Description: "Business unit owning the resources, the widest level of the organization hierarchy",
+				Optional:    true,
+			},
+			"division": schema.StringAttribute{
+				Description: "Division of the business unit owning the resources",
+				Optional:    true,
+			},
+			"portfolio": schema.StringAttribute{
+				Description: "Portfolio of the division owning the resources, the narrowest level of the organization hierarchy",
+				Optional:    true,
+			},
+
+			// SaaS Tenancy
+			"tenant_id": schema.StringAttribute{
+				Description: "Identifier of the SaaS tenant the resources serve, such as a UUID or account number",
+				Optional:    true,
+			},
+			"customer": schema.StringAttribute{
+				Description: "Customer the resources serve (1-16 chars, lowercase alphanumeric with hyphens); included in the name prefix when listed in label_order",
+				Optional:    true,
+			},
+
+			// Resource Management
+			"enabled": schema.BoolAttribute{
+				Description: "Enable/disable resource creation",
+				Optional:    true,
+			},
+			"availability": schema.StringAttribute{
+				Description: "Availability requirement from predefined list",
+				Optional:    true,
+			},
+			"managed_by": schema.StringAttribute{
+				Description: "Management platform identifier",
+				Optional:    true,
+			},
+			"deletion_date": schema.StringAttribute{
+				Description: "Resource deletion date (YYYY-MM-DD format)",
+				Optional:    true,
+			},
+			"decommission_approved": schema.BoolAttribute{
+				Description: "Approve the decommissioning of a Production or MissionCritical environment, which a deletion_date requires so long-lived resources do not get expiry tags by accident. Not inherited from parent_context (default: false)",
+				Optional:    true,
+			},
+			"lifecycle_action": schema.StringAttribute{
+				Description: "Action taken at the deletion date: delete, stop, notify (required when environment_type is Ephemeral)",
+				Optional:    true,
+			},
+
+			// Operations Automation
+			"maintenance_window": schema.StringAttribute{
+				Description: "Maintenance window of the resources: a five field cron expression, such as 0 3 * * SUN, or a day/time range, such as sun:03:00-sun:05:00",
+				Optional:    true,
+			},
+			"patch_group": schema.StringAttribute{
+				Description: "Patch group of the resources, for AWS Systems Manager Patch Manager and Azure Update Manager",
+				Optional:    true,
+			},
+			"region": schema.StringAttribute{
+				Description: "Cloud region of the resources, such as eu-west-1, westeurope or europe-west3; adds a region tag when set. Known AWS, Azure and GCP regions must be inside data_residency",
+				Optional:    true,
+			},
+
+			// Project Management Integration
+			"pm_platform": schema.StringAttribute{
+				Description: "Project management platform (e.g., JIRA, SNOW)",
+				Optional:    true,
+			},
+			"pm_project_code": schema.StringAttribute{
+				Description: "Project code/prefix",
+				Optional:    true,
+			},
+
+			// ITSM Integration
+			"itsm_platform": schema.StringAttribute{
+				Description: "IT Service Management platform",
+				Optional:    true,
+			},
+			"itsm_system_id": schema.StringAttribute{
+				Description: "ITSM system identifier",
+				Optional:    true,
+			},
+			"itsm_component_id": schema.StringAttribute{
+				Description: "ITSM component identifier",
+				Optional:    true,
+			},
+			"itsm_instance_id": schema.StringAttribute{
+				Description: "ITSM instance identifier",
+				Optional:    true,
+			},
+
+			// Ownership and Billing
+			"cost_center": schema.StringAttribute{
+				Description: "Cost center for billing",
+				Optional:    true,
+			},
+			"monthly_budget": schema.Float64Attribute{
+				Description: "Monthly budget amount in budget_currency, emitted as the monthlybudget tag when set",
+				Optional:    true,
+			},
+			"budget_currency": schema.StringAttribute{
+				Description: "ISO 4217 currency code of monthly_budget, emitted as the budgetcurrency tag (default: USD)",
+				Optional:    true,
+			},
+			"product_owners": schema.ListAttribute{
+				Description: "Product owner email addresses",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"code_owners": schema.ListAttribute{
+				Description: "Code owner email addresses",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"data_owners": schema.ListAttribute{
+				Description: "Data owner email addresses",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+
+			// Data Classification
+			"sensitivity": schema.StringAttribute{
+				Description: "Data sensitivity level from predefined list",
+				Optional:    true,
+			},
+			"data_regs": schema.ListAttribute{
+				Description: "Data compliance regulations",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"security_review": schema.StringAttribute{
+				Description: "Security review identifier/date",
 				Optional:    true,
 			},
 			"privacy_review": schema.StringAttribute{
 				Description: "Privacy review identifier/date",
 				Optional:    true,
 			},
+			"data_retention": schema.StringAttribute{
+				Description: "Data retention period as an ISO 8601 duration, such as P7Y, P1Y6M or P90D; adds a dataretention data tag when set. Required for restricted and critical data: a warning, or an error under strict_mode or a compliance_profile",
+				Optional:    true,
+			},
+
+			// Data Residency
+			"data_residency": schema.ListAttribute{
+				Description: "Where the data may reside, as ISO 3166-1 alpha-2 country codes such as DE, ISO 3166-2 subdivision codes such as US-CA, or EU for the member states of the European Union; adds a dataresidency data tag when set. A known region outside it is an error",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"sovereignty_requirements": schema.ListAttribute{
+				Description: "Data sovereignty frameworks the data falls under, such as EUCS, SecNumCloud or C5; adds a sovereignty data tag when set",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+
+			// Compliance
+			"compliance_profile": schema.StringAttribute{
+				Description: "Compliance framework profile activating a bundled rule set: pci, hipaa or fedramp-moderate. The profile requires fields such as cost_center and security_review, adds its data regulation with the per-regulation data tags, defaults and enforces a minimum sensitivity, and rejects required fields set to the N/A placeholder. Inherited from parent_context.",
+				Optional:    true,
+			},
 
 			// Feature Toggles
 			"source_repo_tags_enabled": schema.BoolAttribute{
 				Description: "Include git repository tags",
 				Optional:    true,
 			},
+			"git_root": schema.StringAttribute{
+				Description: "Directory of the repository of the sourcerepo and sourcecommit tags, overriding the git_root of the provider, such as for a stack whose module lives in a submodule. Relative paths are resolved against the source_dir of the provider. Inherited from parent_context.",
+				Optional:    true,
+			},
 			"system_prefixes_enabled": schema.BoolAttribute{
 				Description: "Add platform prefixes to system IDs",
 				Optional:    true,
@@ -408,6 +1195,57 @@ func (d *ContextDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 				Description: "Include owner tags",
 				Optional:    true,
 			},
+			"tooling_tags_enabled": schema.BoolAttribute{
+				Description: "Include Terraform and provider version tags (default: false)",
+				Optional:    true,
+			},
+			"regulation_tags_enabled": schema.BoolAttribute{
+				Description: "Include a reg-<regulation> = \"true\" data tag for each entry in data_regs, such as reg-gdpr, in addition to the joined dataregulations tag (default: false)",
+				Optional:    true,
+			},
+			"digest_tag_enabled": schema.BoolAttribute{
+				Description: "Include a contextdigest tag holding context_digest, cut to the 63 characters allowed by the gcp and dc cloud providers (default: false)",
+				Optional:    true,
+			},
+			"provenance_tags_enabled": schema.BoolAttribute{
+				Description: "Include SLSA-aligned provenance tags: sourcerepo and sourcecommit, and in GitHub Actions builderid (the workflow), buildinvocation (the workflow run) and, for jobs with the id-token: write permission, buildersubject and builderaudience from the sub and aud claims of the job OIDC token (default: false)",
+				Optional:    true,
+			},
+			"sanitization_mode": schema.StringAttribute{
+				Description: "Handling of tag values changed by cloud provider sanitization: fix (default) silently replaces invalid characters, warn also reports a warning, error rejects the value. Additional tag keys using a prefix reserved by the cloud provider, such as aws: or goog-, are reported as warnings, or rejected by error, as are keys the cloud provider rejects and keys that are the same once normalized by the cloud provider, such as Cost Center and cost_center on GCP",
+				Optional:    true,
+			},
+			"length_overflow": schema.StringAttribute{
+				Description: "Handling of tag values over the cloud provider length limit: truncate (default), truncate_with_ellipsis_hash (ends the value with ... and a hash of the full value) or error",
+				Optional:    true,
+			},
+			"tag_schema_version": schema.Int64Attribute{
+				Description: "Pins the generated tag names and defaults to those of a tag schema version, so a provider upgrade that renames or adds generated tags does not retag the stack. Defaults to the latest version, currently 1",
+				Optional:    true,
+			},
+			"na_value_override": schema.StringAttribute{
+				Description: "Placeholder used for empty values instead of the cloud provider N/A value (N/A, NotApplicable or not_applicable)",
+				Optional:    true,
+			},
+			"na_fields": schema.ListAttribute{
+				Description: "Tag keys, without the tag prefix, that get the N/A placeholder when empty. When plain keys are listed only those are included; keys prefixed with ! are always excluded (default: all keys)",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"tokenize_fields": schema.ListAttribute{
+				Description: "Tag keys, without the tag prefix, whose values are replaced with a token: tok- followed by 16 hex characters of the HMAC-SHA256 of the value with the key in the CONTEXT_PROVIDER_TOKENIZATION_KEY environment variable. The same value always gives the same token, so resources stay correlatable without exposing the raw value. One of costcenter, projectmgmtid, systemid, componentid, instanceid, tenant, productowners, codeowners, dataowners",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"azure_policy_inheritance_enabled": schema.BoolAttribute{
+				Description: "When the cloud provider is az, omit the azure_policy_inherited_tags from tags so Terraform and a resource group tag inheritance Azure Policy do not overwrite each other on every apply. inheritable_tags still contains them for the resource group (default: false)",
+				Optional:    true,
+			},
+			"azure_policy_inherited_tags": schema.ListAttribute{
+				Description: "Tag keys, without the tag prefix, that Azure Policy copies from the resource group (default: the inheritable_tags keys)",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
 
 			// Additional Tags
 			"additional_tags": schema.MapAttribute{
@@ -420,16 +1258,76 @@ func (d *ContextDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 				Optional:    true,
 				ElementType: types.StringType,
 			},
+			"additional_typed_tags": schema.MapNestedAttribute{
+				Description: "Custom tags with a number or bool value, such as { replicas = { value = 3, type = \"number\" } }, merged after additional_tags. tags gets the value in a canonical string form, so 01.50 becomes 1.5 and True becomes true, and tags_as_numbers and tags_as_bools get it typed. A value that is not of its type is an error. Merged with the map of parent_context",
+				Optional:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: typedTagAttributes(),
+				},
+			},
+			"raw_tags": schema.MapAttribute{
+				Description: "Escape hatch for vendor-mandated tags that sanitization would change: added to tags verbatim, without the tag prefix, sanitization or validation, replacing any tag of the same key. The cloud provider may reject them at apply time, so each is reported as a warning. Merged with the map of parent_context",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"case_insensitive_keys": schema.BoolAttribute{
+				Description: "Merge additional_tags and additional_data_tags keys of parent_context that differ only in case from a key set here into a single entry, keeping the key and value set here, so Team in the parent and team in the child give one team tag. Azure treats such keys as the same tag, while AWS keeps both (default: false)",
+				Optional:    true,
+			},
+			"legacy_tag_map": schema.MapAttribute{
+				Description: "Tag keys, without the tag prefix, mapped to legacy keys, such as { costcenter = \"CostCenter\" }. During a tag taxonomy migration each mapped tag is also emitted under its legacy key, as given and without the tag prefix, so reports keyed on the old taxonomy keep working. A legacy key already set, such as by additional_tags, keeps its value. Merged with the map of parent_context",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"legacy_tags_until": schema.StringAttribute{
+				Description: "Last day, in UTC, of the legacy_tag_map migration window (YYYY-MM-DD). The legacy tags are no longer emitted after it, with a warning to remove legacy_tag_map. Defaults to emitting them until legacy_tag_map is removed",
+				Optional:    true,
+			},
+
+			"tag_filter": schema.ListAttribute{
+				Description: "Patterns selecting the tags of tags_filtered by key without the tag prefix, such as *owners. A pattern enclosed in slashes, such as /^(code|product)owners$/, is a regular expression; any other pattern is a glob",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
 
 			// Computed Outputs
 			"id": schema.StringAttribute{
-				Description: "Unique identifier for this data source instance",
+				Description: "Unique identifier for this data source instance: the name prefix, or without a name, the name prefix followed by a hash of the resolved inputs",
 				Computed:    true,
 			},
 			"name_prefix": schema.StringAttribute{
 				Description: "Computed name prefix following Brockhoff standards",
 				Computed:    true,
 			},
+			"name_prefix_short": schema.StringAttribute{
+				Description: "name_prefix shortened to name_prefix_short_length as <fragment>-<hash> when it does not fit",
+				Computed:    true,
+			},
+			"named_outputs": schema.MapNestedAttribute{
+				Description: "name_prefix and name_prefix_short of each names entry, keyed like names",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name_prefix": schema.StringAttribute{
+							Description: "Name prefix with the entry's name as the name component",
+							Computed:    true,
+						},
+						"name_prefix_short": schema.StringAttribute{
+							Description: "name_prefix shortened to name_prefix_short_length",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"tags_by_name": schema.MapAttribute{
+				Description: "tags of each names entry with its names_additional_tags merged onto additional_tags, keyed like names",
+				Computed:    true,
+				ElementType: types.MapType{ElemType: types.StringType},
+			},
+			"list_delimiter": schema.StringAttribute{
+				Description: "Delimiter joining list values in tags, for splitting them downstream",
+				Computed:    true,
+			},
 			"tags": schema.MapAttribute{
 				Description: "Normalized tag map",
 				Computed:    true,
@@ -440,6 +1338,87 @@ func (d *ContextDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 				Computed:    true,
 				ElementType: types.StringType,
 			},
+			"required_tags": schema.MapAttribute{
+				Description: "Subset of tags that every resource should carry: environment, availability, managedby, deletiondate, expiryaction and costcenter",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"optional_tags": schema.MapAttribute{
+				Description: "Subset of tags not in required_tags, for resources with tight tag count limits",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"tags_unprefixed": schema.MapAttribute{
+				Description: "Tags keyed without the tag prefix, such as environment, for programmatic use",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"tags_filtered": schema.MapAttribute{
+				Description: "Tags whose key matches any tag_filter pattern; empty when tag_filter is not set",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"tag_count": schema.Int64Attribute{
+				Description: "Number of tags, for preconditions on cloud provider tag count limits",
+				Computed:    true,
+			},
+			"data_tag_count": schema.Int64Attribute{
+				Description: "Number of data tags",
+				Computed:    true,
+			},
+			"has_owner_tags": schema.BoolAttribute{
+				Description: "Whether tags or data_tags hold a product, code or data owner that is not N/A",
+				Computed:    true,
+			},
+			"has_source_tags": schema.BoolAttribute{
+				Description: "Whether tags hold a source repository or commit that is not N/A",
+				Computed:    true,
+			},
+			"compliance": schema.SingleNestedAttribute{
+				Description: "Tagging compliance summary for assertions in check blocks",
+				Computed:    true,
+				Attributes: map[string]schema.Attribute{
+					"owners_present": schema.BoolAttribute{
+						Description: "Whether a product, code or data owner is set",
+						Computed:    true,
+					},
+					"cost_center_present": schema.BoolAttribute{
+						Description: "Whether the cost center tag is set",
+						Computed:    true,
+					},
+					"expiry_set_for_ephemeral": schema.BoolAttribute{
+						Description: "False when an Ephemeral environment has no deletion date tag",
+						Computed:    true,
+					},
+					"within_tag_limits": schema.BoolAttribute{
+						Description: "Whether tags and data_tags are each within the cloud provider's maximum number of tags on a resource",
+						Computed:    true,
+					},
+				},
+			},
+			"context_signature": schema.StringAttribute{
+				Description: "Base64 signature of context_digest with the provider signing key, as written by cosign sign-blob; null unless the provider sets sign_context_digest",
+				Computed:    true,
+			},
+			"context_digest": schema.StringAttribute{
+				Description: "Hex SHA-256 over the tags, encoded as a JSON object with sorted keys and leaving out the contextdigest tag, for drift detection tools to find context-managed tags modified out of band",
+				Computed:    true,
+			},
+			"inheritable_tags": schema.MapAttribute{
+				Description: "Subset of tags describing the whole environment or stack, to set once on an Azure resource group, GCP project or AWS account",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"provider_default_tags": schema.MapAttribute{
+				Description: "Tags to set in the default_tags block of the aws provider: the organization and stack tags of inheritable_tags, without the deletion date, expiry action, budget, customer and region tags that vary per resource or over time. A warning is reported for each additional_tags key that overrides one of them, since setting the key on resources too causes perpetual diffs",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"owner_groups": schema.MapAttribute{
+				Description: "Owner addresses found as groups in the provider group_directory, with the canonical group display_name and member_count. Empty when no group directory is configured",
+				Computed:    true,
+				ElementType: ownerGroupType,
+			},
 			"tags_as_list_of_maps": schema.ListAttribute{
 				Description: "Tags formatted for AWS resources",
 				Computed:    true,
@@ -472,13 +1451,64 @@ func (d *ContextDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 				Description: "Data tags as comma-separated string",
 				Computed:    true,
 			},
+			"tags_as_dd_tags": schema.ListAttribute{
+				Description: "Tags as Datadog key:value tags, lowercased with unsupported characters replaced by underscores and cut to 200 characters",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"tags_as_numbers": schema.MapAttribute{
+				Description: "Number values of additional_typed_tags keyed like tags, for platforms accepting number tag values",
+				Computed:    true,
+				ElementType: types.Float64Type,
+			},
+			"tags_as_bools": schema.MapAttribute{
+				Description: "Bool values of additional_typed_tags keyed like tags, for platforms accepting bool tag values",
+				Computed:    true,
+				ElementType: types.BoolType,
+			},
+			"tags_as_newrelic_tags": schema.MapAttribute{
+				Description: "Tags for New Relic entity tags, with keys cut to 128 and values to 256 characters",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"aws_budgets_filter": schema.MapAttribute{
+				Description: "Cost filters for aws_budgets_budget matching resources tagged with this context's cost center",
+				Computed:    true,
+				ElementType: types.ListType{
+					ElemType: types.StringType,
+				},
+			},
+			"focus_tags": schema.MapAttribute{
+				Description: "Tag values keyed by FinOps FOCUS custom column name, such as x_Owner and x_CostCenter",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"iam_resource_tag_condition": schema.StringAttribute{
+				Description: "IAM policy condition JSON matching aws:ResourceTag to this context's tags, for attribute-based access control",
+				Computed:    true,
+			},
+			"iam_request_tag_condition": schema.StringAttribute{
+				Description: "IAM policy condition JSON matching aws:RequestTag to this context's tags, for attribute-based access control",
+				Computed:    true,
+			},
 			"context_output": schema.SingleNestedAttribute{
-				Description: "Resolved context values that can be used as input for child contexts",
+				Description: "Resolved context values that can be used as input for child contexts; unset values, including availability, sensitivity and managed_by left to their defaults, are null",
 				Computed:    true,
 				Attributes:  getContextAttributes(),
 			},
+			"context_output_map": schema.MapAttribute{
+				Description: "context_output flattened to strings for for_each or map(string) module inputs: lists are comma-joined, bools and numbers are stringified, map entries are keyed as attribute.key, or attribute.key.field for objects, and unset values are omitted",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"event_fields": schema.MapAttribute{
+				Description: "context_output_map and name_prefix as Splunk HEC or Elastic Common Schema custom fields for audit events, keyed as lowercase context.attribute with empty values left out",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
 		},
 	}
+	addRenamedAliases(resp.Schema.Attributes, true)
 }
 
 func (d *ContextDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
@@ -499,23 +1529,65 @@ func (d *ContextDataSource) Configure(ctx context.Context, req datasource.Config
 	d.providerConfig = providerConfig
 }
 
+// isKnown reports whether a value is set and known. The merge helpers treat
+// unknown values as unset rather than as empty strings; Read never gets to
+// them while an input is unknown, as deferUnknownConfig returns first.
+func isKnown(v attr.Value) bool {
+	return !v.IsNull() && !v.IsUnknown()
+}
+
 // mergeStringValue returns the individual value if set, otherwise the context value
 func mergeStringValue(individualValue, contextValue types.String) string {
-	if !individualValue.IsNull() {
+	if isKnown(individualValue) {
 		return individualValue.ValueString()
 	}
-	if !contextValue.IsNull() {
+	if isKnown(contextValue) {
 		return contextValue.ValueString()
 	}
 	return ""
 }
 
+// mergeOptionalStringValue returns the individual value if set, otherwise the
+// context value, or nil if neither is set. Unlike mergeStringValue it keeps an
+// explicitly empty string distinct from an unset value.
+func mergeOptionalStringValue(individualValue, contextValue types.String) *string {
+	if isKnown(individualValue) {
+		return individualValue.ValueStringPointer()
+	}
+	if isKnown(contextValue) {
+		return contextValue.ValueStringPointer()
+	}
+	return nil
+}
+
+// mergeFloat64Value returns the individual value if set, otherwise the context value
+func mergeFloat64Value(individualValue, contextValue types.Float64) float64 {
+	if isKnown(individualValue) {
+		return individualValue.ValueFloat64()
+	}
+	if isKnown(contextValue) {
+		return contextValue.ValueFloat64()
+	}
+	return 0
+}
+
+// mergeInt64Value returns the individual value if set, otherwise the context value
+func mergeInt64Value(individualValue, contextValue types.Int64) int64 {
+	if isKnown(individualValue) {
+		return individualValue.ValueInt64()
+	}
+	if isKnown(contextValue) {
+		return contextValue.ValueInt64()
+	}
+	return 0
+}
+
 // mergeBoolValue returns the individual value if set, otherwise the context value
 func mergeBoolValue(individualValue, contextValue types.Bool, defaultValue bool) bool {
-	if !individualValue.IsNull() {
+	if isKnown(individualValue) {
 		return individualValue.ValueBool()
 	}
-	if !contextValue.IsNull() {
+	if isKnown(contextValue) {
 		return contextValue.ValueBool()
 	}
 	return defaultValue
@@ -523,43 +1595,112 @@ func mergeBoolValue(individualValue, contextValue types.Bool, defaultValue bool)
 
 // mergeListValue returns the individual value if set, otherwise the context value
 func mergeListValue(ctx context.Context, individualValue, contextValue types.List) []string {
-	if !individualValue.IsNull() {
+	if isKnown(individualValue) {
 		values := []string{}
 		individualValue.ElementsAs(ctx, &values, false)
 		return values
 	}
-	if !contextValue.IsNull() {
-		values := []string{}
-		contextValue.ElementsAs(ctx, &values, false)
-		return values
+	if isKnown(contextValue) {
+		values := []string{}
+		contextValue.ElementsAs(ctx, &values, false)
+		return values
+	}
+	return nil
+}
+
+// mergeMapValue returns the individual value if set, otherwise the context value
+func mergeMapValue(ctx context.Context, individualValue, contextValue types.Map, caseInsensitiveKeys bool) map[string]string {
+	parentValues := map[string]string{}
+	if isKnown(contextValue) {
+		contextValue.ElementsAs(ctx, &parentValues, false)
+	}
+
+	childValues := map[string]string{}
+	if isKnown(individualValue) {
+		individualValue.ElementsAs(ctx, &childValues, false)
+	}
+
+	return core.MergeAdditionalTags(parentValues, childValues, caseInsensitiveKeys)
+}
+
+// typedTagAttributes returns the attributes of an additional_typed_tags
+// element
+func typedTagAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"value": schema.StringAttribute{
+			Description: "Tag value; numbers and bools may be given unquoted",
+			Optional:    true,
+		},
+		"type": schema.StringAttribute{
+			Description: "Value type: string, number or bool (default: string)",
+			Optional:    true,
+		},
+	}
+}
+
+// TypedTagsFromValue converts an additional_typed_tags map to typed tags
+func TypedTagsFromValue(ctx context.Context, value types.Map) map[string]core.TypedTag {
+	elems := map[string]typedTagModel{}
+	if !value.IsNull() && !value.IsUnknown() {
+		value.ElementsAs(ctx, &elems, false)
+	}
+
+	tags := make(map[string]core.TypedTag, len(elems))
+	for key, elem := range elems {
+		tags[key] = core.TypedTag{Value: elem.Value.ValueString(), Type: elem.Type.ValueString()}
 	}
-	return nil
+	return tags
 }
 
-// mergeMapValue returns the individual value if set, otherwise the context value
-func mergeMapValue(ctx context.Context, individualValue, contextValue types.Map) map[string]string {
-	merged := make(map[string]string)
+// typedTagsValue converts typed tags to an additional_typed_tags map
+func typedTagsValue(ctx context.Context, tags map[string]core.TypedTag) (types.Map, diag.Diagnostics) {
+	elems := make(map[string]typedTagModel, len(tags))
+	for key, tag := range tags {
+		elems[key] = typedTagModel{Value: types.StringValue(tag.Value), Type: optionalString(tag.Type)}
+	}
+	return types.MapValueFrom(ctx, typedTagType, elems)
+}
 
-	if !contextValue.IsNull() {
-		parentValues := map[string]string{}
-		contextValue.ElementsAs(ctx, &parentValues, false)
-		for k, v := range parentValues {
-			merged[k] = v
-		}
+// optionalFloat64 returns a null number for zero, the value of an unset number
+func optionalFloat64(f float64) types.Float64 {
+	if f == 0 {
+		return types.Float64Null()
 	}
+	return types.Float64Value(f)
+}
 
-	if !individualValue.IsNull() {
-		childValues := map[string]string{}
-		individualValue.ElementsAs(ctx, &childValues, false)
-		for k, v := range childValues {
-			merged[k] = v
-		}
+// optionalInt64 returns a null number for zero, the value of an unset number
+func optionalInt64(i int64) types.Int64 {
+	if i == 0 {
+		return types.Int64Null()
+	}
+	return types.Int64Value(i)
+}
+
+// optionalStringMap returns a null map for an empty map
+func optionalStringMap(ctx context.Context, m map[string]string) (types.Map, diag.Diagnostics) {
+	if len(m) == 0 {
+		return types.MapNull(types.StringType), nil
 	}
+	return types.MapValueFrom(ctx, types.StringType, m)
+}
 
-	return merged
+func mergeTypedTagsValue(ctx context.Context, individualValue, contextValue types.Map, caseInsensitiveKeys bool) map[string]core.TypedTag {
+	return core.MergeAdditionalTypedTags(TypedTagsFromValue(ctx, contextValue), TypedTagsFromValue(ctx, individualValue), caseInsensitiveKeys)
 }
 
 func (d *ContextDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := tracing.StartSpan(ctx, "ContextDataSource.Read")
+	start := time.Now()
+	defer func() {
+		tracing.EndSpan(span, resp.Diagnostics)
+		metrics.RecordRead("brockhoff_context", time.Since(start), resp.Diagnostics)
+	}()
+
+	if deferUnknownConfig(ctx, req, resp) {
+		return
+	}
+
 	var data ContextDataSourceModel
 
 	// Read Terraform configuration data into the model
@@ -572,7 +1713,12 @@ func (d *ContextDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	// Extract parent context if provided
 	var parentCtx ContextInputModel
 	if !data.ParentContext.IsNull() {
-		diag := data.ParentContext.As(ctx, &parentCtx, basetypes.ObjectAsOptions{})
+		parentContext, diag := resolveRenamedAttributes(ctx, data.ParentContext)
+		resp.Diagnostics.Append(diag...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		diag = parentContext.As(ctx, &parentCtx, basetypes.ObjectAsOptions{})
 		resp.Diagnostics.Append(diag...)
 		if resp.Diagnostics.HasError() {
 			return
@@ -580,21 +1726,63 @@ func (d *ContextDataSource) Read(ctx context.Context, req datasource.ReadRequest
 		tflog.Debug(ctx, "Parent context provided, will merge with individual inputs")
 	}
 
+	// Resolve deprecated aliases of renamed attributes
+	managedBy := resolveRenamed(renamedManagedBy, data.ManagedBy, data.ManagedByAlias, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	caseInsensitiveKeys := mergeBoolValue(data.CaseInsensitiveKeys, parentCtx.CaseInsensitiveKeys, false)
+
 	// Convert model to core config, merging parent context with individual inputs
 	// Merge order: defaults -> parent context -> individual inputs
 	config := &core.DataSourceConfig{
-		// Name is always from individual input (not inherited)
-		Name: data.Name.ValueString(),
+		// Name and component are always from individual input (not inherited)
+		Name:      data.Name.ValueString(),
+		Component: data.Component.ValueString(),
+
+		// The suffix is already part of an inherited environment
+		PRNumber:        int(data.PRNumber.ValueInt64()),
+		EphemeralSuffix: data.EphemeralSuffix.ValueString(),
 
 		// These fields can be inherited from parent context
 		Namespace:       mergeStringValue(data.Namespace, parentCtx.Namespace),
+		Tenant:          mergeStringValue(data.Tenant, parentCtx.Tenant),
+		Attributes:      mergeListValue(ctx, data.Attributes, parentCtx.Attributes),
+		LabelOrder:      mergeListValue(ctx, data.LabelOrder, parentCtx.LabelOrder),
 		Environment:     mergeStringValue(data.Environment, parentCtx.Environment),
 		EnvironmentName: mergeStringValue(data.EnvironmentName, parentCtx.EnvironmentName),
 		EnvironmentType: mergeStringValue(data.EnvironmentType, parentCtx.EnvironmentType),
+		NameDelimiter:   mergeOptionalStringValue(data.NameDelimiter, parentCtx.NameDelimiter),
+		ListDelimiter:   mergeStringValue(data.ListDelimiter, parentCtx.ListDelimiter),
+
+		ReservedWords:      mergeListValue(ctx, data.ReservedWords, parentCtx.ReservedWords),
+		ReservedWordAction: mergeStringValue(data.ReservedWordAction, parentCtx.ReservedWordAction),
+
+		StackName: mergeStringValue(data.StackName, parentCtx.StackName),
+
+		Application: mergeStringValue(data.Application, parentCtx.Application),
+		Service:     mergeStringValue(data.Service, parentCtx.Service),
+		Tier:        mergeStringValue(data.Tier, parentCtx.Tier),
+
+		BusinessUnit: mergeStringValue(data.BusinessUnit, parentCtx.BusinessUnit),
+		Division:     mergeStringValue(data.Division, parentCtx.Division),
+		Portfolio:    mergeStringValue(data.Portfolio, parentCtx.Portfolio),
+
+		TenantID: mergeStringValue(data.TenantID, parentCtx.TenantID),
+		Customer: mergeStringValue(data.Customer, parentCtx.Customer),
 
 		Availability: mergeStringValue(data.Availability, parentCtx.Availability),
-		ManagedBy:    mergeStringValue(data.ManagedBy, parentCtx.ManagedBy),
+		ManagedBy:    mergeStringValue(managedBy, parentCtx.ManagedBy),
 		DeletionDate: mergeStringValue(data.DeletionDate, parentCtx.DeletionDate),
+		// Approval is never inherited
+		DecommissionApproved: data.DecommissionApproved.ValueBool(),
+
+		LifecycleAction: mergeStringValue(data.LifecycleAction, parentCtx.LifecycleAction),
+
+		MaintenanceWindow: mergeStringValue(data.MaintenanceWindow, parentCtx.MaintenanceWindow),
+		PatchGroup:        mergeStringValue(data.PatchGroup, parentCtx.PatchGroup),
+		Region:            mergeStringValue(data.Region, parentCtx.Region),
 
 		PMPlatform:    mergeStringValue(data.PMPlatform, parentCtx.PMPlatform),
 		PMProjectCode: mergeStringValue(data.PMProjectCode, parentCtx.PMProjectCode),
@@ -605,115 +1793,249 @@ func (d *ContextDataSource) Read(ctx context.Context, req datasource.ReadRequest
 		ITSMInstanceID:  mergeStringValue(data.ITSMInstanceID, parentCtx.ITSMInstanceID),
 
 		CostCenter:     mergeStringValue(data.CostCenter, parentCtx.CostCenter),
+		MonthlyBudget:  mergeFloat64Value(data.MonthlyBudget, parentCtx.MonthlyBudget),
+		BudgetCurrency: mergeStringValue(data.BudgetCurrency, parentCtx.BudgetCurrency),
 		Sensitivity:    mergeStringValue(data.Sensitivity, parentCtx.Sensitivity),
 		SecurityReview: mergeStringValue(data.SecurityReview, parentCtx.SecurityReview),
 		PrivacyReview:  mergeStringValue(data.PrivacyReview, parentCtx.PrivacyReview),
+		DataRetention:  mergeStringValue(data.DataRetention, parentCtx.DataRetention),
+
+		ComplianceProfile: mergeStringValue(data.ComplianceProfile, parentCtx.ComplianceProfile),
 
 		ProductOwners: mergeListValue(ctx, data.ProductOwners, parentCtx.ProductOwners),
 		CodeOwners:    mergeListValue(ctx, data.CodeOwners, parentCtx.CodeOwners),
 		DataOwners:    mergeListValue(ctx, data.DataOwners, parentCtx.DataOwners),
 		DataRegs:      mergeListValue(ctx, data.DataRegs, parentCtx.DataRegs),
 
-		AdditionalTags:     mergeMapValue(ctx, data.AdditionalTags, parentCtx.AdditionalTags),
-		AdditionalDataTags: mergeMapValue(ctx, data.AdditionalDataTags, parentCtx.AdditionalDataTags),
+		DataResidency:           mergeListValue(ctx, data.DataResidency, parentCtx.DataResidency),
+		SovereigntyRequirements: mergeListValue(ctx, data.SovereigntyRequirements, parentCtx.SovereigntyRequirements),
+
+		AdditionalTags:      mergeMapValue(ctx, data.AdditionalTags, parentCtx.AdditionalTags, caseInsensitiveKeys),
+		AdditionalDataTags:  mergeMapValue(ctx, data.AdditionalDataTags, parentCtx.AdditionalDataTags, caseInsensitiveKeys),
+		AdditionalTypedTags: mergeTypedTagsValue(ctx, data.AdditionalTypedTags, parentCtx.AdditionalTypedTags, caseInsensitiveKeys),
+		RawTags:             mergeMapValue(ctx, data.RawTags, parentCtx.RawTags, false),
+		CaseInsensitiveKeys: caseInsensitiveKeys,
+
+		LegacyTagMap:    mergeMapValue(ctx, data.LegacyTagMap, parentCtx.LegacyTagMap, false),
+		LegacyTagsUntil: mergeStringValue(data.LegacyTagsUntil, parentCtx.LegacyTagsUntil),
 
 		SourceRepoTagsEnabled: mergeBoolValue(data.SourceRepoTagsEnabled, parentCtx.SourceRepoTagsEnabled, true),
+		GitRoot:               mergeStringValue(data.GitRoot, parentCtx.GitRoot),
 		SystemPrefixesEnabled: mergeBoolValue(data.SystemPrefixesEnabled, parentCtx.SystemPrefixesEnabled, true),
 		NotApplicableEnabled:  mergeBoolValue(data.NotApplicableEnabled, parentCtx.NotApplicableEnabled, true),
 		OwnerTagsEnabled:      mergeBoolValue(data.OwnerTagsEnabled, parentCtx.OwnerTagsEnabled, true),
+		ToolingTagsEnabled:    mergeBoolValue(data.ToolingTagsEnabled, parentCtx.ToolingTagsEnabled, false),
+		RegulationTagsEnabled: mergeBoolValue(data.RegulationTagsEnabled, parentCtx.RegulationTagsEnabled, false),
+		DigestTagEnabled:      mergeBoolValue(data.DigestTagEnabled, parentCtx.DigestTagEnabled, false),
+		ProvenanceTagsEnabled: mergeBoolValue(data.ProvenanceTagsEnabled, parentCtx.ProvenanceTagsEnabled, false),
+
+		SanitizationMode: mergeStringValue(data.SanitizationMode, parentCtx.SanitizationMode),
+		LengthOverflow:   mergeStringValue(data.LengthOverflow, parentCtx.LengthOverflow),
+		TagSchemaVersion: int(mergeInt64Value(data.TagSchemaVersion, parentCtx.TagSchemaVersion)),
+
+		NAValue:  mergeStringValue(data.NAValueOverride, parentCtx.NAValueOverride),
+		NAFields: mergeListValue(ctx, data.NAFields, parentCtx.NAFields),
+
+		TokenizeFields: mergeListValue(ctx, data.TokenizeFields, parentCtx.TokenizeFields),
+
+		AzurePolicyInheritanceEnabled: mergeBoolValue(data.AzurePolicyInheritanceEnabled, parentCtx.AzurePolicyInheritanceEnabled, false),
+		AzurePolicyInheritedTags:      mergeListValue(ctx, data.AzurePolicyInheritedTags, parentCtx.AzurePolicyInheritedTags),
+	}
+
+	// Derive component from the module path when not set explicitly
+	if config.Component == "" && !data.ModulePath.IsNull() {
+		if base := path.Base(data.ModulePath.ValueString()); base != "." && base != "/" {
+			config.Component = base
+		}
 	}
 
 	// Handle Enabled field specially - default to true
 	config.Enabled = mergeBoolValue(data.Enabled, parentCtx.Enabled, true)
 
-	// Apply defaults for fields that are still empty after merging
-	if config.Availability == "" {
-		config.Availability = "preemptable"
-	}
-	if config.ManagedBy == "" {
-		config.ManagedBy = "terraform"
-	}
-	if config.Sensitivity == "" {
-		config.Sensitivity = "confidential"
-	}
+	// context_output keeps the attributes ResolveContext defaults or resolves
+	// as they were set, so children apply their own defaults to them
+	unresolved := *config
+	unresolved.DataRegs = slices.Clone(config.DataRegs)
 
-	// Validation
-	if err := core.ValidateNamespace(config.Namespace); err != nil {
-		resp.Diagnostics.AddError("Invalid namespace", err.Error())
+	resolved, diags := ResolveContext(ctx, d.providerConfig, config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
-	if err := core.ValidateEnvironment(config.Environment); err != nil {
-		resp.Diagnostics.AddError("Invalid environment", err.Error())
-		return
+	namePrefix, nameOptions := resolved.NamePrefix, resolved.NameOptions
+	cloudProvider, ownerGroups := resolved.CloudProvider, resolved.OwnerGroups
+	tagProcessor, tags, dataTags := resolved.TagProcessor, resolved.Tags, resolved.DataTags
+
+	shortLength := int64(core.DefaultShortNameLength)
+	if !data.NamePrefixShortLength.IsNull() {
+		shortLength = data.NamePrefixShortLength.ValueInt64()
 	}
-	if err := core.ValidateEnvironmentType(config.EnvironmentType); err != nil {
-		resp.Diagnostics.AddError("Invalid environment_type", err.Error())
+	namePrefixShort, err := core.ShortenNamePrefix(namePrefix, nameOptions.Delimiter, int(shortLength))
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid name_prefix_short_length", err.Error())
 		return
 	}
-	if err := core.ValidateAvailability(config.Availability); err != nil {
-		resp.Diagnostics.AddError("Invalid availability", err.Error())
-		return
+
+	// Generate the name prefixes of the names entries, which differ from the
+	// context only in the name component
+	var names map[string]string
+	resp.Diagnostics.Append(data.Names.ElementsAs(ctx, &names, false)...)
+	var namesAdditionalTags map[string]map[string]string
+	resp.Diagnostics.Append(data.NamesAdditionalTags.ElementsAs(ctx, &namesAdditionalTags, false)...)
+	for _, key := range slices.Sorted(maps.Keys(namesAdditionalTags)) {
+		if _, ok := names[key]; !ok {
+			resp.Diagnostics.AddError("Invalid names_additional_tags",
+				fmt.Sprintf("%q is not a key of names", key))
+		}
 	}
-	if err := core.ValidateSensitivity(config.Sensitivity); err != nil {
-		resp.Diagnostics.AddError("Invalid sensitivity", err.Error())
-		return
+	namedOutputs := make(map[string]namedOutputModel, len(names))
+	for key, name := range names {
+		prefix, diags := generateNamePrefix(config, name, &nameOptions)
+		for _, e := range diags.Errors() {
+			resp.Diagnostics.AddError(e.Summary(), fmt.Sprintf("names[%q]: %s", key, e.Detail()))
+		}
+		if diags.HasError() {
+			continue
+		}
+		short, err := core.ShortenNamePrefix(prefix, nameOptions.Delimiter, int(shortLength))
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid name_prefix_short_length", err.Error())
+			return
+		}
+		namedOutputs[key] = namedOutputModel{
+			NamePrefix:      types.StringValue(prefix),
+			NamePrefixShort: types.StringValue(short),
+		}
 	}
-	if err := core.ValidateDeletionDate(config.DeletionDate); err != nil {
-		resp.Diagnostics.AddError("Invalid deletion_date", err.Error())
+	if resp.Diagnostics.HasError() {
 		return
 	}
-	if err := core.ValidateEmails(config.ProductOwners); err != nil {
-		resp.Diagnostics.AddError("Invalid product_owners", err.Error())
-		return
+
+	// Find globally unique names taken by other accounts before apply fails
+	if action := data.NameAvailabilityCheck.ValueString(); action != "" {
+		if err := core.ValidateNameAvailabilityCheck(action); err != nil {
+			resp.Diagnostics.AddError("Invalid name_availability_check", err.Error())
+			return
+		}
+		var checkNames []string
+		resp.Diagnostics.Append(data.NameAvailabilityNames.ElementsAs(ctx, &checkNames, false)...)
+		prefixes := data.NameAvailabilityNames.IsNull()
+		if prefixes {
+			checkNames = []string{namePrefix}
+			for _, output := range namedOutputs {
+				checkNames = append(checkNames, output.NamePrefix.ValueString())
+			}
+		}
+		slices.Sort(checkNames)
+		resp.Diagnostics.Append(checkNameAvailability(ctx, cloudProvider, action, slices.Compact(checkNames), prefixes)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 	}
-	if err := core.ValidateEmails(config.CodeOwners); err != nil {
-		resp.Diagnostics.AddError("Invalid code_owners", err.Error())
-		return
+
+	// Thousands of generated tags must not flood the plan output with warnings
+	for i, warning := range tagProcessor.Warnings {
+		if i == maxSanitizationWarnings {
+			resp.Diagnostics.AddWarning("Tag value sanitized",
+				fmt.Sprintf("%d more tag values were sanitized", len(tagProcessor.Warnings)-i))
+			break
+		}
+		resp.Diagnostics.AddWarning("Tag value sanitized", warning)
 	}
-	if err := core.ValidateEmails(config.DataOwners); err != nil {
-		resp.Diagnostics.AddError("Invalid data_owners", err.Error())
-		return
+
+	if len(config.LegacyTagMap) > 0 && !tagProcessor.LegacyTagsActive() {
+		resp.Diagnostics.AddWarning("Legacy tag migration ended",
+			fmt.Sprintf("legacy_tags_until %s has passed, so the legacy_tag_map keys are no longer emitted. Remove legacy_tag_map and legacy_tags_until.", config.LegacyTagsUntil))
 	}
 
-	// Process ephemeral environment
-	core.ProcessEphemeralEnvironment(config)
+	// The source repository tags are not applicable when git was too slow;
+	// the lookup is cached, so this does not run git again
+	if config.SourceRepoTagsEnabled || config.ProvenanceTagsEnabled {
+		gitTimeout := d.providerConfig.GitTimeout
+		if gitTimeout == 0 {
+			gitTimeout = core.DefaultGitTimeout
+		}
+		if gitInfo, err := core.GetGitInfoInDir(d.providerConfig.GitRootFor(config.GitRoot), gitTimeout); err == nil && gitInfo.TimedOut {
+			resp.Diagnostics.AddWarning("Git lookup timed out",
+				fmt.Sprintf("git did not answer within %s, so the sourcerepo and sourcecommit tags are not applicable. Increase git_timeout for slow repositories, or set source_repo_tags_enabled to false.", gitTimeout))
+		}
+	}
 
-	// Generate name prefix
-	nameGen := &core.NameGenerator{
-		Namespace:   config.Namespace,
-		Name:        config.Name,
-		Environment: config.Environment,
+	// Like the contextdigest tag, the digest covers the Azure Policy
+	// inherited tags, which the resource carries once inherited
+	contextDigest := tagProcessor.ContextDigest(tags)
+
+	// Inheritable tags are taken before Azure Policy inherited tags are
+	// omitted, since they are what the resource group must carry
+	inheritableTags := tagProcessor.InheritableTags(tags)
+	providerDefaultTags := tagProcessor.ProviderDefaultTags(tags)
+	tags = tagProcessor.OmitPolicyInheritedTags(tags)
+
+	// Each names entry with an overlay gets the tags of the context with the
+	// overlay merged onto additional_tags; the others share tags
+	tagsByName := make(map[string]map[string]string, len(names))
+	for key, name := range names {
+		overlay := namesAdditionalTags[key]
+		if len(overlay) == 0 {
+			tagsByName[key] = tags
+			continue
+		}
+		entryConfig := *config
+		entryConfig.Name = name
+		entryConfig.AdditionalTags = core.MergeAdditionalTags(config.AdditionalTags, overlay, config.CaseInsensitiveKeys)
+		entryProcessor := resolved.NewTagProcessor(&entryConfig, namedOutputs[key].NamePrefix.ValueString())
+		entryTags, err := entryProcessor.Process()
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to generate tags", fmt.Sprintf("names_additional_tags[%q]: %s", key, err))
+			continue
+		}
+		// The shared tag values were reported above
+		for _, warning := range entryProcessor.Warnings {
+			if !slices.Contains(tagProcessor.Warnings, warning) {
+				resp.Diagnostics.AddWarning("Tag value sanitized", fmt.Sprintf("names_additional_tags[%q]: %s", key, warning))
+			}
+		}
+		tagsByName[key] = entryProcessor.OmitPolicyInheritedTags(entryTags)
 	}
-	namePrefix, err := nameGen.Generate()
-	if err != nil {
-		resp.Diagnostics.AddError("Failed to generate name prefix", err.Error())
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Get cloud provider
-	cloudProvider := d.providerConfig.CloudProvider
-	if cloudProvider == "" {
-		cloudProvider = "dc"
+	collisions := slices.Concat(tagProcessor.TagKeyCollisions(tags), tagProcessor.DataTagKeyCollisions(dataTags))
+	for _, collision := range collisions {
+		resp.Diagnostics.AddWarning(
+			"Colliding tag keys",
+			fmt.Sprintf("The tag keys %s are the same key for the cloud provider, so only one of them is applied. Rename or remove the others, or set sanitization_mode to error to reject them", collision),
+		)
 	}
-	cp := core.GetCloudProvider(cloudProvider)
 
-	// Generate tags
-	tagProcessor := &core.TagProcessor{
-		CloudProvider: cp,
-		Config:        config,
-		TagPrefix:     d.providerConfig.TagPrefix,
+	for _, key := range slices.Concat(tagProcessor.InvalidTagKeys(tags), tagProcessor.InvalidTagKeys(dataTags)) {
+		resp.Diagnostics.AddWarning(
+			"Invalid tag key",
+			fmt.Sprintf("The tag key %q is not valid for the cloud provider, which rejects it at apply time. Rename it, or set sanitization_mode to error to reject it", key),
+		)
 	}
 
-	tags, err := tagProcessor.Process()
-	if err != nil {
-		resp.Diagnostics.AddError("Failed to generate tags", err.Error())
-		return
+	for _, key := range tagProcessor.ReservedTagKeys() {
+		resp.Diagnostics.AddWarning(
+			"Reserved tag key",
+			fmt.Sprintf("%s uses the prefix %q reserved by the cloud provider; resources fail to apply or ignore such tags. Rename it in additional_tags or additional_data_tags, or set sanitization_mode to error to reject it", key, core.ReservedTagKeyPrefix(tagProcessor.CloudProvider, key)),
+		)
 	}
 
-	dataTags, err := tagProcessor.ProcessDataTags()
-	if err != nil {
-		resp.Diagnostics.AddError("Failed to generate data tags", err.Error())
-		return
+	// Raw tags are not checked at all, so each one is called out
+	for _, key := range slices.Sorted(maps.Keys(config.RawTags)) {
+		resp.Diagnostics.AddWarning(
+			"Unsanitized raw tag",
+			fmt.Sprintf("raw_tags sets %s = %q verbatim, without the tag prefix, sanitization or validation, so the cloud provider may reject it at apply time. Keep raw_tags for vendor-mandated tags that additional_tags would change", key, config.RawTags[key]),
+		)
+	}
+
+	for _, key := range tagProcessor.DefaultTagConflicts(providerDefaultTags) {
+		resp.Diagnostics.AddWarning(
+			"Tag conflicts with provider default_tags",
+			fmt.Sprintf("additional_tags sets %s, which is also in provider_default_tags; setting it on resources and in the aws provider default_tags causes perpetual diffs", key),
+		)
 	}
 
 	// Convert outputs
@@ -725,9 +2047,25 @@ func (d *ContextDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	dataTagsKVPList := core.ConvertTagsToKVPList(dataTags)
 	dataTagsCommaSeparated := core.ConvertTagsToCommaSeparated(dataTags)
 
-	// Set computed values
-	data.ID = types.StringValue(namePrefix)
+	// Set computed values. Without a name many contexts share the name
+	// prefix, so the ID adds a hash of the resolved config
+	id := namePrefix
+	if config.Name == "" {
+		id, err = core.SurrogateID(namePrefix, config)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to generate id", err.Error())
+			return
+		}
+	}
+	data.ID = types.StringValue(id)
 	data.NamePrefix = types.StringValue(namePrefix)
+	span.SetAttributes(attribute.String("name_prefix", namePrefix))
+	data.NamePrefixShort = types.StringValue(namePrefixShort)
+	data.NamedOutputs, diags = types.MapValueFrom(ctx, namedOutputType, namedOutputs)
+	resp.Diagnostics.Append(diags...)
+	data.TagsByName, diags = types.MapValueFrom(ctx, types.MapType{ElemType: types.StringType}, tagsByName)
+	resp.Diagnostics.Append(diags...)
+	data.ListDelimiterOutput = types.StringValue(tagProcessor.ListDelimiter())
 
 	// Convert maps to types.Map
 	tagsMap, diags := types.MapValueFrom(ctx, types.StringType, tags)
@@ -738,6 +2076,74 @@ func (d *ContextDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	resp.Diagnostics.Append(diags...)
 	data.DataTags = dataTagsMap
 
+	requiredTags, optionalTags := tagProcessor.SplitRequiredTags(tags)
+	requiredTagsMap, diags := types.MapValueFrom(ctx, types.StringType, requiredTags)
+	resp.Diagnostics.Append(diags...)
+	data.RequiredTags = requiredTagsMap
+
+	optionalTagsMap, diags := types.MapValueFrom(ctx, types.StringType, optionalTags)
+	resp.Diagnostics.Append(diags...)
+	data.OptionalTags = optionalTagsMap
+
+	tagsUnprefixedMap, diags := types.MapValueFrom(ctx, types.StringType, tagProcessor.UnprefixedTags(tags))
+	resp.Diagnostics.Append(diags...)
+	data.TagsUnprefixed = tagsUnprefixedMap
+
+	var tagFilter []string
+	resp.Diagnostics.Append(data.TagFilter.ElementsAs(ctx, &tagFilter, false)...)
+	tagsFiltered, err := tagProcessor.FilterTags(tags, tagFilter)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid tag_filter", err.Error())
+		return
+	}
+	tagsFilteredMap, diags := types.MapValueFrom(ctx, types.StringType, tagsFiltered)
+	resp.Diagnostics.Append(diags...)
+	data.TagsFiltered = tagsFilteredMap
+
+	data.TagCount = types.Int64Value(int64(len(tags)))
+	data.DataTagCount = types.Int64Value(int64(len(dataTags)))
+	data.HasOwnerTags = types.BoolValue(tagProcessor.HasTags(tags, core.OwnerTagKeys) || tagProcessor.HasTags(dataTags, core.OwnerTagKeys))
+	data.HasSourceTags = types.BoolValue(tagProcessor.HasTags(tags, core.SourceTagKeys))
+
+	compliance := tagProcessor.Compliance(tags, dataTags)
+	complianceObj, diags := types.ObjectValueFrom(ctx, complianceTypes, complianceModel{
+		OwnersPresent:         types.BoolValue(compliance.OwnersPresent),
+		CostCenterPresent:     types.BoolValue(compliance.CostCenterPresent),
+		ExpirySetForEphemeral: types.BoolValue(compliance.ExpirySetForEphemeral),
+		WithinTagLimits:       types.BoolValue(compliance.WithinTagLimits),
+	})
+	resp.Diagnostics.Append(diags...)
+	data.Compliance = complianceObj
+
+	data.ContextDigest = types.StringValue(contextDigest)
+	data.ContextSignature = types.StringNull()
+	if d.providerConfig.Signer != nil {
+		signature, err := core.SignDigest(d.providerConfig.Signer, contextDigest)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to sign context digest", err.Error())
+			return
+		}
+		data.ContextSignature = types.StringValue(signature)
+	}
+
+	inheritableTagsMap, diags := types.MapValueFrom(ctx, types.StringType, inheritableTags)
+	resp.Diagnostics.Append(diags...)
+	data.InheritableTags = inheritableTagsMap
+	providerDefaultTagsMap, diags := types.MapValueFrom(ctx, types.StringType, providerDefaultTags)
+	resp.Diagnostics.Append(diags...)
+	data.ProviderDefaultTags = providerDefaultTagsMap
+
+	ownerGroupValues := make(map[string]ownerGroupModel, len(ownerGroups))
+	for email, group := range ownerGroups {
+		ownerGroupValues[email] = ownerGroupModel{
+			DisplayName: types.StringValue(group.DisplayName),
+			MemberCount: types.Int64Value(int64(group.MemberCount)),
+		}
+	}
+	ownerGroupsMap, diags := types.MapValueFrom(ctx, ownerGroupType, ownerGroupValues)
+	resp.Diagnostics.Append(diags...)
+	data.OwnerGroups = ownerGroupsMap
+
 	// Convert list of maps
 	tagsListValue, diags := types.ListValueFrom(ctx, types.MapType{ElemType: types.StringType}, tagsListOfMaps)
 	resp.Diagnostics.Append(diags...)
@@ -760,103 +2166,402 @@ func (d *ContextDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	data.TagsAsCommaSeparatedString = types.StringValue(tagsCommaSeparated)
 	data.DataTagsAsCommaSeparatedString = types.StringValue(dataTagsCommaSeparated)
 
+	// Convert observability platform formats
+	ddTagsValue, diags := types.ListValueFrom(ctx, types.StringType, core.ConvertTagsToDatadog(tags))
+	resp.Diagnostics.Append(diags...)
+	data.TagsAsDDTags = ddTagsValue
+
+	// Typed tag values, without the stringification of tags
+	numbersValue, diags := types.MapValueFrom(ctx, types.Float64Type, tagProcessor.NumberTags(tags))
+	resp.Diagnostics.Append(diags...)
+	data.TagsAsNumbers = numbersValue
+
+	boolsValue, diags := types.MapValueFrom(ctx, types.BoolType, tagProcessor.BoolTags(tags))
+	resp.Diagnostics.Append(diags...)
+	data.TagsAsBools = boolsValue
+
+	newRelicTagsValue, diags := types.MapValueFrom(ctx, types.StringType, core.ConvertTagsToNewRelic(tags))
+	resp.Diagnostics.Append(diags...)
+	data.TagsAsNewRelicTags = newRelicTagsValue
+
+	// Convert budget cost filters
+	budgetsFilterValue, diags := types.MapValueFrom(ctx, types.ListType{ElemType: types.StringType}, tagProcessor.AWSBudgetsFilter(tags))
+	resp.Diagnostics.Append(diags...)
+	data.AWSBudgetsFilter = budgetsFilterValue
+
+	focusTagsMap, diags := types.MapValueFrom(ctx, types.StringType, tagProcessor.FOCUSTags(tags))
+	resp.Diagnostics.Append(diags...)
+	data.FOCUSTags = focusTagsMap
+
+	// Render IAM tag conditions
+	resourceTagCondition, err := tagProcessor.IAMTagCondition(tags, core.IAMResourceTag)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to generate IAM tag condition", err.Error())
+		return
+	}
+	requestTagCondition, err := tagProcessor.IAMTagCondition(tags, core.IAMRequestTag)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to generate IAM tag condition", err.Error())
+		return
+	}
+	data.IAMResourceTagCondition = types.StringValue(resourceTagCondition)
+	data.IAMRequestTagCondition = types.StringValue(requestTagCondition)
+
+	sanitizeLookups, sanitizeCacheHits := core.SanitizeStats()
 	tflog.Debug(ctx, "Context data source read", map[string]interface{}{
-		"name_prefix":     namePrefix,
-		"tags_count":      len(tags),
-		"data_tags_count": len(dataTags),
+		"name_prefix":         namePrefix,
+		"tags_count":          len(tags),
+		"data_tags_count":     len(dataTags),
+		"sanitize_lookups":    sanitizeLookups,
+		"sanitize_cache_hits": sanitizeCacheHits,
 	})
 
-	// Populate context_output with resolved values for use in child contexts
+	// Populate context_output with resolved values for use in child contexts,
+	// leaving the defaulted attributes unset and git_root as configured
+	outputConfig := *config
+	outputConfig.Availability = unresolved.Availability
+	outputConfig.ManagedBy = unresolved.ManagedBy
+	outputConfig.Sensitivity = unresolved.Sensitivity
+	outputConfig.DataRetention = unresolved.DataRetention
+	outputConfig.DataRegs = unresolved.DataRegs
+	outputConfig.GitRoot = unresolved.GitRoot
+	contextOutputObj, diags := contextOutputValue(ctx, &outputConfig, types.StringPointerValue(config.NameDelimiter))
+	resp.Diagnostics.Append(diags...)
+	data.ContextOutput = contextOutputObj
+	contextOutputMap := flattenContextOutput(contextOutputObj)
+	data.ContextOutputMap, diags = types.MapValueFrom(ctx, types.StringType, contextOutputMap)
+	resp.Diagnostics.Append(diags...)
+
+	contextOutputMap["name_prefix"] = namePrefix
+	data.EventFields, diags = types.MapValueFrom(ctx, types.StringType, core.ConvertToEventFields(contextOutputMap, core.EventFieldNamespace))
+	resp.Diagnostics.Append(diags...)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// generateNamePrefix returns the name prefix of the resolved config with
+// name as the name component, screened for reserved words
+func generateNamePrefix(config *core.DataSourceConfig, name string, nameOptions *core.NameOptions) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	nameGen := &core.NameGenerator{
+		Namespace:   config.Namespace,
+		Tenant:      config.Tenant,
+		Name:        name,
+		Environment: config.Environment,
+		Attributes:  config.Attributes,
+		Customer:    config.Customer,
+		Options:     nameOptions,
+	}
+	namePrefix, err := nameGen.Generate()
+	if err != nil {
+		diags.AddError("Failed to generate name prefix", err.Error())
+		return "", diags
+	}
+
+	if config.ReservedWordAction == "" {
+		return namePrefix, diags
+	}
+	found := core.FindReservedWords(namePrefix, config.ReservedWords)
+	if len(found) == 0 {
+		return namePrefix, diags
+	}
+	if config.ReservedWordAction == "error" {
+		diags.AddError("Reserved word in name prefix",
+			fmt.Sprintf("name prefix '%s' contains reserved words: %s", namePrefix, strings.Join(found, ", ")))
+		return "", diags
+	}
+	namePrefix = core.RemoveReservedWords(namePrefix, nameOptions.Delimiter, config.ReservedWords)
+	if namePrefix == "" {
+		diags.AddError("Reserved word in name prefix",
+			fmt.Sprintf("name prefix is empty after removing reserved words: %s", strings.Join(found, ", ")))
+	}
+	return namePrefix, diags
+}
+
+// newNameChecker returns the name checker of a cloud provider, replaced in
+// tests
+var newNameChecker = integrations.NewNameChecker
+
+// checkNameAvailability checks names with the name checker of cloudProvider,
+// reporting names taken by other accounts as warnings or, when action is
+// error, as errors. Names the credentials can access are not reported, so
+// plans after the first apply stay clean. Without credentials the check is
+// skipped. Names that are not valid names of the resource are skipped when
+// they are name prefixes, and reported otherwise.
+func checkNameAvailability(ctx context.Context, cloudProvider, action string, names []string, prefixes bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+	checker, err := newNameChecker(ctx, cloudProvider)
+	if errors.Is(err, core.ErrNameCheckNotConfigured) {
+		tflog.Debug(ctx, "Name availability check skipped", map[string]interface{}{
+			"reason": err.Error(),
+		})
+		return diags
+	}
+	if err != nil {
+		diags.AddError("Invalid name_availability_check", err.Error())
+		return diags
+	}
+
+	ctx, span := tracing.StartSpan(ctx, "NameChecker.CheckName")
+	defer func() { tracing.EndSpan(span, diags) }()
+	for _, name := range names {
+		if !core.ValidCheckName(checker, name) {
+			if prefixes {
+				tflog.Debug(ctx, "Name availability check skipped", map[string]interface{}{
+					"reason": fmt.Sprintf("%s is not a valid %s name", name, checker.Resource()),
+				})
+			} else {
+				diags.AddWarning("Name availability check failed",
+					fmt.Sprintf("%s is not a valid %s name", name, checker.Resource()))
+			}
+			continue
+		}
+		availability, err := checker.CheckName(ctx, name)
+		if err != nil {
+			diags.AddWarning("Name availability check failed",
+				fmt.Sprintf("Could not check %s name %s: %s", checker.Resource(), name, err))
+			continue
+		}
+		tflog.Debug(ctx, "Name availability checked", map[string]interface{}{
+			"name":         name,
+			"availability": string(availability),
+		})
+		if availability != core.NameTaken {
+			continue
+		}
+		detail := fmt.Sprintf("%s name %s is used by another account, so creating it fails at apply. Change the name, or the namespace or attributes of the name prefix.", checker.Resource(), name)
+		if action == core.NameAvailabilityCheckError {
+			diags.AddError("Name not available", detail)
+		} else {
+			diags.AddWarning("Name not available", detail)
+		}
+	}
+	return diags
+}
+
+// contextOutputValue converts a resolved config into a context object that
+// can be used as parent_context. nameDelimiter is passed separately because
+// the resolved delimiter is not part of config. Unset fields are null rather
+// than empty, so consumers of the object can tell them from set values.
+func contextOutputValue(ctx context.Context, config *core.DataSourceConfig, nameDelimiter types.String) (types.Object, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
 	contextOutput := ContextInputModel{
-		Namespace:       types.StringValue(config.Namespace),
-		Environment:     types.StringValue(config.Environment),
-		EnvironmentName: types.StringValue(config.EnvironmentName),
-		EnvironmentType: types.StringValue(config.EnvironmentType),
+		Namespace:       optionalString(config.Namespace),
+		Tenant:          optionalString(config.Tenant),
+		Environment:     optionalString(config.Environment),
+		EnvironmentName: optionalString(config.EnvironmentName),
+		EnvironmentType: optionalString(config.EnvironmentType),
+		NameDelimiter:   nameDelimiter,
+		ListDelimiter:   optionalString(config.ListDelimiter),
+
+		ReservedWordAction: optionalString(config.ReservedWordAction),
+
+		StackName: optionalString(config.StackName),
+
+		Application: optionalString(config.Application),
+		Service:     optionalString(config.Service),
+		Tier:        optionalString(config.Tier),
+
+		BusinessUnit: optionalString(config.BusinessUnit),
+		Division:     optionalString(config.Division),
+		Portfolio:    optionalString(config.Portfolio),
+
+		TenantID: optionalString(config.TenantID),
+		Customer: optionalString(config.Customer),
 
 		Enabled:      types.BoolValue(config.Enabled),
-		Availability: types.StringValue(config.Availability),
-		ManagedBy:    types.StringValue(config.ManagedBy),
-		DeletionDate: types.StringValue(config.DeletionDate),
+		Availability: optionalString(config.Availability),
+		ManagedBy:    optionalString(config.ManagedBy),
+		DeletionDate: optionalString(config.DeletionDate),
+
+		LifecycleAction: optionalString(config.LifecycleAction),
+
+		MaintenanceWindow: optionalString(config.MaintenanceWindow),
+		PatchGroup:        optionalString(config.PatchGroup),
+		Region:            optionalString(config.Region),
+
+		PMPlatform:    optionalString(config.PMPlatform),
+		PMProjectCode: optionalString(config.PMProjectCode),
 
-		PMPlatform:    types.StringValue(config.PMPlatform),
-		PMProjectCode: types.StringValue(config.PMProjectCode),
+		ITSMPlatform:    optionalString(config.ITSMPlatform),
+		ITSMSystemID:    optionalString(config.ITSMSystemID),
+		ITSMComponentID: optionalString(config.ITSMComponentID),
+		ITSMInstanceID:  optionalString(config.ITSMInstanceID),
 
-		ITSMPlatform:    types.StringValue(config.ITSMPlatform),
-		ITSMSystemID:    types.StringValue(config.ITSMSystemID),
-		ITSMComponentID: types.StringValue(config.ITSMComponentID),
-		ITSMInstanceID:  types.StringValue(config.ITSMInstanceID),
+		CostCenter:     optionalString(config.CostCenter),
+		MonthlyBudget:  optionalFloat64(config.MonthlyBudget),
+		BudgetCurrency: optionalString(config.BudgetCurrency),
+		Sensitivity:    optionalString(config.Sensitivity),
+		SecurityReview: optionalString(config.SecurityReview),
+		PrivacyReview:  optionalString(config.PrivacyReview),
+		DataRetention:  optionalString(config.DataRetention),
 
-		CostCenter:     types.StringValue(config.CostCenter),
-		Sensitivity:    types.StringValue(config.Sensitivity),
-		SecurityReview: types.StringValue(config.SecurityReview),
-		PrivacyReview:  types.StringValue(config.PrivacyReview),
+		ComplianceProfile: optionalString(config.ComplianceProfile),
 
 		SourceRepoTagsEnabled: types.BoolValue(config.SourceRepoTagsEnabled),
+		GitRoot:               optionalString(config.GitRoot),
 		SystemPrefixesEnabled: types.BoolValue(config.SystemPrefixesEnabled),
 		NotApplicableEnabled:  types.BoolValue(config.NotApplicableEnabled),
 		OwnerTagsEnabled:      types.BoolValue(config.OwnerTagsEnabled),
+		ToolingTagsEnabled:    types.BoolValue(config.ToolingTagsEnabled),
+		RegulationTagsEnabled: types.BoolValue(config.RegulationTagsEnabled),
+		DigestTagEnabled:      types.BoolValue(config.DigestTagEnabled),
+		ProvenanceTagsEnabled: types.BoolValue(config.ProvenanceTagsEnabled),
+
+		SanitizationMode: optionalString(config.SanitizationMode),
+		LengthOverflow:   optionalString(config.LengthOverflow),
+		TagSchemaVersion: optionalInt64(int64(config.TagSchemaVersion)),
+
+		NAValueOverride: optionalString(config.NAValue),
+
+		AzurePolicyInheritanceEnabled: types.BoolValue(config.AzurePolicyInheritanceEnabled),
+
+		CaseInsensitiveKeys: types.BoolValue(config.CaseInsensitiveKeys),
+
+		LegacyTagsUntil: optionalString(config.LegacyTagsUntil),
+
+		ManagedByAlias: optionalString(config.ManagedBy),
 	}
 
-	// Convert list fields - always initialize with proper type even if empty
-	listVal, diags := types.ListValueFrom(ctx, types.StringType, config.ProductOwners)
-	resp.Diagnostics.Append(diags...)
+	// Convert list fields - unset lists are nil and stay null, while lists
+	// set to [] stay empty
+	listVal, d := types.ListValueFrom(ctx, types.StringType, config.ProductOwners)
+	diags.Append(d...)
 	contextOutput.ProductOwners = listVal
 
-	listVal, diags = types.ListValueFrom(ctx, types.StringType, config.CodeOwners)
-	resp.Diagnostics.Append(diags...)
+	listVal, d = types.ListValueFrom(ctx, types.StringType, config.CodeOwners)
+	diags.Append(d...)
 	contextOutput.CodeOwners = listVal
 
-	listVal, diags = types.ListValueFrom(ctx, types.StringType, config.DataOwners)
-	resp.Diagnostics.Append(diags...)
+	listVal, d = types.ListValueFrom(ctx, types.StringType, config.DataOwners)
+	diags.Append(d...)
 	contextOutput.DataOwners = listVal
 
-	listVal, diags = types.ListValueFrom(ctx, types.StringType, config.DataRegs)
-	resp.Diagnostics.Append(diags...)
+	listVal, d = types.ListValueFrom(ctx, types.StringType, config.DataRegs)
+	diags.Append(d...)
 	contextOutput.DataRegs = listVal
 
-	// Convert map fields - always initialize with proper type even if empty
-	mapVal, diags := types.MapValueFrom(ctx, types.StringType, config.AdditionalTags)
-	resp.Diagnostics.Append(diags...)
+	listVal, d = types.ListValueFrom(ctx, types.StringType, config.DataResidency)
+	diags.Append(d...)
+	contextOutput.DataResidency = listVal
+
+	listVal, d = types.ListValueFrom(ctx, types.StringType, config.SovereigntyRequirements)
+	diags.Append(d...)
+	contextOutput.SovereigntyRequirements = listVal
+
+	listVal, d = types.ListValueFrom(ctx, types.StringType, config.Attributes)
+	diags.Append(d...)
+	contextOutput.Attributes = listVal
+
+	listVal, d = types.ListValueFrom(ctx, types.StringType, config.LabelOrder)
+	diags.Append(d...)
+	contextOutput.LabelOrder = listVal
+
+	listVal, d = types.ListValueFrom(ctx, types.StringType, config.ReservedWords)
+	diags.Append(d...)
+	contextOutput.ReservedWords = listVal
+
+	listVal, d = types.ListValueFrom(ctx, types.StringType, config.NAFields)
+	diags.Append(d...)
+	contextOutput.NAFields = listVal
+
+	listVal, d = types.ListValueFrom(ctx, types.StringType, config.TokenizeFields)
+	diags.Append(d...)
+	contextOutput.TokenizeFields = listVal
+
+	listVal, d = types.ListValueFrom(ctx, types.StringType, config.AzurePolicyInheritedTags)
+	diags.Append(d...)
+	contextOutput.AzurePolicyInheritedTags = listVal
+
+	// Convert map fields - merging leaves unset maps empty, so empty maps
+	// become null
+	mapVal, d := optionalStringMap(ctx, config.AdditionalTags)
+	diags.Append(d...)
 	contextOutput.AdditionalTags = mapVal
 
-	mapVal, diags = types.MapValueFrom(ctx, types.StringType, config.AdditionalDataTags)
-	resp.Diagnostics.Append(diags...)
+	mapVal, d = optionalStringMap(ctx, config.AdditionalDataTags)
+	diags.Append(d...)
 	contextOutput.AdditionalDataTags = mapVal
 
-	// Set context_output
-	contextOutputObj, diagsCtx := types.ObjectValueFrom(ctx, map[string]attr.Type{
-		"namespace":                types.StringType,
-		"environment":              types.StringType,
-		"environment_name":         types.StringType,
-		"environment_type":         types.StringType,
-		"enabled":                  types.BoolType,
-		"availability":             types.StringType,
-		"managedby":                types.StringType,
-		"deletion_date":            types.StringType,
-		"pm_platform":              types.StringType,
-		"pm_project_code":          types.StringType,
-		"itsm_platform":            types.StringType,
-		"itsm_system_id":           types.StringType,
-		"itsm_component_id":        types.StringType,
-		"itsm_instance_id":         types.StringType,
-		"cost_center":              types.StringType,
-		"product_owners":           types.ListType{ElemType: types.StringType},
-		"code_owners":              types.ListType{ElemType: types.StringType},
-		"data_owners":              types.ListType{ElemType: types.StringType},
-		"sensitivity":              types.StringType,
-		"data_regs":                types.ListType{ElemType: types.StringType},
-		"security_review":          types.StringType,
-		"privacy_review":           types.StringType,
-		"source_repo_tags_enabled": types.BoolType,
-		"system_prefixes_enabled":  types.BoolType,
-		"not_applicable_enabled":   types.BoolType,
-		"owner_tags_enabled":       types.BoolType,
-		"additional_tags":          types.MapType{ElemType: types.StringType},
-		"additional_data_tags":     types.MapType{ElemType: types.StringType},
-	}, contextOutput)
-	resp.Diagnostics.Append(diagsCtx...)
-	data.ContextOutput = contextOutputObj
+	contextOutput.AdditionalTypedTags = types.MapNull(typedTagType)
+	if len(config.AdditionalTypedTags) > 0 {
+		mapVal, d = typedTagsValue(ctx, config.AdditionalTypedTags)
+		diags.Append(d...)
+		contextOutput.AdditionalTypedTags = mapVal
+	}
 
-	// Save data into Terraform state
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	mapVal, d = optionalStringMap(ctx, config.RawTags)
+	diags.Append(d...)
+	contextOutput.RawTags = mapVal
+
+	mapVal, d = optionalStringMap(ctx, config.LegacyTagMap)
+	diags.Append(d...)
+	contextOutput.LegacyTagMap = mapVal
+
+	contextOutputObj, d := types.ObjectValueFrom(ctx, ContextAttributeTypes(), contextOutput)
+	diags.Append(d...)
+
+	return contextOutputObj, diags
+}
+
+// flattenContextOutput converts a context object into a map(string). Lists
+// are joined with commas, map entries are keyed as attribute.key, or as
+// attribute.key.field for object entries, and null values and deprecated
+// aliases are left out.
+func flattenContextOutput(obj types.Object) map[string]string {
+	flat := map[string]string{}
+	for name, value := range obj.Attributes() {
+		if slices.ContainsFunc(renamedAttributes, func(r renamedAttribute) bool { return r.Old == name }) {
+			continue
+		}
+		switch v := value.(type) {
+		case types.Map:
+			for key, elem := range v.Elements() {
+				if obj, ok := elem.(types.Object); ok {
+					for field, fieldValue := range obj.Attributes() {
+						if s, ok := flattenValue(fieldValue); ok {
+							flat[name+"."+key+"."+field] = s
+						}
+					}
+					continue
+				}
+				if s, ok := flattenValue(elem); ok {
+					flat[name+"."+key] = s
+				}
+			}
+		default:
+			if s, ok := flattenValue(v); ok {
+				flat[name] = s
+			}
+		}
+	}
+	return flat
+}
+
+// flattenValue returns the string form of a scalar or list value.
+func flattenValue(value attr.Value) (string, bool) {
+	if value.IsNull() || value.IsUnknown() {
+		return "", false
+	}
+	switch v := value.(type) {
+	case types.String:
+		return v.ValueString(), true
+	case types.Bool:
+		return strconv.FormatBool(v.ValueBool()), true
+	case types.Int64:
+		return strconv.FormatInt(v.ValueInt64(), 10), true
+	case types.Float64:
+		return strconv.FormatFloat(v.ValueFloat64(), 'f', -1, 64), true
+	case types.List:
+		elems := make([]string, 0, len(v.Elements()))
+		for _, elem := range v.Elements() {
+			if s, ok := flattenValue(elem); ok {
+				elems = append(elems, s)
+			}
+		}
+		return strings.Join(elems, ","), true
+	}
+	return "", false
 }
@@ -0,0 +1,195 @@
+package datasource
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/kbrockhoff/terraform-provider-context/internal/core"
+	"github.com/kbrockhoff/terraform-provider-context/internal/metrics"
+	"github.com/kbrockhoff/terraform-provider-context/internal/tracing"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ContextDiffDataSource{}
+
+func NewContextDiffDataSource() datasource.DataSource {
+	return &ContextDiffDataSource{}
+}
+
+// ContextDiffDataSource compares two context objects field by field.
+type ContextDiffDataSource struct{}
+
+// ContextDiffDataSourceModel describes the data source data model.
+type ContextDiffDataSourceModel struct {
+	Current  types.Object `tfsdk:"current"`
+	Proposed types.Object `tfsdk:"proposed"`
+
+	// Computed Outputs
+	ID         types.String `tfsdk:"id"`
+	Added      types.List   `tfsdk:"added"`
+	Removed    types.List   `tfsdk:"removed"`
+	Changed    types.List   `tfsdk:"changed"`
+	Changes    types.Map    `tfsdk:"changes"`
+	HasChanges types.Bool   `tfsdk:"has_changes"`
+}
+
+// fieldChangeModel describes an element of the changes output.
+type fieldChangeModel struct {
+	Current  types.String `tfsdk:"current"`
+	Proposed types.String `tfsdk:"proposed"`
+}
+
+// fieldChangeTypes are the attribute types of an element of the changes output
+var fieldChangeTypes = map[string]attr.Type{
+	"current":  types.StringType,
+	"proposed": types.StringType,
+}
+
+func (d *ContextDiffDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_context_diff"
+}
+
+func (d *ContextDiffDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Compares two context objects, such as the current and a proposed parent context, and lists the added, removed and changed fields, previewing the blast radius of changing a shared context before rolling it out. Additional tag maps are compared per key, as additional_tags.<key>; null and empty values count as unset.",
+
+		Attributes: map[string]schema.Attribute{
+			"current": schema.SingleNestedAttribute{
+				Description: "Context in use, such as data.brockhoff_context.org.context_output",
+				Required:    true,
+				Attributes:  getContextAttributes(),
+			},
+			"proposed": schema.SingleNestedAttribute{
+				Description: "Context to compare against current",
+				Required:    true,
+				Attributes:  getContextAttributes(),
+			},
+
+			// Computed Outputs
+			"id": schema.StringAttribute{
+				Description: "Unique identifier for this data source instance",
+				Computed:    true,
+			},
+			"added": schema.ListAttribute{
+				Description: "Fields set only in proposed, sorted",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"removed": schema.ListAttribute{
+				Description: "Fields set only in current, sorted",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"changed": schema.ListAttribute{
+				Description: "Fields set in both contexts with different values, sorted",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"changes": schema.MapNestedAttribute{
+				Description: "Current and proposed values of every added, removed and changed field, keyed by field. Lists are comma-joined; unset values are null",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"current": schema.StringAttribute{
+							Description: "Value in current",
+							Computed:    true,
+						},
+						"proposed": schema.StringAttribute{
+							Description: "Value in proposed",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"has_changes": schema.BoolAttribute{
+				Description: "Whether any field differs",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// optionalString returns a null string for an empty value
+func optionalString(s string) types.String {
+	if s == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(s)
+}
+
+func (d *ContextDiffDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := tracing.StartSpan(ctx, "ContextDiffDataSource.Read")
+	start := time.Now()
+	defer func() {
+		tracing.EndSpan(span, resp.Diagnostics)
+		metrics.RecordRead("brockhoff_context_diff", time.Since(start), resp.Diagnostics)
+	}()
+
+	if deferUnknownConfig(ctx, req, resp) {
+		return
+	}
+
+	var data ContextDiffDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, diags := resolveRenamedAttributes(ctx, data.Current)
+	resp.Diagnostics.Append(diags...)
+	proposed, diags := resolveRenamedAttributes(ctx, data.Proposed)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	currentFields := flattenContextOutput(current)
+	proposedFields := flattenContextOutput(proposed)
+	diff := core.DiffFields(currentFields, proposedFields)
+
+	changes := map[string]fieldChangeModel{}
+	var contents strings.Builder
+	for _, fields := range [][]string{diff.Added, diff.Removed, diff.Changed} {
+		for _, field := range fields {
+			changes[field] = fieldChangeModel{
+				Current:  optionalString(currentFields[field]),
+				Proposed: optionalString(proposedFields[field]),
+			}
+			fmt.Fprintf(&contents, "%s=%q>%q\n", field, currentFields[field], proposedFields[field])
+		}
+	}
+
+	data.Added, diags = types.ListValueFrom(ctx, types.StringType, diff.Added)
+	resp.Diagnostics.Append(diags...)
+	data.Removed, diags = types.ListValueFrom(ctx, types.StringType, diff.Removed)
+	resp.Diagnostics.Append(diags...)
+	data.Changed, diags = types.ListValueFrom(ctx, types.StringType, diff.Changed)
+	resp.Diagnostics.Append(diags...)
+	data.Changes, diags = types.MapValueFrom(ctx, types.ObjectType{AttrTypes: fieldChangeTypes}, changes)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.HasChanges = types.BoolValue(!diff.Empty())
+	data.ID = types.StringValue(fmt.Sprintf("%x", sha256.Sum256([]byte(contents.String())))[:16])
+
+	tflog.Debug(ctx, "Context diff data source read", map[string]interface{}{
+		"added":   len(diff.Added),
+		"removed": len(diff.Removed),
+		"changed": len(diff.Changed),
+	})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
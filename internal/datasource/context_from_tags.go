@@ -0,0 +1,146 @@
+package datasource
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/kbrockhoff/terraform-provider-context/internal/core"
+	"github.com/kbrockhoff/terraform-provider-context/internal/metrics"
+	"github.com/kbrockhoff/terraform-provider-context/internal/tracing"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ContextFromTagsDataSource{}
+
+func NewContextFromTagsDataSource() datasource.DataSource {
+	return &ContextFromTagsDataSource{}
+}
+
+// ContextFromTagsDataSource reverse-maps an existing tag map into a context.
+type ContextFromTagsDataSource struct {
+	providerConfig *ProviderConfig
+}
+
+// ContextFromTagsDataSourceModel describes the data source data model.
+type ContextFromTagsDataSourceModel struct {
+	Tags      types.Map    `tfsdk:"tags"`
+	TagPrefix types.String `tfsdk:"tag_prefix"`
+
+	// Computed Outputs
+	ID            types.String `tfsdk:"id"`
+	ContextOutput types.Object `tfsdk:"context_output"`
+}
+
+func (d *ContextFromTagsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_context_from_tags"
+}
+
+func (d *ContextFromTagsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Builds a context from the tags of an existing resource, such as one tagged by earlier tooling, so it can be used as parent_context for brockhoff_context. Tags without the tag prefix and not applicable values are ignored; unrecognized prefixed tags become additional_tags.",
+
+		Attributes: map[string]schema.Attribute{
+			"tags": schema.MapAttribute{
+				Description: "Tags of an existing resource",
+				Required:    true,
+				ElementType: types.StringType,
+			},
+			"tag_prefix": schema.StringAttribute{
+				Description: "Prefix of the context tags (default: the provider tag_prefix)",
+				Optional:    true,
+			},
+
+			// Computed Outputs
+			"id": schema.StringAttribute{
+				Description: "Unique identifier for this data source instance",
+				Computed:    true,
+			},
+			"context_output": schema.SingleNestedAttribute{
+				Description: "Context values recovered from the tags that can be used as parent_context for brockhoff_context",
+				Computed:    true,
+				Attributes:  getContextAttributes(),
+			},
+		},
+	}
+}
+
+func (d *ContextFromTagsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider is not configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerConfig, ok := req.ProviderData.(*ProviderConfig)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ProviderConfig, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.providerConfig = providerConfig
+}
+
+func (d *ContextFromTagsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := tracing.StartSpan(ctx, "ContextFromTagsDataSource.Read")
+	start := time.Now()
+	defer func() {
+		tracing.EndSpan(span, resp.Diagnostics)
+		metrics.RecordRead("brockhoff_context_from_tags", time.Since(start), resp.Diagnostics)
+	}()
+
+	if deferUnknownConfig(ctx, req, resp) {
+		return
+	}
+
+	var data ContextFromTagsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tags := map[string]string{}
+	resp.Diagnostics.Append(data.Tags.ElementsAs(ctx, &tags, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tagPrefix := d.providerConfig.TagPrefix
+	if !data.TagPrefix.IsNull() {
+		tagPrefix = data.TagPrefix.ValueString()
+	}
+
+	cloudProvider := d.providerConfig.CloudProvider
+	if cloudProvider == "" {
+		cloudProvider = "dc"
+	}
+
+	config := core.ConfigFromTags(tags, tagPrefix, core.GetCloudProvider(cloudProvider))
+
+	// The name delimiter cannot be recovered from tags
+	contextOutputObj, diags := contextOutputValue(ctx, config, types.StringNull())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(fmt.Sprintf("%x", sha256.Sum256([]byte(contextOutputObj.String())))[:16])
+	data.ContextOutput = contextOutputObj
+
+	tflog.Debug(ctx, "Context from tags data source read", map[string]interface{}{
+		"tags_count": len(tags),
+	})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
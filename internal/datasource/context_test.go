@@ -0,0 +1,471 @@
+package datasource
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/kbrockhoff/terraform-provider-context/internal/core"
+	pkgcontext "github.com/kbrockhoff/terraform-provider-context/pkg/context"
+	"github.com/kbrockhoff/terraform-provider-context/pkg/integrations"
+	"github.com/kbrockhoff/terraform-provider-context/pkg/integrations/azure"
+)
+
+// readContext runs Read of brockhoff_context with the attributes set in the
+// configuration and the others null, and returns the state
+func readContext(t *testing.T, providerConfig *ProviderConfig, attributes map[string]tftypes.Value) (ContextDataSourceModel, tftypes.Value, diag.Diagnostics) {
+	t.Helper()
+	ctx := context.Background()
+	d := &ContextDataSource{providerConfig: providerConfig}
+
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	values := map[string]tftypes.Value{}
+	for name, attrType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attrType, nil)
+	}
+	for name, value := range attributes {
+		if _, ok := values[name]; !ok {
+			t.Fatalf("unknown attribute %s", name)
+		}
+		values[name] = value
+	}
+	raw := tftypes.NewValue(objectType, values)
+
+	req := datasource.ReadRequest{Config: tfsdk.Config{Raw: raw, Schema: schemaResp.Schema}}
+	resp := &datasource.ReadResponse{State: tfsdk.State{Raw: raw, Schema: schemaResp.Schema}}
+	d.Read(ctx, req, resp)
+
+	var data ContextDataSourceModel
+	if !resp.Diagnostics.HasError() {
+		resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+	}
+	return data, resp.State.Raw, resp.Diagnostics
+}
+
+// contextOutput returns the context_output of a state, for parent_context
+func contextOutput(t *testing.T, state tftypes.Value) tftypes.Value {
+	t.Helper()
+	var attributes map[string]tftypes.Value
+	if err := state.As(&attributes); err != nil {
+		t.Fatal(err)
+	}
+	return attributes["context_output"]
+}
+
+func tfString(s string) tftypes.Value { return tftypes.NewValue(tftypes.String, s) }
+
+func tfBool(b bool) tftypes.Value { return tftypes.NewValue(tftypes.Bool, b) }
+
+// TestContextDataSource_mergeMatchesCore checks that parent_context resolves
+// toggles like Merge of
+// pkg/context
+func TestContextDataSource_mergeMatchesCore(t *testing.T) {
+	providerConfig := &ProviderConfig{TagPrefix: "bc-"}
+	parentToggles := map[string]bool{"owner_tags_enabled": false, "tooling_tags_enabled": true, "digest_tag_enabled": true}
+	childToggles := map[string]bool{"owner_tags_enabled": true, "tooling_tags_enabled": false}
+
+	parentAttributes := map[string]tftypes.Value{"namespace": tfString("myorg"), "environment": tfString("dev")}
+	for name, value := range parentToggles {
+		parentAttributes[name] = tfBool(value)
+	}
+	_, parentState, diags := readContext(t, providerConfig, parentAttributes)
+	if diags.HasError() {
+		t.Fatalf("parent Read() diagnostics = %v", diags)
+	}
+
+	childAttributes := map[string]tftypes.Value{"name": tfString("api"), "parent_context": contextOutput(t, parentState)}
+	for name, value := range childToggles {
+		childAttributes[name] = tfBool(value)
+	}
+	child, _, diags := readContext(t, providerConfig, childAttributes)
+	if diags.HasError() {
+		t.Fatalf("child Read() diagnostics = %v", diags)
+	}
+
+	// The same documents through Merge
+	var parentConfig, childConfig core.DataSourceConfig
+	for document, config := range map[string]*core.DataSourceConfig{
+		mustJSON(t, map[string]any{"namespace": "myorg", "environment": "dev", "owner_tags_enabled": false, "tooling_tags_enabled": true, "digest_tag_enabled": true}): &parentConfig,
+		mustJSON(t, map[string]any{"name": "api", "owner_tags_enabled": true, "tooling_tags_enabled": false}):                                                          &childConfig,
+	} {
+		if err := json.Unmarshal([]byte(document), config); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var merged map[string]any
+	if err := json.Unmarshal([]byte(mustJSON(t, pkgcontext.Merge(&parentConfig, &childConfig))), &merged); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, value := range child.ContextOutput.Attributes() {
+		b, ok := value.(types.Bool)
+		if !ok {
+			continue
+		}
+		if want, ok := merged[name].(bool); ok && b.ValueBool() != want {
+			t.Errorf("context_output.%s = %v, Merge gives %v", name, b.ValueBool(), want)
+		}
+	}
+}
+
+func mustJSON(t *testing.T, v any) string {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestContextDataSource_providerDefaultTags(t *testing.T) {
+	mapValue := func(values map[string]string) tftypes.Value {
+		elements := map[string]tftypes.Value{}
+		for key, value := range values {
+			elements[key] = tfString(value)
+		}
+		return tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, elements)
+	}
+	data, _, diags := readContext(t, &ProviderConfig{TagPrefix: "bc-", CloudProvider: "aws"}, map[string]tftypes.Value{
+		"name":                     tfString("app"),
+		"cost_center":              tfString("cc-100"),
+		"region":                   tfString("us-east-1"),
+		"deletion_date":            tfString("2026-12-31"),
+		"source_repo_tags_enabled": tfBool(false),
+		"additional_tags":          mapValue(map[string]string{"costcenter": "cc-200", "region": "us-west-2"}),
+	})
+	if diags.HasError() {
+		t.Fatalf("Read() diagnostics = %v", diags)
+	}
+
+	var inheritable, defaults map[string]string
+	data.InheritableTags.ElementsAs(context.Background(), &inheritable, false)
+	data.ProviderDefaultTags.ElementsAs(context.Background(), &defaults, false)
+	for _, key := range []string{"bc-region", "bc-deletiondate"} {
+		if _, ok := inheritable[key]; !ok {
+			t.Errorf("inheritable_tags is missing %s", key)
+		}
+		if _, ok := defaults[key]; ok {
+			t.Errorf("provider_default_tags contains %s", key)
+		}
+	}
+	if defaults["bc-costcenter"] != "cc-200" {
+		t.Errorf("provider_default_tags[bc-costcenter] = %q, want cc-200", defaults["bc-costcenter"])
+	}
+
+	// Only the additional_tags key in provider_default_tags conflicts
+	var conflicts []string
+	for _, warning := range diags.Warnings() {
+		if warning.Summary() == "Tag conflicts with provider default_tags" {
+			conflicts = append(conflicts, warning.Detail())
+		}
+	}
+	if len(conflicts) != 1 || !strings.Contains(conflicts[0], "bc-costcenter") {
+		t.Errorf("default_tags conflict warnings = %v, want one for bc-costcenter", conflicts)
+	}
+}
+
+// TestContextDataSource_reservedTagKeys checks that only the emitted keys,
+// with the tag prefix, are checked for reserved prefixes
+// TestContextDataSource_strictModeUnsetAvailability checks that an unset
+// availability defaults to a level the availability matrix allows
+func TestContextDataSource_strictModeUnsetAvailability(t *testing.T) {
+	providerConfig := &ProviderConfig{TagPrefix: "bc-", AvailabilityMatrix: core.AvailabilityMatrix(nil)}
+	tests := []struct {
+		environmentType string
+		availability    string
+		want            string
+		wantErr         bool
+	}{
+		{environmentType: "Production", want: "standard"},
+		{environmentType: "MissionCritical", want: "dedicated"},
+		{environmentType: "Development", want: "preemptable"},
+		{environmentType: "MissionCritical", availability: "isolated", want: "isolated"},
+		{environmentType: "Production", availability: "spot", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.environmentType+" "+tt.availability, func(t *testing.T) {
+			attributes := map[string]tftypes.Value{
+				"name":                     tfString("app"),
+				"environment_type":         tfString(tt.environmentType),
+				"source_repo_tags_enabled": tfBool(false),
+			}
+			if tt.availability != "" {
+				attributes["availability"] = tfString(tt.availability)
+			}
+			data, _, diags := readContext(t, providerConfig, attributes)
+			if tt.wantErr {
+				if !diags.HasError() {
+					t.Fatal("Read() expected an availability error")
+				}
+				return
+			}
+			if diags.HasError() {
+				t.Fatalf("Read() diagnostics = %v", diags)
+			}
+			if got := data.Tags.Elements()["bc-availability"]; got != types.StringValue(tt.want) {
+				t.Errorf("bc-availability = %v, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestContextDataSource_contextOutputDefaults checks that the defaults of a
+// parent are not passed to children as if they were set
+func TestContextDataSource_contextOutputDefaults(t *testing.T) {
+	providerConfig := &ProviderConfig{
+		TagPrefix:          "bc-",
+		AvailabilityMatrix: core.AvailabilityMatrix(nil),
+		DefaultsByEnvironmentType: map[string]core.EnvironmentTypeDefaults{
+			"MissionCritical": {Availability: "dedicated"},
+		},
+	}
+	_, parentState, diags := readContext(t, providerConfig, map[string]tftypes.Value{
+		"namespace":                tfString("myorg"),
+		"environment_type":         tfString("Development"),
+		"source_repo_tags_enabled": tfBool(false),
+	})
+	if diags.HasError() {
+		t.Fatalf("parent Read() diagnostics = %v", diags)
+	}
+	var output map[string]tftypes.Value
+	if err := contextOutput(t, parentState).As(&output); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"availability", "sensitivity", "managed_by", "managedby", "name_delimiter"} {
+		if !output[name].IsNull() {
+			t.Errorf("parent context_output.%s = %v, want null", name, output[name])
+		}
+	}
+
+	owners := tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{tfString("owner@example.com")})
+	child, _, diags := readContext(t, providerConfig, map[string]tftypes.Value{
+		"parent_context":     contextOutput(t, parentState),
+		"name":               tfString("payments"),
+		"environment_type":   tfString("MissionCritical"),
+		"compliance_profile": tfString("hipaa"),
+		"cost_center":        tfString("cc-100"),
+		"product_owners":     owners,
+		"data_owners":        owners,
+		"security_review":    tfString("SEC-1"),
+		"privacy_review":     tfString("PRV-1"),
+		"data_retention":     tfString("P7Y"),
+	})
+	if diags.HasError() {
+		t.Fatalf("child Read() diagnostics = %v", diags)
+	}
+	if got := child.Tags.Elements()["bc-availability"]; got != types.StringValue("dedicated") {
+		t.Errorf("child bc-availability = %v, want the MissionCritical default dedicated", got)
+	}
+	if got := child.DataTags.Elements()["bc-sensitivity"]; got != types.StringValue("restricted") {
+		t.Errorf("child bc-sensitivity = %v, want the hipaa default restricted", got)
+	}
+}
+
+func TestContextDataSource_gitRoot(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found")
+	}
+	t.Setenv("GIT_CONFIG_GLOBAL", os.DevNull)
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	t.Setenv("GIT_AUTHOR_NAME", "Test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "Test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+
+	// A repository other than the one of the provider git_root, beside the
+	// source directory
+	sourceDir := t.TempDir()
+	repo := filepath.Join(sourceDir, "live")
+	for _, args := range [][]string{
+		{"init", "--quiet", repo},
+		{"-C", repo, "commit", "--quiet", "--allow-empty", "--message", "initial"},
+	} {
+		if output, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, output)
+		}
+	}
+	output, err := exec.Command("git", "-C", repo, "rev-parse", "HEAD").Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	commit := strings.TrimSpace(string(output))
+
+	providerConfig := &ProviderConfig{TagPrefix: "bc-", GitRoot: t.TempDir(), SourceDir: sourceDir}
+	parent, parentState, diags := readContext(t, providerConfig, map[string]tftypes.Value{
+		"namespace": tfString("myorg"),
+		"git_root":  tfString("live"),
+	})
+	if diags.HasError() {
+		t.Fatalf("parent Read() diagnostics = %v", diags)
+	}
+	if got := parent.Tags.Elements()["bc-sourcecommit"]; got != types.StringValue(commit) {
+		t.Errorf("parent bc-sourcecommit = %v, want %s of git_root", got, commit)
+	}
+	var contextValues map[string]tftypes.Value
+	if err := contextOutput(t, parentState).As(&contextValues); err != nil {
+		t.Fatal(err)
+	}
+	if !contextValues["git_root"].Equal(tfString("live")) {
+		t.Errorf("parent context_output.git_root = %v, want live as configured", contextValues["git_root"])
+	}
+
+	// Children inherit git_root
+	child, _, diags := readContext(t, providerConfig, map[string]tftypes.Value{
+		"parent_context": contextOutput(t, parentState),
+		"name":           tfString("api"),
+	})
+	if diags.HasError() {
+		t.Fatalf("child Read() diagnostics = %v", diags)
+	}
+	if got := child.Tags.Elements()["bc-sourcecommit"]; got != types.StringValue(commit) {
+		t.Errorf("child bc-sourcecommit = %v, want %s of the inherited git_root", got, commit)
+	}
+
+	_, _, diags = readContext(t, providerConfig, map[string]tftypes.Value{
+		"namespace": tfString("myorg"),
+		"git_root":  tfString("missing"),
+	})
+	if !diags.HasError() || diags.Errors()[0].Summary() != "Invalid git_root" {
+		t.Errorf("Read() diagnostics = %v, want Invalid git_root", diags)
+	}
+}
+
+func TestContextDataSource_reservedTagKeys(t *testing.T) {
+	additionalTags := tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{"aws:team": tfString("platform")})
+	tests := []struct {
+		name      string
+		tagPrefix string
+		want      int
+	}{
+		{name: "default tag prefix", tagPrefix: "bc-", want: 0},
+		{name: "no tag prefix", tagPrefix: "", want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, diags := readContext(t, &ProviderConfig{TagPrefix: tt.tagPrefix, CloudProvider: "aws"}, map[string]tftypes.Value{
+				"name":                     tfString("app"),
+				"source_repo_tags_enabled": tfBool(false),
+				"additional_tags":          additionalTags,
+			})
+			if diags.HasError() {
+				t.Fatalf("Read() diagnostics = %v", diags)
+			}
+			got := 0
+			for _, warning := range diags.Warnings() {
+				if warning.Summary() == "Reserved tag key" {
+					got++
+				}
+			}
+			if got != tt.want {
+				t.Errorf("reserved tag key warnings = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContextDataSource_tagKeysGCP(t *testing.T) {
+	additionalTags := tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+		"Cost Center": tfString("cc-200"),
+		"cost_center": tfString("cc-300"),
+	})
+	_, _, diags := readContext(t, &ProviderConfig{TagPrefix: "bc-", CloudProvider: "gcp"}, map[string]tftypes.Value{
+		"name":                     tfString("app"),
+		"source_repo_tags_enabled": tfBool(false),
+		"additional_tags":          additionalTags,
+	})
+	if diags.HasError() {
+		t.Fatalf("Read() diagnostics = %v", diags)
+	}
+
+	warnings := map[string][]string{}
+	for _, warning := range diags.Warnings() {
+		warnings[warning.Summary()] = append(warnings[warning.Summary()], warning.Detail())
+	}
+	if got := warnings["Colliding tag keys"]; len(got) != 1 || !strings.Contains(got[0], "bc-Cost Center (additional_tags), bc-cost_center (additional_tags)") {
+		t.Errorf("colliding tag key warnings = %v, want bc-Cost Center and bc-cost_center", got)
+	}
+	if got := warnings["Invalid tag key"]; len(got) != 1 || !strings.Contains(got[0], `"bc-Cost Center"`) {
+		t.Errorf("invalid tag key warnings = %v, want bc-Cost Center", got)
+	}
+
+	// sanitization_mode = "error" rejects them
+	_, _, diags = readContext(t, &ProviderConfig{TagPrefix: "bc-", CloudProvider: "gcp"}, map[string]tftypes.Value{
+		"name":                     tfString("app"),
+		"source_repo_tags_enabled": tfBool(false),
+		"sanitization_mode":        tfString("error"),
+		"additional_tags":          additionalTags,
+	})
+	if !diags.HasError() {
+		t.Error("Read() expected an error with sanitization_mode = error")
+	}
+}
+
+// TestContextDataSource_nameAvailabilityAzure checks that hyphenated name
+// prefixes, which cannot be storage account names, are not checked on az,
+// while name_availability_names are
+func TestContextDataSource_nameAvailabilityAzure(t *testing.T) {
+	var checked []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var input struct{ Name string }
+		_ = json.NewDecoder(r.Body).Decode(&input)
+		checked = append(checked, input.Name)
+		switch {
+		case strings.Contains(input.Name, "-"):
+			_, _ = w.Write([]byte(`{"nameAvailable":false,"reason":"AccountNameInvalid","message":"` + input.Name + ` is not a valid storage account name."}`))
+		case input.Name == "myorgappprodlogs":
+			_, _ = w.Write([]byte(`{"nameAvailable":false,"reason":"AlreadyExists","message":"The storage account named myorgappprodlogs is already taken."}`))
+		default:
+			_, _ = w.Write([]byte(`{"nameAvailable":true}`))
+		}
+	}))
+	defer server.Close()
+	newNameChecker = func(ctx context.Context, cloudProvider string) (core.NameChecker, error) {
+		return &azure.StorageAccountNameChecker{Token: "token", SubscriptionID: "sub-1", BaseURL: server.URL}, nil
+	}
+	t.Cleanup(func() { newNameChecker = integrations.NewNameChecker })
+
+	attributes := map[string]tftypes.Value{
+		"namespace":                tfString("myorg"),
+		"name":                     tfString("app"),
+		"environment":              tfString("prod"),
+		"source_repo_tags_enabled": tfBool(false),
+		"name_availability_check":  tfString("warn"),
+	}
+	_, _, diags := readContext(t, &ProviderConfig{TagPrefix: "bc-", CloudProvider: "az"}, attributes)
+	if diags.HasError() || diags.WarningsCount() > 0 || len(checked) > 0 {
+		t.Errorf("hyphenated name prefix: checked %v, diagnostics = %v, want none", checked, diags)
+	}
+
+	attributes["name_availability_names"] = tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+		tfString("myorgappprodlogs"), tfString("myorgappproddata"), tfString("myorg-app-prod"),
+	})
+	_, _, diags = readContext(t, &ProviderConfig{TagPrefix: "bc-", CloudProvider: "az"}, attributes)
+	if diags.HasError() {
+		t.Fatalf("Read() diagnostics = %v", diags)
+	}
+	warnings := map[string][]string{}
+	for _, warning := range diags.Warnings() {
+		warnings[warning.Summary()] = append(warnings[warning.Summary()], warning.Detail())
+	}
+	if got := warnings["Name not available"]; len(got) != 1 || !strings.Contains(got[0], "myorgappprodlogs") {
+		t.Errorf("name not available warnings = %v, want myorgappprodlogs", got)
+	}
+	if got := warnings["Name availability check failed"]; len(got) != 1 || !strings.Contains(got[0], "myorg-app-prod is not a valid") {
+		t.Errorf("name availability check failed warnings = %v, want myorg-app-prod", got)
+	}
+}
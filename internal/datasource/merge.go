@@ -0,0 +1,403 @@
+package datasource
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/kbrockhoff/terraform-provider-context/internal/core"
+	"github.com/kbrockhoff/terraform-provider-context/internal/metrics"
+	"github.com/kbrockhoff/terraform-provider-context/internal/tracing"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &MergeDataSource{}
+
+func NewMergeDataSource() datasource.DataSource {
+	return &MergeDataSource{}
+}
+
+// MergeDataSource combines several context objects into one.
+type MergeDataSource struct{}
+
+// MergeDataSourceModel describes the data source data model.
+type MergeDataSourceModel struct {
+	Contexts types.List `tfsdk:"contexts"`
+
+	// Computed Outputs
+	ID            types.String `tfsdk:"id"`
+	ContextOutput types.Object `tfsdk:"context_output"`
+}
+
+func (d *MergeDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_merge"
+}
+
+func (d *MergeDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Merges multiple context objects (e.g., organization, platform and team contexts) into a single context. Later entries take precedence over earlier ones; null or empty values never override. Additional tag maps are combined, matching keys case-insensitively when the merged case_insensitive_keys is true.",
+
+		Attributes: map[string]schema.Attribute{
+			"contexts": schema.ListNestedAttribute{
+				Description: "Context objects to merge, in increasing order of precedence",
+				Required:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: getContextAttributes(),
+				},
+			},
+
+			// Computed Outputs
+			"id": schema.StringAttribute{
+				Description: "Unique identifier for this data source instance",
+				Computed:    true,
+			},
+			"context_output": schema.SingleNestedAttribute{
+				Description: "Merged context values that can be used as parent_context for brockhoff_context",
+				Computed:    true,
+				Attributes:  getContextAttributes(),
+			},
+		},
+	}
+}
+
+// isUnset reports whether a value should be skipped when merging. Empty
+// strings and lists count as unset too, because hand-written contexts and
+// context_output from earlier versions use them for fields never configured.
+func isUnset(v attr.Value) bool {
+	if v.IsNull() || v.IsUnknown() {
+		return true
+	}
+	switch v := v.(type) {
+	case types.String:
+		return v.ValueString() == ""
+	case types.List:
+		return len(v.Elements()) == 0
+	case types.Float64:
+		return v.ValueFloat64() == 0
+	case types.Int64:
+		return v.ValueInt64() == 0
+	}
+	return false
+}
+
+// lastSet returns next if it is set, otherwise current
+func lastSet[T attr.Value](current, next T) T {
+	if isUnset(next) {
+		return current
+	}
+	return next
+}
+
+// mergeContextInputs merges context inputs so that set values in later
+// inputs replace earlier ones and additional tag maps are combined
+func mergeContextInputs(ctx context.Context, inputs []ContextInputModel) (ContextInputModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	merged := ContextInputModel{
+		Namespace:               types.StringNull(),
+		Tenant:                  types.StringNull(),
+		Attributes:              types.ListNull(types.StringType),
+		LabelOrder:              types.ListNull(types.StringType),
+		Environment:             types.StringNull(),
+		EnvironmentName:         types.StringNull(),
+		EnvironmentType:         types.StringNull(),
+		NameDelimiter:           types.StringNull(),
+		ListDelimiter:           types.StringNull(),
+		ReservedWords:           types.ListNull(types.StringType),
+		ReservedWordAction:      types.StringNull(),
+		StackName:               types.StringNull(),
+		Application:             types.StringNull(),
+		Service:                 types.StringNull(),
+		Tier:                    types.StringNull(),
+		BusinessUnit:            types.StringNull(),
+		Division:                types.StringNull(),
+		Portfolio:               types.StringNull(),
+		TenantID:                types.StringNull(),
+		Customer:                types.StringNull(),
+		Enabled:                 types.BoolNull(),
+		Availability:            types.StringNull(),
+		ManagedBy:               types.StringNull(),
+		DeletionDate:            types.StringNull(),
+		LifecycleAction:         types.StringNull(),
+		MaintenanceWindow:       types.StringNull(),
+		PatchGroup:              types.StringNull(),
+		Region:                  types.StringNull(),
+		PMPlatform:              types.StringNull(),
+		PMProjectCode:           types.StringNull(),
+		ITSMPlatform:            types.StringNull(),
+		ITSMSystemID:            types.StringNull(),
+		ITSMComponentID:         types.StringNull(),
+		ITSMInstanceID:          types.StringNull(),
+		CostCenter:              types.StringNull(),
+		MonthlyBudget:           types.Float64Null(),
+		BudgetCurrency:          types.StringNull(),
+		ProductOwners:           types.ListNull(types.StringType),
+		CodeOwners:              types.ListNull(types.StringType),
+		DataOwners:              types.ListNull(types.StringType),
+		Sensitivity:             types.StringNull(),
+		DataRegs:                types.ListNull(types.StringType),
+		SecurityReview:          types.StringNull(),
+		PrivacyReview:           types.StringNull(),
+		DataRetention:           types.StringNull(),
+		DataResidency:           types.ListNull(types.StringType),
+		SovereigntyRequirements: types.ListNull(types.StringType),
+		ComplianceProfile:       types.StringNull(),
+		SourceRepoTagsEnabled:   types.BoolNull(),
+		GitRoot:                 types.StringNull(),
+		SystemPrefixesEnabled:   types.BoolNull(),
+		NotApplicableEnabled:    types.BoolNull(),
+		OwnerTagsEnabled:        types.BoolNull(),
+		ToolingTagsEnabled:      types.BoolNull(),
+		RegulationTagsEnabled:   types.BoolNull(),
+		DigestTagEnabled:        types.BoolNull(),
+		ProvenanceTagsEnabled:   types.BoolNull(),
+		SanitizationMode:        types.StringNull(),
+		LengthOverflow:          types.StringNull(),
+		TagSchemaVersion:        types.Int64Null(),
+		NAValueOverride:         types.StringNull(),
+		NAFields:                types.ListNull(types.StringType),
+		TokenizeFields:          types.ListNull(types.StringType),
+		AdditionalTags:          types.MapNull(types.StringType),
+		AdditionalDataTags:      types.MapNull(types.StringType),
+		AdditionalTypedTags:     types.MapNull(typedTagType),
+		RawTags:                 types.MapNull(types.StringType),
+		LegacyTagMap:            types.MapNull(types.StringType),
+		LegacyTagsUntil:         types.StringNull(),
+		CaseInsensitiveKeys:     types.BoolNull(),
+
+		AzurePolicyInheritanceEnabled: types.BoolNull(),
+		AzurePolicyInheritedTags:      types.ListNull(types.StringType),
+
+		ManagedByAlias: types.StringNull(),
+	}
+
+	// Additional tag keys are matched case-insensitively across every input
+	// when the merged context enables it
+	for _, in := range inputs {
+		merged.CaseInsensitiveKeys = lastSet(merged.CaseInsensitiveKeys, in.CaseInsensitiveKeys)
+	}
+	caseInsensitiveKeys := merged.CaseInsensitiveKeys.ValueBool()
+
+	var additionalTags, additionalDataTags, rawTags, legacyTagMap map[string]string
+	var additionalTypedTags map[string]core.TypedTag
+
+	for _, in := range inputs {
+		merged.Namespace = lastSet(merged.Namespace, in.Namespace)
+		merged.Tenant = lastSet(merged.Tenant, in.Tenant)
+		merged.Attributes = lastSet(merged.Attributes, in.Attributes)
+		merged.LabelOrder = lastSet(merged.LabelOrder, in.LabelOrder)
+		merged.Environment = lastSet(merged.Environment, in.Environment)
+		merged.EnvironmentName = lastSet(merged.EnvironmentName, in.EnvironmentName)
+		merged.EnvironmentType = lastSet(merged.EnvironmentType, in.EnvironmentType)
+		// An empty name delimiter is meaningful, so only null values are skipped
+		if !in.NameDelimiter.IsNull() && !in.NameDelimiter.IsUnknown() {
+			merged.NameDelimiter = in.NameDelimiter
+		}
+		merged.ListDelimiter = lastSet(merged.ListDelimiter, in.ListDelimiter)
+		merged.ReservedWords = lastSet(merged.ReservedWords, in.ReservedWords)
+		merged.ReservedWordAction = lastSet(merged.ReservedWordAction, in.ReservedWordAction)
+
+		merged.StackName = lastSet(merged.StackName, in.StackName)
+
+		merged.Application = lastSet(merged.Application, in.Application)
+		merged.Service = lastSet(merged.Service, in.Service)
+		merged.Tier = lastSet(merged.Tier, in.Tier)
+
+		merged.BusinessUnit = lastSet(merged.BusinessUnit, in.BusinessUnit)
+		merged.Division = lastSet(merged.Division, in.Division)
+		merged.Portfolio = lastSet(merged.Portfolio, in.Portfolio)
+
+		merged.TenantID = lastSet(merged.TenantID, in.TenantID)
+		merged.Customer = lastSet(merged.Customer, in.Customer)
+
+		merged.Enabled = lastSet(merged.Enabled, in.Enabled)
+		merged.Availability = lastSet(merged.Availability, in.Availability)
+		merged.ManagedBy = lastSet(merged.ManagedBy, in.ManagedBy)
+		merged.DeletionDate = lastSet(merged.DeletionDate, in.DeletionDate)
+		merged.LifecycleAction = lastSet(merged.LifecycleAction, in.LifecycleAction)
+		merged.MaintenanceWindow = lastSet(merged.MaintenanceWindow, in.MaintenanceWindow)
+		merged.PatchGroup = lastSet(merged.PatchGroup, in.PatchGroup)
+		merged.Region = lastSet(merged.Region, in.Region)
+
+		merged.PMPlatform = lastSet(merged.PMPlatform, in.PMPlatform)
+		merged.PMProjectCode = lastSet(merged.PMProjectCode, in.PMProjectCode)
+
+		merged.ITSMPlatform = lastSet(merged.ITSMPlatform, in.ITSMPlatform)
+		merged.ITSMSystemID = lastSet(merged.ITSMSystemID, in.ITSMSystemID)
+		merged.ITSMComponentID = lastSet(merged.ITSMComponentID, in.ITSMComponentID)
+		merged.ITSMInstanceID = lastSet(merged.ITSMInstanceID, in.ITSMInstanceID)
+
+		merged.CostCenter = lastSet(merged.CostCenter, in.CostCenter)
+		merged.MonthlyBudget = lastSet(merged.MonthlyBudget, in.MonthlyBudget)
+		merged.BudgetCurrency = lastSet(merged.BudgetCurrency, in.BudgetCurrency)
+		merged.ProductOwners = lastSet(merged.ProductOwners, in.ProductOwners)
+		merged.CodeOwners = lastSet(merged.CodeOwners, in.CodeOwners)
+		merged.DataOwners = lastSet(merged.DataOwners, in.DataOwners)
+
+		merged.Sensitivity = lastSet(merged.Sensitivity, in.Sensitivity)
+		merged.DataRegs = lastSet(merged.DataRegs, in.DataRegs)
+		merged.SecurityReview = lastSet(merged.SecurityReview, in.SecurityReview)
+		merged.PrivacyReview = lastSet(merged.PrivacyReview, in.PrivacyReview)
+		merged.DataRetention = lastSet(merged.DataRetention, in.DataRetention)
+
+		merged.DataResidency = lastSet(merged.DataResidency, in.DataResidency)
+		merged.SovereigntyRequirements = lastSet(merged.SovereigntyRequirements, in.SovereigntyRequirements)
+
+		merged.ComplianceProfile = lastSet(merged.ComplianceProfile, in.ComplianceProfile)
+
+		merged.SourceRepoTagsEnabled = lastSet(merged.SourceRepoTagsEnabled, in.SourceRepoTagsEnabled)
+		merged.GitRoot = lastSet(merged.GitRoot, in.GitRoot)
+		merged.SystemPrefixesEnabled = lastSet(merged.SystemPrefixesEnabled, in.SystemPrefixesEnabled)
+		merged.NotApplicableEnabled = lastSet(merged.NotApplicableEnabled, in.NotApplicableEnabled)
+		merged.OwnerTagsEnabled = lastSet(merged.OwnerTagsEnabled, in.OwnerTagsEnabled)
+		merged.ToolingTagsEnabled = lastSet(merged.ToolingTagsEnabled, in.ToolingTagsEnabled)
+		merged.RegulationTagsEnabled = lastSet(merged.RegulationTagsEnabled, in.RegulationTagsEnabled)
+		merged.DigestTagEnabled = lastSet(merged.DigestTagEnabled, in.DigestTagEnabled)
+		merged.ProvenanceTagsEnabled = lastSet(merged.ProvenanceTagsEnabled, in.ProvenanceTagsEnabled)
+		merged.SanitizationMode = lastSet(merged.SanitizationMode, in.SanitizationMode)
+		merged.LengthOverflow = lastSet(merged.LengthOverflow, in.LengthOverflow)
+		merged.TagSchemaVersion = lastSet(merged.TagSchemaVersion, in.TagSchemaVersion)
+		merged.NAValueOverride = lastSet(merged.NAValueOverride, in.NAValueOverride)
+		merged.NAFields = lastSet(merged.NAFields, in.NAFields)
+		merged.TokenizeFields = lastSet(merged.TokenizeFields, in.TokenizeFields)
+		merged.AzurePolicyInheritanceEnabled = lastSet(merged.AzurePolicyInheritanceEnabled, in.AzurePolicyInheritanceEnabled)
+		merged.AzurePolicyInheritedTags = lastSet(merged.AzurePolicyInheritedTags, in.AzurePolicyInheritedTags)
+		merged.ManagedByAlias = lastSet(merged.ManagedByAlias, in.ManagedByAlias)
+		merged.LegacyTagsUntil = lastSet(merged.LegacyTagsUntil, in.LegacyTagsUntil)
+
+		if !isUnset(in.AdditionalTags) {
+			if additionalTags == nil {
+				additionalTags = map[string]string{}
+			}
+			values := map[string]string{}
+			diags.Append(in.AdditionalTags.ElementsAs(ctx, &values, false)...)
+			additionalTags = core.MergeAdditionalTags(additionalTags, values, caseInsensitiveKeys)
+		}
+		if !isUnset(in.AdditionalDataTags) {
+			if additionalDataTags == nil {
+				additionalDataTags = map[string]string{}
+			}
+			values := map[string]string{}
+			diags.Append(in.AdditionalDataTags.ElementsAs(ctx, &values, false)...)
+			additionalDataTags = core.MergeAdditionalTags(additionalDataTags, values, caseInsensitiveKeys)
+		}
+		if !isUnset(in.AdditionalTypedTags) {
+			additionalTypedTags = core.MergeAdditionalTypedTags(additionalTypedTags, TypedTagsFromValue(ctx, in.AdditionalTypedTags), caseInsensitiveKeys)
+		}
+		if !isUnset(in.RawTags) {
+			if rawTags == nil {
+				rawTags = map[string]string{}
+			}
+			values := map[string]string{}
+			diags.Append(in.RawTags.ElementsAs(ctx, &values, false)...)
+			rawTags = core.MergeAdditionalTags(rawTags, values, false)
+		}
+		if !isUnset(in.LegacyTagMap) {
+			if legacyTagMap == nil {
+				legacyTagMap = map[string]string{}
+			}
+			values := map[string]string{}
+			diags.Append(in.LegacyTagMap.ElementsAs(ctx, &values, false)...)
+			legacyTagMap = core.MergeAdditionalTags(legacyTagMap, values, false)
+		}
+	}
+
+	if additionalTags != nil {
+		mapVal, d := types.MapValueFrom(ctx, types.StringType, additionalTags)
+		diags.Append(d...)
+		merged.AdditionalTags = mapVal
+	}
+	if additionalDataTags != nil {
+		mapVal, d := types.MapValueFrom(ctx, types.StringType, additionalDataTags)
+		diags.Append(d...)
+		merged.AdditionalDataTags = mapVal
+	}
+	if additionalTypedTags != nil {
+		mapVal, d := typedTagsValue(ctx, additionalTypedTags)
+		diags.Append(d...)
+		merged.AdditionalTypedTags = mapVal
+	}
+	if rawTags != nil {
+		mapVal, d := types.MapValueFrom(ctx, types.StringType, rawTags)
+		diags.Append(d...)
+		merged.RawTags = mapVal
+	}
+	if legacyTagMap != nil {
+		mapVal, d := types.MapValueFrom(ctx, types.StringType, legacyTagMap)
+		diags.Append(d...)
+		merged.LegacyTagMap = mapVal
+	}
+
+	return merged, diags
+}
+
+func (d *MergeDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := tracing.StartSpan(ctx, "MergeDataSource.Read")
+	start := time.Now()
+	defer func() {
+		tracing.EndSpan(span, resp.Diagnostics)
+		metrics.RecordRead("brockhoff_merge", time.Since(start), resp.Diagnostics)
+	}()
+
+	if deferUnknownConfig(ctx, req, resp) {
+		return
+	}
+
+	var data MergeDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var contexts []types.Object
+	resp.Diagnostics.Append(data.Contexts.ElementsAs(ctx, &contexts, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	inputs := make([]ContextInputModel, len(contexts))
+	for i, obj := range contexts {
+		resolved, diags := resolveRenamedAttributes(ctx, obj)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resp.Diagnostics.Append(resolved.As(ctx, &inputs[i], basetypes.ObjectAsOptions{})...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	merged, diags := mergeContextInputs(ctx, inputs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	contextOutputObj, diags := types.ObjectValueFrom(ctx, ContextAttributeTypes(), merged)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(fmt.Sprintf("%x", sha256.Sum256([]byte(contextOutputObj.String())))[:16])
+	data.ContextOutput = contextOutputObj
+
+	tflog.Debug(ctx, "Merge data source read", map[string]interface{}{
+		"contexts_count": len(inputs),
+	})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
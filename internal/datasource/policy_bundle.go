@@ -0,0 +1,141 @@
+package datasource
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/kbrockhoff/terraform-provider-context/internal/core"
+	"github.com/kbrockhoff/terraform-provider-context/internal/metrics"
+	"github.com/kbrockhoff/terraform-provider-context/internal/tracing"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &PolicyBundleDataSource{}
+
+func NewPolicyBundleDataSource() datasource.DataSource {
+	return &PolicyBundleDataSource{}
+}
+
+// PolicyBundleDataSource exports the tagging standard as policy as code.
+type PolicyBundleDataSource struct {
+	providerConfig *ProviderConfig
+}
+
+// PolicyBundleDataSourceModel describes the data source data model.
+type PolicyBundleDataSourceModel struct {
+	Format types.String `tfsdk:"format"`
+
+	// Computed Outputs
+	ID    types.String `tfsdk:"id"`
+	Files types.Map    `tfsdk:"files"`
+}
+
+func (d *PolicyBundleDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_policy_bundle"
+}
+
+func (d *PolicyBundleDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Generates a policy encoding the tagging standard of the provider configuration (tag prefix, required tags and allowed values of enumerated tags), so policy-as-code repositories stay in sync with the provider. The policy checks the resources created or updated by a plan.",
+
+		Attributes: map[string]schema.Attribute{
+			"format": schema.StringAttribute{
+				Description: "Policy format: opa (an OPA bundle for conftest or opa eval against terraform show -json output) or sentinel (a policy set for the tfplan/v2 import)",
+				Required:    true,
+			},
+
+			// Computed Outputs
+			"id": schema.StringAttribute{
+				Description: "Unique identifier for this data source instance",
+				Computed:    true,
+			},
+			"files": schema.MapAttribute{
+				Description: "Contents of the policy files keyed by relative path, for writing with the local_file resource",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func (d *PolicyBundleDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider is not configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerConfig, ok := req.ProviderData.(*ProviderConfig)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ProviderConfig, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.providerConfig = providerConfig
+}
+
+func (d *PolicyBundleDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := tracing.StartSpan(ctx, "PolicyBundleDataSource.Read")
+	start := time.Now()
+	defer func() {
+		tracing.EndSpan(span, resp.Diagnostics)
+		metrics.RecordRead("brockhoff_policy_bundle", time.Since(start), resp.Diagnostics)
+	}()
+
+	if deferUnknownConfig(ctx, req, resp) {
+		return
+	}
+
+	var data PolicyBundleDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cloudProvider := d.providerConfig.CloudProvider
+	if cloudProvider == "" {
+		cloudProvider = "dc"
+	}
+
+	standard := core.NewTaggingStandard(d.providerConfig.TagPrefix, core.GetCloudProvider(cloudProvider))
+	files, err := standard.PolicyBundle(data.Format.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid format", err.Error())
+		return
+	}
+
+	filesValue, diags := types.MapValueFrom(ctx, types.StringType, files)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var contents strings.Builder
+	for _, path := range slices.Sorted(maps.Keys(files)) {
+		contents.WriteString(path + "\n" + files[path])
+	}
+	data.ID = types.StringValue(fmt.Sprintf("%x", sha256.Sum256([]byte(contents.String())))[:16])
+	data.Files = filesValue
+
+	tflog.Debug(ctx, "Policy bundle data source read", map[string]interface{}{
+		"format": data.Format.ValueString(),
+		"files":  len(files),
+	})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
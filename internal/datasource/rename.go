@@ -0,0 +1,120 @@
+package datasource
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// renamedAttribute records an attribute renamed within a major release. The
+// old name stays in the schemas as an alias until the next major release,
+// following the Compatibility section of the README.
+type renamedAttribute struct {
+	Old string
+	New string
+}
+
+// renamedAttributes are the aliases accepted by the data source and context
+// object schemas. Each needs a model field for the old name, resolved in Read
+// with resolveRenamed and set alongside the new name in contextOutputValue.
+var renamedAttributes = []renamedAttribute{renamedManagedBy}
+
+var renamedManagedBy = renamedAttribute{Old: "managedby", New: "managed_by"}
+
+// DeprecationMessage returns the message Terraform reports where the old
+// attribute is configured
+func (r renamedAttribute) DeprecationMessage() string {
+	return fmt.Sprintf("Use %s instead. %s will be removed in the next major release.", r.New, r.Old)
+}
+
+// addRenamedAliases adds the old name of each renamed attribute as a copy of
+// the new one. Data source attributes are deprecated; context object
+// attributes are not, since context_output fills both names and is passed
+// on as parent_context.
+func addRenamedAliases(attributes map[string]schema.Attribute, deprecate bool) {
+	for _, r := range renamedAttributes {
+		description := "Deprecated alias of " + r.New
+		message := ""
+		if deprecate {
+			message = r.DeprecationMessage()
+		}
+
+		switch a := attributes[r.New].(type) {
+		case schema.StringAttribute:
+			a.Description, a.DeprecationMessage = description, message
+			attributes[r.Old] = a
+		case schema.BoolAttribute:
+			a.Description, a.DeprecationMessage = description, message
+			attributes[r.Old] = a
+		case schema.Float64Attribute:
+			a.Description, a.DeprecationMessage = description, message
+			attributes[r.Old] = a
+		case schema.Int64Attribute:
+			a.Description, a.DeprecationMessage = description, message
+			attributes[r.Old] = a
+		case schema.ListAttribute:
+			a.Description, a.DeprecationMessage = description, message
+			attributes[r.Old] = a
+		case schema.MapAttribute:
+			a.Description, a.DeprecationMessage = description, message
+			attributes[r.Old] = a
+		}
+	}
+}
+
+// addRenamedAliasTypes adds the old name of each renamed attribute to the
+// attribute types of the context object
+func addRenamedAliasTypes(attributeTypes map[string]attr.Type) {
+	for _, r := range renamedAttributes {
+		attributeTypes[r.Old] = attributeTypes[r.New]
+	}
+}
+
+// resolveRenamed returns value, or alias when only the old name is set.
+// Setting both to different values is reported as an error.
+func resolveRenamed[T attr.Value](r renamedAttribute, value, alias T, diags *diag.Diagnostics) T {
+	if alias.IsNull() {
+		return value
+	}
+	if value.IsNull() {
+		return alias
+	}
+	if !value.Equal(alias) {
+		diags.AddError(
+			"Conflicting "+r.New,
+			fmt.Sprintf("%s and its deprecated alias %s are set to different values; remove %s", r.New, r.Old, r.Old),
+		)
+	}
+	return value
+}
+
+// resolveRenamedAttributes sets both names of each renamed attribute in a
+// context object, such as a parent_context written for an older release
+// that only has the old name
+func resolveRenamedAttributes(ctx context.Context, obj types.Object) (types.Object, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if obj.IsNull() || obj.IsUnknown() {
+		return obj, diags
+	}
+
+	attributes := obj.Attributes()
+	for _, r := range renamedAttributes {
+		value, alias := attributes[r.New], attributes[r.Old]
+		if value == nil || alias == nil {
+			continue
+		}
+		attributes[r.New] = resolveRenamed(r, value, alias, &diags)
+		attributes[r.Old] = attributes[r.New]
+	}
+	if diags.HasError() {
+		return obj, diags
+	}
+
+	resolved, d := types.ObjectValue(obj.AttributeTypes(ctx), attributes)
+	diags.Append(d...)
+	return resolved, diags
+}
@@ -0,0 +1,135 @@
+package datasource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestResolveRenamed(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   types.String
+		alias   types.String
+		want    types.String
+		wantErr bool
+	}{
+		{name: "null alias", value: types.StringValue("opentofu"), alias: types.StringNull(), want: types.StringValue("opentofu")},
+		{name: "null value", value: types.StringNull(), alias: types.StringValue("opentofu"), want: types.StringValue("opentofu")},
+		{name: "both null", value: types.StringNull(), alias: types.StringNull(), want: types.StringNull()},
+		{name: "equal values", value: types.StringValue("opentofu"), alias: types.StringValue("opentofu"), want: types.StringValue("opentofu")},
+		{name: "conflicting values", value: types.StringValue("opentofu"), alias: types.StringValue("pulumi"), want: types.StringValue("opentofu"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			got := resolveRenamed(renamedManagedBy, tt.value, tt.alias, &diags)
+			if diags.HasError() != tt.wantErr {
+				t.Errorf("resolveRenamed() diagnostics = %v, wantErr %v", diags, tt.wantErr)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("resolveRenamed() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveRenamedAttributes(t *testing.T) {
+	ctx := context.Background()
+	attributeTypes := map[string]attr.Type{"name": types.StringType, "managed_by": types.StringType, "managedby": types.StringType}
+	object := func(managedBy, alias types.String) types.Object {
+		return types.ObjectValueMust(attributeTypes, map[string]attr.Value{
+			"name":       types.StringValue("app"),
+			"managed_by": managedBy,
+			"managedby":  alias,
+		})
+	}
+
+	tests := []struct {
+		name    string
+		obj     types.Object
+		want    types.Object
+		wantErr bool
+	}{
+		{name: "null object", obj: types.ObjectNull(attributeTypes), want: types.ObjectNull(attributeTypes)},
+		{name: "unknown object", obj: types.ObjectUnknown(attributeTypes), want: types.ObjectUnknown(attributeTypes)},
+		{
+			name: "old name only",
+			obj:  object(types.StringNull(), types.StringValue("pulumi")),
+			want: object(types.StringValue("pulumi"), types.StringValue("pulumi")),
+		},
+		{
+			name: "new name only",
+			obj:  object(types.StringValue("pulumi"), types.StringNull()),
+			want: object(types.StringValue("pulumi"), types.StringValue("pulumi")),
+		},
+		{
+			name: "equal values",
+			obj:  object(types.StringValue("pulumi"), types.StringValue("pulumi")),
+			want: object(types.StringValue("pulumi"), types.StringValue("pulumi")),
+		},
+		{
+			name:    "conflicting values",
+			obj:     object(types.StringValue("opentofu"), types.StringValue("pulumi")),
+			want:    object(types.StringValue("opentofu"), types.StringValue("pulumi")),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, diags := resolveRenamedAttributes(ctx, tt.obj)
+			if diags.HasError() != tt.wantErr {
+				t.Errorf("resolveRenamedAttributes() diagnostics = %v, wantErr %v", diags, tt.wantErr)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("resolveRenamedAttributes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestContextDataSource_parentContextOldName reads a parent_context written
+// for an older release, carrying managedby without managed_by
+func TestContextDataSource_parentContextOldName(t *testing.T) {
+	providerConfig := &ProviderConfig{TagPrefix: "bc-"}
+	_, parentState, diags := readContext(t, providerConfig, map[string]tftypes.Value{
+		"namespace": tfString("myorg"),
+		"managedby": tfString("pulumi"),
+	})
+	if diags.HasError() {
+		t.Fatalf("parent Read() diagnostics = %v", diags)
+	}
+
+	parentOutput := contextOutput(t, parentState)
+	var attributes map[string]tftypes.Value
+	if err := parentOutput.As(&attributes); err != nil {
+		t.Fatal(err)
+	}
+	attributes["managed_by"] = tftypes.NewValue(tftypes.String, nil)
+	parentContext := tftypes.NewValue(parentOutput.Type(), attributes)
+
+	child, _, diags := readContext(t, providerConfig, map[string]tftypes.Value{
+		"name":           tfString("api"),
+		"parent_context": parentContext,
+	})
+	if diags.HasError() {
+		t.Fatalf("child Read() diagnostics = %v", diags)
+	}
+
+	for _, name := range []string{"managed_by", "managedby"} {
+		if got := child.ContextOutput.Attributes()[name]; !got.Equal(types.StringValue("pulumi")) {
+			t.Errorf("context_output.%s = %v, want pulumi", name, got)
+		}
+	}
+	var tags map[string]string
+	child.Tags.ElementsAs(context.Background(), &tags, false)
+	if tags["bc-managedby"] != "pulumi" {
+		t.Errorf("tags[bc-managedby] = %q, want pulumi", tags["bc-managedby"])
+	}
+}
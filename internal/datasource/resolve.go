@@ -0,0 +1,394 @@
+package datasource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/kbrockhoff/terraform-provider-context/internal/core"
+	"github.com/kbrockhoff/terraform-provider-context/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// ResolvedContext is a context resolved by ResolveContext
+type ResolvedContext struct {
+	// Config is the context with the defaults applied and the owners
+	// normalized
+	Config *core.DataSourceConfig
+
+	NameOptions core.NameOptions
+	NamePrefix  string
+
+	// CloudProvider is the provider cloud_provider, dc when unset
+	CloudProvider string
+
+	// TagProcessor generated Tags and DataTags; its Warnings list the
+	// sanitized values
+	TagProcessor *core.TagProcessor
+	Tags         map[string]string
+	DataTags     map[string]string
+
+	// OwnerGroups holds the owners found in the group directory
+	OwnerGroups map[string]core.Group
+
+	newTagProcessor func(config *core.DataSourceConfig, namePrefix string) *core.TagProcessor
+}
+
+// NewTagProcessor returns a tag processor with the settings of the context
+// for config and namePrefix, such as for a names entry
+func (r *ResolvedContext) NewTagProcessor(config *core.DataSourceConfig, namePrefix string) *core.TagProcessor {
+	return r.newTagProcessor(config, namePrefix)
+}
+
+// ApplyContextDefaults applies the brockhoff_context defaults to the
+// attributes of config that are still empty after merging
+func ApplyContextDefaults(providerConfig *ProviderConfig, config *core.DataSourceConfig) {
+	// The compliance profile defaults take precedence over the environment
+	// type defaults, which take precedence over the generic defaults below
+	core.ApplyComplianceProfile(config)
+	core.ApplyEnvironmentTypeDefaults(config, providerConfig.DefaultsByEnvironmentType)
+
+	if config.Availability == "" {
+		config.Availability = "preemptable"
+		// Under strict_mode, an unset availability defaults to the first
+		// level the matrix allows for the environment type, so the check
+		// never rejects a value the provider chose
+		if levels := providerConfig.AvailabilityMatrix[config.EnvironmentType]; len(levels) > 0 && !slices.Contains(levels, config.Availability) {
+			config.Availability = levels[0]
+		}
+	}
+	if config.ManagedBy == "" {
+		config.ManagedBy = core.ManagedByTerraform
+		if providerConfig.DetectManagedBy {
+			config.ManagedBy = core.DetectManagedBy()
+		}
+	}
+	if config.Sensitivity == "" {
+		config.Sensitivity = "confidential"
+	}
+}
+
+// ContextCheck checks one attribute of a context
+type ContextCheck struct {
+	Field string
+	// Summary defaults to "Invalid <Field>"
+	Summary string
+	Check   func(config *core.DataSourceConfig) error
+}
+
+// ErrorSummary returns the summary of the error of the check
+func (c ContextCheck) ErrorSummary() string {
+	if c.Summary != "" {
+		return c.Summary
+	}
+	return "Invalid " + c.Field
+}
+
+// ContextChecks returns the attribute checks of ResolveContext with the
+// settings of providerConfig, in the order it runs them, for a context with
+// the defaults of ApplyContextDefaults
+func ContextChecks(providerConfig *ProviderConfig) []ContextCheck {
+	validateEmails := core.ValidateEmails
+	if providerConfig.StrictEmailValidation {
+		validateEmails = core.ValidateEmailsStrict
+	}
+	constraints := providerConfig.NamingConstraints
+
+	return []ContextCheck{
+		{Field: "namespace", Check: func(c *core.DataSourceConfig) error { return constraints.ValidateNamespace(c.Namespace) }},
+		{Field: "namespace", Check: func(c *core.DataSourceConfig) error {
+			return core.ValidateAllowedNamespace(c.Namespace, providerConfig.AllowedNamespaces, providerConfig.NamespaceRegistryURL)
+		}},
+		{Field: "environment", Check: func(c *core.DataSourceConfig) error { return constraints.ValidateEnvironment(c.Environment) }},
+		{Field: "tenant", Check: func(c *core.DataSourceConfig) error { return core.ValidateTenant(c.Tenant) }},
+		{Field: "attributes", Check: func(c *core.DataSourceConfig) error { return core.ValidateAttributes(c.Attributes) }},
+		{Field: "tier", Check: func(c *core.DataSourceConfig) error { return core.ValidateTier(c.Tier) }},
+		{Field: "tenant_id", Check: func(c *core.DataSourceConfig) error { return core.ValidateTenantID(c.TenantID) }},
+		{Field: "customer", Check: func(c *core.DataSourceConfig) error { return core.ValidateCustomer(c.Customer) }},
+		{Field: "business_unit", Check: func(c *core.DataSourceConfig) error { return core.ValidateBusinessUnit(c.BusinessUnit) }},
+		{Field: "division", Check: func(c *core.DataSourceConfig) error { return core.ValidateDivision(c.Division) }},
+		{Field: "portfolio", Check: func(c *core.DataSourceConfig) error { return core.ValidatePortfolio(c.Portfolio) }},
+		{Field: "label_order", Check: func(c *core.DataSourceConfig) error { return core.ValidateLabelOrder(c.LabelOrder) }},
+		{Field: "environment_type", Check: func(c *core.DataSourceConfig) error { return core.ValidateEnvironmentType(c.EnvironmentType) }},
+		{Field: "name_delimiter", Check: func(c *core.DataSourceConfig) error {
+			if c.NameDelimiter == nil {
+				return nil
+			}
+			return core.ValidateNameDelimiter(*c.NameDelimiter)
+		}},
+		{Field: "reserved_word_action", Check: func(c *core.DataSourceConfig) error { return core.ValidateReservedWordAction(c.ReservedWordAction) }},
+		{Field: "sanitization_mode", Check: func(c *core.DataSourceConfig) error { return core.ValidateSanitizationMode(c.SanitizationMode) }},
+		{Field: "length_overflow", Check: func(c *core.DataSourceConfig) error { return core.ValidateLengthOverflow(c.LengthOverflow) }},
+		{Field: "tag_schema_version", Check: func(c *core.DataSourceConfig) error { return core.ValidateTagSchemaVersion(c.TagSchemaVersion) }},
+		{Field: "additional_typed_tags", Check: func(c *core.DataSourceConfig) error { return core.ValidateAdditionalTypedTags(c.AdditionalTypedTags) }},
+		{Field: "legacy_tag_map", Check: func(c *core.DataSourceConfig) error { return core.ValidateLegacyTagMap(c.LegacyTagMap) }},
+		{Field: "legacy_tags_until", Check: func(c *core.DataSourceConfig) error { return core.ValidateLegacyTagsUntil(c.LegacyTagsUntil) }},
+		{Field: "na_fields", Check: func(c *core.DataSourceConfig) error { return core.ValidateNAFields(c.NAFields) }},
+		{Field: "tokenize_fields", Check: func(c *core.DataSourceConfig) error { return core.ValidateTokenizeFields(c.TokenizeFields) }},
+		{Field: "tokenize_fields", Summary: "Missing tokenization key", Check: func(c *core.DataSourceConfig) error {
+			if len(c.TokenizeFields) > 0 && len(providerConfig.TokenizationKey) == 0 {
+				return fmt.Errorf("tokenize_fields requires the %s environment variable", core.TokenizationKeyEnvVar)
+			}
+			return nil
+		}},
+		{Field: "availability", Check: func(c *core.DataSourceConfig) error { return core.ValidateAvailability(c.Availability) }},
+		{Field: "availability", Check: func(c *core.DataSourceConfig) error {
+			if providerConfig.AvailabilityMatrix == nil {
+				return nil
+			}
+			return core.ValidateEnvironmentAvailability(c.EnvironmentType, c.Availability, providerConfig.AvailabilityMatrix)
+		}},
+		{Field: "sensitivity", Check: func(c *core.DataSourceConfig) error { return core.ValidateSensitivity(c.Sensitivity) }},
+		{Field: "data_retention", Check: func(c *core.DataSourceConfig) error { return core.ValidateDataRetention(c.DataRetention) }},
+		{Field: "data_residency", Check: func(c *core.DataSourceConfig) error { return core.ValidateDataResidency(c.DataResidency) }},
+		{Field: "region", Check: func(c *core.DataSourceConfig) error { return core.ValidateRegion(c.Region) }},
+		{Field: "region", Summary: "Region outside data_residency", Check: func(c *core.DataSourceConfig) error {
+			return core.ValidateRegionResidency(c.Region, c.DataResidency)
+		}},
+		{Field: "compliance_profile", Check: func(c *core.DataSourceConfig) error { return core.ValidateComplianceProfile(c.ComplianceProfile) }},
+		{Field: "deletion_date", Check: func(c *core.DataSourceConfig) error { return core.ValidateDeletionDate(c.DeletionDate) }},
+		{Field: "decommission_approved", Summary: "Decommission not approved", Check: func(c *core.DataSourceConfig) error {
+			return core.ValidateDecommission(c.EnvironmentType, c.DeletionDate, c.DecommissionApproved)
+		}},
+		{Field: "lifecycle_action", Check: func(c *core.DataSourceConfig) error { return core.ValidateLifecycleAction(c.LifecycleAction) }},
+		{Field: "lifecycle_action", Summary: "Missing lifecycle_action", Check: func(c *core.DataSourceConfig) error {
+			if c.EnvironmentType == "Ephemeral" && c.LifecycleAction == "" {
+				return errors.New("lifecycle_action is required when environment_type is Ephemeral")
+			}
+			return nil
+		}},
+		{Field: "git_root", Check: func(c *core.DataSourceConfig) error {
+			if c.GitRoot == "" {
+				return nil
+			}
+			info, err := os.Stat(providerConfig.GitRootFor(c.GitRoot))
+			if err != nil {
+				return err
+			}
+			if !info.IsDir() {
+				return fmt.Errorf("%s is not a directory", c.GitRoot)
+			}
+			return nil
+		}},
+		{Field: "maintenance_window", Check: func(c *core.DataSourceConfig) error { return core.ValidateMaintenanceWindow(c.MaintenanceWindow) }},
+		{Field: "pr_number", Check: func(c *core.DataSourceConfig) error { return core.ValidatePRNumber(c.PRNumber) }},
+		{Field: "ephemeral_suffix", Check: func(c *core.DataSourceConfig) error { return core.ValidateEphemeralSuffix(c.EphemeralSuffix) }},
+		{Field: "monthly_budget", Check: func(c *core.DataSourceConfig) error { return core.ValidateMonthlyBudget(c.MonthlyBudget) }},
+		{Field: "budget_currency", Check: func(c *core.DataSourceConfig) error { return core.ValidateBudgetCurrency(c.BudgetCurrency) }},
+		// Owners are checked as ResolveContext normalizes them, so that
+		// casing, whitespace and duplicates do not fail the check
+		{Field: "product_owners", Check: func(c *core.DataSourceConfig) error { return validateEmails(core.NormalizeOwners(c.ProductOwners)) }},
+		{Field: "code_owners", Check: func(c *core.DataSourceConfig) error { return validateEmails(core.NormalizeOwners(c.CodeOwners)) }},
+		{Field: "data_owners", Check: func(c *core.DataSourceConfig) error { return validateEmails(core.NormalizeOwners(c.DataOwners)) }},
+	}
+}
+
+// ResolveContext applies the brockhoff_context defaults to config, checks it
+// with the settings of providerConfig and generates its name prefix and tags.
+// The data source and the context function share it, so both resolve the
+// same inputs alike. Checks stop at the first failure, except the compliance
+// profile and sensitivity checks, which report each violation.
+func ResolveContext(ctx context.Context, providerConfig *ProviderConfig, config *core.DataSourceConfig) (*ResolvedContext, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	ApplyContextDefaults(providerConfig, config)
+
+	for _, check := range ContextChecks(providerConfig) {
+		if err := check.Check(config); err != nil {
+			diags.AddError(check.ErrorSummary(), err.Error())
+			return nil, diags
+		}
+	}
+
+	// A relative git_root refers to the source directory
+	if config.GitRoot != "" {
+		config.GitRoot = providerConfig.ResolvePath(config.GitRoot)
+	}
+
+	// Normalize owner lists so that casing, whitespace and duplicates do
+	// not change the joined tag values
+	config.ProductOwners = core.NormalizeOwners(config.ProductOwners)
+	config.CodeOwners = core.NormalizeOwners(config.CodeOwners)
+	config.DataOwners = core.NormalizeOwners(config.DataOwners)
+
+	// Convert internationalized owner domains to punycode for clouds that
+	// only accept ASCII tag values
+	if providerConfig.PunycodeEmailDomains {
+		var err error
+		if config.ProductOwners, err = core.NormalizeEmails(config.ProductOwners); err != nil {
+			diags.AddError("Invalid product_owners", err.Error())
+			return nil, diags
+		}
+		if config.CodeOwners, err = core.NormalizeEmails(config.CodeOwners); err != nil {
+			diags.AddError("Invalid code_owners", err.Error())
+			return nil, diags
+		}
+		if config.DataOwners, err = core.NormalizeEmails(config.DataOwners); err != nil {
+			diags.AddError("Invalid data_owners", err.Error())
+			return nil, diags
+		}
+
+		// The same domain may have been listed in both forms
+		config.ProductOwners = core.NormalizeOwners(config.ProductOwners)
+		config.CodeOwners = core.NormalizeOwners(config.CodeOwners)
+		config.DataOwners = core.NormalizeOwners(config.DataOwners)
+	}
+
+	// Look up the owners that are groups, such as distribution lists
+	ownerGroups := map[string]core.Group{}
+	if providerConfig.GroupDirectory != nil && config.OwnerTagsEnabled {
+		groups, err := core.LookupOwnerGroups(ctx, providerConfig.GroupDirectory, config.ProductOwners, config.CodeOwners, config.DataOwners)
+		if err != nil {
+			diags.AddWarning("Group directory lookup failed",
+				fmt.Sprintf("Owner tags list group addresses unchanged: %s", err))
+		} else {
+			ownerGroups = groups
+		}
+	}
+
+	// Process ephemeral environment
+	providerConfig.NamingConstraints.ProcessEphemeralEnvironment(config)
+
+	// Generate name prefix
+	nameOptions := core.DefaultNameOptions()
+	nameOptions.MaxLength = providerConfig.NamingConstraints.MaxNamePrefixLength()
+	if config.NameDelimiter != nil {
+		nameOptions.Delimiter = *config.NameDelimiter
+	}
+	if len(config.LabelOrder) > 0 {
+		nameOptions.Order = make([]core.NameComponent, 0, len(config.LabelOrder))
+		for _, label := range config.LabelOrder {
+			nameOptions.Order = append(nameOptions.Order, core.NameComponent(label))
+		}
+	}
+	namePrefix, nameDiags := generateNamePrefix(config, config.Name, &nameOptions)
+	diags.Append(nameDiags...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// Get cloud provider
+	cloudProvider := providerConfig.CloudProvider
+	if cloudProvider == "" {
+		cloudProvider = "dc"
+	}
+	cp := core.GetCloudProvider(cloudProvider)
+
+	if err := core.ValidateListDelimiter(config.ListDelimiter, cp); err != nil {
+		diags.AddError("Invalid list_join_delimiter", err.Error())
+		return nil, diags
+	}
+
+	for _, warning := range core.CheckAdditionalTagLimits("additional_tags", config.AdditionalTags) {
+		diags.AddWarning("Large additional_tags", warning)
+	}
+	for _, warning := range core.CheckAdditionalTagLimits("additional_data_tags", config.AdditionalDataTags) {
+		diags.AddWarning("Large additional_data_tags", warning)
+	}
+
+	// Generate tags
+	tagPrefix := providerConfig.TagPrefixFor(config.Namespace)
+	newTagProcessor := func(config *core.DataSourceConfig, namePrefix string) *core.TagProcessor {
+		tagProcessor := &core.TagProcessor{
+			CloudProvider:    cp,
+			Config:           config,
+			TagPrefix:        tagPrefix,
+			TerraformVersion: providerConfig.TerraformVersion,
+			ProviderVersion:  providerConfig.ProviderVersion,
+
+			OwnerGroups:    ownerGroups,
+			OwnerGroupTags: providerConfig.OwnerGroupTags,
+
+			TokenizationKey: providerConfig.TokenizationKey,
+
+			GitTimeout: providerConfig.GitTimeout,
+			GitRoot:    providerConfig.GitRoot,
+		}
+		if program := providerConfig.EnrichmentProgram; len(program) > 0 {
+			tagProcessor.Enrich = func(tags map[string]string) (map[string]string, error) {
+				return core.RunEnrichmentProgram(ctx, program, core.EnrichmentInput{
+					NamePrefix:    namePrefix,
+					CloudProvider: cloudProvider,
+					TagPrefix:     tagPrefix,
+					Tags:          tags,
+				})
+			}
+		}
+		if policy := providerConfig.Policy; policy != nil {
+			tagProcessor.ApplyPolicy = func(tags map[string]string) (map[string]string, error) {
+				return policy.Evaluate(ctx, core.PolicyInput{
+					NamePrefix:    namePrefix,
+					CloudProvider: cloudProvider,
+					TagPrefix:     tagPrefix,
+					Context:       config,
+					Tags:          tags,
+				})
+			}
+		}
+		return tagProcessor
+	}
+	tagProcessor := newTagProcessor(config, namePrefix)
+
+	violations := tagProcessor.ComplianceViolations()
+	for _, violation := range violations {
+		diags.AddError("Compliance profile violation", violation)
+	}
+	if len(violations) > 0 {
+		return nil, diags
+	}
+
+	// Sensitivity requirements are errors in strict mode and under a
+	// compliance profile, and warnings otherwise
+	strict := providerConfig.StrictMode || config.ComplianceProfile != ""
+	violations = tagProcessor.SensitivityViolations()
+	for _, violation := range violations {
+		if strict {
+			diags.AddError("Sensitivity requirement not met", violation)
+		} else {
+			diags.AddWarning("Sensitivity requirement not met", violation)
+		}
+	}
+	if strict && len(violations) > 0 {
+		return nil, diags
+	}
+
+	_, tagSpan := tracing.StartSpan(ctx, "TagProcessor.Process")
+	tags, err := tagProcessor.Process()
+	var denied *core.PolicyDeniedError
+	if errors.As(err, &denied) {
+		for _, message := range denied.Messages {
+			diags.AddError("Denied by policy", message)
+		}
+		tracing.EndSpan(tagSpan, diags)
+		return nil, diags
+	}
+	if err != nil {
+		diags.AddError("Failed to generate tags", err.Error())
+		tracing.EndSpan(tagSpan, diags)
+		return nil, diags
+	}
+
+	dataTags, err := tagProcessor.ProcessDataTags()
+	if err != nil {
+		diags.AddError("Failed to generate data tags", err.Error())
+		tracing.EndSpan(tagSpan, diags)
+		return nil, diags
+	}
+	tagSpan.SetAttributes(attribute.Int("tags", len(tags)), attribute.Int("data_tags", len(dataTags)))
+	tracing.EndSpan(tagSpan, nil)
+
+	return &ResolvedContext{
+		Config:          config,
+		NameOptions:     nameOptions,
+		NamePrefix:      namePrefix,
+		CloudProvider:   cloudProvider,
+		TagProcessor:    tagProcessor,
+		Tags:            tags,
+		DataTags:        dataTags,
+		OwnerGroups:     ownerGroups,
+		newTagProcessor: newTagProcessor,
+	}, diags
+}
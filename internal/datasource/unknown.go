@@ -0,0 +1,49 @@
+package datasource
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// deferUnknownConfig handles a read whose configuration holds values only
+// known after apply, such as attributes of resources not created yet. It
+// defers the read when Terraform allows it, and sets the state to the
+// configuration with every computed attribute unknown, so that unknown inputs
+// are never validated or merged as empty strings. It returns false when the
+// configuration is fully known and the read should go on.
+func deferUnknownConfig(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) bool {
+	if req.Config.Raw.IsFullyKnown() {
+		return false
+	}
+
+	tflog.Debug(ctx, "Configuration has unknown values, outputs are unknown until apply", map[string]interface{}{
+		"deferral_allowed": req.ClientCapabilities.DeferralAllowed,
+	})
+	if req.ClientCapabilities.DeferralAllowed {
+		resp.Deferred = &datasource.Deferred{Reason: datasource.DeferredReasonDataSourceConfigUnknown}
+	}
+
+	attributes := req.Config.Schema.GetAttributes()
+	state, err := tftypes.Transform(req.Config.Raw, func(p *tftypes.AttributePath, v tftypes.Value) (tftypes.Value, error) {
+		if len(p.Steps()) != 1 {
+			return v, nil
+		}
+		name, ok := p.Steps()[0].(tftypes.AttributeName)
+		if !ok {
+			return v, nil
+		}
+		if a, ok := attributes[string(name)]; ok && a.IsComputed() && !a.IsOptional() {
+			return tftypes.NewValue(v.Type(), tftypes.UnknownValue), nil
+		}
+		return v, nil
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to mark outputs unknown", err.Error())
+		return true
+	}
+	resp.State.Raw = state
+	return true
+}
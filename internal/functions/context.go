@@ -0,0 +1,243 @@
+package functions
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/kbrockhoff/terraform-provider-context/internal/core"
+	"github.com/kbrockhoff/terraform-provider-context/internal/datasource"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &ContextFunction{}
+
+func NewContextFunction() function.Function {
+	return &ContextFunction{}
+}
+
+// ContextFunction generates the name prefix and tags of a context without a
+// data source.
+type ContextFunction struct{}
+
+// contextResultModel describes the object returned by context.
+type contextResultModel struct {
+	NamePrefix string            `tfsdk:"name_prefix"`
+	Tags       map[string]string `tfsdk:"tags"`
+	DataTags   map[string]string `tfsdk:"data_tags"`
+}
+
+// Provider functions cannot read the provider configuration, so the inputs
+// take these settings of the provider too
+const (
+	contextCloudProviderInput = "cloud_provider"
+	contextTagPrefixInput     = "tag_prefix"
+)
+
+func (f *ContextFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "context"
+}
+
+func (f *ContextFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Generate the name prefix and tags of a context",
+		Description: "Returns the name_prefix, tags and data_tags that brockhoff_context generates from the same inputs, with the provider defaults, so contexts can be built in locals and for_each without a data source. The inputs object takes the brockhoff_context input attributes except parent_context, plus cloud_provider (default: dc) and tag_prefix (default: bc-).",
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:        "inputs",
+				Description: "Object of brockhoff_context input attributes, such as { namespace = \"myorg\", name = \"app\", environment = \"prod\" }",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: map[string]attr.Type{
+				"name_prefix": types.StringType,
+				"tags":        types.MapType{ElemType: types.StringType},
+				"data_tags":   types.MapType{ElemType: types.StringType},
+			},
+		},
+	}
+}
+
+func (f *ContextFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var inputs types.Dynamic
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &inputs))
+	if resp.Error != nil {
+		return
+	}
+
+	values, ok := nativeValue(inputs).(map[string]any)
+	if !ok {
+		resp.Error = function.NewArgumentFuncError(0, "inputs must be an object of brockhoff_context input attributes")
+		return
+	}
+
+	cloudProvider, tagPrefix := "dc", "bc-"
+	for name, setting := range map[string]*string{contextCloudProviderInput: &cloudProvider, contextTagPrefixInput: &tagPrefix} {
+		if value, ok := values[name]; ok {
+			s, ok := value.(string)
+			if !ok {
+				resp.Error = function.NewArgumentFuncError(0, name+" must be a string")
+				return
+			}
+			*setting = s
+			delete(values, name)
+		}
+	}
+	if err := core.ValidateCloudProvider(cloudProvider); err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "invalid cloud_provider: "+err.Error())
+		return
+	}
+
+	config, err := contextInputsConfig(values)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	// The provider settings other than cloud_provider and tag_prefix take
+	// their defaults
+	providerConfig := &datasource.ProviderConfig{CloudProvider: cloudProvider, TagPrefix: tagPrefix}
+	resolved, diags := datasource.ResolveContext(ctx, providerConfig, config)
+	for _, warning := range diags.Warnings() {
+		tflog.Warn(ctx, "warning: call function", map[string]interface{}{"summary": warning.Summary(), "detail": warning.Detail()})
+	}
+	if diags.HasError() {
+		resp.Error = contextInputsError(diags)
+		return
+	}
+
+	result := contextResultModel{
+		NamePrefix: resolved.NamePrefix,
+		Tags:       resolved.TagProcessor.OmitPolicyInheritedTags(resolved.Tags),
+		DataTags:   resolved.DataTags,
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, result))
+}
+
+// nativeValue converts a value of any type to strings, bools, float64s,
+// slices and maps, as decoded from JSON. Null values become nil.
+func nativeValue(v attr.Value) any {
+	if v == nil || v.IsNull() || v.IsUnknown() {
+		return nil
+	}
+
+	switch v := v.(type) {
+	case types.Dynamic:
+		return nativeValue(v.UnderlyingValue())
+	case types.String:
+		return v.ValueString()
+	case types.Bool:
+		return v.ValueBool()
+	case types.Number:
+		f, _ := v.ValueBigFloat().Float64()
+		return f
+	case types.Int64:
+		return v.ValueInt64()
+	case types.Float64:
+		return v.ValueFloat64()
+	case types.List:
+		return nativeValues(v.Elements())
+	case types.Set:
+		return nativeValues(v.Elements())
+	case types.Tuple:
+		return nativeValues(v.Elements())
+	case types.Map:
+		return nativeAttributes(v.Elements())
+	case types.Object:
+		return nativeAttributes(v.Attributes())
+	}
+	return nil
+}
+
+// nativeValues converts the elements of a list, set or tuple with nativeValue
+func nativeValues(elements []attr.Value) []any {
+	values := make([]any, len(elements))
+	for i, elem := range elements {
+		values[i] = nativeValue(elem)
+	}
+	return values
+}
+
+// nativeAttributes converts the attributes of a map or object with
+// nativeValue, leaving out null attributes like unset data source inputs
+func nativeAttributes(attributes map[string]attr.Value) map[string]any {
+	values := make(map[string]any, len(attributes))
+	for name, value := range attributes {
+		if native := nativeValue(value); native != nil {
+			values[name] = native
+		}
+	}
+	return values
+}
+
+// contextInputsConfig decodes the inputs like a context file, with the
+// defaults of NewDataSourceConfig. Attributes that are not brockhoff_context
+// inputs are reported instead of ignored, since they are most likely typos.
+func contextInputsConfig(values map[string]any) (*core.DataSourceConfig, error) {
+	var known []string
+	for _, field := range reflect.VisibleFields(reflect.TypeFor[core.DataSourceConfig]()) {
+		if name, _, _ := strings.Cut(field.Tag.Get("json"), ","); name != "" && name != "-" {
+			known = append(known, name)
+		}
+	}
+	for name := range values {
+		if !slices.Contains(known, name) {
+			return nil, fmt.Errorf("unsupported input attribute %q", name)
+		}
+	}
+
+	data, err := json.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+	config := core.NewDataSourceConfig()
+	if err := json.NewDecoder(bytes.NewReader(data)).Decode(config); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			return nil, fmt.Errorf("input attribute %s must be a %s, got a %s", typeErr.Field, terraformTypeName(typeErr.Type), typeErr.Value)
+		}
+		return nil, fmt.Errorf("invalid inputs: %w", err)
+	}
+	return config, nil
+}
+
+// terraformTypeName returns the Terraform name of the type of a
+// DataSourceConfig field
+func terraformTypeName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Pointer:
+		return terraformTypeName(t.Elem())
+	case reflect.Slice:
+		return "list(" + terraformTypeName(t.Elem()) + ")"
+	case reflect.Map:
+		return "map(" + terraformTypeName(t.Elem()) + ")"
+	case reflect.Int, reflect.Int64, reflect.Float64:
+		return "number"
+	case reflect.Struct:
+		return "object"
+	}
+	return t.Kind().String()
+}
+
+// contextInputsError reports the errors of diags, as added by
+// ResolveContext, as an error of the inputs argument such as
+// "invalid namespace: ..."
+func contextInputsError(diags diag.Diagnostics) *function.FuncError {
+	var messages []string
+	for _, d := range diags.Errors() {
+		summary := d.Summary()
+		messages = append(messages, strings.ToLower(summary[:1])+summary[1:]+": "+d.Detail())
+	}
+	return function.NewArgumentFuncError(0, strings.Join(messages, "; "))
+}
@@ -0,0 +1,34 @@
+package functions
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/kbrockhoff/terraform-provider-context/internal/core"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &ContextSchemaFunction{}
+
+func NewContextSchemaFunction() function.Function {
+	return &ContextSchemaFunction{}
+}
+
+// ContextSchemaFunction returns the JSON Schema of context documents.
+type ContextSchemaFunction struct{}
+
+func (f *ContextSchemaFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "context_schema"
+}
+
+func (f *ContextSchemaFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "JSON Schema of context documents",
+		Description: "Returns the JSON Schema (draft 2020-12) of context documents, the brockhoff_context inputs as JSON or YAML, with the attribute types, enums and patterns the data source validates, for tooling in other languages.",
+		Return:      function.StringReturn{},
+	}
+}
+
+func (f *ContextSchemaFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, string(core.ContextSchema())))
+}
@@ -0,0 +1,178 @@
+package functions
+
+import (
+	"context"
+	"maps"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	ds "github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/kbrockhoff/terraform-provider-context/internal/datasource"
+)
+
+// callContext runs the context function with inputs, which hold strings,
+// bools and lists of strings, and returns its result or error
+func callContext(t *testing.T, inputs map[string]any) (contextResultModel, *function.FuncError) {
+	t.Helper()
+	ctx := context.Background()
+
+	attributeTypes := map[string]attr.Type{}
+	attributes := map[string]attr.Value{}
+	for name, value := range inputs {
+		switch value := value.(type) {
+		case string:
+			attributeTypes[name] = types.StringType
+			attributes[name] = types.StringValue(value)
+		case bool:
+			attributeTypes[name] = types.BoolType
+			attributes[name] = types.BoolValue(value)
+		case []string:
+			elements := make([]attr.Value, len(value))
+			for i, s := range value {
+				elements[i] = types.StringValue(s)
+			}
+			attributeTypes[name] = types.ListType{ElemType: types.StringType}
+			attributes[name] = types.ListValueMust(types.StringType, elements)
+		}
+	}
+
+	f := &ContextFunction{}
+	var definition function.DefinitionResponse
+	f.Definition(ctx, function.DefinitionRequest{}, &definition)
+	resultType := definition.Definition.Return.(function.ObjectReturn).AttributeTypes
+
+	req := function.RunRequest{Arguments: function.NewArgumentsData([]attr.Value{
+		types.DynamicValue(types.ObjectValueMust(attributeTypes, attributes)),
+	})}
+	resp := &function.RunResponse{Result: function.NewResultData(types.ObjectUnknown(resultType))}
+	f.Run(ctx, req, resp)
+
+	var result contextResultModel
+	if resp.Error == nil {
+		if diags := resp.Result.Value().(types.Object).As(ctx, &result, basetypes.ObjectAsOptions{}); diags.HasError() {
+			t.Fatalf("result diagnostics = %v", diags)
+		}
+	}
+	return result, resp.Error
+}
+
+// readContext runs Read of brockhoff_context with inputs and the default
+// provider settings, and returns the name prefix, tags and data tags
+func readContext(t *testing.T, inputs map[string]any) (contextResultModel, []string) {
+	t.Helper()
+	ctx := context.Background()
+	d := datasource.NewContextDataSource().(*datasource.ContextDataSource)
+	var configureResp ds.ConfigureResponse
+	d.Configure(ctx, ds.ConfigureRequest{ProviderData: &datasource.ProviderConfig{TagPrefix: "bc-"}}, &configureResp)
+
+	var schemaResp ds.SchemaResponse
+	d.Schema(ctx, ds.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	values := map[string]tftypes.Value{}
+	for name, attrType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attrType, nil)
+	}
+	for name, value := range inputs {
+		switch value := value.(type) {
+		case string:
+			values[name] = tftypes.NewValue(tftypes.String, value)
+		case bool:
+			values[name] = tftypes.NewValue(tftypes.Bool, value)
+		case []string:
+			elements := make([]tftypes.Value, len(value))
+			for i, s := range value {
+				elements[i] = tftypes.NewValue(tftypes.String, s)
+			}
+			values[name] = tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, elements)
+		}
+	}
+	raw := tftypes.NewValue(objectType, values)
+
+	resp := &ds.ReadResponse{State: tfsdk.State{Raw: raw, Schema: schemaResp.Schema}}
+	d.Read(ctx, ds.ReadRequest{Config: tfsdk.Config{Raw: raw, Schema: schemaResp.Schema}}, resp)
+
+	var errs []string
+	for _, e := range resp.Diagnostics.Errors() {
+		errs = append(errs, e.Detail())
+	}
+	if len(errs) > 0 {
+		return contextResultModel{}, errs
+	}
+	var data datasource.ContextDataSourceModel
+	if diags := resp.State.Get(ctx, &data); diags.HasError() {
+		t.Fatalf("state diagnostics = %v", diags)
+	}
+	result := contextResultModel{NamePrefix: data.NamePrefix.ValueString()}
+	data.Tags.ElementsAs(ctx, &result.Tags, false)
+	data.DataTags.ElementsAs(ctx, &result.DataTags, false)
+	return result, nil
+}
+
+// TestContextFunction_matchesDataSource feeds the same inputs to the context
+// function and the data source
+func TestContextFunction_matchesDataSource(t *testing.T) {
+	hipaa := map[string]any{
+		"namespace":                "myorg",
+		"name":                     "app",
+		"environment":              "prod",
+		"compliance_profile":       "hipaa",
+		"cost_center":              "cc-100",
+		"product_owners":           []string{"owner@example.com"},
+		"data_owners":              []string{"data@example.com"},
+		"security_review":          "SEC-1",
+		"privacy_review":           "PRV-1",
+		"source_repo_tags_enabled": false,
+	}
+	withRetention := maps.Clone(hipaa)
+	withRetention["data_retention"] = "P7Y"
+	invalid := maps.Clone(withRetention)
+	invalid["namespace"] = "My Org"
+
+	tests := []struct {
+		name    string
+		inputs  map[string]any
+		wantErr string
+	}{
+		{name: "valid", inputs: withRetention},
+		{name: "sensitivity requirement under compliance profile", inputs: hipaa, wantErr: "data_retention is required for restricted data"},
+		{name: "invalid namespace", inputs: invalid, wantErr: "namespace"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want, dsErrs := readContext(t, tt.inputs)
+			got, fnErr := callContext(t, tt.inputs)
+
+			if tt.wantErr != "" {
+				if len(dsErrs) == 0 || !strings.Contains(strings.Join(dsErrs, "; "), tt.wantErr) {
+					t.Errorf("data source errors = %v, want %q", dsErrs, tt.wantErr)
+				}
+				if fnErr == nil || !strings.Contains(fnErr.Error(), tt.wantErr) {
+					t.Errorf("function error = %v, want %q", fnErr, tt.wantErr)
+				}
+				return
+			}
+			if len(dsErrs) > 0 || fnErr != nil {
+				t.Fatalf("data source errors = %v, function error = %v", dsErrs, fnErr)
+			}
+			if len(want.Tags) == 0 || len(want.DataTags) == 0 {
+				t.Fatalf("data source gives no tags: %v, %v", want.Tags, want.DataTags)
+			}
+			if got.NamePrefix != want.NamePrefix {
+				t.Errorf("name_prefix = %q, data source gives %q", got.NamePrefix, want.NamePrefix)
+			}
+			if !maps.Equal(got.Tags, want.Tags) {
+				t.Errorf("tags = %v, data source gives %v", got.Tags, want.Tags)
+			}
+			if !maps.Equal(got.DataTags, want.DataTags) {
+				t.Errorf("data_tags = %v, data source gives %v", got.DataTags, want.DataTags)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package functions
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/kbrockhoff/terraform-provider-context/internal/core"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &MergeTagsFunction{}
+
+func NewMergeTagsFunction() function.Function {
+	return &MergeTagsFunction{}
+}
+
+// MergeTagsFunction merges two tag maps and sanitizes the values for a cloud provider.
+type MergeTagsFunction struct{}
+
+func (f *MergeTagsFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "merge_tags"
+}
+
+func (f *MergeTagsFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Merge tag maps with cloud-aware sanitization",
+		Description: "Merges override into base, with override values taking precedence, and sanitizes and truncates every resulting value using the rules of the given cloud provider.",
+		Parameters: []function.Parameter{
+			function.MapParameter{
+				Name:           "base",
+				Description:    "Base tags, such as the tags output of brockhoff_context",
+				ElementType:    types.StringType,
+				AllowNullValue: true,
+			},
+			function.MapParameter{
+				Name:           "override",
+				Description:    "Tags that take precedence over base",
+				ElementType:    types.StringType,
+				AllowNullValue: true,
+			},
+			function.StringParameter{
+				Name:        "cloud",
+				Description: "Cloud provider identifier: dc, aws, az, gcp, oci, ibm, do, vul, ali, cv",
+			},
+		},
+		Return: function.MapReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (f *MergeTagsFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var base, override map[string]string
+	var cloud string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &base, &override, &cloud))
+	if resp.Error != nil {
+		return
+	}
+
+	if err := core.ValidateCloudProvider(cloud); err != nil {
+		resp.Error = function.NewArgumentFuncError(2, err.Error())
+		return
+	}
+
+	merged := core.MergeTags(base, override, core.GetCloudProvider(cloud))
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, merged))
+}
@@ -0,0 +1,97 @@
+package functions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/kbrockhoff/terraform-provider-context/internal/core"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &NameFunction{}
+
+func NewNameFunction() function.Function {
+	return &NameFunction{}
+}
+
+// NameFunction generates a name prefix without a data source.
+type NameFunction struct{}
+
+func (f *NameFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "name"
+}
+
+func (f *NameFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Generate a name prefix",
+		Description: "Generates a name prefix following Brockhoff standards from a namespace, name and environment, using the same rules as the name_prefix output of brockhoff_context.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:           "namespace",
+				Description:    "Organization or business unit identifier (1-8 chars, lowercase alphanumeric with hyphens)",
+				AllowNullValue: true,
+			},
+			function.StringParameter{
+				Name:        "name",
+				Description: "Unique resource name",
+			},
+			function.StringParameter{
+				Name:           "environment",
+				Description:    "Environment abbreviation (1-8 chars, lowercase alphanumeric with hyphens)",
+				AllowNullValue: true,
+			},
+			function.Int64Parameter{
+				Name:           "maxlen",
+				Description:    "Maximum name prefix length; null uses the default of 24",
+				AllowNullValue: true,
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *NameFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var namespace, environment types.String
+	var name string
+	var maxLen types.Int64
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &namespace, &name, &environment, &maxLen))
+	if resp.Error != nil {
+		return
+	}
+
+	if err := core.ValidateNamespace(namespace.ValueString()); err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	if err := core.ValidateEnvironment(environment.ValueString()); err != nil {
+		resp.Error = function.NewArgumentFuncError(2, err.Error())
+		return
+	}
+
+	options := core.DefaultNameOptions()
+	if !maxLen.IsNull() {
+		if maxLen.ValueInt64() < core.MinNamePrefixLength {
+			resp.Error = function.NewArgumentFuncError(3, fmt.Sprintf("maxlen must be at least %d, got %d", core.MinNamePrefixLength, maxLen.ValueInt64()))
+			return
+		}
+		options.MaxLength = int(maxLen.ValueInt64())
+	}
+
+	nameGen := &core.NameGenerator{
+		Namespace:   namespace.ValueString(),
+		Name:        name,
+		Environment: environment.ValueString(),
+		Options:     &options,
+	}
+
+	namePrefix, err := nameGen.Generate()
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, namePrefix))
+}
@@ -0,0 +1,75 @@
+package functions
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/kbrockhoff/terraform-provider-context/internal/core"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &TagSupportFunction{}
+
+func NewTagSupportFunction() function.Function {
+	return &TagSupportFunction{}
+}
+
+// TagSupportFunction reports whether a Terraform resource type can be tagged.
+type TagSupportFunction struct{}
+
+// tagSupportModel describes the object returned by tag_support.
+type tagSupportModel struct {
+	Known     bool   `tfsdk:"known"`
+	Supported bool   `tfsdk:"supported"`
+	Attribute string `tfsdk:"attribute"`
+	MaxTags   int64  `tfsdk:"max_tags"`
+	Notes     string `tfsdk:"notes"`
+}
+
+func (f *TagSupportFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "tag_support"
+}
+
+func (f *TagSupportFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Look up tag support of a resource type",
+		Description: "Returns whether a Terraform resource type, such as aws_s3_object, supports tags, the argument that takes them, the maximum number of tags and any special constraints. Resource types not in the capability table return known = false and supported = false.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "resource_type",
+				Description: "Terraform resource type, such as aws_s3_bucket",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: map[string]attr.Type{
+				"known":     types.BoolType,
+				"supported": types.BoolType,
+				"attribute": types.StringType,
+				"max_tags":  types.Int64Type,
+				"notes":     types.StringType,
+			},
+		},
+	}
+}
+
+func (f *TagSupportFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var resourceType string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &resourceType))
+	if resp.Error != nil {
+		return
+	}
+
+	support, known := core.LookupTagSupport(resourceType)
+	result := tagSupportModel{
+		Known:     known,
+		Supported: support.Supported,
+		Attribute: support.Attribute,
+		MaxTags:   int64(support.MaxTags),
+		Notes:     support.Notes,
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, result))
+}
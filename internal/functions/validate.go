@@ -0,0 +1,135 @@
+package functions
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/kbrockhoff/terraform-provider-context/internal/core"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &ValidateFunction{}
+
+// ValidateFunction exposes a single value validation rule as a provider
+// function returning whether the value is valid. Null values are validated
+// as empty strings, so optional variables pass when unset.
+type ValidateFunction struct {
+	name        string
+	summary     string
+	description string
+	validate    func(string) error
+}
+
+func NewValidateEmailFunction() function.Function {
+	return &ValidateFunction{
+		name:        "validate_email",
+		summary:     "Validate an email address",
+		description: "Returns true if the value is a valid email address, as required for product_owners, code_owners and data_owners.",
+		validate:    core.ValidateEmail,
+	}
+}
+
+func NewValidateNamespaceFunction() function.Function {
+	return &ValidateFunction{
+		name:        "validate_namespace",
+		summary:     "Validate a namespace",
+		description: "Returns true if the value is a valid namespace (1-8 chars, lowercase alphanumeric with hyphens).",
+		validate:    core.ValidateNamespace,
+	}
+}
+
+func NewValidateEnvironmentFunction() function.Function {
+	return &ValidateFunction{
+		name:        "validate_environment",
+		summary:     "Validate an environment abbreviation",
+		description: "Returns true if the value is a valid environment abbreviation (1-8 chars, lowercase alphanumeric with hyphens).",
+		validate:    core.ValidateEnvironment,
+	}
+}
+
+func NewValidateEnvironmentTypeFunction() function.Function {
+	return &ValidateFunction{
+		name:        "validate_environment_type",
+		summary:     "Validate an environment type",
+		description: "Returns true if the value is one of: None, Ephemeral, Development, Testing, UAT, Production, MissionCritical.",
+		validate:    core.ValidateEnvironmentType,
+	}
+}
+
+func NewValidateAvailabilityFunction() function.Function {
+	return &ValidateFunction{
+		name:        "validate_availability",
+		summary:     "Validate an availability level",
+		description: "Returns true if the value is one of: preemptable, spot, standard, dedicated, isolated.",
+		validate:    core.ValidateAvailability,
+	}
+}
+
+func NewValidateSensitivityFunction() function.Function {
+	return &ValidateFunction{
+		name:        "validate_sensitivity",
+		summary:     "Validate a data sensitivity level",
+		description: "Returns true if the value is one of: public, internal, confidential, restricted, critical.",
+		validate:    core.ValidateSensitivity,
+	}
+}
+
+func NewValidateDeletionDateFunction() function.Function {
+	return &ValidateFunction{
+		name:        "validate_deletion_date",
+		summary:     "Validate a deletion date",
+		description: "Returns true if the value is a valid date in YYYY-MM-DD format.",
+		validate:    core.ValidateDeletionDate,
+	}
+}
+
+func NewValidateLifecycleActionFunction() function.Function {
+	return &ValidateFunction{
+		name:        "validate_lifecycle_action",
+		summary:     "Validate a lifecycle action",
+		description: "Returns true if the value is one of: delete, stop, notify.",
+		validate:    core.ValidateLifecycleAction,
+	}
+}
+
+func NewValidateCloudProviderFunction() function.Function {
+	return &ValidateFunction{
+		name:        "validate_cloud_provider",
+		summary:     "Validate a cloud provider identifier",
+		description: "Returns true if the value is one of: dc, aws, az, gcp, oci, ibm, do, vul, ali, cv.",
+		validate:    core.ValidateCloudProvider,
+	}
+}
+
+func (f *ValidateFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = f.name
+}
+
+func (f *ValidateFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     f.summary,
+		Description: f.description,
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:           "value",
+				Description:    "Value to validate",
+				AllowNullValue: true,
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *ValidateFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value types.String
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &value))
+	if resp.Error != nil {
+		return
+	}
+
+	valid := f.validate(value.ValueString()) == nil
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, valid))
+}
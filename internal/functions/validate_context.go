@@ -0,0 +1,131 @@
+package functions
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/kbrockhoff/terraform-provider-context/internal/core"
+	"github.com/kbrockhoff/terraform-provider-context/internal/datasource"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &ValidateContextFunction{}
+
+func NewValidateContextFunction() function.Function {
+	return &ValidateContextFunction{}
+}
+
+// ValidateContextFunction applies the brockhoff_context input validation
+// rules to a whole context object.
+type ValidateContextFunction struct{}
+
+func (f *ValidateContextFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "validate_context"
+}
+
+func (f *ValidateContextFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Validate a context object",
+		Description: "Returns true if every value in a context object (such as the context_output of brockhoff_context or brockhoff_merge) passes the same validation as the brockhoff_context data source inputs.",
+		Parameters: []function.Parameter{
+			function.ObjectParameter{
+				Name:           "context",
+				Description:    "Context object to validate",
+				AttributeTypes: datasource.ContextAttributeTypes(),
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *ValidateContextFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var obj types.Object
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &obj))
+	if resp.Error != nil {
+		return
+	}
+
+	var input datasource.ContextInputModel
+	if diags := obj.As(ctx, &input, basetypes.ObjectAsOptions{}); diags.HasError() {
+		resp.Error = function.FuncErrorFromDiags(ctx, diags)
+		return
+	}
+
+	var owners, attributes, labelOrder, naFields, tokenizeFields, dataResidency []string
+	for _, list := range []types.List{input.ProductOwners, input.CodeOwners, input.DataOwners} {
+		var values []string
+		if diags := list.ElementsAs(ctx, &values, false); diags.HasError() {
+			resp.Error = function.FuncErrorFromDiags(ctx, diags)
+			return
+		}
+		owners = append(owners, values...)
+	}
+	if diags := input.Attributes.ElementsAs(ctx, &attributes, false); diags.HasError() {
+		resp.Error = function.FuncErrorFromDiags(ctx, diags)
+		return
+	}
+	if diags := input.LabelOrder.ElementsAs(ctx, &labelOrder, false); diags.HasError() {
+		resp.Error = function.FuncErrorFromDiags(ctx, diags)
+		return
+	}
+	if diags := input.NAFields.ElementsAs(ctx, &naFields, false); diags.HasError() {
+		resp.Error = function.FuncErrorFromDiags(ctx, diags)
+		return
+	}
+	if diags := input.TokenizeFields.ElementsAs(ctx, &tokenizeFields, false); diags.HasError() {
+		resp.Error = function.FuncErrorFromDiags(ctx, diags)
+		return
+	}
+	if diags := input.DataResidency.ElementsAs(ctx, &dataResidency, false); diags.HasError() {
+		resp.Error = function.FuncErrorFromDiags(ctx, diags)
+		return
+	}
+	var legacyTagMap map[string]string
+	if diags := input.LegacyTagMap.ElementsAs(ctx, &legacyTagMap, false); diags.HasError() {
+		resp.Error = function.FuncErrorFromDiags(ctx, diags)
+		return
+	}
+
+	valid := core.ValidateNamespace(input.Namespace.ValueString()) == nil &&
+		core.ValidateEnvironment(input.Environment.ValueString()) == nil &&
+		core.ValidateTenant(input.Tenant.ValueString()) == nil &&
+		core.ValidateTenantID(input.TenantID.ValueString()) == nil &&
+		core.ValidateCustomer(input.Customer.ValueString()) == nil &&
+		core.ValidateTier(input.Tier.ValueString()) == nil &&
+		core.ValidateBusinessUnit(input.BusinessUnit.ValueString()) == nil &&
+		core.ValidateDivision(input.Division.ValueString()) == nil &&
+		core.ValidatePortfolio(input.Portfolio.ValueString()) == nil &&
+		core.ValidateAttributes(attributes) == nil &&
+		core.ValidateLabelOrder(labelOrder) == nil &&
+		core.ValidateEnvironmentType(input.EnvironmentType.ValueString()) == nil &&
+		core.ValidateNameDelimiter(input.NameDelimiter.ValueString()) == nil &&
+		core.ValidateListDelimiter(input.ListDelimiter.ValueString(), core.GetCloudProvider("dc")) == nil &&
+		core.ValidateReservedWordAction(input.ReservedWordAction.ValueString()) == nil &&
+		core.ValidateSanitizationMode(input.SanitizationMode.ValueString()) == nil &&
+		core.ValidateLengthOverflow(input.LengthOverflow.ValueString()) == nil &&
+		core.ValidateTagSchemaVersion(int(input.TagSchemaVersion.ValueInt64())) == nil &&
+		core.ValidateAdditionalTypedTags(datasource.TypedTagsFromValue(ctx, input.AdditionalTypedTags)) == nil &&
+		core.ValidateLegacyTagMap(legacyTagMap) == nil &&
+		core.ValidateLegacyTagsUntil(input.LegacyTagsUntil.ValueString()) == nil &&
+		core.ValidateNAFields(naFields) == nil &&
+		core.ValidateTokenizeFields(tokenizeFields) == nil &&
+		core.ValidateAvailability(input.Availability.ValueString()) == nil &&
+		core.ValidateSensitivity(input.Sensitivity.ValueString()) == nil &&
+		core.ValidateComplianceProfile(input.ComplianceProfile.ValueString()) == nil &&
+		core.ValidateDataRetention(input.DataRetention.ValueString()) == nil &&
+		core.ValidateDataResidency(dataResidency) == nil &&
+		core.ValidateRegion(input.Region.ValueString()) == nil &&
+		core.ValidateRegionResidency(input.Region.ValueString(), dataResidency) == nil &&
+		core.ValidateDeletionDate(input.DeletionDate.ValueString()) == nil &&
+		core.ValidateLifecycleAction(input.LifecycleAction.ValueString()) == nil &&
+		core.ValidateMaintenanceWindow(input.MaintenanceWindow.ValueString()) == nil &&
+		core.ValidateMonthlyBudget(input.MonthlyBudget.ValueFloat64()) == nil &&
+		core.ValidateBudgetCurrency(input.BudgetCurrency.ValueString()) == nil &&
+		core.ValidateEmails(owners) == nil &&
+		(input.EnvironmentType.ValueString() != "Ephemeral" || input.LifecycleAction.ValueString() != "")
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, valid))
+}
@@ -0,0 +1,62 @@
+// Command gencontextschema generates the JSON Schema of context documents,
+// pkg/context/context.schema.json, from pkg/context.DataSourceConfig and the
+// attribute descriptions of the brockhoff_context data source.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+
+	internaldatasource "github.com/kbrockhoff/terraform-provider-context/internal/datasource"
+	ctx "github.com/kbrockhoff/terraform-provider-context/pkg/context"
+)
+
+// documentNames maps the context document keys that differ from the data
+// source attribute names to those names. Context documents keep managedby,
+// which the data source renamed to managed_by.
+var documentNames = map[string]string{
+	"managedby": "managed_by",
+}
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "Usage: gencontextschema FILE")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	out, err := generate()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(flag.Arg(0), out, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// generate returns the JSON Schema of context documents
+func generate() ([]byte, error) {
+	var resp datasource.SchemaResponse
+	internaldatasource.NewContextDataSource().Schema(context.Background(), datasource.SchemaRequest{}, &resp)
+	if resp.Diagnostics.HasError() {
+		return nil, fmt.Errorf("data source schema: %v", resp.Diagnostics)
+	}
+
+	descriptions := map[string]string{}
+	for name, attribute := range resp.Schema.Attributes {
+		descriptions[name] = attribute.GetDescription()
+	}
+	for documentName, name := range documentNames {
+		descriptions[documentName] = descriptions[name]
+	}
+	return ctx.GenerateContextSchema(descriptions)
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	ctx "github.com/kbrockhoff/terraform-provider-context/pkg/context"
+)
+
+func TestSchemaUpToDate(t *testing.T) {
+	want, err := generate()
+	if err != nil {
+		t.Fatalf("generate() error = %v", err)
+	}
+	src, err := os.ReadFile(filepath.Join("..", "..", "pkg", "context", "context.schema.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(src, want) {
+		t.Error("pkg/context/context.schema.json is out of date, run go generate -tags generate in tools")
+	}
+	if !bytes.Equal(ctx.ContextSchema(), src) {
+		t.Error("ContextSchema() differs from pkg/context/context.schema.json")
+	}
+}
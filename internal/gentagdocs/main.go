@@ -0,0 +1,114 @@
+// Command gentagdocs generates the tag mapping and cloud provider
+// sanitization sections of the brockhoff_context documentation from
+// pkg/context.TagSpecs and TagRules. It replaces the text between the
+// beginMarker and endMarker lines of each file given as an argument, such
+// as the tfplugindocs template and the documentation generated from it.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"log"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	ctx "github.com/kbrockhoff/terraform-provider-context/pkg/context"
+)
+
+const (
+	beginMarker = "<!-- BEGIN GENERATED TAGS: gentagdocs -->"
+	endMarker   = "<!-- END GENERATED TAGS -->"
+)
+
+// clouds are the cloud providers with their own tag rules
+var clouds = []string{"aws", "az", "gcp"}
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "Usage: gentagdocs FILE...")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	section := render()
+	for _, path := range flag.Args() {
+		src, err := os.ReadFile(path)
+		if err != nil {
+			log.Fatal(err)
+		}
+		out, err := replaceSection(src, section)
+		if err != nil {
+			log.Fatalf("%s: %v", path, err)
+		}
+		if err := os.WriteFile(path, out, 0o644); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+// replaceSection replaces the lines between the markers of src with section
+func replaceSection(src, section []byte) ([]byte, error) {
+	begin := bytes.Index(src, []byte(beginMarker+"\n"))
+	end := bytes.Index(src, []byte(endMarker))
+	if begin < 0 || end < begin {
+		return nil, fmt.Errorf("missing %q and %q lines", beginMarker, endMarker)
+	}
+	begin += len(beginMarker) + 1
+
+	var out bytes.Buffer
+	out.Write(src[:begin])
+	out.Write(section)
+	out.Write(src[end:])
+	return out.Bytes(), nil
+}
+
+// render returns the Markdown of the generated sections
+func render() []byte {
+	var b bytes.Buffer
+
+	fmt.Fprintln(&b, "## Generated Tags")
+	fmt.Fprintln(&b)
+	fmt.Fprintln(&b, "Tag keys are shown without the provider `tag_prefix`. Tags generated \"always\" hold the cloud provider N/A value when their attributes are not set, subject to `not_applicable_enabled` and `na_fields`. `additional_tags` and `additional_data_tags` are merged over the generated tags of `tags` and `data_tags`.")
+	fmt.Fprintln(&b)
+	fmt.Fprintln(&b, "| Tag | Output | Attributes | Generated |")
+	fmt.Fprintln(&b, "|-----|--------|------------|-----------|")
+	for _, spec := range ctx.TagSpecs {
+		fields := "-"
+		if len(spec.Fields) > 0 {
+			fields = "`" + strings.Join(spec.Fields, "`, `") + "`"
+		}
+		fmt.Fprintf(&b, "| `%s` | `%s` | %s | %s |\n", spec.Key, spec.Output, fields, spec.Condition)
+	}
+
+	fmt.Fprintln(&b)
+	fmt.Fprintln(&b, "## Cloud Provider Sanitization")
+	fmt.Fprintln(&b)
+	fmt.Fprintln(&b, "Tag values are sanitized for the provider `cloud_provider`, then values longer than the maximum length are handled as selected by `length_overflow`. `sanitization_mode` selects whether sanitized values are accepted, reported as warnings or rejected. List values are joined with the list delimiter unless `list_join_delimiter` is set, and the N/A value is replaced by `na_value_override` when set.")
+	fmt.Fprintln(&b)
+	fmt.Fprintln(&b, "| Cloud provider | Max value length | List delimiter | N/A value | Sanitization |")
+	fmt.Fprintln(&b, "|----------------|------------------|----------------|-----------|--------------|")
+	for _, cloud := range clouds {
+		writeRules(&b, "`"+cloud+"`", ctx.TagRules(cloud))
+	}
+	var others []string
+	for _, cloud := range slices.Sorted(maps.Keys(ctx.ValidCloudProviders)) {
+		if !slices.Contains(clouds, cloud) {
+			others = append(others, "`"+cloud+"`")
+		}
+	}
+	writeRules(&b, strings.Join(others, ", "), ctx.TagRules("dc"))
+	return b.Bytes()
+}
+
+// writeRules writes the table row of the rules of cloud providers
+func writeRules(b *bytes.Buffer, cloudProviders string, rules ctx.CloudTagRules) {
+	fmt.Fprintf(b, "| %s | %d | `%q` | `%s` | %s |\n",
+		cloudProviders, rules.MaxLength, rules.Delimiter, rules.NAValue, rules.Sanitization)
+}
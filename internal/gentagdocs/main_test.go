@@ -0,0 +1,50 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// documents are the files holding the generated sections, relative to the
+// repository root
+var documents = []string{
+	"templates/data-sources/context.md.tmpl",
+	"docs/data-sources/context.md",
+}
+
+// TestDocumentsUpToDate fails when the generated sections differ from
+// pkg/context.TagSpecs and TagRules; run go generate -tags generate in tools to update them
+func TestDocumentsUpToDate(t *testing.T) {
+	section := render()
+	for _, document := range documents {
+		src, err := os.ReadFile(filepath.Join("..", "..", document))
+		if err != nil {
+			t.Fatal(err)
+		}
+		want, err := replaceSection(src, section)
+		if err != nil {
+			t.Fatalf("%s: %v", document, err)
+		}
+		if !bytes.Equal(src, want) {
+			t.Errorf("%s is out of date, run go generate -tags generate in tools", document)
+		}
+	}
+}
+
+func TestReplaceSection(t *testing.T) {
+	src := []byte("# Title\n\n" + beginMarker + "\nold\n" + endMarker + "\ntrailer\n")
+	got, err := replaceSection(src, []byte("new\n"))
+	if err != nil {
+		t.Fatalf("replaceSection() error = %v", err)
+	}
+	want := "# Title\n\n" + beginMarker + "\nnew\n" + endMarker + "\ntrailer\n"
+	if string(got) != want {
+		t.Errorf("replaceSection() = %q, want %q", got, want)
+	}
+
+	if _, err := replaceSection([]byte("# Title\n"), []byte("new\n")); err == nil {
+		t.Error("replaceSection() without markers succeeded")
+	}
+}
@@ -0,0 +1,160 @@
+// Package metrics counts provider operations and, when
+// CONTEXT_PROVIDER_METRICS_FILE is set, writes them to that file in the
+// Prometheus text format at the end of the plugin process, so that CI can
+// measure the plan-time cost of the context layer.
+package metrics
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/kbrockhoff/terraform-provider-context/internal/core"
+)
+
+// FileEnvVar is the environment variable naming the metrics file
+const FileEnvVar = "CONTEXT_PROVIDER_METRICS_FILE"
+
+// metricPrefix namespaces the metric names
+const metricPrefix = "terraform_provider_context_"
+
+var (
+	mu                 sync.Mutex
+	reads              = map[string]float64{}
+	readSeconds        = map[string]float64{}
+	validationFailures = map[string]float64{}
+)
+
+// RecordRead counts a read of dataSource that took elapsed, and counts each
+// error diagnostic of the read as a validation failure
+func RecordRead(dataSource string, elapsed time.Duration, diags diag.Diagnostics) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	reads[labels("data_source", dataSource)]++
+	readSeconds[labels("data_source", dataSource)] += elapsed.Seconds()
+	for _, d := range diags.Errors() {
+		validationFailures[labels("data_source", dataSource, "summary", d.Summary())]++
+	}
+}
+
+// WriteFile writes the metrics to the file named by CONTEXT_PROVIDER_METRICS_FILE,
+// doing nothing when it is not set. Terraform starts the plugin several times
+// during a run, so the values are added to those of an existing file.
+func WriteFile() error {
+	path := os.Getenv(FileEnvVar)
+	if path == "" {
+		return nil
+	}
+
+	previous := map[string]float64{}
+	f, err := os.Open(path)
+	switch {
+	case err == nil:
+		previous, err = parse(f)
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", path, err)
+		}
+	case !errors.Is(err, fs.ErrNotExist):
+		return err
+	}
+
+	var b strings.Builder
+	if err := Write(&b, previous); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(b.String()), 0o644)
+}
+
+// Write writes the metrics in the Prometheus text format, adding the values
+// of previous, keyed by series such as name{label="value"}
+func Write(w io.Writer, previous map[string]float64) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	gitLookups, gitCacheHits := core.GitStats()
+	groupLookups, groupCacheHits := core.GroupDirectoryStats()
+	sanitizeLookups, sanitizeCacheHits := core.SanitizeStats()
+
+	families := []struct {
+		name    string
+		help    string
+		samples map[string]float64
+	}{
+		{"reads_total", "Data source reads.", reads},
+		{"read_seconds_total", "Time spent in data source reads.", readSeconds},
+		{"validation_failures_total", "Error diagnostics of data source reads.", validationFailures},
+		{"git_lookups_total", "Runs of git to find the repository and commit.", map[string]float64{"": float64(gitLookups)}},
+		{"git_cache_hits_total", "Repository and commit lookups served from the cache.", map[string]float64{"": float64(gitCacheHits)}},
+		{"group_directory_lookups_total", "Owner lookups sent to the group directory.", map[string]float64{"": float64(groupLookups)}},
+		{"group_directory_cache_hits_total", "Owner lookups served from the cache.", map[string]float64{"": float64(groupCacheHits)}},
+		{"sanitize_lookups_total", "Tag values sanitized for the cloud provider.", map[string]float64{"": float64(sanitizeLookups)}},
+		{"sanitize_cache_hits_total", "Sanitized tag values served from the cache.", map[string]float64{"": float64(sanitizeCacheHits)}},
+	}
+
+	bw := bufio.NewWriter(w)
+	for _, family := range families {
+		name := metricPrefix + family.name
+
+		// Series only found in the previous file are kept
+		samples := maps.Clone(family.samples)
+		for series, value := range previous {
+			if l, ok := strings.CutPrefix(series, name); ok && (l == "" || l[0] == '{') {
+				samples[l] += value
+			}
+		}
+
+		fmt.Fprintf(bw, "# HELP %s %s\n# TYPE %s counter\n", name, family.help, name)
+		for _, l := range slices.Sorted(maps.Keys(samples)) {
+			fmt.Fprintf(bw, "%s%s %s\n", name, l, strconv.FormatFloat(samples[l], 'g', -1, 64))
+		}
+	}
+	return bw.Flush()
+}
+
+// parse reads the series of a file written by Write
+func parse(r io.Reader) (map[string]float64, error) {
+	series := map[string]float64{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		i := strings.LastIndexByte(line, ' ')
+		if i < 0 {
+			return nil, fmt.Errorf("invalid sample: %s", line)
+		}
+		value, err := strconv.ParseFloat(line[i+1:], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid sample: %s", line)
+		}
+		series[line[:i]] += value
+	}
+	return series, scanner.Err()
+}
+
+// labels formats name/value pairs as a Prometheus label set
+func labels(pairs ...string) string {
+	var b strings.Builder
+	b.WriteByte('{')
+	for i := 0; i < len(pairs); i += 2 {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		fmt.Fprintf(&b, "%s=%s", pairs[i], strconv.Quote(pairs[i+1]))
+	}
+	b.WriteByte('}')
+	return b.String()
+}
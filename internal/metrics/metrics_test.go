@@ -0,0 +1,59 @@
+package metrics
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+func TestWriteFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics.prom")
+	t.Setenv(FileEnvVar, path)
+
+	var diags diag.Diagnostics
+	diags.AddError("Invalid namespace", "namespace must be 1-8 characters")
+	RecordRead("brockhoff_context", 2*time.Second, nil)
+	RecordRead("brockhoff_context", time.Second, diags)
+
+	// A second plugin process adds to the values of the first
+	for range 2 {
+		if err := WriteFile(); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"# TYPE terraform_provider_context_reads_total counter\n",
+		`terraform_provider_context_reads_total{data_source="brockhoff_context"} 4` + "\n",
+		`terraform_provider_context_read_seconds_total{data_source="brockhoff_context"} 6` + "\n",
+		`terraform_provider_context_validation_failures_total{data_source="brockhoff_context",summary="Invalid namespace"} 2` + "\n",
+		"terraform_provider_context_git_lookups_total ",
+		"terraform_provider_context_group_directory_cache_hits_total ",
+		"terraform_provider_context_sanitize_cache_hits_total ",
+	} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("metrics file does not contain %q:\n%s", want, content)
+		}
+	}
+}
+
+func TestWriteFile_disabled(t *testing.T) {
+	t.Setenv(FileEnvVar, "")
+	if err := WriteFile(); err != nil {
+		t.Errorf("WriteFile() error = %v", err)
+	}
+}
+
+func TestParse_invalid(t *testing.T) {
+	if _, err := parse(strings.NewReader("terraform_provider_context_reads_total many\n")); err == nil {
+		t.Error("parse() error = nil, want error")
+	}
+}
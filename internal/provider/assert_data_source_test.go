@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccAssertDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "brockhoff" {
+  cloud_provider = "aws"
+  tag_prefix     = "bc-"
+}
+
+data "brockhoff_context" "test" {
+  namespace        = "myorg"
+  name             = "webapp"
+  environment      = "prod"
+  environment_name = "Production"
+  cost_center      = "cc-100"
+}
+
+data "brockhoff_assert" "test" {
+  name_prefix          = data.brockhoff_context.test.name_prefix
+  expected_name_prefix = "myorg-webapp-prod"
+  tags                 = data.brockhoff_context.test.tags
+  expected_tags = {
+    "bc-environment" = "Production"
+    "bc-costcenter"  = "cc-100"
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.brockhoff_assert.test", "id"),
+				),
+			},
+			{
+				Config: `
+provider "brockhoff" {
+  cloud_provider = "aws"
+  tag_prefix     = "bc-"
+}
+
+data "brockhoff_context" "test" {
+  namespace        = "myorg"
+  name             = "webapp"
+  environment      = "dev"
+  environment_name = "Development"
+}
+
+data "brockhoff_assert" "test" {
+  name_prefix          = data.brockhoff_context.test.name_prefix
+  expected_name_prefix = "myorg-webapp-prod"
+  tags                 = data.brockhoff_context.test.tags
+  expected_tags = {
+    "bc-environment" = "Production"
+  }
+}
+`,
+				ExpectError: regexp.MustCompile(`(?s)Assertion failed.*~ name_prefix: expected "myorg-webapp-prod", got\s+"myorg-webapp-dev"`),
+			},
+			{
+				Config: `
+data "brockhoff_assert" "test" {
+  tags          = { "bc-environment" = "Production", "bc-extra" = "x" }
+  expected_tags = { "bc-environment" = "Production" }
+  exact_tags    = true
+}
+`,
+				ExpectError: regexp.MustCompile(`\+ tags\["bc-extra"\]: unexpected "x"`),
+			},
+		},
+	})
+}
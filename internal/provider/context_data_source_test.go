@@ -0,0 +1,2029 @@
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/kbrockhoff/terraform-provider-context/internal/core"
+	ctxdatasource "github.com/kbrockhoff/terraform-provider-context/internal/datasource"
+)
+
+func TestAccContextDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  namespace                = "myorg"
+  name                     = "app"
+  environment              = "dev"
+  environment_name         = "Development"
+  source_repo_tags_enabled = false
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "id", "myorg-app-dev"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "name_prefix", "myorg-app-dev"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.%", "13"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.bc-environment", "Development"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.bc-availability", "preemptable"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.bc-managedby", "terraform"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.bc-costcenter", "N/A"),
+					resource.TestCheckNoResourceAttr("data.brockhoff_context.test", "tags.bc-sourcerepo"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "data_tags.%", "3"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "data_tags.bc-sensitivity", "confidential"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "data_tags.bc-dataowners", "N/A"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "context_output.enabled", "true"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "context_output.availability", "preemptable"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "context_output.managed_by", "terraform"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "context_output.managedby", "terraform"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "context_output.sensitivity", "confidential"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccContextDataSource_outputFormats(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  name                     = "app"
+  cost_center              = "cc1"
+  not_applicable_enabled   = false
+  source_repo_tags_enabled = false
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.%", "3"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags_as_list_of_maps.#", "3"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags_as_list_of_maps.0.key", "bc-availability"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags_as_list_of_maps.0.value", "preemptable"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags_as_kvp_list.#", "3"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags_as_kvp_list.1", "bc-costcenter=cc1"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags_as_comma_separated_string", "bc-availability=preemptable,bc-costcenter=cc1,bc-managedby=terraform"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "data_tags.%", "1"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "data_tags_as_list_of_maps.0.key", "bc-sensitivity"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "data_tags_as_kvp_list.0", "bc-sensitivity=confidential"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "data_tags_as_comma_separated_string", "bc-sensitivity=confidential"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccContextDataSource_parentInheritance(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "brockhoff_context" "parent" {
+  namespace                = "myorg"
+  environment              = "prod"
+  environment_name         = "Production"
+  cost_center              = "cc-100"
+  availability             = "dedicated"
+  source_repo_tags_enabled = false
+  additional_tags = {
+    team = "platform"
+  }
+}
+
+data "brockhoff_context" "child" {
+  parent_context = data.brockhoff_context.parent.context_output
+  name           = "api"
+  cost_center    = "cc-200"
+  additional_tags = {
+    component = "api"
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.brockhoff_context.parent", "name_prefix", "myorg-prod"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.child", "name_prefix", "myorg-api-prod"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.child", "tags.bc-environment", "Production"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.child", "tags.bc-availability", "dedicated"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.child", "tags.bc-costcenter", "cc-200"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.child", "tags.bc-team", "platform"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.child", "tags.bc-component", "api"),
+					resource.TestCheckNoResourceAttr("data.brockhoff_context.child", "tags.bc-sourcerepo"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.child", "context_output.namespace", "myorg"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.child", "context_output.cost_center", "cc-200"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.child", "context_output.additional_tags.%", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccContextDataSource_ephemeral(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  name                     = "app"
+  environment_type         = "Ephemeral"
+  lifecycle_action         = "delete"
+  source_repo_tags_enabled = false
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("data.brockhoff_context.test", "tags.bc-deletiondate", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.bc-expiryaction", "delete"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccContextDataSource_stackIdentity(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  name                     = "app"
+  stack_name               = "payments"
+  module_path              = "./modules/api"
+  source_repo_tags_enabled = false
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.bc-stack", "payments"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.bc-component", "api"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "context_output.stack_name", "payments"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccContextDataSource_toolingTags(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  name                     = "app"
+  tooling_tags_enabled     = true
+  source_repo_tags_enabled = false
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("data.brockhoff_context.test", "tags.bc-terraformversion", regexp.MustCompile(`^\d+\.\d+\.\d+`)),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.bc-contextproviderversion", "test"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccContextDataSource_nameDelimiter(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "brockhoff_context" "parent" {
+  namespace      = "myorg"
+  environment    = "prod"
+  name_delimiter = "_"
+}
+
+data "brockhoff_context" "test" {
+  parent_context = data.brockhoff_context.parent.context_output
+  name           = "app"
+}
+
+data "brockhoff_context" "storage" {
+  parent_context = data.brockhoff_context.parent.context_output
+  name           = "logs"
+  name_delimiter = ""
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "name_prefix", "myorg_app_prod"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.storage", "name_prefix", "myorglogsprod"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.storage", "context_output.name_delimiter", ""),
+				),
+			},
+		},
+	})
+}
+
+func TestAccContextDataSource_tenantAndAttributes(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  namespace                = "myorg"
+  tenant                   = "acme"
+  name                     = "app"
+  environment              = "prod"
+  attributes               = ["blue"]
+  source_repo_tags_enabled = false
+}
+
+data "brockhoff_context" "ordered" {
+  parent_context = data.brockhoff_context.test.context_output
+  name           = "app"
+  label_order    = ["tenant", "name", "environment"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "name_prefix", "myorg-acme-app-prod-blue"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.bc-tenant", "acme"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.bc-attributes", "blue"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.ordered", "name_prefix", "acme-app-prod"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccContextDataSource_namePrefixShort(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  namespace   = "myorg"
+  name        = "webapp"
+  environment = "prod"
+}
+
+data "brockhoff_context" "fits" {
+  namespace                = "myorg"
+  name                     = "webapp"
+  environment              = "prod"
+  name_prefix_short_length = 20
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "name_prefix_short", "myorg-36a3e2"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.fits", "name_prefix_short", "myorg-webapp-prod"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccContextDataSource_reservedWords(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "brockhoff_context" "parent" {
+  namespace            = "myorg"
+  environment          = "prod"
+  reserved_words       = ["legacy"]
+  reserved_word_action = "remove"
+}
+
+data "brockhoff_context" "test" {
+  parent_context = data.brockhoff_context.parent.context_output
+  name           = "azurelegacy"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "name_prefix", "myorg-prod"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "context_output.reserved_words.0", "legacy"),
+				),
+			},
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  namespace            = "myorg"
+  name                 = "awsapp"
+  reserved_word_action = "error"
+}
+`,
+				ExpectError: regexp.MustCompile(`Reserved word in name prefix`),
+			},
+		},
+	})
+}
+
+func TestAccContextDataSource_prEnvironment(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "brockhoff_context" "parent" {
+  namespace        = "myorg"
+  environment      = "dev"
+  environment_type = "Ephemeral"
+  lifecycle_action = "delete"
+  pr_number        = 42
+}
+
+data "brockhoff_context" "test" {
+  parent_context = data.brockhoff_context.parent.context_output
+  name           = "webapp"
+}
+
+data "brockhoff_context" "branch" {
+  namespace        = "myorg"
+  name             = "webapp"
+  environment      = "e"
+  environment_type = "Ephemeral"
+  lifecycle_action = "delete"
+  ephemeral_suffix = "Fix/42"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.brockhoff_context.parent", "context_output.environment", "dev-pr42"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "name_prefix", "myorg-webapp-dev-pr42"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.branch", "name_prefix", "myorg-webapp-e-fix-42"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccContextDataSource_budget(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  name            = "app"
+  cost_center     = "cc-100"
+  monthly_budget  = 2500
+  budget_currency = "EUR"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.bc-monthlybudget", "2500"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.bc-budgetcurrency", "EUR"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "aws_budgets_filter.TagKeyValue.0", "user:bc-costcenter$cc-100"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "context_output.monthly_budget", "2500"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "focus_tags.x_CostCenter", "cc-100"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "focus_tags.x_Budget", "2500"),
+					resource.TestCheckNoResourceAttr("data.brockhoff_context.test", "focus_tags.x_Owner"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccContextDataSource_iamTagConditions(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  name        = "app"
+  cost_center = "cc-100"
+  stack_name  = "payments"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "iam_resource_tag_condition", `{"StringEquals":{"aws:ResourceTag/bc-costcenter":"cc-100","aws:ResourceTag/bc-stack":"payments"}}`),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "iam_request_tag_condition", `{"StringEquals":{"aws:RequestTag/bc-costcenter":"cc-100","aws:RequestTag/bc-stack":"payments"}}`),
+				),
+			},
+		},
+	})
+}
+
+func TestAccContextDataSource_sanitizationMode(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "brockhoff_context" "parent" {
+  cost_center       = "R&D"
+  sanitization_mode = "warn"
+}
+
+data "brockhoff_context" "test" {
+  parent_context = data.brockhoff_context.parent.context_output
+  name           = "app"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.bc-costcenter", "R_D"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "context_output.sanitization_mode", "warn"),
+				),
+			},
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  name              = "app"
+  cost_center       = "R&D"
+  sanitization_mode = "error"
+}
+`,
+				ExpectError: regexp.MustCompile(`contains characters not allowed`),
+			},
+		},
+	})
+}
+
+func TestAccContextDataSource_lengthOverflow(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  name            = "app"
+  length_overflow = "truncate_with_ellipsis_hash"
+  additional_tags = {
+    description = join("", [for i in range(70) : "a"])
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.bc-description", "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa...6bd5e5"),
+				),
+			},
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  name            = "app"
+  length_overflow = "error"
+  additional_tags = {
+    description = join("", [for i in range(70) : "a"])
+  }
+}
+`,
+				ExpectError: regexp.MustCompile(`exceeding the cloud provider limit`),
+			},
+		},
+	})
+}
+
+func TestAccContextDataSource_notApplicableFields(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "brockhoff_context" "parent" {
+  na_value_override = "none"
+  na_fields         = ["costcenter", "dataowners"]
+}
+
+data "brockhoff_context" "test" {
+  parent_context = data.brockhoff_context.parent.context_output
+  name           = "app"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.bc-costcenter", "none"),
+					resource.TestCheckNoResourceAttr("data.brockhoff_context.test", "tags.bc-securityreview"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "data_tags.bc-dataowners", "none"),
+					resource.TestCheckNoResourceAttr("data.brockhoff_context.test", "data_tags.bc-dataregulations"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "context_output.na_fields.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccContextDataSource_requiredTags(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  name                     = "app"
+  cost_center              = "cc-100"
+  stack_name               = "payments"
+  not_applicable_enabled   = false
+  source_repo_tags_enabled = false
+  additional_tags = {
+    team = "platform"
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "required_tags.%", "3"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "required_tags.bc-costcenter", "cc-100"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "required_tags.bc-managedby", "terraform"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "optional_tags.%", "2"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "optional_tags.bc-stack", "payments"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "optional_tags.bc-team", "platform"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags_unprefixed.%", "5"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags_unprefixed.costcenter", "cc-100"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags_unprefixed.team", "platform"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "inheritable_tags.%", "3"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "inheritable_tags.bc-stack", "payments"),
+					resource.TestCheckNoResourceAttr("data.brockhoff_context.test", "inheritable_tags.bc-availability"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "provider_default_tags.%", "3"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "provider_default_tags.bc-costcenter", "cc-100"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccContextDataSource_regulationTags(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  name                    = "app"
+  data_regs               = ["GDPR", "PCI DSS"]
+  regulation_tags_enabled = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "data_tags.bc-dataregulations", "GDPR;PCI DSS"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "data_tags.bc-reg-gdpr", "true"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "data_tags.bc-reg-pci-dss", "true"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "context_output.regulation_tags_enabled", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccContextDataSource_listDelimiter(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  name      = "app"
+  data_regs = ["GDPR", "SOX;404"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "list_delimiter", ";"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "data_tags.bc-dataregulations", "GDPR;SOX_404"),
+				),
+			},
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  name                = "app"
+  data_regs           = ["GDPR", "PCI DSS"]
+  list_join_delimiter = "|"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "list_delimiter", "|"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "data_tags.bc-dataregulations", "GDPR|PCI DSS"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "context_output.list_join_delimiter", "|"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccContextDataSource_azurePolicyInheritance(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "brockhoff" {
+  cloud_provider = "az"
+}
+
+data "brockhoff_context" "test" {
+  name                             = "app"
+  cost_center                      = "cc-100"
+  stack_name                       = "payments"
+  not_applicable_enabled           = false
+  source_repo_tags_enabled         = false
+  azure_policy_inheritance_enabled = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.%", "1"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.bc-availability", "preemptable"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "inheritable_tags.%", "3"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "inheritable_tags.bc-costcenter", "cc-100"),
+				),
+			},
+			{
+				Config: `
+provider "brockhoff" {
+  cloud_provider = "az"
+}
+
+data "brockhoff_context" "test" {
+  name                             = "app"
+  cost_center                      = "cc-100"
+  stack_name                       = "payments"
+  not_applicable_enabled           = false
+  source_repo_tags_enabled         = false
+  azure_policy_inheritance_enabled = true
+  azure_policy_inherited_tags      = ["costcenter"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.%", "3"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.bc-stack", "payments"),
+					resource.TestCheckNoResourceAttr("data.brockhoff_context.test", "tags.bc-costcenter"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccContextDataSource_managedByAlias(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "brockhoff_context" "parent" {
+  name      = "platform"
+  managedby = "atlantis"
+}
+
+data "brockhoff_context" "child" {
+  parent_context = data.brockhoff_context.parent.context_output
+  name           = "api"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.brockhoff_context.parent", "tags.bc-managedby", "atlantis"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.parent", "context_output.managed_by", "atlantis"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.child", "tags.bc-managedby", "atlantis"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.child", "context_output.managedby", "atlantis"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccContextDataSource_ownerNormalization(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  name           = "app"
+  product_owners = ["Owner@Example.com", " lead@example.com", "owner@example.com"]
+  code_owners    = ["dev@example.com", "DEV@example.com"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.bc-productowners", "lead@example.com;owner@example.com"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.bc-codeowners", "dev@example.com"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "context_output.product_owners.#", "2"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "context_output.product_owners.0", "lead@example.com"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccContextDataSource_tokenizeFields(t *testing.T) {
+	t.Setenv("CONTEXT_PROVIDER_TOKENIZATION_KEY", "secret")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "brockhoff_context" "parent" {
+  itsm_system_id   = "SYS-1"
+  itsm_instance_id = "INC-1"
+  tokenize_fields  = ["instanceid"]
+}
+
+data "brockhoff_context" "test" {
+  parent_context = data.brockhoff_context.parent.context_output
+  name           = "app"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.bc-instanceid", "tok-975668c751774afe"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.bc-systemid", "SYS-1"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "context_output.tokenize_fields.0", "instanceid"),
+				),
+			},
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  name            = "app"
+  tokenize_fields = ["environment"]
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid tokenize_fields`),
+			},
+		},
+	})
+}
+
+func TestAccContextDataSource_contextDigest(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "brockhoff" {
+  cloud_provider = "aws"
+}
+
+data "brockhoff_context" "test" {
+  name                     = "app"
+  source_repo_tags_enabled = false
+  digest_tag_enabled       = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("data.brockhoff_context.test", "context_digest", regexp.MustCompile(`^[0-9a-f]{64}$`)),
+					resource.TestCheckResourceAttrPair("data.brockhoff_context.test", "context_digest", "data.brockhoff_context.test", "tags.bc-contextdigest"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccContextDataSource_provenanceTags(t *testing.T) {
+	// GitHub Actions run of a job without the id-token: write permission
+	t.Setenv("GITHUB_ACTIONS", "true")
+	t.Setenv("GITHUB_SERVER_URL", "https://github.com")
+	t.Setenv("GITHUB_REPOSITORY", "example/infra")
+	t.Setenv("GITHUB_WORKFLOW_REF", "example/infra/.github/workflows/deploy.yml@refs/heads/main")
+	t.Setenv("GITHUB_RUN_ID", "123")
+	t.Setenv("GITHUB_RUN_ATTEMPT", "1")
+	t.Setenv("ACTIONS_ID_TOKEN_REQUEST_URL", "")
+	core.ClearProvenanceCache()
+	t.Cleanup(core.ClearProvenanceCache)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "brockhoff" {
+  cloud_provider = "aws"
+}
+
+data "brockhoff_context" "test" {
+  name                    = "app"
+  provenance_tags_enabled = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.bc-builderid", "https://github.com/example/infra/.github/workflows/deploy.yml@refs/heads/main"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.bc-buildinvocation", "https://github.com/example/infra/actions/runs/123/attempts/1"),
+					resource.TestCheckNoResourceAttr("data.brockhoff_context.test", "tags.bc-buildersubject"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccContextDataSource_largeAdditionalTags(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// Over the soft limits, reported as warnings only
+				Config: `
+data "brockhoff_context" "test" {
+  name              = "app"
+  sanitization_mode = "warn"
+  additional_tags   = { for i in range(2000) : "tag${i}" => "value&${i}" }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "additional_tags.%", "2000"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.bc-tag1999", "value_1999"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccContextDataSource_caseInsensitiveKeys(t *testing.T) {
+	config := func(caseInsensitiveKeys bool) string {
+		return fmt.Sprintf(`
+data "brockhoff_context" "parent" {
+  name            = "platform"
+  additional_tags = { Team = "platform", Tier = "web" }
+}
+
+data "brockhoff_context" "test" {
+  parent_context        = data.brockhoff_context.parent.context_output
+  name                  = "app"
+  additional_tags       = { team = "payments" }
+  case_insensitive_keys = %t
+}
+`, caseInsensitiveKeys)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.bc-Team", "platform"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.bc-team", "payments"),
+				),
+			},
+			{
+				Config: config(true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("data.brockhoff_context.test", "tags.bc-Team"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.bc-team", "payments"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.bc-Tier", "web"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "context_output.case_insensitive_keys", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccContextDataSource_invalidInputs(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  namespace = "ThisIsTooLong"
+  name      = "app"
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid namespace`),
+			},
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  name           = "app"
+  product_owners = ["not-an-email"]
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid product_owners`),
+			},
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  name             = "app"
+  environment_type = "Ephemeral"
+}
+`,
+				ExpectError: regexp.MustCompile(`Missing lifecycle_action`),
+			},
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  name             = "app"
+  lifecycle_action = "archive"
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid lifecycle_action`),
+			},
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  name           = "app"
+  name_delimiter = "."
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid name_delimiter`),
+			},
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  name        = "app"
+  label_order = ["name", "stage"]
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid label_order`),
+			},
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  name                     = "app"
+  name_prefix_short_length = 4
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid name_prefix_short_length`),
+			},
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  name                 = "app"
+  reserved_word_action = "strip"
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid reserved_word_action`),
+			},
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  name              = "app"
+  sanitization_mode = "strict"
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid sanitization_mode`),
+			},
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  name            = "app"
+  length_overflow = "ignore"
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid length_overflow`),
+			},
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  name      = "app"
+  na_fields = ["!tenant"]
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid na_fields`),
+			},
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  name                = "app"
+  list_join_delimiter = "and"
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid list_join_delimiter`),
+			},
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  name             = "app"
+  environment_type = "Ephemeral"
+  lifecycle_action = "delete"
+  pr_number        = -1
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid pr_number`),
+			},
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  name            = "app"
+  monthly_budget  = 100
+  budget_currency = "usd"
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid budget_currency`),
+			},
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  name       = "app"
+  managed_by = "terraform"
+  managedby  = "pulumi"
+}
+`,
+				ExpectError: regexp.MustCompile(`Conflicting managed_by`),
+			},
+		},
+	})
+}
+
+func TestAccContextDataSource_contextOutputMap(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  namespace       = "ex"
+  environment     = "dev"
+  product_owners  = ["a@example.com", "b@example.com"]
+  monthly_budget  = 250.5
+  additional_tags = { Team = "payments" }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "context_output_map.namespace", "ex"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "context_output_map.enabled", "true"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "context_output_map.product_owners", "a@example.com,b@example.com"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "context_output_map.monthly_budget", "250.5"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "context_output_map.additional_tags.Team", "payments"),
+					resource.TestCheckNoResourceAttr("data.brockhoff_context.test", "context_output_map.managedby"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccContextDataSource_tagFilter(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  environment    = "dev"
+  product_owners = ["a@example.com"]
+  code_owners    = ["b@example.com"]
+  cost_center    = "cc-100"
+  tag_filter     = ["*owners", "/^cost/"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags_filtered.%", "3"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags_filtered.bc-productowners", "a@example.com"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags_filtered.bc-codeowners", "b@example.com"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags_filtered.bc-costcenter", "cc-100"),
+				),
+			},
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  tag_filter = ["[owners"]
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid tag_filter`),
+			},
+		},
+	})
+}
+
+func TestAccContextDataSource_tagSummary(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  environment              = "dev"
+  data_owners              = ["data@example.com"]
+  source_repo_tags_enabled = false
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.brockhoff_context.test", "tag_count", "data.brockhoff_context.test", "tags.%"),
+					resource.TestCheckResourceAttrPair("data.brockhoff_context.test", "data_tag_count", "data.brockhoff_context.test", "data_tags.%"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "has_owner_tags", "true"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "has_source_tags", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccContextDataSource_compliance(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  environment = "dev"
+  cost_center = "cc-100"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "compliance.owners_present", "false"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "compliance.cost_center_present", "true"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "compliance.expiry_set_for_ephemeral", "true"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "compliance.within_tag_limits", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccContextDataSource_observabilityTags(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  environment     = "dev"
+  additional_tags = { Team = "Payments Core" }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckTypeSetElemAttr("data.brockhoff_context.test", "tags_as_dd_tags.*", "bc-team:payments_core"),
+					resource.TestCheckTypeSetElemAttr("data.brockhoff_context.test", "tags_as_dd_tags.*", "bc-managedby:terraform"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags_as_newrelic_tags.bc-Team", "Payments Core"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccContextDataSource_eventFields(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  namespace       = "ex"
+  environment     = "dev"
+  additional_tags = { Team = "payments" }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "event_fields.context.namespace", "ex"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "event_fields.context.name_prefix", "ex-dev"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "event_fields.context.additional_tags.team", "payments"),
+					resource.TestCheckNoResourceAttr("data.brockhoff_context.test", "event_fields.context.tenant"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccContextDataSource_tagSchemaVersion(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "brockhoff_context" "org" {
+  namespace          = "ex"
+  tag_schema_version = 1
+}
+
+data "brockhoff_context" "test" {
+  parent_context = data.brockhoff_context.org.context_output
+  environment    = "dev"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "context_output.tag_schema_version", "1"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.bc-managedby", "terraform"),
+				),
+			},
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  namespace          = "ex"
+  environment        = "dev"
+  tag_schema_version = 99
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid tag_schema_version`),
+			},
+		},
+	})
+}
+
+func TestAccContextDataSource_legacyTagMap(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  namespace      = "ex"
+  environment    = "dev"
+  cost_center    = "cc-100"
+  sensitivity    = "internal"
+  legacy_tag_map = { costcenter = "CostCenter", sensitivity = "DataClassification" }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.bc-costcenter", "cc-100"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.CostCenter", "cc-100"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "data_tags.DataClassification", "internal"),
+				),
+			},
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  namespace         = "ex"
+  environment       = "dev"
+  cost_center       = "cc-100"
+  legacy_tag_map    = { costcenter = "CostCenter" }
+  legacy_tags_until = "2020-01-31"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("data.brockhoff_context.test", "tags.CostCenter"),
+				),
+			},
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  namespace         = "ex"
+  environment       = "dev"
+  legacy_tag_map    = { costcenter = "CostCenter" }
+  legacy_tags_until = "31/01/2020"
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid legacy_tags_until`),
+			},
+		},
+	})
+}
+
+func TestAccContextDataSource_orgHierarchy(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "brockhoff_context" "parent" {
+  namespace     = "ex"
+  environment   = "dev"
+  business_unit = "retail"
+  division      = "payments"
+}
+
+data "brockhoff_context" "test" {
+  parent_context = data.brockhoff_context.parent.context_output
+  name           = "api"
+  portfolio      = "cards"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.bc-businessunit", "retail"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.bc-division", "payments"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.bc-portfolio", "cards"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "focus_tags.x_BusinessUnit", "retail"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "context_output.portfolio", "cards"),
+					resource.TestCheckNoResourceAttr("data.brockhoff_context.parent", "tags.bc-portfolio"),
+				),
+			},
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  namespace     = "ex"
+  environment   = "dev"
+  business_unit = "Retail Banking"
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid business_unit`),
+			},
+		},
+	})
+}
+
+func TestAccContextDataSource_saasTenancy(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  namespace   = "ex"
+  name        = "api"
+  environment = "prod"
+  tenant_id   = "3f2a9c1e-0042"
+  customer    = "globex"
+  label_order = ["namespace", "customer", "name", "environment"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "name_prefix", "ex-globex-api-prod"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.bc-tenantid", "3f2a9c1e-0042"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.bc-customer", "globex"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "focus_tags.x_Customer", "globex"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "context_output.tenant_id", "3f2a9c1e-0042"),
+				),
+			},
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  namespace   = "ex"
+  name        = "api"
+  environment = "prod"
+  customer    = "globex"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "name_prefix", "ex-api-prod"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.bc-customer", "globex"),
+				),
+			},
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  namespace   = "ex"
+  environment = "prod"
+  customer    = "Globex Corporation"
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid customer`),
+			},
+		},
+	})
+}
+
+func TestAccContextDataSource_application(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  namespace   = "ex"
+  environment = "dev"
+  application = "checkout"
+  service     = "payments-api"
+  tier        = "app"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.bc-application", "checkout"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.bc-service", "payments-api"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.bc-tier", "app"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "inheritable_tags.bc-application", "checkout"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "context_output.tier", "app"),
+				),
+			},
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  namespace   = "ex"
+  environment = "dev"
+  tier        = "cache"
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid tier`),
+			},
+		},
+	})
+}
+
+func TestAccContextDataSource_maintenanceWindow(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "brockhoff" {
+  cloud_provider = "aws"
+}
+
+data "brockhoff_context" "test" {
+  namespace          = "ex"
+  environment        = "dev"
+  maintenance_window = "sun:03:00-sun:05:00"
+  patch_group        = "web-servers"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.bc-maintenancewindow", "sun:03:00-sun:05:00"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.bc-patchgroup", "web-servers"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.PatchGroup", "web-servers"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "context_output.maintenance_window", "sun:03:00-sun:05:00"),
+				),
+			},
+			{
+				Config: `
+provider "brockhoff" {
+  cloud_provider = "az"
+}
+
+data "brockhoff_context" "test" {
+  namespace          = "ex"
+  environment        = "dev"
+  maintenance_window = "0 3 * * SUN"
+  sanitization_mode  = "error"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.bc-maintenancewindow", "0_3_*_*_SUN"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "context_output.maintenance_window", "0 3 * * SUN"),
+				),
+			},
+			{
+				Config: `
+provider "brockhoff" {
+  cloud_provider = "gcp"
+}
+
+data "brockhoff_context" "test" {
+  namespace          = "ex"
+  environment        = "dev"
+  maintenance_window = "sun:03:00-sun:05:00"
+  sanitization_mode  = "error"
+}
+`,
+				Check: resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.bc-maintenancewindow", "sun_03_00-sun_05_00"),
+			},
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  namespace          = "ex"
+  environment        = "dev"
+  maintenance_window = "Sunday 3am"
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid maintenance_window`),
+			},
+		},
+	})
+}
+
+func TestAccContextDataSource_complianceProfile(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  namespace          = "ex"
+  environment        = "prod"
+  compliance_profile = "hipaa"
+  cost_center        = "cc-100"
+  product_owners     = ["product@example.com"]
+  data_owners        = ["data@example.com"]
+  security_review    = "SR-42"
+  privacy_review     = "PR-7"
+  data_retention     = "P6Y"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "data_tags.bc-sensitivity", "restricted"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "data_tags.bc-dataregulations", "HIPAA"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "data_tags.bc-reg-hipaa", "true"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "context_output.compliance_profile", "hipaa"),
+				),
+			},
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  namespace          = "ex"
+  environment        = "prod"
+  compliance_profile = "pci"
+  cost_center        = "N/A"
+  sensitivity        = "internal"
+}
+`,
+				ExpectError: regexp.MustCompile(`cost_center must not be the N/A placeholder`),
+			},
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  namespace          = "ex"
+  environment        = "prod"
+  compliance_profile = "sox"
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid compliance_profile`),
+			},
+		},
+	})
+}
+
+func TestAccContextDataSource_dataResidency(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  namespace                = "ex"
+  environment              = "prod"
+  region                   = "westeurope"
+  data_residency           = ["EU"]
+  sovereignty_requirements = ["EUCS"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.bc-region", "westeurope"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "data_tags.bc-dataresidency", "EU"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "data_tags.bc-sovereignty", "EUCS"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "context_output.data_residency.0", "EU"),
+				),
+			},
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  namespace      = "ex"
+  environment    = "prod"
+  region         = "uksouth"
+  data_residency = ["EU"]
+}
+`,
+				ExpectError: regexp.MustCompile(`Region outside data_residency`),
+			},
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  namespace      = "ex"
+  environment    = "prod"
+  data_residency = ["Germany"]
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid data_residency`),
+			},
+		},
+	})
+}
+
+func TestAccContextDataSource_dataRetention(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  namespace      = "ex"
+  environment    = "prod"
+  sensitivity    = "restricted"
+  data_retention = "P7Y"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "data_tags.bc-dataretention", "P7Y"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "context_output.data_retention", "P7Y"),
+				),
+			},
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  namespace      = "ex"
+  environment    = "prod"
+  data_retention = "7 years"
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid data_retention`),
+			},
+			{
+				Config: `
+provider "brockhoff" {
+  strict_mode = true
+}
+
+data "brockhoff_context" "test" {
+  namespace   = "ex"
+  environment = "prod"
+  sensitivity = "restricted"
+}
+`,
+				ExpectError: regexp.MustCompile(`data_retention is required for restricted data`),
+			},
+		},
+	})
+}
+
+func TestAccContextDataSource_additionalTypedTags(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  namespace   = "ex"
+  environment = "dev"
+  additional_typed_tags = {
+    replicas = { value = 3, type = "number" }
+    ratio    = { value = "01.50", type = "number" }
+    backup   = { value = true, type = "bool" }
+    team     = { value = "core" }
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.bc-replicas", "3"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.bc-ratio", "1.5"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.bc-backup", "true"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.bc-team", "core"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags_as_numbers.%", "2"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags_as_numbers.bc-ratio", "1.5"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags_as_bools.bc-backup", "true"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "context_output.additional_typed_tags.replicas.type", "number"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "context_output_map.additional_typed_tags.backup.value", "true"),
+				),
+			},
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  namespace   = "ex"
+  environment = "dev"
+  additional_typed_tags = {
+    replicas = { value = "three", type = "number" }
+  }
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid additional_typed_tags`),
+			},
+		},
+	})
+}
+
+func TestAccContextDataSource_rawTags(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "brockhoff" {
+  cloud_provider = "gcp"
+}
+
+data "brockhoff_context" "test" {
+  namespace   = "ex"
+  environment = "dev"
+  raw_tags = {
+    "Vendor:ID" = "ACME Corp/42"
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.Vendor:ID", "ACME Corp/42"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "context_output.raw_tags.Vendor:ID", "ACME Corp/42"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccContextDataSource_decommissionApproved(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  name             = "app"
+  environment_type = "Production"
+  deletion_date    = "2030-06-30"
+}
+`,
+				ExpectError: regexp.MustCompile(`requires decommission_approved = true`),
+			},
+			{
+				Config: `
+data "brockhoff_context" "parent" {
+  name                  = "parent"
+  environment_type      = "Production"
+  deletion_date         = "2030-06-30"
+  decommission_approved = true
+}
+
+data "brockhoff_context" "test" {
+  parent_context = data.brockhoff_context.parent.context_output
+  name           = "app"
+}
+`,
+				// Approval is not inherited with the deletion date
+				ExpectError: regexp.MustCompile(`Decommission not approved`),
+			},
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  name                     = "app"
+  environment_type         = "MissionCritical"
+  availability             = "dedicated"
+  deletion_date            = "2030-06-30"
+  decommission_approved    = true
+  source_repo_tags_enabled = false
+}
+`,
+				Check: resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.bc-deletiondate", "2030-06-30"),
+			},
+		},
+	})
+}
+
+func TestAccContextDataSource_surrogateID(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "brockhoff_context" "network" {
+  namespace       = "myorg"
+  environment     = "dev"
+  additional_tags = { layer = "network" }
+}
+
+data "brockhoff_context" "storage" {
+  namespace       = "myorg"
+  environment     = "dev"
+  additional_tags = { layer = "storage" }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.brockhoff_context.network", "name_prefix", "myorg-dev"),
+					resource.TestMatchResourceAttr("data.brockhoff_context.network", "id", regexp.MustCompile(`^myorg-dev-[0-9a-f]{16}$`)),
+					resource.TestMatchResourceAttr("data.brockhoff_context.storage", "id", regexp.MustCompile(`^myorg-dev-[0-9a-f]{16}$`)),
+					func(s *terraform.State) error {
+						network := s.RootModule().Resources["data.brockhoff_context.network"].Primary.ID
+						storage := s.RootModule().Resources["data.brockhoff_context.storage"].Primary.ID
+						if network == storage {
+							return fmt.Errorf("contexts without a name share the id %s", network)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccContextDataSource_contextOutputUnset(t *testing.T) {
+	var parentChecks, childChecks []resource.TestCheckFunc
+	for name, attrType := range ctxdatasource.ContextAttributeTypes() {
+		// Bools always have a resolved value
+		if attrType == types.BoolType {
+			continue
+		}
+		key := "context_output." + name
+		switch attrType.(type) {
+		case types.ListType:
+			key += ".#"
+		case types.MapType:
+			key += ".%"
+		}
+		parentChecks = append(parentChecks, resource.TestCheckNoResourceAttr("data.brockhoff_context.parent", key))
+		if name != "namespace" {
+			childChecks = append(childChecks, resource.TestCheckNoResourceAttr("data.brockhoff_context.child", key))
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "brockhoff_context" "parent" {
+  name                     = "platform"
+  source_repo_tags_enabled = false
+}
+
+data "brockhoff_context" "child" {
+  parent_context = data.brockhoff_context.parent.context_output
+  namespace      = "myorg"
+  name           = "app"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(append(append(parentChecks, childChecks...),
+					resource.TestCheckNoResourceAttr("data.brockhoff_context.parent", "context_output_map.tenant"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.child", "context_output.namespace", "myorg"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.child", "context_output.sensitivity", "confidential"),
+				)...),
+			},
+		},
+	})
+}
+
+func TestAccContextDataSource_unknownInputs(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// An empty name would fail validation, so the unknown name
+				// must not be read as empty during plan
+				Config: `
+resource "terraform_data" "upstream" {
+  input = "app"
+}
+
+data "brockhoff_context" "parent" {
+  name                     = terraform_data.upstream.output
+  environment              = "dev"
+  source_repo_tags_enabled = false
+}
+
+data "brockhoff_context" "child" {
+  parent_context = data.brockhoff_context.parent.context_output
+  name           = "api"
+  depends_on     = [terraform_data.upstream]
+}
+
+data "brockhoff_merge" "test" {
+  contexts = [data.brockhoff_context.parent.context_output]
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectUnknownValue("data.brockhoff_context.parent", tfjsonpath.New("name_prefix")),
+						plancheck.ExpectUnknownValue("data.brockhoff_context.parent", tfjsonpath.New("tags")),
+						plancheck.ExpectUnknownValue("data.brockhoff_context.child", tfjsonpath.New("context_output")),
+						plancheck.ExpectUnknownValue("data.brockhoff_merge.test", tfjsonpath.New("context_output")),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.brockhoff_context.parent", "name_prefix", "app-dev"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.child", "name_prefix", "api-dev"),
+					resource.TestCheckResourceAttr("data.brockhoff_merge.test", "context_output.environment", "dev"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccContextDataSource_names(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  namespace   = "myorg"
+  name        = "app"
+  environment = "dev"
+  names = {
+    api    = "api"
+    worker = "worker-queue-processor"
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "name_prefix", "myorg-app-dev"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "named_outputs.%", "2"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "named_outputs.api.name_prefix", "myorg-api-dev"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "named_outputs.worker.name_prefix", "myorg-worker-queue-p-dev"),
+					resource.TestMatchResourceAttr("data.brockhoff_context.test", "named_outputs.worker.name_prefix_short", regexp.MustCompile(`^myorg-[0-9a-f]{6}$`)),
+				),
+			},
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  namespace            = "myorg"
+  environment          = "dev"
+  reserved_word_action = "error"
+  names = {
+    portal = "azure"
+  }
+}
+`,
+				ExpectError: regexp.MustCompile(`names\["portal"\]`),
+			},
+		},
+	})
+}
+
+func TestAccContextDataSource_namesAdditionalTags(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  namespace       = "myorg"
+  environment     = "dev"
+  additional_tags = { team = "platform" }
+  names = {
+    api = "api"
+    db  = "db"
+  }
+  names_additional_tags = {
+    db = { backup = "daily", team = "data" }
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags_by_name.%", "2"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags_by_name.api.bc-team", "platform"),
+					resource.TestCheckNoResourceAttr("data.brockhoff_context.test", "tags_by_name.api.bc-backup"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags_by_name.db.bc-team", "data"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags_by_name.db.bc-backup", "daily"),
+					resource.TestCheckNoResourceAttr("data.brockhoff_context.test", "tags.bc-backup"),
+				),
+			},
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  namespace = "myorg"
+  names     = { api = "api" }
+  names_additional_tags = {
+    web = { tier = "web" }
+  }
+}
+`,
+				ExpectError: regexp.MustCompile(`"web" is not a key of names`),
+			},
+		},
+	})
+}
+
+func TestAccContextDataSource_nameAvailabilityCheck(t *testing.T) {
+	// myorg-api-dev belongs to another account
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/myorg-api-dev":
+			w.WriteHeader(http.StatusForbidden)
+		case "/myorg-app-dev":
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIDEXAMPLE")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	t.Setenv("AWS_REGION", "us-east-1")
+	t.Setenv("AWS_ENDPOINT_URL_S3", server.URL)
+
+	config := func(action string, names string) string {
+		return fmt.Sprintf(`
+provider "brockhoff" {
+  cloud_provider = "aws"
+}
+
+data "brockhoff_context" "test" {
+  namespace               = "myorg"
+  name                    = "app"
+  environment             = "dev"
+  name_availability_check = %q
+  names                   = %s
+}
+`, action, names)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("error", `{ worker = "worker" }`),
+				Check:  resource.TestCheckResourceAttr("data.brockhoff_context.test", "name_prefix", "myorg-app-dev"),
+			},
+			{
+				Config: config("warn", `{ api = "api" }`),
+				Check:  resource.TestCheckResourceAttr("data.brockhoff_context.test", "named_outputs.api.name_prefix", "myorg-api-dev"),
+			},
+			{
+				Config:      config("error", `{ api = "api" }`),
+				ExpectError: regexp.MustCompile(`Name not available`),
+			},
+		},
+	})
+}
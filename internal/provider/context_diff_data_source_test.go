@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccContextDiffDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "brockhoff_context" "current" {
+  namespace       = "ex"
+  environment     = "dev"
+  cost_center     = "cc-100"
+  additional_tags = { Team = "payments" }
+}
+
+data "brockhoff_context" "proposed" {
+  namespace       = "ex"
+  cost_center     = "cc-200"
+  sensitivity     = "confidential"
+  additional_tags = { Team = "payments" }
+}
+
+data "brockhoff_context_diff" "test" {
+  current  = data.brockhoff_context.current.context_output
+  proposed = data.brockhoff_context.proposed.context_output
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.brockhoff_context_diff.test", "has_changes", "true"),
+					resource.TestCheckResourceAttr("data.brockhoff_context_diff.test", "added.#", "1"),
+					resource.TestCheckResourceAttr("data.brockhoff_context_diff.test", "added.0", "sensitivity"),
+					resource.TestCheckResourceAttr("data.brockhoff_context_diff.test", "removed.#", "1"),
+					resource.TestCheckResourceAttr("data.brockhoff_context_diff.test", "removed.0", "environment"),
+					resource.TestCheckResourceAttr("data.brockhoff_context_diff.test", "changed.#", "1"),
+					resource.TestCheckResourceAttr("data.brockhoff_context_diff.test", "changes.cost_center.current", "cc-100"),
+					resource.TestCheckResourceAttr("data.brockhoff_context_diff.test", "changes.cost_center.proposed", "cc-200"),
+					resource.TestCheckNoResourceAttr("data.brockhoff_context_diff.test", "changes.additional_tags.Team"),
+				),
+			},
+		},
+	})
+}
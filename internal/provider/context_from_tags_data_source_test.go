@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccContextFromTagsDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "brockhoff_context_from_tags" "test" {
+  tags = {
+    "bc-environment"   = "Production"
+    "bc-costcenter"    = "cc-100"
+    "bc-availability"  = "dedicated"
+    "bc-productowners" = "owner@example.com;lead@example.com"
+    "bc-sensitivity"   = "N/A"
+    "bc-team"          = "payments"
+    "Name"             = "legacy"
+  }
+}
+
+data "brockhoff_context" "child" {
+  parent_context           = data.brockhoff_context_from_tags.test.context_output
+  namespace                = "myorg"
+  name                     = "api"
+  source_repo_tags_enabled = false
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.brockhoff_context_from_tags.test", "id"),
+					resource.TestCheckResourceAttr("data.brockhoff_context_from_tags.test", "context_output.environment_name", "Production"),
+					resource.TestCheckResourceAttr("data.brockhoff_context_from_tags.test", "context_output.cost_center", "cc-100"),
+					resource.TestCheckResourceAttr("data.brockhoff_context_from_tags.test", "context_output.product_owners.#", "2"),
+					resource.TestCheckNoResourceAttr("data.brockhoff_context_from_tags.test", "context_output.sensitivity"),
+					resource.TestCheckResourceAttr("data.brockhoff_context_from_tags.test", "context_output.additional_tags.%", "1"),
+					resource.TestCheckResourceAttr("data.brockhoff_context_from_tags.test", "context_output.additional_tags.team", "payments"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.child", "tags.bc-costcenter", "cc-100"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.child", "tags.bc-availability", "dedicated"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.child", "tags.bc-team", "payments"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,105 @@
+//go:build integrations
+
+package provider
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+// testAccSSM serves the PutParameter, GetParameter and DeleteParameter
+// actions from memory and points the AWS environment at it
+func testAccSSM(t *testing.T) map[string]string {
+	t.Helper()
+	var mu sync.Mutex
+	parameters := map[string]string{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		var input struct {
+			Name  string
+			Value string
+		}
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		value, exists := parameters[input.Name]
+		switch target := r.Header.Get("X-Amz-Target"); {
+		case target == "AmazonSSM.PutParameter":
+			parameters[input.Name] = input.Value
+			_ = json.NewEncoder(w).Encode(map[string]any{"Version": 1})
+		case target == "AmazonSSM.GetParameter" && exists:
+			_ = json.NewEncoder(w).Encode(map[string]any{"Parameter": map[string]any{"Value": value, "Version": 1}})
+		case target == "AmazonSSM.DeleteParameter" && exists:
+			delete(parameters, input.Name)
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"__type":"ParameterNotFound"}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	dir := t.TempDir()
+	t.Setenv("AWS_CONFIG_FILE", filepath.Join(dir, "config"))
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", filepath.Join(dir, "credentials"))
+	t.Setenv("AWS_PROFILE", "")
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIDEXAMPLE")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	t.Setenv("AWS_REGION", "us-east-1")
+	t.Setenv("AWS_ENDPOINT_URL_SSM", server.URL)
+	return parameters
+}
+
+func TestAccContextSSMPublishResource_basic(t *testing.T) {
+	parameters := testAccSSM(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(*terraform.State) error {
+			if len(parameters) > 0 {
+				t.Errorf("parameters left after destroy: %v", parameters)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  namespace   = "ex"
+  environment = "dev"
+}
+
+resource "brockhoff_context_ssm_publish" "test" {
+  name    = "/contexts/ex/dev"
+  context = data.brockhoff_context.test.context_output
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("brockhoff_context_ssm_publish.test", "id", "/contexts/ex/dev"),
+					resource.TestCheckResourceAttr("brockhoff_context_ssm_publish.test", "version", "1"),
+					func(*terraform.State) error {
+						var published map[string]any
+						if err := json.Unmarshal([]byte(parameters["/contexts/ex/dev"]), &published); err != nil {
+							t.Errorf("published value is not JSON: %v", err)
+						} else if published["namespace"] != "ex" {
+							t.Errorf("published namespace = %v, want ex", published["namespace"])
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
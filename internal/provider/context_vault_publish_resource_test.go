@@ -0,0 +1,165 @@
+//go:build integrations
+
+package provider
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+// testAccVault serves the KV version 2 endpoints of the mount "secret" from
+// memory and points VAULT_ADDR and VAULT_TOKEN at it
+func testAccVault(t *testing.T) map[string]map[string]any {
+	t.Helper()
+	var mu sync.Mutex
+	secrets := map[string]map[string]any{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		dataPath, isData := strings.CutPrefix(r.URL.Path, "/v1/secret/data/")
+		metadataPath, isMetadata := strings.CutPrefix(r.URL.Path, "/v1/secret/metadata/")
+		switch {
+		case isData && r.Method == http.MethodPost:
+			var body struct {
+				Data map[string]any `json:"data"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			secrets[dataPath] = body.Data
+			_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"version": 1}})
+		case isData && r.Method == http.MethodGet && secrets[dataPath] != nil:
+			_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{
+				"data":     secrets[dataPath],
+				"metadata": map[string]any{"version": 1},
+			}})
+		case isMetadata && r.Method == http.MethodDelete:
+			delete(secrets, metadataPath)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	t.Setenv("VAULT_ADDR", server.URL)
+	t.Setenv("VAULT_TOKEN", "test-token")
+	return secrets
+}
+
+func TestAccContextVaultPublishResource_basic(t *testing.T) {
+	secrets := testAccVault(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(*terraform.State) error {
+			if len(secrets) > 0 {
+				t.Errorf("secrets left after destroy: %v", secrets)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  namespace   = "ex"
+  environment = "dev"
+}
+
+resource "brockhoff_context_vault_publish" "test" {
+  path    = "contexts/ex/dev"
+  context = data.brockhoff_context.test.context_output
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("brockhoff_context_vault_publish.test", "id", "secret/contexts/ex/dev"),
+					resource.TestCheckResourceAttr("brockhoff_context_vault_publish.test", "mount", "secret"),
+					resource.TestCheckResourceAttr("brockhoff_context_vault_publish.test", "version", "1"),
+					func(*terraform.State) error {
+						if got := secrets["contexts/ex/dev"]["namespace"]; got != "ex" {
+							t.Errorf("published namespace = %v, want ex", got)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccContextVaultPublishResource_auditWebhook(t *testing.T) {
+	testAccVault(t)
+
+	var mu sync.Mutex
+	var records []map[string]any
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		var record map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&record); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		records = append(records, record)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer webhook.Close()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "brockhoff" {
+  audit_webhook_url = "` + webhook.URL + `"
+}
+
+data "brockhoff_context" "test" {
+  namespace   = "ex"
+  environment = "dev"
+}
+
+resource "brockhoff_context_vault_publish" "test" {
+  path        = "contexts/ex/dev"
+  context     = data.brockhoff_context.test.context_output
+  name_prefix = data.brockhoff_context.test.name_prefix
+  tags        = data.brockhoff_context.test.tags
+}
+`,
+				Check: func(*terraform.State) error {
+					mu.Lock()
+					defer mu.Unlock()
+					if len(records) != 1 || records[0]["name_prefix"] != "ex-dev" || records[0]["tag_digest"] == "" {
+						t.Errorf("audit records = %v, want one for ex-dev", records)
+					}
+					return nil
+				},
+			},
+			{
+				Config: `
+provider "brockhoff" {
+  audit_webhook_url = "ftp://audit.example.com"
+}
+
+data "brockhoff_context" "test" {
+  namespace = "ex"
+}
+`,
+				ExpectError: regexp.MustCompile(`audit_webhook_url must be an http or https URL`),
+			},
+		},
+	})
+}
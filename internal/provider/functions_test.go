@@ -0,0 +1,326 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccValidateFunctions(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "valid_email" {
+  value = provider::brockhoff::validate_email("owner@example.com")
+}
+
+output "invalid_email" {
+  value = provider::brockhoff::validate_email("not-an-email")
+}
+
+output "null_email" {
+  value = provider::brockhoff::validate_email(null)
+}
+
+output "invalid_namespace" {
+  value = provider::brockhoff::validate_namespace("ThisIsTooLong")
+}
+
+output "valid_lifecycle_action" {
+  value = provider::brockhoff::validate_lifecycle_action("stop")
+}
+`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("valid_email", knownvalue.Bool(true)),
+					statecheck.ExpectKnownOutputValue("invalid_email", knownvalue.Bool(false)),
+					statecheck.ExpectKnownOutputValue("null_email", knownvalue.Bool(true)),
+					statecheck.ExpectKnownOutputValue("invalid_namespace", knownvalue.Bool(false)),
+					statecheck.ExpectKnownOutputValue("valid_lifecycle_action", knownvalue.Bool(true)),
+				},
+			},
+		},
+	})
+}
+
+func TestAccValidateContextFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  namespace                = "myorg"
+  name                     = "app"
+  source_repo_tags_enabled = false
+}
+
+data "brockhoff_merge" "invalid" {
+  contexts = [
+    data.brockhoff_context.test.context_output,
+    { product_owners = ["not-an-email"] },
+  ]
+}
+
+data "brockhoff_merge" "invalid_tokenize_fields" {
+  contexts = [
+    data.brockhoff_context.test.context_output,
+    { tokenize_fields = ["namespace"] },
+  ]
+}
+
+output "valid" {
+  value = provider::brockhoff::validate_context(data.brockhoff_context.test.context_output)
+}
+
+output "invalid" {
+  value = provider::brockhoff::validate_context(data.brockhoff_merge.invalid.context_output)
+}
+
+output "invalid_tokenize_fields" {
+  value = provider::brockhoff::validate_context(data.brockhoff_merge.invalid_tokenize_fields.context_output)
+}
+`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("valid", knownvalue.Bool(true)),
+					statecheck.ExpectKnownOutputValue("invalid", knownvalue.Bool(false)),
+					statecheck.ExpectKnownOutputValue("invalid_tokenize_fields", knownvalue.Bool(false)),
+				},
+			},
+		},
+	})
+}
+
+func TestAccMergeTagsFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "test" {
+  value = provider::brockhoff::merge_tags(
+    { "bc-environment" = "Production", team = "platform" },
+    { team = "Team Payments" },
+    "gcp",
+  )
+}
+`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test", knownvalue.MapExact(map[string]knownvalue.Check{
+						"bc-environment": knownvalue.StringExact("production"),
+						"team":           knownvalue.StringExact("team-payments"),
+					})),
+				},
+			},
+			{
+				Config: `
+output "test" {
+  value = provider::brockhoff::merge_tags({}, {}, "bogus")
+}
+`,
+				ExpectError: regexp.MustCompile(`invalid cloud provider`),
+			},
+		},
+	})
+}
+
+func TestAccTagSupportFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "object" {
+  value = provider::brockhoff::tag_support("aws_s3_object")
+}
+
+output "attachment" {
+  value = provider::brockhoff::tag_support("aws_iam_role_policy_attachment").supported
+}
+
+output "unknown" {
+  value = provider::brockhoff::tag_support("aws_not_a_resource").known
+}
+`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("object", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"known":     knownvalue.Bool(true),
+						"supported": knownvalue.Bool(true),
+						"attribute": knownvalue.StringExact("tags"),
+						"max_tags":  knownvalue.Int64Exact(10),
+						"notes":     knownvalue.StringExact("S3 objects allow at most 10 tags"),
+					})),
+					statecheck.ExpectKnownOutputValue("attachment", knownvalue.Bool(false)),
+					statecheck.ExpectKnownOutputValue("unknown", knownvalue.Bool(false)),
+				},
+			},
+		},
+	})
+}
+
+func TestAccNameFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "db" {
+  value = provider::brockhoff::name("myorg", "db", "prod", null)
+}
+
+output "truncated" {
+  value = provider::brockhoff::name("myorg", "averyveryverylongname", "prod", null)
+}
+
+output "maxlen" {
+  value = provider::brockhoff::name("myorg", "averyveryverylongname", "prod", 40)
+}
+`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("db", knownvalue.StringExact("myorg-db-prod")),
+					statecheck.ExpectKnownOutputValue("truncated", knownvalue.StringExact("myorg-averyveryvery-prod")),
+					statecheck.ExpectKnownOutputValue("maxlen", knownvalue.StringExact("myorg-averyveryverylongname-prod")),
+				},
+			},
+			{
+				Config: `
+output "test" {
+  value = provider::brockhoff::name("MyOrg", "db", "prod", null)
+}
+`,
+				ExpectError: regexp.MustCompile(`namespace must be lowercase`),
+			},
+		},
+	})
+}
+
+func TestAccContextSchemaFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+locals {
+  schema = jsondecode(provider::brockhoff::context_schema())
+}
+
+output "id" {
+  value = local.schema["$id"]
+}
+
+output "sensitivity" {
+  value = local.schema.properties.sensitivity.enum
+}
+`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("id", knownvalue.StringExact("https://raw.githubusercontent.com/kbrockhoff/terraform-provider-context/main/pkg/context/context.schema.json")),
+					statecheck.ExpectKnownOutputValue("sensitivity", knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.StringExact(""),
+						knownvalue.StringExact("confidential"),
+						knownvalue.StringExact("critical"),
+						knownvalue.StringExact("internal"),
+						knownvalue.StringExact("public"),
+						knownvalue.StringExact("restricted"),
+					})),
+				},
+			},
+		},
+	})
+}
+
+func TestAccContextFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+locals {
+  contexts = {
+    for service in ["api", "worker"] : service => provider::brockhoff::context({
+      namespace                = "myorg"
+      name                     = service
+      environment              = "prod"
+      cost_center              = "cc-100"
+      additional_tags          = { team = "payments" }
+      source_repo_tags_enabled = false
+      cloud_provider           = "aws"
+      tag_prefix               = "acme-"
+    })
+  }
+}
+
+output "name_prefix" {
+  value = local.contexts["worker"].name_prefix
+}
+
+output "cost_center" {
+  value = local.contexts["api"].tags["acme-costcenter"]
+}
+
+output "team" {
+  value = local.contexts["api"].tags["acme-team"]
+}
+
+output "sensitivity" {
+  value = local.contexts["api"].data_tags["acme-sensitivity"]
+}
+`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("name_prefix", knownvalue.StringExact("myorg-worker-prod")),
+					statecheck.ExpectKnownOutputValue("cost_center", knownvalue.StringExact("cc-100")),
+					statecheck.ExpectKnownOutputValue("team", knownvalue.StringExact("payments")),
+					statecheck.ExpectKnownOutputValue("sensitivity", knownvalue.StringExact("confidential")),
+				},
+			},
+			{
+				Config: `
+output "test" {
+  value = provider::brockhoff::context({ namespace = "BAD_NS", name = "app" })
+}
+`,
+				ExpectError: regexp.MustCompile(`invalid namespace`),
+			},
+			{
+				Config: `
+output "test" {
+  value = provider::brockhoff::context({ nmae = "app" })
+}
+`,
+				ExpectError: regexp.MustCompile(`unsupported input attribute "nmae"`),
+			},
+		},
+	})
+}
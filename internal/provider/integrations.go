@@ -0,0 +1,14 @@
+//go:build integrations
+
+package provider
+
+import (
+	ctxresource "github.com/kbrockhoff/terraform-provider-context/internal/resource"
+)
+
+func init() {
+	integrationResources = append(integrationResources,
+		ctxresource.NewContextVaultPublishResource,
+		ctxresource.NewContextSSMPublishResource,
+	)
+}
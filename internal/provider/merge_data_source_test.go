@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccMergeDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "brockhoff_context" "org" {
+  namespace                = "myorg"
+  environment              = "prod"
+  cost_center              = "cc-100"
+  source_repo_tags_enabled = false
+  additional_tags = {
+    org = "acme"
+  }
+}
+
+data "brockhoff_merge" "test" {
+  contexts = [
+    data.brockhoff_context.org.context_output,
+    {
+      availability    = "dedicated"
+      additional_tags = { team = "payments" }
+    },
+    {
+      cost_center = "cc-200"
+    },
+  ]
+}
+
+data "brockhoff_context" "child" {
+  parent_context = data.brockhoff_merge.test.context_output
+  name           = "api"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.brockhoff_merge.test", "id"),
+					resource.TestCheckResourceAttr("data.brockhoff_merge.test", "context_output.namespace", "myorg"),
+					resource.TestCheckResourceAttr("data.brockhoff_merge.test", "context_output.environment", "prod"),
+					resource.TestCheckResourceAttr("data.brockhoff_merge.test", "context_output.availability", "dedicated"),
+					resource.TestCheckResourceAttr("data.brockhoff_merge.test", "context_output.cost_center", "cc-200"),
+					resource.TestCheckResourceAttr("data.brockhoff_merge.test", "context_output.additional_tags.%", "2"),
+					resource.TestCheckResourceAttr("data.brockhoff_merge.test", "context_output.additional_tags.org", "acme"),
+					resource.TestCheckResourceAttr("data.brockhoff_merge.test", "context_output.additional_tags.team", "payments"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.child", "name_prefix", "myorg-api-prod"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.child", "tags.bc-costcenter", "cc-200"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.child", "tags.bc-availability", "dedicated"),
+					resource.TestCheckNoResourceAttr("data.brockhoff_context.child", "tags.bc-sourcerepo"),
+				),
+			},
+		},
+	})
+}
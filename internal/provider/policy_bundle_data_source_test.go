@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccPolicyBundleDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "brockhoff" {
+  cloud_provider = "aws"
+  tag_prefix     = "acme-"
+}
+
+data "brockhoff_policy_bundle" "opa" {
+  format = "opa"
+}
+
+data "brockhoff_policy_bundle" "sentinel" {
+  format = "sentinel"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.brockhoff_policy_bundle.opa", "id"),
+					resource.TestCheckResourceAttr("data.brockhoff_policy_bundle.opa", "files.%", "3"),
+					resource.TestMatchResourceAttr("data.brockhoff_policy_bundle.opa", "files.brockhoff/standard/data.json", regexp.MustCompile(`"acme-costcenter"`)),
+					resource.TestMatchResourceAttr("data.brockhoff_policy_bundle.opa", "files.brockhoff/tagging/policy.rego", regexp.MustCompile(`package brockhoff.tagging`)),
+					resource.TestCheckResourceAttr("data.brockhoff_policy_bundle.sentinel", "files.%", "2"),
+					resource.TestMatchResourceAttr("data.brockhoff_policy_bundle.sentinel", "files.brockhoff-tagging.sentinel", regexp.MustCompile(`tag_attributes = \["tags_all","tags"\]`)),
+				),
+			},
+			{
+				Config: `
+data "brockhoff_policy_bundle" "test" {
+  format = "kyverno"
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid format`),
+			},
+		},
+	})
+}
@@ -2,19 +2,30 @@ package provider
 
 import (
 	"context"
+	"crypto"
 	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/kbrockhoff/terraform-provider-context/internal/core"
 	ctxdatasource "github.com/kbrockhoff/terraform-provider-context/internal/datasource"
+	"github.com/kbrockhoff/terraform-provider-context/internal/functions"
+	"github.com/kbrockhoff/terraform-provider-context/internal/tracing"
 )
 
 // Ensure ContextProvider satisfies various provider interfaces.
 var _ provider.Provider = &ContextProvider{}
+var _ provider.ProviderWithFunctions = &ContextProvider{}
 
 // ContextProvider defines the provider implementation.
 type ContextProvider struct {
@@ -26,8 +37,58 @@ type ContextProvider struct {
 
 // ContextProviderModel describes the provider data model.
 type ContextProviderModel struct {
-	CloudProvider types.String `tfsdk:"cloud_provider"`
-	TagPrefix     types.String `tfsdk:"tag_prefix"`
+	CloudProvider   types.String `tfsdk:"cloud_provider"`
+	TagPrefix       types.String `tfsdk:"tag_prefix"`
+	DetectManagedBy types.Bool   `tfsdk:"detect_managed_by"`
+
+	TagPrefixByNamespace      types.Map `tfsdk:"tag_prefix_by_namespace"`
+	DefaultsByEnvironmentType types.Map `tfsdk:"defaults_by_environment_type"`
+
+	AvailabilityByEnvironmentType types.Map `tfsdk:"availability_by_environment_type"`
+
+	AllowedNamespaces       types.List   `tfsdk:"allowed_namespaces"`
+	AllowedNamespacesSource types.String `tfsdk:"allowed_namespaces_source"`
+	NamespaceRegistryURL    types.String `tfsdk:"namespace_registry_url"`
+
+	NamingConstraints *NamingConstraintsModel `tfsdk:"naming_constraints"`
+
+	StrictMode            types.Bool `tfsdk:"strict_mode"`
+	StrictEmailValidation types.Bool `tfsdk:"strict_email_validation"`
+	PunycodeEmailDomains  types.Bool `tfsdk:"punycode_email_domains"`
+
+	SignContextDigest types.Bool   `tfsdk:"sign_context_digest"`
+	AuditWebhookURL   types.String `tfsdk:"audit_webhook_url"`
+
+	EnrichmentProgram types.List   `tfsdk:"enrichment_program"`
+	PolicyPath        types.String `tfsdk:"policy_path"`
+
+	GitTimeout types.String `tfsdk:"git_timeout"`
+	GitRoot    types.String `tfsdk:"git_root"`
+	SourceDir  types.String `tfsdk:"source_dir"`
+
+	GroupDirectory *GroupDirectoryModel `tfsdk:"group_directory"`
+}
+
+// EnvironmentTypeDefaultsModel describes an element of
+// defaults_by_environment_type.
+type EnvironmentTypeDefaultsModel struct {
+	Availability  types.String `tfsdk:"availability"`
+	Sensitivity   types.String `tfsdk:"sensitivity"`
+	DataRetention types.String `tfsdk:"data_retention"`
+}
+
+// GroupDirectoryModel describes the group_directory block.
+type GroupDirectoryModel struct {
+	Type      types.String `tfsdk:"type"`
+	Token     types.String `tfsdk:"token"`
+	BaseURL   types.String `tfsdk:"base_url"`
+	OwnerTags types.String `tfsdk:"owner_tags"`
+}
+
+// NamingConstraintsModel describes the naming_constraints block.
+type NamingConstraintsModel struct {
+	NamespaceMaxLength   types.Int64 `tfsdk:"namespace_max_length"`
+	EnvironmentMaxLength types.Int64 `tfsdk:"environment_max_length"`
 }
 
 func (p *ContextProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -47,6 +108,131 @@ func (p *ContextProvider) Schema(ctx context.Context, req provider.SchemaRequest
 				Description: "Prefix for all generated tags",
 				Optional:    true,
 			},
+			"tag_prefix_by_namespace": schema.MapAttribute{
+				Description: "Tag prefixes keyed by namespace, overriding tag_prefix for the brockhoff_context data sources of those namespaces, such as business units required to keep their own prefix after a merger",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"defaults_by_environment_type": schema.MapNestedAttribute{
+				Description: "Defaults of the brockhoff_context data sources keyed by environment_type (None, Ephemeral, Development, Testing, UAT, Production or MissionCritical), such as dedicated availability and restricted sensitivity for Production, applied when the data source, its parent_context and its compliance_profile leave them unset",
+				Optional:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"availability": schema.StringAttribute{
+							Description: "Default availability (default: preemptable)",
+							Optional:    true,
+						},
+						"sensitivity": schema.StringAttribute{
+							Description: "Default sensitivity (default: confidential)",
+							Optional:    true,
+						},
+						"data_retention": schema.StringAttribute{
+							Description: "Default data_retention, an ISO 8601 duration such as P7Y (default: none)",
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"detect_managed_by": schema.BoolAttribute{
+				Description: "Detect the platform running Terraform (terraform, hcp-terraform, spacelift or atlantis) for managed_by when it is not set, instead of always using terraform (default: false)",
+				Optional:    true,
+			},
+			"allowed_namespaces": schema.ListAttribute{
+				Description: "Namespaces accepted by the data sources, such as the official business units; other namespaces are rejected (default: any namespace)",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"allowed_namespaces_source": schema.StringAttribute{
+				Description: "Local file path or http(s) URL of a namespace registry with one allowed namespace per line; blank lines and # comments are ignored. Combined with allowed_namespaces",
+				Optional:    true,
+			},
+			"namespace_registry_url": schema.StringAttribute{
+				Description: "Link to the namespace registry included in the error for a namespace that is not allowed (default: allowed_namespaces_source when it is a URL)",
+				Optional:    true,
+			},
+			"strict_mode": schema.BoolAttribute{
+				Description: "Fail brockhoff_context reads whose data sensitivity requirements are not met, such as restricted or critical data without a data_retention period, instead of warning, and reads whose availability contradicts their environment_type, such as Production on spot capacity. Production allows standard, dedicated and isolated, and MissionCritical dedicated and isolated, unless availability_by_environment_type overrides them; an unset availability defaults to the first allowed level. A compliance_profile always fails unmet sensitivity requirements (default: false)",
+				Optional:    true,
+			},
+			"availability_by_environment_type": schema.MapAttribute{
+				Description: "Availability levels allowed for environment types by strict_mode, replacing the built-in entries of the environment types it holds; an empty list removes the restriction of an environment type",
+				ElementType: types.ListType{ElemType: types.StringType},
+				Optional:    true,
+			},
+			"strict_email_validation": schema.BoolAttribute{
+				Description: "Validate owner emails against the ASCII-only pattern of earlier releases, rejecting internationalized addresses such as user@bücher.example (default: false)",
+				Optional:    true,
+			},
+			"punycode_email_domains": schema.BoolAttribute{
+				Description: "Convert internationalized owner email domains to punycode, such as user@xn--bcher-kva.example, and lowercase them before tagging (default: false)",
+				Optional:    true,
+			},
+			"sign_context_digest": schema.BoolAttribute{
+				Description: "Sign the context_digest of each brockhoff_context with the PEM private key in the CONTEXT_PROVIDER_SIGNING_KEY environment variable, exposed as context_signature. Keys from cosign generate-key-pair are decrypted with COSIGN_PASSWORD, and signatures verify with cosign verify-blob (default: false)",
+				Optional:    true,
+			},
+			"audit_webhook_url": schema.StringAttribute{
+				Description: "http(s) URL that brockhoff_context_vault_publish and brockhoff_context_ssm_publish post an audit record to after each create or update, holding their name_prefix, a digest of their tags and, unless the published context sets source_repo_tags_enabled to false, the Git repository and commit of the git_root of the context or the provider. Failed deliveries are reported as warnings",
+				Optional:    true,
+			},
+			"enrichment_program": schema.ListAttribute{
+				Description: "Program, followed by its arguments, run by each brockhoff_context read with a JSON object holding name_prefix, cloud_provider, tag_prefix and the unprefixed tags on its standard input. Like the program of the external data source, it writes a JSON object with string values to its standard output, merged over the tags without the tag prefix, and reports errors with a non-zero exit status and a message on its standard error",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"git_timeout": schema.StringAttribute{
+				Description: "Maximum time, as a duration such as 2s, that the git commands of the sourcerepo and sourcecommit tags may take. Slower lookups, such as of network-mounted or very large repositories, leave the tags not applicable with a warning, and lookup results, including timeouts and directories outside a repository, are cached for 5 minutes (default: 5s)",
+				Optional:    true,
+			},
+			"git_root": schema.StringAttribute{
+				Description: "Directory of the repository of the sourcerepo and sourcecommit tags, such as the root of the parent repository when the module lives in a submodule, or the source directory when Terraform runs in a copy of it. Relative paths are resolved against the working directory. The git_root of a brockhoff_context overrides it (default: the working directory)",
+				Optional:    true,
+			},
+			"source_dir": schema.StringAttribute{
+				Description: "Directory of the configuration Terraform runs for, used for the sourcerepo and sourcecommit tags and to resolve relative git_root, policy_path and allowed_namespaces_source paths, for wrappers running Terraform in a copy of the configuration. Under Terragrunt, the unit directory is detected from the .terragrunt-cache path and the TG_DOWNLOAD_DIR, TG_WORKING_DIR and legacy TERRAGRUNT_DOWNLOAD and TERRAGRUNT_WORKING_DIR environment variables (default: the working directory)",
+				Optional:    true,
+			},
+			"policy_path": schema.StringAttribute{
+				Description: "Rego file, or directory of .rego files, of package brockhoff.context evaluated by each brockhoff_context read with the resolved context and final tags as input. Messages of the deny set reject the read, and the tags object adds derived tags, keyed without the tag prefix",
+				Optional:    true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"naming_constraints": schema.SingleNestedBlock{
+				Description: "Length limits of the namespace and environment components for organizations whose identifiers do not fit the defaults. The maximum name prefix length grows by the characters added over the defaults",
+				Attributes: map[string]schema.Attribute{
+					"namespace_max_length": schema.Int64Attribute{
+						Description: fmt.Sprintf("Maximum namespace length, 1-%d (default: %d)", core.MaxComponentLength, core.DefaultComponentMaxLength),
+						Optional:    true,
+					},
+					"environment_max_length": schema.Int64Attribute{
+						Description: fmt.Sprintf("Maximum environment length, 1-%d (default: %d)", core.MaxComponentLength, core.DefaultComponentMaxLength),
+						Optional:    true,
+					},
+				},
+			},
+			"group_directory": schema.SingleNestedBlock{
+				Description: "Opt-in lookup of owner addresses in a group directory, so that owner tags can reference maintained groups such as distribution lists rather than individuals",
+				Attributes: map[string]schema.Attribute{
+					"type": schema.StringAttribute{
+						Description: "Directory type: microsoft_graph (Microsoft 365 and Entra ID groups) or google (Google Workspace groups)",
+						Optional:    true,
+					},
+					"token": schema.StringAttribute{
+						Description: "OAuth access token with the GroupMember.Read.All permission for microsoft_graph or the admin.directory.group.readonly scope for google (default: the MSGRAPH_ACCESS_TOKEN or GOOGLE_OAUTH_ACCESS_TOKEN environment variable)",
+						Optional:    true,
+						Sensitive:   true,
+					},
+					"base_url": schema.StringAttribute{
+						Description: "API endpoint, such as for national clouds (default: https://graph.microsoft.com or https://admin.googleapis.com)",
+						Optional:    true,
+					},
+					"owner_tags": schema.StringAttribute{
+						Description: "How groups appear in the owner tags: display_name replaces group addresses with the canonical group display name, member_count keeps the addresses and adds productownersmembers, codeownersmembers and dataownersmembers tags with the number of group members (default: display_name)",
+						Optional:    true,
+					},
+				},
+			},
 		},
 	}
 }
@@ -54,6 +240,9 @@ func (p *ContextProvider) Schema(ctx context.Context, req provider.SchemaRequest
 func (p *ContextProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
 	tflog.Debug(ctx, "Configuring Context provider")
 
+	ctx, span := tracing.StartSpan(ctx, "ContextProvider.Configure")
+	defer func() { tracing.EndSpan(span, resp.Diagnostics) }()
+
 	var data ContextProviderModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
@@ -88,10 +277,264 @@ func (p *ContextProvider) Configure(ctx context.Context, req provider.ConfigureR
 		return
 	}
 
+	namingConstraints := core.DefaultNamingConstraints()
+	if data.NamingConstraints != nil {
+		if v := data.NamingConstraints.NamespaceMaxLength; !v.IsNull() {
+			namingConstraints.NamespaceMaxLength = int(v.ValueInt64())
+		}
+		if v := data.NamingConstraints.EnvironmentMaxLength; !v.IsNull() {
+			namingConstraints.EnvironmentMaxLength = int(v.ValueInt64())
+		}
+	}
+	if err := namingConstraints.Validate(); err != nil {
+		resp.Diagnostics.AddError("Invalid naming_constraints", err.Error())
+		return
+	}
+
+	var groupDirectory core.GroupDirectory
+	ownerGroupTags := ""
+	if gd := data.GroupDirectory; gd != nil {
+		directoryType := gd.Type.ValueString()
+		token := gd.Token.ValueString()
+		if token == "" {
+			token = os.Getenv(core.GroupDirectoryTokenEnvVars[directoryType])
+		}
+
+		directory, err := core.NewGroupDirectory(directoryType, token, gd.BaseURL.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid group_directory", err.Error())
+			return
+		}
+		if token == "" {
+			resp.Diagnostics.AddError("Invalid group_directory",
+				fmt.Sprintf("token is required; set it or the %s environment variable", core.GroupDirectoryTokenEnvVars[directoryType]))
+			return
+		}
+
+		ownerGroupTags = core.OwnerGroupTagsDisplayName
+		if !gd.OwnerTags.IsNull() {
+			ownerGroupTags = gd.OwnerTags.ValueString()
+		}
+		if err := core.ValidateOwnerGroupTags(ownerGroupTags); err != nil {
+			resp.Diagnostics.AddError("Invalid group_directory", err.Error())
+			return
+		}
+
+		// Data sources share the provider, so each owner is looked up once
+		groupDirectory = core.CacheGroupDirectory(directory)
+	}
+
+	var signer crypto.Signer
+	if data.SignContextDigest.ValueBool() {
+		pemData := os.Getenv(core.SigningKeyEnvVar)
+		if pemData == "" {
+			resp.Diagnostics.AddError("Invalid signing key",
+				fmt.Sprintf("sign_context_digest requires the %s environment variable", core.SigningKeyEnvVar))
+			return
+		}
+		var err error
+		signer, err = core.ParseSigningKey([]byte(pemData), []byte(os.Getenv(core.SigningKeyPasswordEnvVar)))
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid signing key", err.Error())
+			return
+		}
+	}
+
+	auditWebhookURL := data.AuditWebhookURL.ValueString()
+	if auditWebhookURL != "" {
+		if u, err := url.Parse(auditWebhookURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			resp.Diagnostics.AddError("Invalid audit_webhook_url",
+				fmt.Sprintf("audit_webhook_url must be an http or https URL, got: %s", auditWebhookURL))
+			return
+		}
+	}
+
+	var enrichmentProgram []string
+	resp.Diagnostics.Append(data.EnrichmentProgram.ElementsAs(ctx, &enrichmentProgram, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !data.EnrichmentProgram.IsNull() && (len(enrichmentProgram) == 0 || enrichmentProgram[0] == "") {
+		resp.Diagnostics.AddError("Invalid enrichment_program", "enrichment_program must start with the program to run")
+		return
+	}
+
+	// Relative paths and the source repository tags refer to the source
+	// directory, which is not the working directory when Terraform runs in
+	// a copy of the configuration, such as under Terragrunt
+	var sourceDir string
+	if dir := data.SourceDir.ValueString(); dir != "" {
+		var err error
+		sourceDir, err = absDir(dir)
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid source_dir", err.Error())
+			return
+		}
+	} else if wd, err := os.Getwd(); err == nil {
+		sourceDir = core.DetectTerragruntSourceDir(wd)
+		if sourceDir != "" {
+			tflog.Debug(ctx, "Detected Terragrunt unit directory", map[string]interface{}{
+				"source_dir": sourceDir,
+			})
+		}
+	}
+	resolvePath := func(path string) string {
+		if sourceDir == "" || filepath.IsAbs(path) {
+			return path
+		}
+		return filepath.Join(sourceDir, path)
+	}
+
+	var policy *core.Policy
+	if path := data.PolicyPath.ValueString(); path != "" {
+		var err error
+		policy, err = core.LoadPolicy(ctx, resolvePath(path))
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid policy_path", err.Error())
+			return
+		}
+	}
+
+	gitTimeout := core.DefaultGitTimeout
+	if !data.GitTimeout.IsNull() {
+		var err error
+		gitTimeout, err = time.ParseDuration(data.GitTimeout.ValueString())
+		if err == nil && gitTimeout <= 0 {
+			err = fmt.Errorf("must be positive, got %s", data.GitTimeout.ValueString())
+		}
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid git_timeout", err.Error())
+			return
+		}
+	}
+
+	gitRoot := sourceDir
+	if root := data.GitRoot.ValueString(); root != "" {
+		var err error
+		gitRoot, err = absDir(resolvePath(root))
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid git_root", err.Error())
+			return
+		}
+	}
+
+	// Load the namespace registry
+	var allowedNamespaces []string
+	resp.Diagnostics.Append(data.AllowedNamespaces.ElementsAs(ctx, &allowedNamespaces, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for _, namespace := range allowedNamespaces {
+		if err := namingConstraints.ValidateNamespace(namespace); err != nil {
+			resp.Diagnostics.AddError("Invalid allowed_namespaces", err.Error())
+			return
+		}
+	}
+
+	var tagPrefixByNamespace map[string]string
+	resp.Diagnostics.Append(data.TagPrefixByNamespace.ElementsAs(ctx, &tagPrefixByNamespace, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for namespace := range tagPrefixByNamespace {
+		if err := namingConstraints.ValidateNamespace(namespace); err != nil {
+			resp.Diagnostics.AddError("Invalid tag_prefix_by_namespace", err.Error())
+			return
+		}
+	}
+
+	var defaultsModels map[string]EnvironmentTypeDefaultsModel
+	resp.Diagnostics.Append(data.DefaultsByEnvironmentType.ElementsAs(ctx, &defaultsModels, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var defaultsByEnvironmentType map[string]core.EnvironmentTypeDefaults
+	if len(defaultsModels) > 0 {
+		defaultsByEnvironmentType = make(map[string]core.EnvironmentTypeDefaults, len(defaultsModels))
+		for envType, m := range defaultsModels {
+			defaultsByEnvironmentType[envType] = core.EnvironmentTypeDefaults{
+				Availability:  m.Availability.ValueString(),
+				Sensitivity:   m.Sensitivity.ValueString(),
+				DataRetention: m.DataRetention.ValueString(),
+			}
+		}
+	}
+	if err := core.ValidateEnvironmentTypeDefaults(defaultsByEnvironmentType); err != nil {
+		resp.Diagnostics.AddError("Invalid defaults_by_environment_type", err.Error())
+		return
+	}
+
+	var availabilityOverrides map[string][]string
+	resp.Diagnostics.Append(data.AvailabilityByEnvironmentType.ElementsAs(ctx, &availabilityOverrides, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if err := core.ValidateAvailabilityMatrix(availabilityOverrides); err != nil {
+		resp.Diagnostics.AddError("Invalid availability_by_environment_type", err.Error())
+		return
+	}
+	var availabilityMatrix map[string][]string
+	if data.StrictMode.ValueBool() {
+		availabilityMatrix = core.AvailabilityMatrix(availabilityOverrides)
+	} else if len(availabilityOverrides) > 0 {
+		resp.Diagnostics.AddWarning("Unused availability_by_environment_type",
+			"availability_by_environment_type only applies with strict_mode = true")
+	}
+
+	namespaceRegistry := data.NamespaceRegistryURL.ValueString()
+	if source := data.AllowedNamespacesSource.ValueString(); source != "" {
+		path := source
+		if !strings.Contains(source, "://") {
+			path = resolvePath(source)
+		}
+		registered, err := core.LoadNamespaceRegistry(ctx, path, namingConstraints)
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid allowed_namespaces_source", err.Error())
+			return
+		}
+		allowedNamespaces = append(allowedNamespaces, registered...)
+
+		if namespaceRegistry == "" && strings.Contains(source, "://") {
+			namespaceRegistry = source
+		}
+	}
+
 	// Create provider configuration
 	providerConfig := &ctxdatasource.ProviderConfig{
-		CloudProvider: cloudProvider,
-		TagPrefix:     tagPrefix,
+		CloudProvider:    cloudProvider,
+		TagPrefix:        tagPrefix,
+		TerraformVersion: req.TerraformVersion,
+		ProviderVersion:  p.version,
+		DetectManagedBy:  data.DetectManagedBy.ValueBool(),
+
+		TagPrefixByNamespace:      tagPrefixByNamespace,
+		DefaultsByEnvironmentType: defaultsByEnvironmentType,
+
+		AllowedNamespaces:    allowedNamespaces,
+		NamespaceRegistryURL: namespaceRegistry,
+
+		NamingConstraints: namingConstraints,
+
+		StrictMode:            data.StrictMode.ValueBool(),
+		AvailabilityMatrix:    availabilityMatrix,
+		StrictEmailValidation: data.StrictEmailValidation.ValueBool(),
+		PunycodeEmailDomains:  data.PunycodeEmailDomains.ValueBool(),
+
+		GroupDirectory: groupDirectory,
+		OwnerGroupTags: ownerGroupTags,
+
+		TokenizationKey: []byte(os.Getenv(core.TokenizationKeyEnvVar)),
+
+		Signer: signer,
+
+		AuditWebhookURL: auditWebhookURL,
+
+		EnrichmentProgram: enrichmentProgram,
+		Policy:            policy,
+
+		GitTimeout: gitTimeout,
+		GitRoot:    gitRoot,
+		SourceDir:  sourceDir,
 	}
 
 	tflog.Debug(ctx, "Context provider configured", map[string]interface{}{
@@ -104,13 +547,58 @@ func (p *ContextProvider) Configure(ctx context.Context, req provider.ConfigureR
 	resp.ResourceData = providerConfig
 }
 
+// absDir returns the absolute path of dir, which must be a directory
+func absDir(dir string) (string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	info, err := os.Stat(abs)
+	if err != nil {
+		return "", err
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("%s is not a directory", dir)
+	}
+	return abs, nil
+}
+
+// integrationResources holds the resources that call external services. They
+// are only registered when the provider is built with the integrations tag.
+var integrationResources []func() resource.Resource
+
 func (p *ContextProvider) Resources(ctx context.Context) []func() resource.Resource {
-	return []func() resource.Resource{}
+	return integrationResources
 }
 
 func (p *ContextProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		ctxdatasource.NewContextDataSource,
+		ctxdatasource.NewMergeDataSource,
+		ctxdatasource.NewContextFromTagsDataSource,
+		ctxdatasource.NewPolicyBundleDataSource,
+		ctxdatasource.NewAssertDataSource,
+		ctxdatasource.NewContextDiffDataSource,
+	}
+}
+
+func (p *ContextProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		functions.NewValidateEmailFunction,
+		functions.NewValidateNamespaceFunction,
+		functions.NewValidateEnvironmentFunction,
+		functions.NewValidateEnvironmentTypeFunction,
+		functions.NewValidateAvailabilityFunction,
+		functions.NewValidateSensitivityFunction,
+		functions.NewValidateDeletionDateFunction,
+		functions.NewValidateLifecycleActionFunction,
+		functions.NewValidateCloudProviderFunction,
+		functions.NewValidateContextFunction,
+		functions.NewMergeTagsFunction,
+		functions.NewTagSupportFunction,
+		functions.NewNameFunction,
+		functions.NewContextSchemaFunction,
+		functions.NewContextFunction,
 	}
 }
 
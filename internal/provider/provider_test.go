@@ -0,0 +1,842 @@
+package provider
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// testAccProtoV6ProviderFactories are used to instantiate a provider during
+// acceptance testing. The factory function will be invoked for every Terraform
+// CLI command executed to create a provider server to which the CLI can
+// reattach.
+var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+	"brockhoff": providerserver.NewProtocol6WithError(New("test")()),
+}
+
+// testAccPreCheck validates the environment before running acceptance tests
+func testAccPreCheck(t *testing.T) {
+	t.Helper()
+}
+
+func TestAccProvider_defaults(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "brockhoff" {}
+
+data "brockhoff_context" "test" {
+  name                     = "app"
+  source_repo_tags_enabled = false
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.bc-availability", "preemptable"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.bc-costcenter", "N/A"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccProvider_azure(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "brockhoff" {
+  cloud_provider = "az"
+  tag_prefix     = "ctx-"
+}
+
+data "brockhoff_context" "test" {
+  name                     = "app"
+  environment_name         = "User Acceptance"
+  product_owners           = ["a@example.com", "b@example.com"]
+  source_repo_tags_enabled = false
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.ctx-environment", "UserAcceptance"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.ctx-productowners", "a@example.com;b@example.com"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.ctx-costcenter", "NotApplicable"),
+					resource.TestCheckNoResourceAttr("data.brockhoff_context.test", "tags.bc-availability"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccProvider_gcp(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "brockhoff" {
+  cloud_provider = "gcp"
+}
+
+data "brockhoff_context" "test" {
+  name                     = "app"
+  environment_name         = "Production"
+  source_repo_tags_enabled = false
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.bc-environment", "production"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.bc-costcenter", "not_applicable"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccProvider_invalidCloudProvider(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "brockhoff" {
+  cloud_provider = "xyz"
+}
+
+data "brockhoff_context" "test" {
+  name = "app"
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid cloud provider`),
+			},
+		},
+	})
+}
+
+func TestAccProvider_detectManagedBy(t *testing.T) {
+	t.Setenv("TFC_RUN_ID", "run-abc123")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "brockhoff" {
+  detect_managed_by = true
+}
+
+data "brockhoff_context" "detected" {
+  name = "app"
+}
+
+data "brockhoff_context" "explicit" {
+  name       = "app"
+  managed_by = "terraform"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.brockhoff_context.detected", "tags.bc-managedby", "hcp-terraform"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.explicit", "tags.bc-managedby", "terraform"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccProvider_allowedNamespaces(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "brockhoff" {
+  allowed_namespaces = ["myorg", "payments"]
+}
+
+data "brockhoff_context" "test" {
+  namespace = "payments"
+  name      = "app"
+}
+`,
+				Check: resource.TestCheckResourceAttr("data.brockhoff_context.test", "name_prefix", "payments-app"),
+			},
+			{
+				Config: `
+provider "brockhoff" {
+  allowed_namespaces     = ["myorg", "payments"]
+  namespace_registry_url = "https://wiki.example.com/namespaces"
+}
+
+data "brockhoff_context" "test" {
+  namespace = "other"
+  name      = "app"
+}
+`,
+				ExpectError: regexp.MustCompile(`see https://wiki.example.com/namespaces`),
+			},
+		},
+	})
+}
+
+func TestAccProvider_tagPrefixByNamespace(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "brockhoff" {
+  tag_prefix = "bc-"
+  tag_prefix_by_namespace = {
+    acme = "acme-"
+  }
+}
+
+data "brockhoff_context" "acme" {
+  namespace                = "acme"
+  name                     = "app"
+  source_repo_tags_enabled = false
+}
+
+data "brockhoff_context" "other" {
+  namespace                = "myorg"
+  name                     = "app"
+  source_repo_tags_enabled = false
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.brockhoff_context.acme", "tags.acme-availability", "preemptable"),
+					resource.TestCheckNoResourceAttr("data.brockhoff_context.acme", "tags.bc-availability"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.other", "tags.bc-availability", "preemptable"),
+				),
+			},
+			{
+				Config: `
+provider "brockhoff" {
+  tag_prefix_by_namespace = {
+    Bad_NS = "bad-"
+  }
+}
+
+data "brockhoff_context" "test" {
+  name = "app"
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid tag_prefix_by_namespace`),
+			},
+		},
+	})
+}
+
+func TestAccProvider_defaultsByEnvironmentType(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "brockhoff" {
+  defaults_by_environment_type = {
+    Production = {
+      availability   = "dedicated"
+      sensitivity    = "restricted"
+      data_retention = "P7Y"
+    }
+    Development = {
+      sensitivity = "internal"
+    }
+  }
+}
+
+data "brockhoff_context" "prod" {
+  name                     = "app"
+  environment_type         = "Production"
+  source_repo_tags_enabled = false
+}
+
+data "brockhoff_context" "dev" {
+  name                     = "app"
+  environment_type         = "Development"
+  source_repo_tags_enabled = false
+}
+
+data "brockhoff_context" "explicit" {
+  name                     = "app"
+  environment_type         = "Production"
+  availability             = "spot"
+  source_repo_tags_enabled = false
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.brockhoff_context.prod", "tags.bc-availability", "dedicated"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.prod", "data_tags.bc-sensitivity", "restricted"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.prod", "data_tags.bc-dataretention", "P7Y"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.dev", "tags.bc-availability", "preemptable"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.dev", "data_tags.bc-sensitivity", "internal"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.explicit", "tags.bc-availability", "spot"),
+				),
+			},
+			{
+				Config: `
+provider "brockhoff" {
+  defaults_by_environment_type = {
+    Staging = {
+      availability = "dedicated"
+    }
+  }
+}
+
+data "brockhoff_context" "test" {
+  name = "app"
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid defaults_by_environment_type`),
+			},
+		},
+	})
+}
+
+func TestAccProvider_strictAvailability(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "brockhoff" {}
+
+data "brockhoff_context" "test" {
+  name             = "app"
+  environment_type = "Production"
+  availability     = "spot"
+}
+`,
+				Check: resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.bc-availability", "spot"),
+			},
+			{
+				Config: `
+provider "brockhoff" {
+  strict_mode = true
+}
+
+data "brockhoff_context" "test" {
+  name             = "app"
+  environment_type = "Production"
+  availability     = "spot"
+}
+`,
+				ExpectError: regexp.MustCompile(`availability 'spot' is not allowed for environment type Production`),
+			},
+			{
+				Config: `
+provider "brockhoff" {
+  strict_mode = true
+}
+
+data "brockhoff_context" "test" {
+  name             = "app"
+  environment_type = "MissionCritical"
+}
+`,
+				Check: resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.bc-availability", "dedicated"),
+			},
+			{
+				Config: `
+provider "brockhoff" {
+  strict_mode = true
+  availability_by_environment_type = {
+    Production = ["spot", "standard"]
+    UAT        = ["standard"]
+  }
+}
+
+data "brockhoff_context" "prod" {
+  name                     = "app"
+  environment_type         = "Production"
+  availability             = "spot"
+  source_repo_tags_enabled = false
+}
+
+data "brockhoff_context" "uat" {
+  name                     = "app"
+  environment_type         = "UAT"
+  availability             = "standard"
+  source_repo_tags_enabled = false
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.brockhoff_context.prod", "tags.bc-availability", "spot"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.uat", "tags.bc-availability", "standard"),
+				),
+			},
+			{
+				Config: `
+provider "brockhoff" {
+  strict_mode = true
+  availability_by_environment_type = {
+    Production = ["always"]
+  }
+}
+
+data "brockhoff_context" "test" {
+  name = "app"
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid availability_by_environment_type`),
+			},
+		},
+	})
+}
+
+func TestAccProvider_namingConstraints(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "brockhoff" {
+  naming_constraints {
+    namespace_max_length = 12
+  }
+}
+
+data "brockhoff_context" "test" {
+  namespace   = "platform-eng"
+  name        = "payments-api"
+  environment = "prod"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					// The name prefix limit grows from 24 to 28 with the namespace
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "name_prefix", "platform-eng-payments-a-prod"),
+				),
+			},
+			{
+				Config: `
+provider "brockhoff" {
+  naming_constraints {
+    namespace_max_length = 12
+  }
+}
+
+data "brockhoff_context" "test" {
+  namespace = "platform-engineering"
+  name      = "app"
+}
+`,
+				ExpectError: regexp.MustCompile(`namespace must be 1-12 characters`),
+			},
+			{
+				Config: `
+provider "brockhoff" {
+  naming_constraints {
+    environment_max_length = 20
+  }
+}
+
+data "brockhoff_context" "test" {
+  name = "app"
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid naming_constraints`),
+			},
+		},
+	})
+}
+
+func TestAccProvider_emailValidation(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "brockhoff" {
+  punycode_email_domains = true
+}
+
+data "brockhoff_context" "test" {
+  name           = "app"
+  product_owners = ["user@Bücher.example"]
+}
+`,
+				Check: resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.bc-productowners", "user@xn--bcher-kva.example"),
+			},
+			{
+				Config: `
+provider "brockhoff" {
+  strict_email_validation = true
+}
+
+data "brockhoff_context" "test" {
+  name           = "app"
+  product_owners = ["user@bücher.example"]
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid product_owners`),
+			},
+		},
+	})
+}
+
+func TestAccProvider_groupDirectory(t *testing.T) {
+	// Fake Google Directory API with one group
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/admin/directory/v1/groups/team-payments@example.com" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, `{"email":"team-payments@example.com","name":"Team Payments","directMembersCount":"5"}`)
+	}))
+	defer server.Close()
+
+	config := func(ownerTags string) string {
+		return fmt.Sprintf(`
+provider "brockhoff" {
+  group_directory {
+    type       = "google"
+    token      = "test"
+    base_url   = %q
+    owner_tags = %q
+  }
+}
+
+data "brockhoff_context" "test" {
+  name           = "app"
+  product_owners = ["lead@example.com", "team-payments@example.com"]
+}
+`, server.URL, ownerTags)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("display_name"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.bc-productowners", "lead@example.com;Team Payments"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "owner_groups.team-payments@example.com.display_name", "Team Payments"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "owner_groups.team-payments@example.com.member_count", "5"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "owner_groups.%", "1"),
+				),
+			},
+			{
+				Config: config("member_count"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.bc-productowners", "lead@example.com;team-payments@example.com"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.bc-productownersmembers", "5"),
+				),
+			},
+			{
+				Config:      config("email"),
+				ExpectError: regexp.MustCompile(`Invalid group_directory`),
+			},
+		},
+	})
+}
+
+func TestAccProvider_signContextDigest(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signingKey := string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+
+	config := `
+provider "brockhoff" {
+  sign_context_digest = true
+}
+
+data "brockhoff_context" "test" {
+  name = "app"
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      config,
+				ExpectError: regexp.MustCompile(`Invalid signing key`),
+			},
+			{
+				PreConfig: func() { t.Setenv("CONTEXT_PROVIDER_SIGNING_KEY", signingKey) },
+				Config:    config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.brockhoff_context.test", "context_digest"),
+					resource.TestCheckResourceAttrSet("data.brockhoff_context.test", "context_signature"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccProvider_enrichmentProgram(t *testing.T) {
+	config := func(program string) string {
+		return fmt.Sprintf(`
+provider "brockhoff" {
+  cloud_provider     = "aws"
+  enrichment_program = %s
+}
+
+data "brockhoff_context" "test" {
+  name        = "app"
+  environment = "prod"
+}
+`, program)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(`["sh", "-c", "cat > /dev/null; echo '{\"cmdbowner\": \"team-payments\"}'"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.bc-cmdbowner", "team-payments"),
+				),
+			},
+			{
+				Config:      config(`["sh", "-c", "echo CMDB unavailable >&2; exit 1"]`),
+				ExpectError: regexp.MustCompile(`CMDB unavailable`),
+			},
+			{
+				Config:      config(`[]`),
+				ExpectError: regexp.MustCompile(`Invalid enrichment_program`),
+			},
+		},
+	})
+}
+
+func TestAccProvider_policyPath(t *testing.T) {
+	policyPath := filepath.Join(t.TempDir(), "context.rego")
+	policy := `package brockhoff.context
+
+deny contains msg if {
+	input.context.environment == "prod"
+	input.tags["bc-costcenter"] == "N/A"
+	msg := "production contexts need a cost center"
+}
+
+tags["tier"] := "critical" if input.context.availability == "dedicated"
+`
+	if err := os.WriteFile(policyPath, []byte(policy), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	config := func(path, costCenter string) string {
+		return fmt.Sprintf(`
+provider "brockhoff" {
+  cloud_provider = "aws"
+  policy_path    = %q
+}
+
+data "brockhoff_context" "test" {
+  name         = "app"
+  environment  = "prod"
+  availability = "dedicated"
+  cost_center  = %q
+}
+`, path, costCenter)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(policyPath, "cc-100"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.bc-tier", "critical"),
+				),
+			},
+			{
+				Config:      config(policyPath, ""),
+				ExpectError: regexp.MustCompile(`production contexts need a cost center`),
+			},
+			{
+				Config:      config(filepath.Join(t.TempDir(), "missing.rego"), "cc-100"),
+				ExpectError: regexp.MustCompile(`Invalid policy_path`),
+			},
+		},
+	})
+}
+
+func TestAccProvider_gitTimeout(t *testing.T) {
+	config := func(timeout string) string {
+		return fmt.Sprintf(`
+provider "brockhoff" {
+  git_timeout = %q
+}
+
+data "brockhoff_context" "test" {
+  name        = "app"
+  environment = "dev"
+}
+`, timeout)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("10s"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.brockhoff_context.test", "tags.bc-sourcecommit"),
+				),
+			},
+			{
+				Config:      config("0s"),
+				ExpectError: regexp.MustCompile(`Invalid git_timeout`),
+			},
+			{
+				Config:      config("soon"),
+				ExpectError: regexp.MustCompile(`Invalid git_timeout`),
+			},
+		},
+	})
+}
+
+func TestAccProvider_gitRoot(t *testing.T) {
+	config := func(root string) string {
+		return fmt.Sprintf(`
+provider "brockhoff" {
+  git_root = %q
+}
+
+data "brockhoff_context" "test" {
+  name        = "app"
+  environment = "dev"
+}
+`, root)
+	}
+
+	file := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(file, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("../.."),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.brockhoff_context.test", "tags.bc-sourcecommit"),
+				),
+			},
+			{
+				Config:      config(filepath.Join(t.TempDir(), "missing")),
+				ExpectError: regexp.MustCompile(`Invalid git_root`),
+			},
+			{
+				Config:      config(file),
+				ExpectError: regexp.MustCompile(`Invalid git_root`),
+			},
+			// The git_root of a context overrides the provider's
+			{
+				Config: fmt.Sprintf(`
+provider "brockhoff" {
+  git_root = %q
+}
+
+data "brockhoff_context" "test" {
+  name        = "app"
+  environment = "dev"
+  git_root    = "../.."
+}
+`, t.TempDir()),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.brockhoff_context.test", "tags.bc-sourcecommit"),
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "context_output.git_root", "../.."),
+				),
+			},
+		},
+	})
+}
+
+func TestAccProvider_sourceDir(t *testing.T) {
+	// A Terragrunt copy of a unit outside the repository
+	unit := t.TempDir()
+	copyDir := filepath.Join(unit, ".terragrunt-cache", "abc123", "def456")
+	if err := os.MkdirAll(copyDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	repoRoot := filepath.Dir(filepath.Dir(wd))
+	t.Chdir(copyDir)
+
+	config := func(sourceDir string) string {
+		return fmt.Sprintf(`
+provider "brockhoff" {
+  source_dir = %q
+}
+
+data "brockhoff_context" "test" {
+  name        = "app"
+  environment = "dev"
+}
+`, sourceDir)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "brockhoff_context" "test" {
+  name        = "app"
+  environment = "dev"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.brockhoff_context.test", "tags.bc-sourcecommit", "N/A"),
+				),
+			},
+			{
+				Config: config(repoRoot),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.brockhoff_context.test", "tags.bc-sourcecommit"),
+				),
+			},
+			{
+				Config:      config(filepath.Join(unit, "missing")),
+				ExpectError: regexp.MustCompile(`Invalid source_dir`),
+			},
+		},
+	})
+}
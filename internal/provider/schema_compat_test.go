@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+var updateSchema = flag.Bool("update", false, "update testdata/schema.json")
+
+// TestDataSourceSchemaCompatibility guards the compatibility policy in the
+// README. Data source schemas cannot be versioned or upgraded, so attributes
+// may be added but never removed or retyped outside a major release.
+// Run with -update after adding attributes.
+func TestDataSourceSchemaCompatibility(t *testing.T) {
+	server, err := providerserver.NewProtocol6WithError(New("test")())()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := server.GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := map[string]map[string]string{}
+	for name, s := range resp.DataSourceSchemas {
+		got[name] = map[string]string{}
+		addAttributeTypes(got[name], "", s.Block.Attributes)
+	}
+
+	path := filepath.Join("testdata", "schema.json")
+	if *updateSchema {
+		data, err := json.MarshalIndent(got, "", "  ")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading %s: %v (run with -update to create it)", path, err)
+	}
+	var want map[string]map[string]string
+	if err := json.Unmarshal(data, &want); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(want)) {
+		for _, attr := range slices.Sorted(maps.Keys(want[name])) {
+			typ, ok := got[name][attr]
+			switch {
+			case !ok:
+				t.Errorf("%s.%s was removed; deprecate it and remove it in a major release", name, attr)
+			case typ != want[name][attr]:
+				t.Errorf("%s.%s changed type from %s to %s; add a new attribute instead", name, attr, want[name][attr], typ)
+			}
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(got)) {
+		for _, attr := range slices.Sorted(maps.Keys(got[name])) {
+			if _, ok := want[name][attr]; !ok {
+				t.Errorf("%s.%s is not in %s; run the test with -update", name, attr, path)
+			}
+		}
+	}
+}
+
+// addAttributeTypes records the type of each attribute by path, with nested
+// attributes such as context_output.namespace listed individually so that
+// adding one is not reported as a type change of its parent
+func addAttributeTypes(types map[string]string, prefix string, attributes []*tfprotov6.SchemaAttribute) {
+	for _, a := range attributes {
+		if a.NestedType != nil {
+			addAttributeTypes(types, prefix+a.Name+".", a.NestedType.Attributes)
+			continue
+		}
+		types[prefix+a.Name] = a.Type.String()
+	}
+}
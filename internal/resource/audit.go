@@ -0,0 +1,94 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/kbrockhoff/terraform-provider-context/internal/core"
+	ctxdatasource "github.com/kbrockhoff/terraform-provider-context/internal/datasource"
+)
+
+// auditAttributes are the attributes recorded in the audit record of the
+// publish resources
+func auditAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"name_prefix": schema.StringAttribute{
+			Description: "Name prefix of the context, such as data.brockhoff_context.this.name_prefix, recorded in the audit record posted to the provider audit_webhook_url",
+			Optional:    true,
+		},
+		"tags": schema.MapAttribute{
+			Description: "Tags of the context, such as data.brockhoff_context.this.tags, whose digest is recorded in the audit record posted to the provider audit_webhook_url",
+			ElementType: types.StringType,
+			Optional:    true,
+		},
+	}
+}
+
+// configureProvider returns the provider configuration passed to Configure
+func configureProvider(req resource.ConfigureRequest, resp *resource.ConfigureResponse) *ctxdatasource.ProviderConfig {
+	// Prevent panic if the provider is not configured.
+	if req.ProviderData == nil {
+		return nil
+	}
+
+	providerConfig, ok := req.ProviderData.(*ctxdatasource.ProviderConfig)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ProviderConfig, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return nil
+	}
+	return providerConfig
+}
+
+// sourceRepoTagsEnabled reports whether source_repo_tags_enabled of the
+// context is true, its default when null
+func sourceRepoTagsEnabled(contextValue types.Object) bool {
+	enabled, ok := contextValue.Attributes()["source_repo_tags_enabled"].(types.Bool)
+	return !ok || enabled.IsNull() || enabled.IsUnknown() || enabled.ValueBool()
+}
+
+// postAuditRecord posts the audit record of a published context to the
+// audit_webhook_url of the provider, when set. The repository and commit, of
+// the git_root of the context when set, are recorded unless
+// source_repo_tags_enabled of the context is false. The
+// context is published already, so a failed delivery is a warning.
+func postAuditRecord(ctx context.Context, providerConfig *ctxdatasource.ProviderConfig, id string, contextValue types.Object, namePrefix types.String, tags types.Map, diags *diag.Diagnostics) {
+	if providerConfig == nil || providerConfig.AuditWebhookURL == "" {
+		return
+	}
+
+	var tagValues map[string]string
+	diags.Append(tags.ElementsAs(ctx, &tagValues, false)...)
+	if diags.HasError() {
+		return
+	}
+	record := core.NewAuditRecord(namePrefix.ValueString(), tagValues)
+	record.Repo, record.Commit = "", ""
+	if sourceRepoTagsEnabled(contextValue) {
+		gitTimeout := providerConfig.GitTimeout
+		if gitTimeout == 0 {
+			gitTimeout = core.DefaultGitTimeout
+		}
+		gitRoot, _ := contextValue.Attributes()["git_root"].(types.String)
+		if gitInfo, err := core.GetGitInfoInDir(providerConfig.GitRootFor(gitRoot.ValueString()), gitTimeout); err == nil {
+			record.Repo, record.Commit = gitInfo.RepoURL, gitInfo.CommitHash
+		}
+	}
+
+	if err := core.PostAuditRecord(ctx, providerConfig.AuditWebhookURL, record); err != nil {
+		diags.AddWarning("Failed to post audit record",
+			fmt.Sprintf("The context of %s is published, but its audit record was not delivered: %s", id, err))
+		return
+	}
+	tflog.Debug(ctx, "Posted audit record", map[string]interface{}{
+		"id":         id,
+		"tag_digest": record.TagDigest,
+	})
+}
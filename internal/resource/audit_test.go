@@ -0,0 +1,223 @@
+package resource
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/kbrockhoff/terraform-provider-context/internal/core"
+	ctxdatasource "github.com/kbrockhoff/terraform-provider-context/internal/datasource"
+)
+
+// testAuditWebhook serves an audit webhook answering with status and
+// returns the records it received
+func testAuditWebhook(t *testing.T, status int) (string, func() []core.AuditRecord) {
+	t.Helper()
+	var mu sync.Mutex
+	var records []core.AuditRecord
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		var record core.AuditRecord
+		if err := json.NewDecoder(r.Body).Decode(&record); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		records = append(records, record)
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+
+	return server.URL, func() []core.AuditRecord {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]core.AuditRecord(nil), records...)
+	}
+}
+
+// testVault serves the KV version 2 writes of the mount "secret" and points
+// VAULT_ADDR and VAULT_TOKEN at it
+func testVault(t *testing.T) {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, "/v1/secret/data/") || r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"version": 1}})
+	}))
+	t.Cleanup(server.Close)
+
+	t.Setenv("VAULT_ADDR", server.URL)
+	t.Setenv("VAULT_TOKEN", "test-token")
+}
+
+// testSSM serves the PutParameter action and points the AWS environment at it
+func testSSM(t *testing.T) {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Amz-Target") != "AmazonSSM.PutParameter" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"Version": 1})
+	}))
+	t.Cleanup(server.Close)
+
+	dir := t.TempDir()
+	t.Setenv("AWS_CONFIG_FILE", filepath.Join(dir, "config"))
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", filepath.Join(dir, "credentials"))
+	t.Setenv("AWS_PROFILE", "")
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIDEXAMPLE")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	t.Setenv("AWS_REGION", "us-east-1")
+	t.Setenv("AWS_ENDPOINT_URL_SSM", server.URL)
+}
+
+// applyResource runs Create, then Update with the same plan, of r with the
+// attributes and the context attributes set in the plan and the others null
+func applyResource(t *testing.T, r resource.Resource, providerConfig *ctxdatasource.ProviderConfig, attributes, contextAttributes map[string]tftypes.Value) diag.Diagnostics {
+	t.Helper()
+	ctx := context.Background()
+
+	var configureResp resource.ConfigureResponse
+	r.(resource.ResourceWithConfigure).Configure(ctx, resource.ConfigureRequest{ProviderData: providerConfig}, &configureResp)
+	if configureResp.Diagnostics.HasError() {
+		return configureResp.Diagnostics
+	}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	values := map[string]tftypes.Value{}
+	for name, attrType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attrType, nil)
+	}
+	for name, value := range attributes {
+		values[name] = value
+	}
+	contextType := objectType.AttributeTypes["context"].(tftypes.Object)
+	contextValues := map[string]tftypes.Value{}
+	for name, attrType := range contextType.AttributeTypes {
+		contextValues[name] = tftypes.NewValue(attrType, nil)
+	}
+	contextValues["namespace"] = tftypes.NewValue(tftypes.String, "ex")
+	for name, value := range contextAttributes {
+		contextValues[name] = value
+	}
+	values["context"] = tftypes.NewValue(contextType, contextValues)
+	plan := tfsdk.Plan{Raw: tftypes.NewValue(objectType, values), Schema: schemaResp.Schema}
+
+	createResp := &resource.CreateResponse{State: tfsdk.State{Raw: plan.Raw, Schema: schemaResp.Schema}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, createResp)
+	if createResp.Diagnostics.HasError() {
+		return createResp.Diagnostics
+	}
+
+	updateResp := &resource.UpdateResponse{State: createResp.State}
+	r.Update(ctx, resource.UpdateRequest{Plan: plan, State: createResp.State}, updateResp)
+	return append(createResp.Diagnostics, updateResp.Diagnostics...)
+}
+
+func TestPublishResources_auditRecord(t *testing.T) {
+	testVault(t)
+	testSSM(t)
+	tags := tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+		"bc-environment": tftypes.NewValue(tftypes.String, "dev"),
+	})
+
+	tests := []struct {
+		name       string
+		resource   resource.Resource
+		attributes map[string]tftypes.Value
+	}{
+		{
+			name:     "vault",
+			resource: NewContextVaultPublishResource(),
+			attributes: map[string]tftypes.Value{
+				"mount": tftypes.NewValue(tftypes.String, "secret"),
+				"path":  tftypes.NewValue(tftypes.String, "contexts/ex/dev"),
+			},
+		},
+		{
+			name:       "ssm",
+			resource:   NewContextSSMPublishResource(),
+			attributes: map[string]tftypes.Value{"name": tftypes.NewValue(tftypes.String, "/contexts/ex/dev")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.attributes["name_prefix"] = tftypes.NewValue(tftypes.String, "ex-dev")
+			tt.attributes["tags"] = tags
+
+			webhookURL, records := testAuditWebhook(t, http.StatusAccepted)
+			diags := applyResource(t, tt.resource, &ctxdatasource.ProviderConfig{AuditWebhookURL: webhookURL}, tt.attributes, nil)
+			if diags.HasError() || diags.WarningsCount() > 0 {
+				t.Fatalf("apply diagnostics = %v", diags)
+			}
+			got := records()
+			if len(got) != 2 {
+				t.Fatalf("audit records = %d, want one for create and one for update", len(got))
+			}
+			want := core.NewAuditRecord("ex-dev", map[string]string{"bc-environment": "dev"})
+			gitInfo, err := core.GetGitInfoInDir("", core.DefaultGitTimeout)
+			if err != nil || gitInfo.CommitHash == "" {
+				t.Fatalf("GetGitInfoInDir() = %+v, %v, want the commit of the working directory", gitInfo, err)
+			}
+			for _, record := range got {
+				if record.NamePrefix != want.NamePrefix || record.TagDigest != want.TagDigest {
+					t.Errorf("audit record = %+v, want name prefix %s and tag digest %s", record, want.NamePrefix, want.TagDigest)
+				}
+				if record.Repo != gitInfo.RepoURL || record.Commit != gitInfo.CommitHash {
+					t.Errorf("audit record = %+v, want repo %q and commit %s of the working directory", record, gitInfo.RepoURL, gitInfo.CommitHash)
+				}
+			}
+
+			// Without the source repository tags the repository and commit
+			// are not recorded
+			webhookURL, records = testAuditWebhook(t, http.StatusAccepted)
+			diags = applyResource(t, tt.resource, &ctxdatasource.ProviderConfig{AuditWebhookURL: webhookURL}, tt.attributes, map[string]tftypes.Value{
+				"source_repo_tags_enabled": tftypes.NewValue(tftypes.Bool, false),
+			})
+			if diags.HasError() || diags.WarningsCount() > 0 {
+				t.Fatalf("apply diagnostics = %v", diags)
+			}
+			for _, record := range records() {
+				if record.Repo != "" || record.Commit != "" {
+					t.Errorf("audit record = %+v, want no repo or commit", record)
+				}
+			}
+
+			// A failed delivery does not fail the apply
+			webhookURL, records = testAuditWebhook(t, http.StatusInternalServerError)
+			diags = applyResource(t, tt.resource, &ctxdatasource.ProviderConfig{AuditWebhookURL: webhookURL}, tt.attributes, nil)
+			if diags.HasError() {
+				t.Fatalf("apply diagnostics = %v", diags)
+			}
+			if diags.WarningsCount() != 2 || diags.Warnings()[0].Summary() != "Failed to post audit record" {
+				t.Errorf("apply warnings = %v, want a failed audit record warning for create and update", diags.Warnings())
+			}
+			if len(records()) != 2 {
+				t.Errorf("audit records = %d, want 2", len(records()))
+			}
+
+			// Without audit_webhook_url nothing is posted
+			diags = applyResource(t, tt.resource, &ctxdatasource.ProviderConfig{}, tt.attributes, nil)
+			if diags.HasError() || diags.WarningsCount() > 0 {
+				t.Errorf("apply diagnostics = %v", diags)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package resource
+
+import (
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// contextData converts a context object into the secret data, keeping null
+// attributes as JSON null so the secret decodes back into a context object
+// with jsondecode
+func contextData(obj types.Object) map[string]any {
+	data := make(map[string]any, len(obj.Attributes()))
+	for name, value := range obj.Attributes() {
+		data[name] = jsonValue(value)
+	}
+	return data
+}
+
+// jsonValue returns the JSON form of a context attribute value
+func jsonValue(value attr.Value) any {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+	switch v := value.(type) {
+	case types.String:
+		return v.ValueString()
+	case types.Bool:
+		return v.ValueBool()
+	case types.Int64:
+		return v.ValueInt64()
+	case types.Float64:
+		return v.ValueFloat64()
+	case types.List:
+		elems := make([]any, 0, len(v.Elements()))
+		for _, elem := range v.Elements() {
+			elems = append(elems, jsonValue(elem))
+		}
+		return elems
+	case types.Map:
+		elems := make(map[string]any, len(v.Elements()))
+		for key, elem := range v.Elements() {
+			elems[key] = jsonValue(elem)
+		}
+		return elems
+	case types.Object:
+		return contextData(v)
+	}
+	return nil
+}
+
+// sameJSON reports whether a and b encode to the same JSON. Map keys are
+// encoded in order, so the result does not depend on map iteration.
+func sameJSON(a, b map[string]any) bool {
+	aJSON, aErr := json.Marshal(a)
+	bJSON, bErr := json.Marshal(b)
+	return aErr == nil && bErr == nil && string(aJSON) == string(bJSON)
+}
@@ -0,0 +1,211 @@
+package resource
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	ctxdatasource "github.com/kbrockhoff/terraform-provider-context/internal/datasource"
+	"github.com/kbrockhoff/terraform-provider-context/internal/tracing"
+	"github.com/kbrockhoff/terraform-provider-context/pkg/integrations/aws"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ContextSSMPublishResource{}
+var _ resource.ResourceWithConfigure = &ContextSSMPublishResource{}
+
+func NewContextSSMPublishResource() resource.Resource {
+	return &ContextSSMPublishResource{}
+}
+
+// ContextSSMPublishResource writes a resolved context as JSON to an AWS
+// Systems Manager parameter, so runtime applications can read it.
+type ContextSSMPublishResource struct {
+	providerConfig *ctxdatasource.ProviderConfig
+}
+
+// ContextSSMPublishResourceModel describes the resource data model.
+type ContextSSMPublishResourceModel struct {
+	Name    types.String `tfsdk:"name"`
+	Context types.Object `tfsdk:"context"`
+
+	// Audit record
+	NamePrefix types.String `tfsdk:"name_prefix"`
+	Tags       types.Map    `tfsdk:"tags"`
+
+	// Computed Outputs
+	ID      types.String `tfsdk:"id"`
+	Version types.Int64  `tfsdk:"version"`
+}
+
+func (r *ContextSSMPublishResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_context_ssm_publish"
+}
+
+func (r *ContextSSMPublishResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Publishes a resolved context as JSON to an AWS Systems Manager Parameter Store parameter, giving runtime applications the context that tagged their infrastructure. Credentials and region are resolved like the AWS CLI, from the environment, shared config profiles, web identity or the ECS task or EC2 instance role.",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Description: "Name of the parameter, such as /contexts/payments/prod",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"context": schema.ObjectAttribute{
+				Description:    "Context to publish, such as data.brockhoff_context.this.context_output",
+				Required:       true,
+				AttributeTypes: ctxdatasource.ContextAttributeTypes(),
+			},
+
+			// Computed Outputs
+			"id": schema.StringAttribute{
+				Description: "Name of the parameter",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"version": schema.Int64Attribute{
+				Description: "Version of the parameter written by the last apply",
+				Computed:    true,
+			},
+		},
+	}
+	maps.Copy(resp.Schema.Attributes, auditAttributes())
+}
+
+func (r *ContextSSMPublishResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.providerConfig = configureProvider(req, resp)
+}
+
+func (r *ContextSSMPublishResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tracing.StartSpan(ctx, "ContextSSMPublishResource.Create")
+	defer func() { tracing.EndSpan(span, resp.Diagnostics) }()
+
+	var data ContextSSMPublishResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.publish(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ContextSSMPublishResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := tracing.StartSpan(ctx, "ContextSSMPublishResource.Read")
+	defer func() { tracing.EndSpan(span, resp.Diagnostics) }()
+
+	var data ContextSSMPublishResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ssm, err := aws.NewSSMParametersFromConfig(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing AWS configuration", err.Error())
+		return
+	}
+	value, version, found, err := ssm.Get(ctx, data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read context from SSM", err.Error())
+		return
+	}
+	if !found {
+		tflog.Info(ctx, "Published context not found in SSM, removing from state", map[string]interface{}{
+			"id": data.ID.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// A parameter changed outside Terraform clears context, so the next plan
+	// publishes the configured context again
+	var published map[string]any
+	if json.Unmarshal([]byte(value), &published) != nil || !sameJSON(published, contextData(data.Context)) {
+		data.Context = types.ObjectNull(ctxdatasource.ContextAttributeTypes())
+	}
+	data.Version = types.Int64Value(version)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ContextSSMPublishResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := tracing.StartSpan(ctx, "ContextSSMPublishResource.Update")
+	defer func() { tracing.EndSpan(span, resp.Diagnostics) }()
+
+	var data ContextSSMPublishResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.publish(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ContextSSMPublishResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tracing.StartSpan(ctx, "ContextSSMPublishResource.Delete")
+	defer func() { tracing.EndSpan(span, resp.Diagnostics) }()
+
+	var data ContextSSMPublishResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ssm, err := aws.NewSSMParametersFromConfig(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing AWS configuration", err.Error())
+		return
+	}
+	if err := ssm.Delete(ctx, data.Name.ValueString()); err != nil {
+		resp.Diagnostics.AddError("Failed to delete context from SSM", err.Error())
+	}
+}
+
+// publish writes the context of data to SSM and sets the computed outputs
+func (r *ContextSSMPublishResource) publish(ctx context.Context, data *ContextSSMPublishResourceModel, diags *diag.Diagnostics) {
+	ssm, err := aws.NewSSMParametersFromConfig(ctx)
+	if err != nil {
+		diags.AddError("Missing AWS configuration", err.Error())
+		return
+	}
+	value, err := json.Marshal(contextData(data.Context))
+	if err != nil {
+		diags.AddError("Failed to encode context", err.Error())
+		return
+	}
+	version, err := ssm.Put(ctx, data.Name.ValueString(), string(value))
+	if err != nil {
+		diags.AddError("Failed to publish context to SSM", err.Error())
+		return
+	}
+
+	data.ID = data.Name
+	data.Version = types.Int64Value(version)
+
+	tflog.Debug(ctx, "Published context to SSM", map[string]interface{}{
+		"id":      data.ID.ValueString(),
+		"version": version,
+	})
+
+	postAuditRecord(ctx, r.providerConfig, data.ID.ValueString(), data.Context, data.NamePrefix, data.Tags, diags)
+}
@@ -0,0 +1,216 @@
+package resource
+
+import (
+	"context"
+	"maps"
+	"path"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	ctxdatasource "github.com/kbrockhoff/terraform-provider-context/internal/datasource"
+	"github.com/kbrockhoff/terraform-provider-context/internal/tracing"
+	"github.com/kbrockhoff/terraform-provider-context/pkg/integrations/vault"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ContextVaultPublishResource{}
+var _ resource.ResourceWithConfigure = &ContextVaultPublishResource{}
+
+func NewContextVaultPublishResource() resource.Resource {
+	return &ContextVaultPublishResource{}
+}
+
+// ContextVaultPublishResource writes a resolved context to a Vault KV
+// version 2 secret, so other stacks and applications can read it.
+type ContextVaultPublishResource struct {
+	providerConfig *ctxdatasource.ProviderConfig
+}
+
+// ContextVaultPublishResourceModel describes the resource data model.
+type ContextVaultPublishResourceModel struct {
+	Mount   types.String `tfsdk:"mount"`
+	Path    types.String `tfsdk:"path"`
+	Context types.Object `tfsdk:"context"`
+
+	// Audit record
+	NamePrefix types.String `tfsdk:"name_prefix"`
+	Tags       types.Map    `tfsdk:"tags"`
+
+	// Computed Outputs
+	ID      types.String `tfsdk:"id"`
+	Version types.Int64  `tfsdk:"version"`
+}
+
+func (r *ContextVaultPublishResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_context_vault_publish"
+}
+
+func (r *ContextVaultPublishResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Publishes a resolved context to a Vault KV version 2 secret, as a remote context backend that other stacks and applications can read. The Vault address and token are read from VAULT_ADDR, VAULT_TOKEN and VAULT_NAMESPACE.",
+
+		Attributes: map[string]schema.Attribute{
+			"mount": schema.StringAttribute{
+				Description: "Mount path of the KV version 2 secrets engine (default: secret)",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(vault.DefaultKVMount),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"path": schema.StringAttribute{
+				Description: "Path of the secret within the mount, such as contexts/payments",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"context": schema.ObjectAttribute{
+				Description:    "Context to publish, such as data.brockhoff_context.this.context_output. Each attribute is a key of the secret data",
+				Required:       true,
+				AttributeTypes: ctxdatasource.ContextAttributeTypes(),
+			},
+
+			// Computed Outputs
+			"id": schema.StringAttribute{
+				Description: "Mount and path of the secret",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"version": schema.Int64Attribute{
+				Description: "Version of the secret written by the last apply",
+				Computed:    true,
+			},
+		},
+	}
+	maps.Copy(resp.Schema.Attributes, auditAttributes())
+}
+
+func (r *ContextVaultPublishResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.providerConfig = configureProvider(req, resp)
+}
+
+func (r *ContextVaultPublishResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tracing.StartSpan(ctx, "ContextVaultPublishResource.Create")
+	defer func() { tracing.EndSpan(span, resp.Diagnostics) }()
+
+	var data ContextVaultPublishResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.publish(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ContextVaultPublishResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := tracing.StartSpan(ctx, "ContextVaultPublishResource.Read")
+	defer func() { tracing.EndSpan(span, resp.Diagnostics) }()
+
+	var data ContextVaultPublishResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	kv, err := vault.NewKVFromEnv(data.Mount.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Vault configuration", err.Error())
+		return
+	}
+	secret, version, found, err := kv.Get(ctx, data.Path.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read context from Vault", err.Error())
+		return
+	}
+	if !found {
+		tflog.Info(ctx, "Published context not found in Vault, removing from state", map[string]interface{}{
+			"id": data.ID.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// A secret changed outside Terraform clears context, so the next plan
+	// publishes the configured context again
+	if !sameJSON(secret, contextData(data.Context)) {
+		data.Context = types.ObjectNull(ctxdatasource.ContextAttributeTypes())
+	}
+	data.Version = types.Int64Value(version)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ContextVaultPublishResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := tracing.StartSpan(ctx, "ContextVaultPublishResource.Update")
+	defer func() { tracing.EndSpan(span, resp.Diagnostics) }()
+
+	var data ContextVaultPublishResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.publish(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ContextVaultPublishResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tracing.StartSpan(ctx, "ContextVaultPublishResource.Delete")
+	defer func() { tracing.EndSpan(span, resp.Diagnostics) }()
+
+	var data ContextVaultPublishResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	kv, err := vault.NewKVFromEnv(data.Mount.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Vault configuration", err.Error())
+		return
+	}
+	if err := kv.Delete(ctx, data.Path.ValueString()); err != nil {
+		resp.Diagnostics.AddError("Failed to delete context from Vault", err.Error())
+	}
+}
+
+// publish writes the context of data to Vault and sets the computed outputs
+func (r *ContextVaultPublishResource) publish(ctx context.Context, data *ContextVaultPublishResourceModel, diags *diag.Diagnostics) {
+	kv, err := vault.NewKVFromEnv(data.Mount.ValueString())
+	if err != nil {
+		diags.AddError("Missing Vault configuration", err.Error())
+		return
+	}
+	version, err := kv.Put(ctx, data.Path.ValueString(), contextData(data.Context))
+	if err != nil {
+		diags.AddError("Failed to publish context to Vault", err.Error())
+		return
+	}
+
+	data.ID = types.StringValue(path.Join(data.Mount.ValueString(), data.Path.ValueString()))
+	data.Version = types.Int64Value(version)
+
+	tflog.Debug(ctx, "Published context to Vault", map[string]interface{}{
+		"id":      data.ID.ValueString(),
+		"version": version,
+	})
+
+	postAuditRecord(ctx, r.providerConfig, data.ID.ValueString(), data.Context, data.NamePrefix, data.Tags, diags)
+}
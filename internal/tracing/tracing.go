@@ -0,0 +1,76 @@
+// Package tracing exports OpenTelemetry spans of provider operations over
+// OTLP when OTEL_EXPORTER_OTLP_ENDPOINT or OTEL_EXPORTER_OTLP_TRACES_ENDPOINT
+// is set, so that slow plans with many data sources can be diagnosed in a
+// tracing backend. Without an endpoint the spans are no-ops.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName identifies the provider as the source of the spans
+const instrumentationName = "github.com/kbrockhoff/terraform-provider-context"
+
+// Enabled reports whether an OTLP endpoint is configured
+func Enabled() bool {
+	return os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" || os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") != ""
+}
+
+// Start installs the global tracer provider exporting over OTLP/HTTP when
+// Enabled. The exporter reads the other OTEL_EXPORTER_OTLP_* variables, such
+// as headers. The returned function flushes the spans and must be called
+// before the plugin process exits.
+func Start(ctx context.Context, version string) (func(context.Context) error, error) {
+	if !Enabled() {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName("terraform-provider-context"),
+		semconv.ServiceVersion(version),
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(tp)
+	return tp.Shutdown, nil
+}
+
+// StartSpan starts a span of a provider operation, such as
+// ContextDataSource.Read, as a child of any span in ctx
+func StartSpan(ctx context.Context, name string, attrs ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, attrs...)
+}
+
+// EndSpan ends span, marking it failed when diags has errors
+func EndSpan(span trace.Span, diags diag.Diagnostics) {
+	if diags.HasError() {
+		for _, d := range diags.Errors() {
+			span.RecordError(errors.New(d.Summary() + ": " + d.Detail()))
+		}
+		span.SetStatus(codes.Error, diags.Errors()[0].Summary())
+	}
+	span.End()
+}
@@ -0,0 +1,49 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+func TestStart_disabled(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "")
+
+	if Enabled() {
+		t.Fatal("Enabled() = true without an endpoint")
+	}
+	shutdown, err := Start(context.Background(), "test")
+	if err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	if err := shutdown(context.Background()); err != nil {
+		t.Errorf("shutdown() error = %v", err)
+	}
+
+	// Spans are no-ops and must not fail without a tracer provider
+	ctx, span := StartSpan(context.Background(), "test")
+	if ctx == nil {
+		t.Fatal("StartSpan() returned a nil context")
+	}
+	var diags diag.Diagnostics
+	diags.AddError("summary", "detail")
+	EndSpan(span, diags)
+}
+
+func TestStart_enabled(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://127.0.0.1:1")
+
+	if !Enabled() {
+		t.Fatal("Enabled() = false with an endpoint")
+	}
+	shutdown, err := Start(context.Background(), "test")
+	if err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	// No span was recorded, so shutdown has nothing to export
+	if err := shutdown(context.Background()); err != nil {
+		t.Errorf("shutdown() error = %v", err)
+	}
+}
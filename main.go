@@ -6,7 +6,9 @@ import (
 	"log"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/kbrockhoff/terraform-provider-context/internal/metrics"
 	"github.com/kbrockhoff/terraform-provider-context/internal/provider"
+	"github.com/kbrockhoff/terraform-provider-context/internal/tracing"
 )
 
 var (
@@ -24,7 +26,22 @@ func main() {
 		Debug:   debug,
 	}
 
-	err := providerserver.Serve(context.Background(), provider.New(version), opts)
+	ctx := context.Background()
+
+	shutdownTracing, err := tracing.Start(ctx, version)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	err = providerserver.Serve(ctx, provider.New(version), opts)
+
+	// Flush the spans before exiting
+	if shutdownErr := shutdownTracing(ctx); shutdownErr != nil {
+		log.Printf("flushing traces: %s", shutdownErr)
+	}
+	if metricsErr := metrics.WriteFile(); metricsErr != nil {
+		log.Printf("writing metrics: %s", metricsErr)
+	}
 
 	if err != nil {
 		log.Fatal(err.Error())
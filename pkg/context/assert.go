@@ -0,0 +1,54 @@
+package context
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// Expectation is a naming and tagging contract pinned by a test stack,
+// checked against generated values with Diff
+type Expectation struct {
+	// NamePrefix is the expected name prefix; nil skips the check
+	NamePrefix *string
+	// Tags are the expected tag values, keyed with the tag prefix. Other
+	// tags are not checked unless ExactTags is set.
+	Tags map[string]string
+	// ExactTags also rejects tags not in Tags
+	ExactTags bool
+}
+
+// Diff returns the differences between the expectation and the generated
+// namePrefix and tags, one line each, or nil when they match. Lines start
+// with "~" for a different value, "-" for a missing tag and "+" for an
+// unexpected tag; tag lines are sorted by key after the name prefix line.
+func (e Expectation) Diff(namePrefix string, tags map[string]string) []string {
+	var diff []string
+	if e.NamePrefix != nil && *e.NamePrefix != namePrefix {
+		diff = append(diff, fmt.Sprintf("~ name_prefix: expected %q, got %q", *e.NamePrefix, namePrefix))
+	}
+
+	keys := slices.Collect(maps.Keys(e.Tags))
+	if e.ExactTags {
+		for key := range tags {
+			if _, ok := e.Tags[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+	}
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		want, expected := e.Tags[key]
+		got, generated := tags[key]
+		switch {
+		case !expected:
+			diff = append(diff, fmt.Sprintf("+ tags[%q]: unexpected %q", key, got))
+		case !generated:
+			diff = append(diff, fmt.Sprintf("- tags[%q]: expected %q, missing", key, want))
+		case want != got:
+			diff = append(diff, fmt.Sprintf("~ tags[%q]: expected %q, got %q", key, want, got))
+		}
+	}
+	return diff
+}
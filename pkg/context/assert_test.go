@@ -0,0 +1,77 @@
+package context
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExpectation_Diff(t *testing.T) {
+	namePrefix := "myorg-webapp-prod"
+	other := "myorg-webapp-dev"
+	tags := map[string]string{
+		"bc-environment": "Production",
+		"bc-costcenter":  "cc-100",
+		"bc-managedby":   "terraform",
+	}
+
+	tests := []struct {
+		name        string
+		expectation Expectation
+		want        []string
+	}{
+		{
+			name:        "empty expectation",
+			expectation: Expectation{},
+		},
+		{
+			name: "matching subset",
+			expectation: Expectation{
+				NamePrefix: &namePrefix,
+				Tags:       map[string]string{"bc-environment": "Production"},
+			},
+		},
+		{
+			name: "matching exact",
+			expectation: Expectation{
+				Tags:      map[string]string{"bc-environment": "Production", "bc-costcenter": "cc-100", "bc-managedby": "terraform"},
+				ExactTags: true,
+			},
+		},
+		{
+			name:        "different name prefix",
+			expectation: Expectation{NamePrefix: &other},
+			want:        []string{`~ name_prefix: expected "myorg-webapp-dev", got "myorg-webapp-prod"`},
+		},
+		{
+			name: "different and missing tags",
+			expectation: Expectation{
+				NamePrefix: &other,
+				Tags:       map[string]string{"bc-environment": "Development", "bc-tenant": "acme", "bc-costcenter": "cc-100"},
+			},
+			want: []string{
+				`~ name_prefix: expected "myorg-webapp-dev", got "myorg-webapp-prod"`,
+				`~ tags["bc-environment"]: expected "Development", got "Production"`,
+				`- tags["bc-tenant"]: expected "acme", missing`,
+			},
+		},
+		{
+			name: "unexpected tags",
+			expectation: Expectation{
+				Tags:      map[string]string{"bc-environment": "Production"},
+				ExactTags: true,
+			},
+			want: []string{
+				`+ tags["bc-costcenter"]: unexpected "cc-100"`,
+				`+ tags["bc-managedby"]: unexpected "terraform"`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.expectation.Diff(namePrefix, tags); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Diff() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
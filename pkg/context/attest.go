@@ -0,0 +1,162 @@
+package context
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+)
+
+// Environment variables holding the key that signs context digests and the
+// password of an encrypted cosign key
+const (
+	SigningKeyEnvVar         = "CONTEXT_PROVIDER_SIGNING_KEY"
+	SigningKeyPasswordEnvVar = "COSIGN_PASSWORD"
+)
+
+// ParseSigningKey parses a PEM private key: a key generated by
+// cosign generate-key-pair, decrypted with password, or an unencrypted
+// PKCS #8, EC or PKCS #1 key. ECDSA, Ed25519 and RSA keys are supported.
+func ParseSigningKey(pemData, password []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(pemData)
+	if block == nil {
+		return nil, errors.New("signing key is not PEM encoded")
+	}
+
+	var key any
+	var err error
+	switch block.Type {
+	case "ENCRYPTED SIGSTORE PRIVATE KEY", "ENCRYPTED COSIGN PRIVATE KEY":
+		der, decryptErr := decryptCosignKey(block.Bytes, password)
+		if decryptErr != nil {
+			return nil, decryptErr
+		}
+		key, err = x509.ParsePKCS8PrivateKey(der)
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported signing key PEM type %q", block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parsing signing key: %w", err)
+	}
+
+	switch k := key.(type) {
+	case *ecdsa.PrivateKey:
+		return k, nil
+	case ed25519.PrivateKey:
+		return k, nil
+	case *rsa.PrivateKey:
+		return k, nil
+	default:
+		return nil, fmt.Errorf("unsupported signing key type %T", key)
+	}
+}
+
+// cosignKeyEnvelope is the encrypted private key written by cosign: the
+// PKCS #8 key sealed with nacl/secretbox under a scrypt-derived key
+type cosignKeyEnvelope struct {
+	KDF struct {
+		Name   string `json:"name"`
+		Params struct {
+			N int `json:"N"`
+			R int `json:"r"`
+			P int `json:"p"`
+		} `json:"params"`
+		Salt []byte `json:"salt"`
+	} `json:"kdf"`
+	Cipher struct {
+		Name  string `json:"name"`
+		Nonce []byte `json:"nonce"`
+	} `json:"cipher"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// decryptCosignKey returns the PKCS #8 key of an encrypted cosign key
+func decryptCosignKey(data, password []byte) ([]byte, error) {
+	var envelope cosignKeyEnvelope
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return nil, fmt.Errorf("decoding encrypted signing key: %w", err)
+	}
+	if envelope.KDF.Name != "scrypt" || envelope.Cipher.Name != "nacl/secretbox" || len(envelope.Cipher.Nonce) != 24 {
+		return nil, fmt.Errorf("unsupported signing key encryption %s with %s", envelope.KDF.Name, envelope.Cipher.Name)
+	}
+
+	derived, err := scrypt.Key(password, envelope.KDF.Salt, envelope.KDF.Params.N, envelope.KDF.Params.R, envelope.KDF.Params.P, 32)
+	if err != nil {
+		return nil, fmt.Errorf("deriving signing key encryption key: %w", err)
+	}
+
+	var key [32]byte
+	var nonce [24]byte
+	copy(key[:], derived)
+	copy(nonce[:], envelope.Cipher.Nonce)
+	der, ok := secretbox.Open(nil, envelope.Ciphertext, &nonce, &key)
+	if !ok {
+		return nil, fmt.Errorf("decrypting signing key: wrong %s", SigningKeyPasswordEnvVar)
+	}
+	return der, nil
+}
+
+// SignDigest returns the base64 signature of digest, such as the context
+// digest of ContextDigest, as written by cosign sign-blob. It verifies with
+//
+//	printf %s "$digest" > digest.txt
+//	cosign verify-blob --key cosign.pub --signature "$signature" digest.txt
+func SignDigest(signer crypto.Signer, digest string) (string, error) {
+	var signature []byte
+	var err error
+	if _, ok := signer.(ed25519.PrivateKey); ok {
+		// Ed25519 signs the message itself
+		signature, err = signer.Sign(rand.Reader, []byte(digest), crypto.Hash(0))
+	} else {
+		sum := sha256.Sum256([]byte(digest))
+		signature, err = signer.Sign(rand.Reader, sum[:], crypto.SHA256)
+	}
+	if err != nil {
+		return "", fmt.Errorf("signing context digest: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(signature), nil
+}
+
+// VerifyDigestSignature verifies a signature returned by SignDigest
+func VerifyDigestSignature(publicKey crypto.PublicKey, digest, signature string) error {
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return fmt.Errorf("decoding signature: %w", err)
+	}
+
+	sum := sha256.Sum256([]byte(digest))
+	switch k := publicKey.(type) {
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(k, sum[:], sig) {
+			return errors.New("invalid signature")
+		}
+	case ed25519.PublicKey:
+		if !ed25519.Verify(k, []byte(digest), sig) {
+			return errors.New("invalid signature")
+		}
+	case *rsa.PublicKey:
+		if err := rsa.VerifyPKCS1v15(k, crypto.SHA256, sum[:], sig); err != nil {
+			return errors.New("invalid signature")
+		}
+	default:
+		return fmt.Errorf("unsupported public key type %T", publicKey)
+	}
+	return nil
+}
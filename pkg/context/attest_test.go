@@ -0,0 +1,115 @@
+package context
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"testing"
+
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+)
+
+const testDigest = "73d5bf72c3591a809d885a6583d8ca944b29774199a87b8873a2bced78a0ab9a"
+
+// encryptCosignKey encrypts a PKCS #8 key the way cosign generate-key-pair
+// does, with low scrypt parameters to keep the test fast
+func encryptCosignKey(t *testing.T, der, password []byte) []byte {
+	t.Helper()
+
+	var envelope cosignKeyEnvelope
+	envelope.KDF.Name = "scrypt"
+	envelope.KDF.Params.N, envelope.KDF.Params.R, envelope.KDF.Params.P = 1024, 8, 1
+	envelope.KDF.Salt = []byte("0123456789abcdef0123456789abcdef")
+	envelope.Cipher.Name = "nacl/secretbox"
+	envelope.Cipher.Nonce = []byte("0123456789abcdef01234567")
+
+	derived, err := scrypt.Key(password, envelope.KDF.Salt, 1024, 8, 1, 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var key [32]byte
+	var nonce [24]byte
+	copy(key[:], derived)
+	copy(nonce[:], envelope.Cipher.Nonce)
+	envelope.Ciphertext = secretbox.Seal(nil, der, &nonce, &key)
+
+	data, err := json.Marshal(envelope)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "ENCRYPTED SIGSTORE PRIVATE KEY", Bytes: data})
+}
+
+func TestSignDigest(t *testing.T) {
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	_, edKey, _ := ed25519.GenerateKey(rand.Reader)
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+
+	ecDER, _ := x509.MarshalPKCS8PrivateKey(ecKey)
+	edDER, _ := x509.MarshalPKCS8PrivateKey(edKey)
+	rsaDER := x509.MarshalPKCS1PrivateKey(rsaKey)
+	sec1DER, _ := x509.MarshalECPrivateKey(ecKey)
+
+	tests := []struct {
+		name      string
+		pem       []byte
+		password  string
+		publicKey crypto.PublicKey
+	}{
+		{name: "cosign encrypted", pem: encryptCosignKey(t, ecDER, []byte("secret")), password: "secret", publicKey: ecKey.Public()},
+		{name: "pkcs8 ecdsa", pem: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: ecDER}), publicKey: ecKey.Public()},
+		{name: "sec1 ecdsa", pem: pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: sec1DER}), publicKey: ecKey.Public()},
+		{name: "pkcs8 ed25519", pem: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: edDER}), publicKey: edKey.Public()},
+		{name: "pkcs1 rsa", pem: pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: rsaDER}), publicKey: rsaKey.Public()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signer, err := ParseSigningKey(tt.pem, []byte(tt.password))
+			if err != nil {
+				t.Fatalf("ParseSigningKey() error = %v", err)
+			}
+			signature, err := SignDigest(signer, testDigest)
+			if err != nil {
+				t.Fatalf("SignDigest() error = %v", err)
+			}
+			if err := VerifyDigestSignature(tt.publicKey, testDigest, signature); err != nil {
+				t.Errorf("VerifyDigestSignature() error = %v", err)
+			}
+			if err := VerifyDigestSignature(tt.publicKey, "0"+testDigest[1:], signature); err == nil {
+				t.Error("VerifyDigestSignature() error = nil for another digest")
+			}
+		})
+	}
+}
+
+func TestParseSigningKey_Invalid(t *testing.T) {
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	ecDER, _ := x509.MarshalPKCS8PrivateKey(ecKey)
+
+	tests := []struct {
+		name     string
+		pem      []byte
+		password string
+	}{
+		{name: "not pem", pem: []byte("secret")},
+		{name: "wrong password", pem: encryptCosignKey(t, ecDER, []byte("secret")), password: "wrong"},
+		{name: "public key", pem: pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: ecDER})},
+		{name: "corrupt key", pem: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("corrupt")})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseSigningKey(tt.pem, []byte(tt.password)); err == nil {
+				t.Error("ParseSigningKey() error = nil, want error")
+			}
+		})
+	}
+}
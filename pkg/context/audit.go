@@ -0,0 +1,84 @@
+package context
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// auditTimeout bounds the delivery of an audit record
+const auditTimeout = 10 * time.Second
+
+// AuditRecord describes one resolved context for the audit trail of
+// governance teams: what name prefix and tags were stamped from which
+// commit of which repository
+type AuditRecord struct {
+	NamePrefix string    `json:"name_prefix"`
+	TagDigest  string    `json:"tag_digest"`
+	Repo       string    `json:"repo,omitempty"`
+	Commit     string    `json:"commit,omitempty"`
+	ResolvedAt time.Time `json:"resolved_at"`
+}
+
+// NewAuditRecord returns the audit record of a resolved context, with the
+// repository and commit from GetGitInfo when available
+func NewAuditRecord(namePrefix string, tags map[string]string) AuditRecord {
+	record := AuditRecord{
+		NamePrefix: namePrefix,
+		TagDigest:  TagDigest(tags),
+		ResolvedAt: time.Now().UTC(),
+	}
+	if gitInfo, err := GetGitInfo(); err == nil && gitInfo != nil {
+		record.Repo = gitInfo.RepoURL
+		record.Commit = gitInfo.CommitHash
+	}
+	return record
+}
+
+// TagDigest returns the hex SHA-256 of the JSON object of the tags, as
+// written by encoding/json with keys sorted, so that the same tags always give
+// the same digest. Keys and values are quoted strings, so tags such as
+// {"a=b": "c"} and {"a": "b=c"} give different digests.
+func TagDigest(tags map[string]string) string {
+	if tags == nil {
+		tags = map[string]string{}
+	}
+	// Maps of strings always marshal
+	data, _ := json.Marshal(tags)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// PostAuditRecord sends record as JSON to webhookURL. Any status other than
+// 2xx is reported as an error.
+func PostAuditRecord(ctx context.Context, webhookURL string, record AuditRecord) error {
+	body, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, auditTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("posting audit record to %s: %s", req.URL.Host, resp.Status)
+	}
+	return nil
+}
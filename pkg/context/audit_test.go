@@ -0,0 +1,97 @@
+package context
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestTagDigest(t *testing.T) {
+	a := TagDigest(map[string]string{"bc-environment": "Production", "bc-costcenter": "cc-100"})
+	b := TagDigest(map[string]string{"bc-costcenter": "cc-100", "bc-environment": "Production"})
+	if a != b {
+		t.Errorf("TagDigest() depends on map order: %s != %s", a, b)
+	}
+	if len(a) != 64 {
+		t.Errorf("TagDigest() = %q, want 64 hex characters", a)
+	}
+
+	if c := TagDigest(map[string]string{"bc-environment": "Development", "bc-costcenter": "cc-100"}); c == a {
+		t.Error("TagDigest() is the same for different tags")
+	}
+
+	// Tools recompute the digest over the JSON object of the tags
+	if got, want := TagDigest(map[string]string{"c": "d", "a": "b"}), "b85c7da93e8790518898c280e15e3f1af5d46bf4aaa4407690f0f0a3b0316478"; got != want {
+		t.Errorf("TagDigest() = %s, want the SHA-256 of {\"a\":\"b\",\"c\":\"d\"} %s", got, want)
+	}
+	if TagDigest(nil) != TagDigest(map[string]string{}) {
+		t.Error("TagDigest() differs for nil and empty tags")
+	}
+}
+
+func TestTagDigest_Unambiguous(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b map[string]string
+	}{
+		{name: "separator in key or value", a: map[string]string{"a=b": "c"}, b: map[string]string{"a": "b=c"}},
+		{name: "line break in value", a: map[string]string{"a": "b\nc=d"}, b: map[string]string{"a": "b", "c": "d"}},
+		{name: "empty value", a: map[string]string{"a": ""}, b: map[string]string{"a=": ""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if TagDigest(tt.a) == TagDigest(tt.b) {
+				t.Errorf("TagDigest(%v) = TagDigest(%v)", tt.a, tt.b)
+			}
+		})
+	}
+}
+
+func TestPostAuditRecord(t *testing.T) {
+	var got AuditRecord
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" || r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	record := AuditRecord{
+		NamePrefix: "myorg-app-prod",
+		TagDigest:  TagDigest(map[string]string{"bc-environment": "Production"}),
+		Repo:       "https://github.com/example/infra",
+		Commit:     "abc123",
+		ResolvedAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+	if err := PostAuditRecord(context.Background(), server.URL, record); err != nil {
+		t.Fatalf("PostAuditRecord() error = %v", err)
+	}
+	if got != record {
+		t.Errorf("received %+v, want %+v", got, record)
+	}
+
+	if err := PostAuditRecord(context.Background(), server.URL+"/missing", AuditRecord{}); err == nil {
+		t.Error("PostAuditRecord() error = nil, want error for a 400 response")
+	}
+}
+
+func TestNewAuditRecord(t *testing.T) {
+	tags := map[string]string{"bc-environment": "Production"}
+	record := NewAuditRecord("myorg-app-prod", tags)
+	if record.NamePrefix != "myorg-app-prod" || record.TagDigest != TagDigest(tags) {
+		t.Errorf("NewAuditRecord() = %+v", record)
+	}
+	if record.ResolvedAt.IsZero() {
+		t.Error("NewAuditRecord() ResolvedAt is zero")
+	}
+}
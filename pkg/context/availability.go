@@ -0,0 +1,65 @@
+package context
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// DefaultAvailabilityByEnvironmentType is the built-in compatibility matrix
+// of environment types and availability levels: production environments
+// cannot run on preemptable or spot capacity. Environment types not in the
+// matrix accept any availability.
+var DefaultAvailabilityByEnvironmentType = map[string][]string{
+	"Production":      {"standard", "dedicated", "isolated"},
+	"MissionCritical": {"dedicated", "isolated"},
+}
+
+// AvailabilityMatrix returns DefaultAvailabilityByEnvironmentType with the
+// entries of overrides replacing its own. An empty list removes the
+// restriction of its environment type.
+func AvailabilityMatrix(overrides map[string][]string) map[string][]string {
+	matrix := maps.Clone(DefaultAvailabilityByEnvironmentType)
+	for envType, levels := range overrides {
+		if len(levels) == 0 {
+			delete(matrix, envType)
+			continue
+		}
+		matrix[envType] = levels
+	}
+	return matrix
+}
+
+// ValidateAvailabilityMatrix checks that matrix is keyed by environment types
+// and lists availability levels
+func ValidateAvailabilityMatrix(matrix map[string][]string) error {
+	for _, envType := range slices.Sorted(maps.Keys(matrix)) {
+		if envType == "" {
+			return fmt.Errorf("environment type must not be empty")
+		}
+		if err := ValidateEnvironmentType(envType); err != nil {
+			return err
+		}
+		for _, level := range matrix[envType] {
+			if level == "" {
+				return fmt.Errorf("%s: availability must not be empty", envType)
+			}
+			if err := ValidateAvailability(level); err != nil {
+				return fmt.Errorf("%s: %w", envType, err)
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateEnvironmentAvailability checks that availability is allowed for
+// envType by matrix, such as rejecting spot capacity for Production
+func ValidateEnvironmentAvailability(envType, availability string, matrix map[string][]string) error {
+	levels, ok := matrix[envType]
+	if !ok || slices.Contains(levels, availability) {
+		return nil
+	}
+	return fmt.Errorf("availability '%s' is not allowed for environment type %s, must be one of: %s",
+		availability, envType, strings.Join(levels, ", "))
+}
@@ -0,0 +1,94 @@
+package context
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestDefaultAvailabilityByEnvironmentType(t *testing.T) {
+	if err := ValidateAvailabilityMatrix(DefaultAvailabilityByEnvironmentType); err != nil {
+		t.Errorf("DefaultAvailabilityByEnvironmentType is invalid: %v", err)
+	}
+}
+
+func TestAvailabilityMatrix(t *testing.T) {
+	matrix := AvailabilityMatrix(map[string][]string{
+		"Production":      {"dedicated"},
+		"UAT":             {"standard", "dedicated"},
+		"MissionCritical": nil,
+	})
+
+	if !slices.Equal(matrix["Production"], []string{"dedicated"}) {
+		t.Errorf("Production = %v, want the override", matrix["Production"])
+	}
+	if !slices.Equal(matrix["UAT"], []string{"standard", "dedicated"}) {
+		t.Errorf("UAT = %v, want the added entry", matrix["UAT"])
+	}
+	if _, ok := matrix["MissionCritical"]; ok {
+		t.Error("MissionCritical is restricted, want the empty override to remove it")
+	}
+	if len(DefaultAvailabilityByEnvironmentType["MissionCritical"]) == 0 {
+		t.Error("AvailabilityMatrix() modified DefaultAvailabilityByEnvironmentType")
+	}
+}
+
+func TestValidateAvailabilityMatrix(t *testing.T) {
+	tests := []struct {
+		name    string
+		matrix  map[string][]string
+		wantErr string
+	}{
+		{name: "valid", matrix: map[string][]string{"UAT": {"standard"}, "Development": nil}},
+		{name: "empty environment type", matrix: map[string][]string{"": {"standard"}}, wantErr: "environment type must not be empty"},
+		{name: "unknown environment type", matrix: map[string][]string{"Staging": {"standard"}}, wantErr: "invalid environment type 'Staging'"},
+		{name: "empty availability", matrix: map[string][]string{"UAT": {""}}, wantErr: "UAT: availability must not be empty"},
+		{name: "unknown availability", matrix: map[string][]string{"UAT": {"always"}}, wantErr: "UAT: invalid availability"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateAvailabilityMatrix(tt.matrix)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateAvailabilityMatrix() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ValidateAvailabilityMatrix() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateEnvironmentAvailability(t *testing.T) {
+	tests := []struct {
+		envType      string
+		availability string
+		wantErr      bool
+	}{
+		{"MissionCritical", "preemptable", true},
+		{"MissionCritical", "standard", true},
+		{"MissionCritical", "dedicated", false},
+		{"Production", "spot", true},
+		{"Production", "standard", false},
+		{"Development", "spot", false},
+		{"", "preemptable", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.envType+"/"+tt.availability, func(t *testing.T) {
+			err := ValidateEnvironmentAvailability(tt.envType, tt.availability, DefaultAvailabilityByEnvironmentType)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateEnvironmentAvailability() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	err := ValidateEnvironmentAvailability("Production", "spot", DefaultAvailabilityByEnvironmentType)
+	want := "availability 'spot' is not allowed for environment type Production, must be one of: standard, dedicated, isolated"
+	if err == nil || err.Error() != want {
+		t.Errorf("ValidateEnvironmentAvailability() error = %v, want %q", err, want)
+	}
+}
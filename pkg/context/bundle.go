@@ -0,0 +1,218 @@
+package context
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"text/template"
+)
+
+// Policy bundle formats of TaggingStandard.PolicyBundle
+const (
+	// PolicyBundleOPA is an OPA bundle with rego rules of package
+	// brockhoff.tagging, for conftest or opa eval against a plan in JSON
+	PolicyBundleOPA = "opa"
+	// PolicyBundleSentinel is a Sentinel policy for the tfplan/v2 import of
+	// HCP Terraform and Terraform Enterprise
+	PolicyBundleSentinel = "sentinel"
+)
+
+// ValidPolicyBundleFormats contains the list of valid policy bundle formats
+var ValidPolicyBundleFormats = map[string]bool{
+	PolicyBundleOPA:      true,
+	PolicyBundleSentinel: true,
+}
+
+// enumTagKeys are the tags, without the tag prefix, whose values come from a
+// fixed list
+var enumTagKeys = map[string]map[string]bool{
+	"availability": ValidAvailabilityLevels,
+	"expiryaction": ValidLifecycleActions,
+	"sensitivity":  ValidSensitivityLevels,
+	"tier":         ValidTiers,
+}
+
+// TaggingStandard is the tagging standard enforced by the provider: the tags
+// every resource must carry and the values allowed for enumerated tags
+type TaggingStandard struct {
+	TagPrefix string `json:"tag_prefix"`
+	// RequiredTags are the prefixed RequiredTagKeys
+	RequiredTags []string `json:"required_tags"`
+	// AllowedValues are the values, sanitized for the cloud provider, of the
+	// prefixed enumerated tags, including the N/A value
+	AllowedValues map[string][]string `json:"allowed_values"`
+	// TagAttributes are the resource attributes holding tags, in lookup
+	// order, such as tags_all and tags for AWS or labels for GCP
+	TagAttributes []string `json:"tag_attributes"`
+}
+
+// NewTaggingStandard returns the tagging standard of tags generated with
+// tagPrefix for a cloud provider
+func NewTaggingStandard(tagPrefix string, cp CloudProvider) TaggingStandard {
+	standard := TaggingStandard{
+		TagPrefix:     tagPrefix,
+		AllowedValues: map[string][]string{},
+		TagAttributes: []string{"tags"},
+	}
+	for _, key := range RequiredTagKeys {
+		standard.RequiredTags = append(standard.RequiredTags, tagPrefix+key)
+	}
+	for key, valid := range enumTagKeys {
+		values := []string{cp.GetNAValue()}
+		for value := range valid {
+			if value != "" {
+				values = append(values, cp.SanitizeTagValue(value))
+			}
+		}
+		slices.Sort(values)
+		standard.AllowedValues[tagPrefix+key] = slices.Compact(values)
+	}
+
+	switch cp.(type) {
+	case *AWSProvider:
+		// tags_all includes the provider default_tags
+		standard.TagAttributes = []string{"tags_all", "tags"}
+	case *GCPProvider:
+		standard.TagAttributes = []string{"labels"}
+	}
+	return standard
+}
+
+// PolicyBundle returns the files, keyed by path, of a policy in format
+// encoding the standard. Both formats check the resources created or
+// updated by a plan that have a tag attribute: required tags must be
+// present and enumerated tags must hold an allowed value.
+func (s TaggingStandard) PolicyBundle(format string) (map[string]string, error) {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case PolicyBundleOPA:
+		return map[string]string{
+			".manifest":                     `{"roots": ["brockhoff/tagging", "brockhoff/standard"]}` + "\n",
+			"brockhoff/standard/data.json":  string(data) + "\n",
+			"brockhoff/tagging/policy.rego": opaTaggingPolicy,
+		}, nil
+	case PolicyBundleSentinel:
+		var b strings.Builder
+		if err := sentinelTaggingPolicy.Execute(&b, s); err != nil {
+			return nil, err
+		}
+		return map[string]string{
+			"brockhoff-tagging.sentinel": b.String(),
+			"sentinel.hcl":               sentinelConfig,
+		}, nil
+	default:
+		return nil, fmt.Errorf("invalid policy bundle format '%s', must be one of: %s",
+			format, strings.Join(slices.Sorted(maps.Keys(ValidPolicyBundleFormats)), ", "))
+	}
+}
+
+// opaTaggingPolicy checks a Terraform plan in JSON against the standard in
+// data.brockhoff.standard
+const opaTaggingPolicy = `# Generated by the brockhoff provider from its tagging standard; do not edit.
+package brockhoff.tagging
+
+standard := data.brockhoff.standard
+
+# violations returns the messages of the tags that do not follow the standard
+violations(tags) := {msg |
+	some key in standard.required_tags
+	not tags[key]
+	msg := sprintf("missing required tag %s", [key])
+} | {msg |
+	some key, allowed in standard.allowed_values
+	value := tags[key]
+	not value in allowed
+	msg := sprintf("tag %s value '%s' is not one of: %s", [key, value, concat(", ", allowed)])
+}
+
+# resource_tags returns the first tag attribute of a planned resource
+resource_tags(after) := tags if {
+	attributes := [a | some a in standard.tag_attributes; is_object(after[a])]
+	tags := after[attributes[0]]
+}
+
+deny contains msg if {
+	some rc in input.resource_changes
+	rc.mode == "managed"
+	some action in rc.change.actions
+	action in {"create", "update"}
+	tags := resource_tags(rc.change.after)
+	some violation in violations(tags)
+	msg := sprintf("%s: %s", [rc.address, violation])
+}
+`
+
+// sentinelConfig enforces the generated Sentinel policy
+const sentinelConfig = `# Generated by the brockhoff provider from its tagging standard; do not edit.
+policy "brockhoff-tagging" {
+  source            = "./brockhoff-tagging.sentinel"
+  enforcement_level = "hard-mandatory"
+}
+`
+
+// sentinelTaggingPolicy checks the tfplan/v2 import against the standard
+var sentinelTaggingPolicy = template.Must(template.New("sentinel").Funcs(template.FuncMap{
+	"json": func(v any) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+}).Parse(`# Generated by the brockhoff provider from its tagging standard; do not edit.
+import "tfplan/v2" as tfplan
+
+required_tags = {{json .RequiredTags}}
+allowed_values = {{json .AllowedValues}}
+tag_attributes = {{json .TagAttributes}}
+
+# The resources created or updated by the plan that have a tag attribute
+resources = filter tfplan.resource_changes as _, rc {
+	rc.mode is "managed" and
+		(rc.change.actions contains "create" or rc.change.actions contains "update") and
+		any tag_attributes as a { (rc.change.after[a] else null) is not null }
+}
+
+# resource_tags returns the first tag attribute of a planned resource
+resource_tags = func(after) {
+	for tag_attributes as a {
+		if (after[a] else null) is not null {
+			return after[a]
+		}
+	}
+	return {}
+}
+
+# violations returns the messages of the tags that do not follow the standard
+violations = func(tags) {
+	messages = []
+	for required_tags as key {
+		if tags not contains key {
+			append(messages, "missing required tag " + key)
+		}
+	}
+	for allowed_values as key, allowed {
+		if tags contains key and allowed not contains tags[key] {
+			append(messages, "tag " + key + " value '" + tags[key] + "' is not allowed")
+		}
+	}
+	return messages
+}
+
+messages = []
+for resources as address, rc {
+	for violations(resource_tags(rc.change.after)) as msg {
+		append(messages, address + ": " + msg)
+	}
+}
+for messages as msg {
+	print(msg)
+}
+
+main = rule {
+	length(messages) is 0
+}
+`))
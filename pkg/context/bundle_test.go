@@ -0,0 +1,137 @@
+package context
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/open-policy-agent/opa/v1/rego"
+	"github.com/open-policy-agent/opa/v1/storage/inmem"
+)
+
+func TestNewTaggingStandard(t *testing.T) {
+	standard := NewTaggingStandard("bc-", GetCloudProvider("aws"))
+
+	if len(standard.RequiredTags) != len(RequiredTagKeys) || standard.RequiredTags[0] != "bc-environment" {
+		t.Errorf("RequiredTags = %v", standard.RequiredTags)
+	}
+	want := []string{"N/A", "delete", "notify", "stop"}
+	if got := standard.AllowedValues["bc-expiryaction"]; !reflect.DeepEqual(got, want) {
+		t.Errorf("AllowedValues[bc-expiryaction] = %v, want %v", got, want)
+	}
+	if want := []string{"N/A", "app", "data", "web"}; !reflect.DeepEqual(standard.AllowedValues["bc-tier"], want) {
+		t.Errorf("AllowedValues[bc-tier] = %v, want %v", standard.AllowedValues["bc-tier"], want)
+	}
+	if !reflect.DeepEqual(standard.TagAttributes, []string{"tags_all", "tags"}) {
+		t.Errorf("TagAttributes = %v", standard.TagAttributes)
+	}
+
+	gcp := NewTaggingStandard("bc-", GetCloudProvider("gcp"))
+	if !slices.Contains(gcp.AllowedValues["bc-availability"], "not_applicable") {
+		t.Errorf("AllowedValues[bc-availability] = %v, want the gcp N/A value", gcp.AllowedValues["bc-availability"])
+	}
+	if !reflect.DeepEqual(gcp.TagAttributes, []string{"labels"}) {
+		t.Errorf("TagAttributes = %v", gcp.TagAttributes)
+	}
+}
+
+// testPlan is a Terraform plan in JSON with one compliant and one
+// non-compliant resource
+const testPlan = `{
+  "resource_changes": [
+    {
+      "address": "aws_s3_bucket.good",
+      "mode": "managed",
+      "change": {
+        "actions": ["create"],
+        "after": {"tags_all": {"bc-environment": "prod", "bc-availability": "spot", "bc-managedby": "terraform",
+          "bc-deletiondate": "N/A", "bc-expiryaction": "N/A", "bc-costcenter": "cc-100"}}
+      }
+    },
+    {
+      "address": "aws_s3_bucket.bad",
+      "mode": "managed",
+      "change": {
+        "actions": ["update"],
+        "after": {"tags_all": {"bc-environment": "prod", "bc-availability": "always", "bc-managedby": "terraform",
+          "bc-deletiondate": "N/A", "bc-expiryaction": "N/A"}}
+      }
+    },
+    {
+      "address": "aws_iam_policy_document.untagged",
+      "mode": "data",
+      "change": {"actions": ["read"], "after": {}}
+    }
+  ]
+}`
+
+func TestTaggingStandard_PolicyBundleOPA(t *testing.T) {
+	files, err := NewTaggingStandard("bc-", GetCloudProvider("aws")).PolicyBundle(PolicyBundleOPA)
+	if err != nil {
+		t.Fatalf("PolicyBundle() error = %v", err)
+	}
+
+	var standard map[string]any
+	if err := json.Unmarshal([]byte(files["brockhoff/standard/data.json"]), &standard); err != nil {
+		t.Fatalf("data.json: %v", err)
+	}
+	var plan map[string]any
+	if err := json.Unmarshal([]byte(testPlan), &plan); err != nil {
+		t.Fatal(err)
+	}
+
+	query, err := rego.New(
+		rego.Query("data.brockhoff.tagging.deny"),
+		rego.Module("policy.rego", files["brockhoff/tagging/policy.rego"]),
+		rego.Store(inmem.NewFromObject(map[string]any{"brockhoff": map[string]any{"standard": standard}})),
+	).PrepareForEval(context.Background())
+	if err != nil {
+		t.Fatalf("compiling policy.rego: %v", err)
+	}
+	results, err := query.Eval(context.Background(), rego.EvalInput(plan))
+	if err != nil {
+		t.Fatalf("evaluating policy.rego: %v", err)
+	}
+
+	var got []string
+	for _, v := range results[0].Expressions[0].Value.([]any) {
+		got = append(got, v.(string))
+	}
+	slices.Sort(got)
+	want := []string{
+		"aws_s3_bucket.bad: missing required tag bc-costcenter",
+		"aws_s3_bucket.bad: tag bc-availability value 'always' is not one of: N/A, dedicated, isolated, preemptable, spot, standard",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("deny = %v, want %v", got, want)
+	}
+}
+
+func TestTaggingStandard_PolicyBundleSentinel(t *testing.T) {
+	files, err := NewTaggingStandard("bc-", GetCloudProvider("az")).PolicyBundle(PolicyBundleSentinel)
+	if err != nil {
+		t.Fatalf("PolicyBundle() error = %v", err)
+	}
+
+	policy := files["brockhoff-tagging.sentinel"]
+	for _, want := range []string{
+		`import "tfplan/v2" as tfplan`,
+		`required_tags = ["bc-environment","bc-availability","bc-managedby","bc-deletiondate","bc-expiryaction","bc-costcenter"]`,
+		`tag_attributes = ["tags"]`,
+		`"bc-expiryaction":["NotApplicable","delete","notify","stop"]`,
+	} {
+		if !strings.Contains(policy, want) {
+			t.Errorf("brockhoff-tagging.sentinel does not contain %s:\n%s", want, policy)
+		}
+	}
+	if !strings.Contains(files["sentinel.hcl"], `source            = "./brockhoff-tagging.sentinel"`) {
+		t.Errorf("sentinel.hcl = %s", files["sentinel.hcl"])
+	}
+
+	if _, err := NewTaggingStandard("bc-", GetCloudProvider("az")).PolicyBundle("kyverno"); err == nil {
+		t.Error("PolicyBundle() error = nil for an invalid format")
+	}
+}
@@ -17,6 +17,20 @@ var (
 	defaultValidateKeyRegex = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
 )
 
+// Tag key prefixes reserved by each cloud provider, matched case
+// insensitively. Keys using them are rejected at apply time or, like the
+// hidden- tags of the Azure portal, behave unexpectedly.
+var (
+	// kubernetesReservedPrefixes are reserved on every cloud provider, since
+	// Kubernetes controllers and cluster autoscalers act on them
+	kubernetesReservedPrefixes = []string{"k8s.io/", "kubernetes.io/"}
+
+	awsReservedPrefixes     = append([]string{"aws:"}, kubernetesReservedPrefixes...)
+	azureReservedPrefixes   = append([]string{"azure", "microsoft", "windows", "hidden-"}, kubernetesReservedPrefixes...)
+	gcpReservedPrefixes     = append([]string{"goog-"}, kubernetesReservedPrefixes...)
+	defaultReservedPrefixes = kubernetesReservedPrefixes
+)
+
 // CloudProvider interface defines cloud-specific tag formatting rules
 type CloudProvider interface {
 	GetMaxTagLength() int
@@ -26,6 +40,54 @@ type CloudProvider interface {
 	ValidateTagKey(key string) bool
 }
 
+// TagCountLimiter is implemented by cloud providers limiting the number of
+// tags on one resource. Use MaxTagCount to get the limit of any CloudProvider.
+type TagCountLimiter interface {
+	// GetMaxTagCount returns the maximum number of tags on one resource
+	GetMaxTagCount() int
+}
+
+// DefaultMaxTagCount is the tag count limit of cloud providers that do not
+// implement TagCountLimiter
+const DefaultMaxTagCount = 50
+
+// MaxTagCount returns the maximum number of tags on one resource of cp, or
+// DefaultMaxTagCount when cp does not implement TagCountLimiter
+func MaxTagCount(cp CloudProvider) int {
+	if limiter, ok := cp.(TagCountLimiter); ok {
+		return limiter.GetMaxTagCount()
+	}
+	return DefaultMaxTagCount
+}
+
+// ReservedTagKeyChecker is implemented by cloud providers reserving tag key
+// prefixes. Use ReservedTagKeyPrefix to check the keys of any CloudProvider.
+type ReservedTagKeyChecker interface {
+	// ReservedTagKeyPrefix returns the reserved prefix used by key, or ""
+	ReservedTagKeyPrefix(key string) string
+}
+
+// ReservedTagKeyPrefix returns the prefix reserved by cp that key uses, or
+// "". Cloud providers that do not implement ReservedTagKeyChecker reserve the
+// Kubernetes prefixes, as every cloud provider does.
+func ReservedTagKeyPrefix(cp CloudProvider, key string) string {
+	if checker, ok := cp.(ReservedTagKeyChecker); ok {
+		return checker.ReservedTagKeyPrefix(key)
+	}
+	return reservedPrefix(key, kubernetesReservedPrefixes)
+}
+
+// reservedPrefix returns the first of prefixes that key starts with,
+// ignoring case, or ""
+func reservedPrefix(key string, prefixes []string) string {
+	for _, prefix := range prefixes {
+		if len(key) >= len(prefix) && strings.EqualFold(key[:len(prefix)], prefix) {
+			return prefix
+		}
+	}
+	return ""
+}
+
 // AWSProvider implements CloudProvider for AWS
 type AWSProvider struct{}
 
@@ -33,6 +95,10 @@ func (p *AWSProvider) GetMaxTagLength() int {
 	return 256
 }
 
+func (p *AWSProvider) GetMaxTagCount() int {
+	return 50
+}
+
 func (p *AWSProvider) GetDelimiter() string {
 	return " "
 }
@@ -43,7 +109,7 @@ func (p *AWSProvider) GetNAValue() string {
 
 func (p *AWSProvider) SanitizeTagValue(value string) string {
 	// Replace characters not matching /[a-zA-Z0-9 \\.:=+@_/-]/ with _
-	return awsSanitizeRegex.ReplaceAllString(value, "_")
+	return awsSanitizeCache.get(value)
 }
 
 func (p *AWSProvider) ValidateTagKey(key string) bool {
@@ -51,6 +117,11 @@ func (p *AWSProvider) ValidateTagKey(key string) bool {
 	return awsValidateKeyRegex.MatchString(key)
 }
 
+func (p *AWSProvider) ReservedTagKeyPrefix(key string) string {
+	// The aws: prefix is reserved for tags created by AWS
+	return reservedPrefix(key, awsReservedPrefixes)
+}
+
 // AzureProvider implements CloudProvider for Azure
 type AzureProvider struct{}
 
@@ -58,6 +129,10 @@ func (p *AzureProvider) GetMaxTagLength() int {
 	return 256
 }
 
+func (p *AzureProvider) GetMaxTagCount() int {
+	return 50
+}
+
 func (p *AzureProvider) GetDelimiter() string {
 	return ";"
 }
@@ -68,7 +143,7 @@ func (p *AzureProvider) GetNAValue() string {
 
 func (p *AzureProvider) SanitizeTagValue(value string) string {
 	// Replace /[ <>%&\\?/#:]/ with empty string
-	return azureSanitizeRegex.ReplaceAllString(value, "")
+	return azureSanitizeCache.get(value)
 }
 
 func (p *AzureProvider) ValidateTagKey(key string) bool {
@@ -76,6 +151,12 @@ func (p *AzureProvider) ValidateTagKey(key string) bool {
 	return !azureValidateKeyRegex.MatchString(key)
 }
 
+func (p *AzureProvider) ReservedTagKeyPrefix(key string) string {
+	// azure, microsoft and windows are reserved; hidden- tags are hidden by
+	// the portal
+	return reservedPrefix(key, azureReservedPrefixes)
+}
+
 // GCPProvider implements CloudProvider for GCP
 type GCPProvider struct{}
 
@@ -83,6 +164,10 @@ func (p *GCPProvider) GetMaxTagLength() int {
 	return 63
 }
 
+func (p *GCPProvider) GetMaxTagCount() int {
+	return 64
+}
+
 func (p *GCPProvider) GetDelimiter() string {
 	return "_"
 }
@@ -92,9 +177,7 @@ func (p *GCPProvider) GetNAValue() string {
 }
 
 func (p *GCPProvider) SanitizeTagValue(value string) string {
-	// Convert to lowercase and replace non-alphanumeric/underscore/hyphen with hyphen
-	value = strings.ToLower(value)
-	return gcpSanitizeRegex.ReplaceAllString(value, "-")
+	return gcpSanitizeCache.get(value)
 }
 
 func (p *GCPProvider) ValidateTagKey(key string) bool {
@@ -102,6 +185,18 @@ func (p *GCPProvider) ValidateTagKey(key string) bool {
 	return gcpValidateKeyRegex.MatchString(key)
 }
 
+func (p *GCPProvider) ReservedTagKeyPrefix(key string) string {
+	// goog- labels are reserved for labels created by Google Cloud
+	return reservedPrefix(key, gcpReservedPrefixes)
+}
+
+// sanitizeGCPTagValue lowercases value and replaces the characters other than
+// letters, digits, underscores and hyphens with hyphens
+func sanitizeGCPTagValue(value string) string {
+	value = strings.ToLower(value)
+	return gcpSanitizeRegex.ReplaceAllString(value, "-")
+}
+
 // DefaultProvider implements CloudProvider for DC and other providers
 type DefaultProvider struct{}
 
@@ -109,6 +204,10 @@ func (p *DefaultProvider) GetMaxTagLength() int {
 	return 63
 }
 
+func (p *DefaultProvider) GetMaxTagCount() int {
+	return 50
+}
+
 func (p *DefaultProvider) GetDelimiter() string {
 	return ";"
 }
@@ -119,7 +218,7 @@ func (p *DefaultProvider) GetNAValue() string {
 
 func (p *DefaultProvider) SanitizeTagValue(value string) string {
 	// Replace /[<>%&\\?]/ with _
-	return defaultSanitizeRegex.ReplaceAllString(value, "_")
+	return defaultSanitizeCache.get(value)
 }
 
 func (p *DefaultProvider) ValidateTagKey(key string) bool {
@@ -127,6 +226,10 @@ func (p *DefaultProvider) ValidateTagKey(key string) bool {
 	return defaultValidateKeyRegex.MatchString(key)
 }
 
+func (p *DefaultProvider) ReservedTagKeyPrefix(key string) string {
+	return reservedPrefix(key, defaultReservedPrefixes)
+}
+
 // GetCloudProvider returns the appropriate CloudProvider implementation
 func GetCloudProvider(provider string) CloudProvider {
 	switch provider {
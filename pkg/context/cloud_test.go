@@ -2,7 +2,10 @@ package context
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
 	"testing"
+	"unicode/utf8"
 )
 
 func TestAWSProvider(t *testing.T) {
@@ -43,6 +46,9 @@ func TestAWSProvider(t *testing.T) {
 	if p.GetMaxTagLength() != 256 {
 		t.Errorf("AWSProvider.GetMaxTagLength() = %v, want 256", p.GetMaxTagLength())
 	}
+	if p.GetMaxTagCount() != 50 {
+		t.Errorf("AWSProvider.GetMaxTagCount() = %v, want 50", p.GetMaxTagCount())
+	}
 	if p.GetDelimiter() != " " {
 		t.Errorf("AWSProvider.GetDelimiter() = %v, want ' '", p.GetDelimiter())
 	}
@@ -89,6 +95,9 @@ func TestAzureProvider(t *testing.T) {
 	if p.GetMaxTagLength() != 256 {
 		t.Errorf("AzureProvider.GetMaxTagLength() = %v, want 256", p.GetMaxTagLength())
 	}
+	if p.GetMaxTagCount() != 50 {
+		t.Errorf("AzureProvider.GetMaxTagCount() = %v, want 50", p.GetMaxTagCount())
+	}
 	if p.GetDelimiter() != ";" {
 		t.Errorf("AzureProvider.GetDelimiter() = %v, want ';'", p.GetDelimiter())
 	}
@@ -140,6 +149,9 @@ func TestGCPProvider(t *testing.T) {
 	if p.GetMaxTagLength() != 63 {
 		t.Errorf("GCPProvider.GetMaxTagLength() = %v, want 63", p.GetMaxTagLength())
 	}
+	if p.GetMaxTagCount() != 64 {
+		t.Errorf("GCPProvider.GetMaxTagCount() = %v, want 64", p.GetMaxTagCount())
+	}
 	if p.GetDelimiter() != "_" {
 		t.Errorf("GCPProvider.GetDelimiter() = %v, want '_'", p.GetDelimiter())
 	}
@@ -191,3 +203,134 @@ func TestGetCloudProvider(t *testing.T) {
 		})
 	}
 }
+
+func FuzzSanitizeTagValue(f *testing.F) {
+	seeds := []string{
+		"",
+		"test-value_123",
+		"test value 123",
+		"test<>%&\\?/#:value",
+		"test@value#123",
+		"TEST-VALUE",
+		"ünïcödé 値 🚀",
+		"\x00\xff\xfe",
+	}
+	for _, seed := range seeds {
+		f.Add(seed)
+	}
+
+	sanitizers := map[string]*regexp.Regexp{
+		"aws": awsSanitizeRegex,
+		"az":  azureSanitizeRegex,
+		"gcp": gcpSanitizeRegex,
+		"dc":  defaultSanitizeRegex,
+	}
+
+	f.Fuzz(func(t *testing.T, value string) {
+		for provider, illegal := range sanitizers {
+			p := GetCloudProvider(provider)
+			got := p.SanitizeTagValue(value)
+
+			if illegal.MatchString(got) {
+				t.Errorf("%s: SanitizeTagValue(%q) = %q still contains illegal characters", provider, value, got)
+			}
+			if again := p.SanitizeTagValue(got); again != got {
+				t.Errorf("%s: SanitizeTagValue is not idempotent: %q -> %q -> %q", provider, value, got, again)
+			}
+		}
+	})
+}
+
+func FuzzTagProcessor_AdditionalTags(f *testing.F) {
+	f.Add("team", "platform")
+	f.Add("notes", "uses <special> chars? yes: 100%")
+	f.Add("long", strings.Repeat("ab cd ", 100))
+	f.Add("unicode", strings.Repeat("値", 100))
+
+	f.Fuzz(func(t *testing.T, key, value string) {
+		for _, provider := range []string{"aws", "az", "gcp", "dc"} {
+			cp := GetCloudProvider(provider)
+			processor := &TagProcessor{
+				CloudProvider: cp,
+				Config: &DataSourceConfig{
+					AdditionalTags:     map[string]string{key: value},
+					AdditionalDataTags: map[string]string{key: value},
+				},
+				TagPrefix: "bc-",
+			}
+
+			tags, err := processor.Process()
+			if err != nil {
+				t.Fatalf("%s: Process() error = %v", provider, err)
+			}
+			dataTags, err := processor.ProcessDataTags()
+			if err != nil {
+				t.Fatalf("%s: ProcessDataTags() error = %v", provider, err)
+			}
+
+			for _, set := range []map[string]string{tags, dataTags} {
+				for k, v := range set {
+					if len(v) > cp.GetMaxTagLength() {
+						t.Errorf("%s: tag %q value length %d exceeds max %d", provider, k, len(v), cp.GetMaxTagLength())
+					}
+					if utf8.ValidString(value) && !utf8.ValidString(v) {
+						t.Errorf("%s: tag %q value %q is not valid UTF-8", provider, k, v)
+					}
+				}
+			}
+		}
+	})
+}
+
+func TestCloudProvider_ReservedTagKeyPrefix(t *testing.T) {
+	tests := []struct {
+		provider string
+		key      string
+		want     string
+	}{
+		{provider: "aws", key: "aws:cloudformation:stack-name", want: "aws:"},
+		{provider: "aws", key: "AWS:Team", want: "aws:"},
+		{provider: "aws", key: "k8s.io/cluster-autoscaler/enabled", want: "k8s.io/"},
+		{provider: "aws", key: "bc-aws:team"},
+		{provider: "aws", key: "goog-team"},
+		{provider: "az", key: "hidden-link", want: "hidden-"},
+		{provider: "az", key: "Microsoft.Team", want: "microsoft"},
+		{provider: "az", key: "azureteam", want: "azure"},
+		{provider: "az", key: "aws:team"},
+		{provider: "gcp", key: "goog-managed-by", want: "goog-"},
+		{provider: "gcp", key: "team"},
+		{provider: "dc", key: "kubernetes.io/cluster/prod", want: "kubernetes.io/"},
+		{provider: "dc", key: "aws:team"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider+" "+tt.key, func(t *testing.T) {
+			if got := ReservedTagKeyPrefix(GetCloudProvider(tt.provider), tt.key); got != tt.want {
+				t.Errorf("ReservedTagKeyPrefix(%q) = %q, want %q", tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+// minimalProvider implements only CloudProvider, like a provider written
+// before the optional interfaces were added
+type minimalProvider struct{}
+
+func (minimalProvider) GetMaxTagLength() int                 { return 63 }
+func (minimalProvider) GetDelimiter() string                 { return ";" }
+func (minimalProvider) GetNAValue() string                   { return "N/A" }
+func (minimalProvider) SanitizeTagValue(value string) string { return value }
+func (minimalProvider) ValidateTagKey(key string) bool       { return key != "" }
+
+func TestCloudProvider_OptionalInterfaces(t *testing.T) {
+	var minimal CloudProvider = minimalProvider{}
+	if got := MaxTagCount(minimal); got != DefaultMaxTagCount {
+		t.Errorf("MaxTagCount() = %d, want DefaultMaxTagCount", got)
+	}
+	if got := MaxTagCount(GetCloudProvider("gcp")); got != 64 {
+		t.Errorf("MaxTagCount(gcp) = %d, want 64", got)
+	}
+	if got := ReservedTagKeyPrefix(minimal, "k8s.io/role"); got != "k8s.io/" {
+		t.Errorf("ReservedTagKeyPrefix() = %q, want the Kubernetes prefix", got)
+	}
+}
@@ -0,0 +1,171 @@
+package context
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ComplianceProfile is the rule set of a compliance framework, activated by
+// DataSourceConfig.ComplianceProfile
+type ComplianceProfile struct {
+	// RequiredFields are the context attributes, keys of complianceFields,
+	// that must be set
+	RequiredFields []string
+	// DataRegs are the data regulations added to DataRegs, with their
+	// per-regulation data tags enabled
+	DataRegs []string
+	// DefaultSensitivity is used when no sensitivity is set
+	DefaultSensitivity string
+	// MinSensitivity is the lowest sensitivity level allowed
+	MinSensitivity string
+	// ForbidNotApplicable rejects required fields set to the N/A placeholder
+	ForbidNotApplicable bool
+}
+
+// ComplianceProfiles are the bundled compliance profiles by name
+var ComplianceProfiles = map[string]ComplianceProfile{
+	"pci": {
+		RequiredFields:      []string{"cost_center", "product_owners", "data_owners", "security_review", "itsm_system_id"},
+		DataRegs:            []string{"PCI DSS"},
+		DefaultSensitivity:  "confidential",
+		MinSensitivity:      "confidential",
+		ForbidNotApplicable: true,
+	},
+	"hipaa": {
+		RequiredFields:      []string{"cost_center", "product_owners", "data_owners", "security_review", "privacy_review"},
+		DataRegs:            []string{"HIPAA"},
+		DefaultSensitivity:  "restricted",
+		MinSensitivity:      "confidential",
+		ForbidNotApplicable: true,
+	},
+	"fedramp-moderate": {
+		RequiredFields:      []string{"cost_center", "product_owners", "code_owners", "security_review", "itsm_system_id"},
+		DataRegs:            []string{"FedRAMP Moderate"},
+		DefaultSensitivity:  "confidential",
+		MinSensitivity:      "internal",
+		ForbidNotApplicable: true,
+	},
+}
+
+// SensitivityOrder lists the sensitivity levels from the lowest to the highest
+var SensitivityOrder = []string{"public", "internal", "confidential", "restricted", "critical"}
+
+// complianceFields returns the values of the context attributes a
+// compliance profile can require
+var complianceFields = map[string]func(c *DataSourceConfig) []string{
+	"availability":      func(c *DataSourceConfig) []string { return []string{c.Availability} },
+	"managed_by":        func(c *DataSourceConfig) []string { return []string{c.ManagedBy} },
+	"business_unit":     func(c *DataSourceConfig) []string { return []string{c.BusinessUnit} },
+	"application":       func(c *DataSourceConfig) []string { return []string{c.Application} },
+	"cost_center":       func(c *DataSourceConfig) []string { return []string{c.CostCenter} },
+	"product_owners":    func(c *DataSourceConfig) []string { return c.ProductOwners },
+	"code_owners":       func(c *DataSourceConfig) []string { return c.CodeOwners },
+	"data_owners":       func(c *DataSourceConfig) []string { return c.DataOwners },
+	"security_review":   func(c *DataSourceConfig) []string { return []string{c.SecurityReview} },
+	"privacy_review":    func(c *DataSourceConfig) []string { return []string{c.PrivacyReview} },
+	"data_retention":    func(c *DataSourceConfig) []string { return []string{c.DataRetention} },
+	"pm_project_code":   func(c *DataSourceConfig) []string { return []string{c.PMProjectCode} },
+	"itsm_system_id":    func(c *DataSourceConfig) []string { return []string{c.ITSMSystemID} },
+	"itsm_component_id": func(c *DataSourceConfig) []string { return []string{c.ITSMComponentID} },
+}
+
+// SensitivityRequiredFields are the context attributes, keys of
+// complianceFields, that must be set for data of a sensitivity level
+var SensitivityRequiredFields = map[string][]string{
+	"restricted": {"data_retention"},
+	"critical":   {"data_retention"},
+}
+
+// ComplianceProfileNames returns the names of ComplianceProfiles, sorted
+func ComplianceProfileNames() []string {
+	names := make([]string, 0, len(ComplianceProfiles))
+	for name := range ComplianceProfiles {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// ApplyComplianceProfile sets the defaults of the compliance profile of
+// config: its sensitivity when none is set, and its data regulations with
+// their per-regulation data tags. Unknown profiles are left to
+// ValidateComplianceProfile.
+func ApplyComplianceProfile(config *DataSourceConfig) {
+	profile, ok := ComplianceProfiles[config.ComplianceProfile]
+	if !ok {
+		return
+	}
+	if config.Sensitivity == "" {
+		config.Sensitivity = profile.DefaultSensitivity
+	}
+	for _, reg := range profile.DataRegs {
+		listed := slices.ContainsFunc(config.DataRegs, func(r string) bool {
+			return RegulationTagKey(r) == RegulationTagKey(reg)
+		})
+		if !listed {
+			config.DataRegs = append(config.DataRegs, reg)
+		}
+	}
+	if len(profile.DataRegs) > 0 {
+		config.RegulationTagsEnabled = true
+	}
+}
+
+// ComplianceViolations returns the rules of the compliance profile of
+// tp.Config that the context breaks, in the order of the profile
+func (tp *TagProcessor) ComplianceViolations() []string {
+	name := tp.Config.ComplianceProfile
+	profile, ok := ComplianceProfiles[name]
+	if !ok {
+		return nil
+	}
+
+	var violations []string
+	for _, field := range profile.RequiredFields {
+		values := tp.complianceFieldValues(field)
+		if len(values) == 0 {
+			violations = append(violations, fmt.Sprintf("%s is required by the %s compliance profile", field, name))
+			continue
+		}
+		if profile.ForbidNotApplicable && slices.ContainsFunc(values, tp.isNotApplicable) {
+			violations = append(violations, fmt.Sprintf("%s must not be the N/A placeholder under the %s compliance profile", field, name))
+		}
+	}
+
+	if profile.MinSensitivity != "" && tp.Config.Sensitivity != "" &&
+		slices.Index(SensitivityOrder, tp.Config.Sensitivity) < slices.Index(SensitivityOrder, profile.MinSensitivity) {
+		violations = append(violations, fmt.Sprintf("sensitivity '%s' is below the minimum '%s' of the %s compliance profile",
+			tp.Config.Sensitivity, profile.MinSensitivity, name))
+	}
+	return violations
+}
+
+// SensitivityViolations returns the SensitivityRequiredFields of the
+// sensitivity of tp.Config that are not set, such as data_retention for
+// restricted data
+func (tp *TagProcessor) SensitivityViolations() []string {
+	var violations []string
+	for _, field := range SensitivityRequiredFields[tp.Config.Sensitivity] {
+		if values := tp.complianceFieldValues(field); len(values) == 0 || slices.ContainsFunc(values, tp.isNotApplicable) {
+			violations = append(violations, fmt.Sprintf("%s is required for %s data", field, tp.Config.Sensitivity))
+		}
+	}
+	return violations
+}
+
+// complianceFieldValues returns the values of a complianceFields attribute
+// of tp.Config, leaving out blank values
+func (tp *TagProcessor) complianceFieldValues(field string) []string {
+	return slices.DeleteFunc(slices.Clone(complianceFields[field](tp.Config)), func(v string) bool {
+		return strings.TrimSpace(v) == ""
+	})
+}
+
+// isNotApplicable reports whether value is the N/A placeholder, as
+// configured or as sanitized for the cloud provider
+func (tp *TagProcessor) isNotApplicable(value string) bool {
+	value = strings.TrimSpace(value)
+	naValue := tp.naValue()
+	return strings.EqualFold(value, naValue) || strings.EqualFold(value, tp.CloudProvider.SanitizeTagValue(naValue))
+}
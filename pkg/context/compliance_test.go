@@ -0,0 +1,166 @@
+package context
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestComplianceProfiles(t *testing.T) {
+	for name, profile := range ComplianceProfiles {
+		for _, field := range profile.RequiredFields {
+			if _, ok := complianceFields[field]; !ok {
+				t.Errorf("%s requires %s, which is not in complianceFields", name, field)
+			}
+		}
+		for _, level := range []string{profile.DefaultSensitivity, profile.MinSensitivity} {
+			if !slices.Contains(SensitivityOrder, level) {
+				t.Errorf("%s sensitivity %q is not in SensitivityOrder", name, level)
+			}
+		}
+		if slices.Index(SensitivityOrder, profile.DefaultSensitivity) < slices.Index(SensitivityOrder, profile.MinSensitivity) {
+			t.Errorf("%s default sensitivity %s is below its minimum %s", name, profile.DefaultSensitivity, profile.MinSensitivity)
+		}
+	}
+
+	for level := range ValidSensitivityLevels {
+		if level != "" && !slices.Contains(SensitivityOrder, level) {
+			t.Errorf("sensitivity level %s is not in SensitivityOrder", level)
+		}
+	}
+}
+
+func TestApplyComplianceProfile(t *testing.T) {
+	config := &DataSourceConfig{ComplianceProfile: "pci", DataRegs: []string{"GDPR"}}
+	ApplyComplianceProfile(config)
+	if config.Sensitivity != "confidential" {
+		t.Errorf("Sensitivity = %q, want the pci default confidential", config.Sensitivity)
+	}
+	if !slices.Equal(config.DataRegs, []string{"GDPR", "PCI DSS"}) || !config.RegulationTagsEnabled {
+		t.Errorf("DataRegs = %v, RegulationTagsEnabled = %v, want PCI DSS added with regulation tags", config.DataRegs, config.RegulationTagsEnabled)
+	}
+
+	// A set sensitivity and a differently spelled regulation are kept
+	config = &DataSourceConfig{ComplianceProfile: "hipaa", Sensitivity: "critical", DataRegs: []string{"hipaa"}}
+	ApplyComplianceProfile(config)
+	if config.Sensitivity != "critical" || !slices.Equal(config.DataRegs, []string{"hipaa"}) {
+		t.Errorf("Sensitivity = %q, DataRegs = %v, want critical and [hipaa] unchanged", config.Sensitivity, config.DataRegs)
+	}
+
+	// No profile changes nothing
+	config = &DataSourceConfig{}
+	ApplyComplianceProfile(config)
+	if config.Sensitivity != "" || config.DataRegs != nil || config.RegulationTagsEnabled {
+		t.Errorf("ApplyComplianceProfile() without a profile changed %+v", config)
+	}
+}
+
+func TestComplianceViolations(t *testing.T) {
+	compliant := func() *DataSourceConfig {
+		return &DataSourceConfig{
+			ComplianceProfile: "pci",
+			CostCenter:        "cc-100",
+			ProductOwners:     []string{"product@example.com"},
+			DataOwners:        []string{"data@example.com"},
+			SecurityReview:    "SR-42",
+			ITSMSystemID:      "SYS-1",
+			Sensitivity:       "confidential",
+		}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(c *DataSourceConfig)
+		want   []string
+	}{
+		{
+			name:   "compliant",
+			modify: func(c *DataSourceConfig) {},
+		},
+		{
+			name:   "no profile",
+			modify: func(c *DataSourceConfig) { *c = DataSourceConfig{Sensitivity: "public"} },
+		},
+		{
+			name: "missing fields",
+			modify: func(c *DataSourceConfig) {
+				c.CostCenter = ""
+				c.DataOwners = []string{}
+			},
+			want: []string{
+				"cost_center is required by the pci compliance profile",
+				"data_owners is required by the pci compliance profile",
+			},
+		},
+		{
+			name:   "blank field",
+			modify: func(c *DataSourceConfig) { c.SecurityReview = " " },
+			want:   []string{"security_review is required by the pci compliance profile"},
+		},
+		{
+			name:   "N/A placeholder",
+			modify: func(c *DataSourceConfig) { c.ITSMSystemID = "n/a" },
+			want:   []string{"itsm_system_id must not be the N/A placeholder under the pci compliance profile"},
+		},
+		{
+			name: "overridden N/A placeholder",
+			modify: func(c *DataSourceConfig) {
+				c.NAValue = "none"
+				c.CostCenter = "none"
+			},
+			want: []string{"cost_center must not be the N/A placeholder under the pci compliance profile"},
+		},
+		{
+			name:   "sensitivity below minimum",
+			modify: func(c *DataSourceConfig) { c.Sensitivity = "internal" },
+			want:   []string{"sensitivity 'internal' is below the minimum 'confidential' of the pci compliance profile"},
+		},
+		{
+			name:   "sensitivity above minimum",
+			modify: func(c *DataSourceConfig) { c.Sensitivity = "critical" },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := compliant()
+			tt.modify(config)
+			processor := &TagProcessor{CloudProvider: GetCloudProvider("aws"), Config: config}
+			if got := processor.ComplianceViolations(); !slices.Equal(got, tt.want) {
+				t.Errorf("ComplianceViolations() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSensitivityViolations(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *DataSourceConfig
+		want   []string
+	}{
+		{name: "restricted with retention", config: &DataSourceConfig{Sensitivity: "restricted", DataRetention: "P7Y"}},
+		{name: "confidential without retention", config: &DataSourceConfig{Sensitivity: "confidential"}},
+		{name: "restricted without retention", config: &DataSourceConfig{Sensitivity: "restricted"}, want: []string{"data_retention is required for restricted data"}},
+		{name: "critical with N/A retention", config: &DataSourceConfig{Sensitivity: "critical", DataRetention: "N/A"}, want: []string{"data_retention is required for critical data"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processor := &TagProcessor{CloudProvider: GetCloudProvider("aws"), Config: tt.config}
+			if got := processor.SensitivityViolations(); !slices.Equal(got, tt.want) {
+				t.Errorf("SensitivityViolations() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	for level, fields := range SensitivityRequiredFields {
+		if !ValidSensitivityLevels[level] {
+			t.Errorf("SensitivityRequiredFields level %s is not a valid sensitivity", level)
+		}
+		for _, field := range fields {
+			if _, ok := complianceFields[field]; !ok {
+				t.Errorf("%s requires %s, which is not in complianceFields", level, field)
+			}
+		}
+	}
+}
@@ -0,0 +1,322 @@
+package context
+
+import (
+	"encoding/json"
+	"maps"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// NewDataSourceConfig returns a config with the data source defaults for
+// boolean fields, which are all enabled when not set except the opt-in
+// ToolingTagsEnabled, RegulationTagsEnabled, DigestTagEnabled,
+// ProvenanceTagsEnabled, AzurePolicyInheritanceEnabled and CaseInsensitiveKeys
+func NewDataSourceConfig() *DataSourceConfig {
+	return &DataSourceConfig{
+		Enabled:               true,
+		SourceRepoTagsEnabled: true,
+		SystemPrefixesEnabled: true,
+		NotApplicableEnabled:  true,
+		OwnerTagsEnabled:      true,
+	}
+}
+
+// toggleFields are the boolean fields inherited by Merge, keyed by their
+// JSON and YAML name, with their default values
+var toggleFields = map[string]bool{
+	"enabled":                          true,
+	"source_repo_tags_enabled":         true,
+	"system_prefixes_enabled":          true,
+	"not_applicable_enabled":           true,
+	"owner_tags_enabled":               true,
+	"tooling_tags_enabled":             false,
+	"regulation_tags_enabled":          false,
+	"digest_tag_enabled":               false,
+	"provenance_tags_enabled":          false,
+	"azure_policy_inheritance_enabled": false,
+	"case_insensitive_keys":            false,
+}
+
+// dataSourceConfigFields avoids recursion into the custom unmarshalers
+type dataSourceConfigFields DataSourceConfig
+
+// UnmarshalJSON decodes a config, applying the NewDataSourceConfig defaults to absent boolean fields
+func (c *DataSourceConfig) UnmarshalJSON(data []byte) error {
+	fields := dataSourceConfigFields(*NewDataSourceConfig())
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	var present map[string]json.RawMessage
+	if err := json.Unmarshal(data, &present); err != nil {
+		return err
+	}
+	fields.setToggles = map[string]bool{}
+	for name, value := range present {
+		if _, ok := toggleFields[name]; ok && string(value) != "null" {
+			fields.setToggles[name] = true
+		}
+	}
+	*c = DataSourceConfig(fields)
+	return nil
+}
+
+// UnmarshalYAML decodes a config, applying the NewDataSourceConfig defaults to absent boolean fields
+func (c *DataSourceConfig) UnmarshalYAML(value *yaml.Node) error {
+	fields := dataSourceConfigFields(*NewDataSourceConfig())
+	if err := value.Decode(&fields); err != nil {
+		return err
+	}
+	fields.setToggles = map[string]bool{}
+	if value.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(value.Content); i += 2 {
+			name, v := value.Content[i].Value, value.Content[i+1]
+			if _, ok := toggleFields[name]; ok && v.Tag != "!!null" {
+				fields.setToggles[name] = true
+			}
+		}
+	}
+	*c = DataSourceConfig(fields)
+	return nil
+}
+
+// toggleSet reports whether the toggle field is set: present in the decoded
+// document, or for configs built in code, different from its default
+func (c *DataSourceConfig) toggleSet(field string, value bool) bool {
+	if c.setToggles != nil {
+		return c.setToggles[field]
+	}
+	return value != toggleFields[field]
+}
+
+// Merge combines a parent and child config using the same precedence as the
+// context data source's parent_context handling:
+//   - Name, Component, PRNumber and EphemeralSuffix are never inherited, since
+//     a parent's suffix is already part of its resolved environment, and
+//     neither is DecommissionApproved, which each stack gives itself
+//   - strings and numbers are inherited when the child value is empty or
+//     zero, except NameDelimiter which is inherited when the child value is nil
+//   - lists are inherited when the child value is nil
+//   - additional tag maps are merged with child keys taking precedence, over
+//     parent keys differing only in case too when CaseInsensitiveKeys is set,
+//     and so is LegacyTagMap
+//   - boolean fields take the child value when the child sets them, otherwise
+//     the parent value when the parent sets them, otherwise their default, so
+//     a child can turn a toggle back on or off whatever its parent set. A
+//     field is set when present in the decoded JSON or YAML document; in
+//     configs built in code, when it differs from its NewDataSourceConfig
+//     default
+//
+// Either argument may be nil. Neither argument is modified.
+func Merge(parent, child *DataSourceConfig) *DataSourceConfig {
+	if parent == nil {
+		parent = NewDataSourceConfig()
+	}
+	if child == nil {
+		child = NewDataSourceConfig()
+	}
+	toggle := func(field string, parentValue, childValue bool) bool {
+		if child.toggleSet(field, childValue) {
+			return childValue
+		}
+		if parent.toggleSet(field, parentValue) {
+			return parentValue
+		}
+		return toggleFields[field]
+	}
+	caseInsensitiveKeys := toggle("case_insensitive_keys", parent.CaseInsensitiveKeys, child.CaseInsensitiveKeys)
+
+	merged := &DataSourceConfig{
+		Name:      child.Name,
+		Component: child.Component,
+
+		PRNumber:        child.PRNumber,
+		EphemeralSuffix: child.EphemeralSuffix,
+
+		DecommissionApproved: child.DecommissionApproved,
+
+		Namespace:       mergeString(parent.Namespace, child.Namespace),
+		Tenant:          mergeString(parent.Tenant, child.Tenant),
+		Attributes:      mergeList(parent.Attributes, child.Attributes),
+		LabelOrder:      mergeList(parent.LabelOrder, child.LabelOrder),
+		Environment:     mergeString(parent.Environment, child.Environment),
+		EnvironmentName: mergeString(parent.EnvironmentName, child.EnvironmentName),
+		EnvironmentType: mergeString(parent.EnvironmentType, child.EnvironmentType),
+		NameDelimiter:   mergeStringPtr(parent.NameDelimiter, child.NameDelimiter),
+		ListDelimiter:   mergeString(parent.ListDelimiter, child.ListDelimiter),
+
+		ReservedWords:      mergeList(parent.ReservedWords, child.ReservedWords),
+		ReservedWordAction: mergeString(parent.ReservedWordAction, child.ReservedWordAction),
+
+		StackName: mergeString(parent.StackName, child.StackName),
+
+		Application: mergeString(parent.Application, child.Application),
+		Service:     mergeString(parent.Service, child.Service),
+		Tier:        mergeString(parent.Tier, child.Tier),
+
+		BusinessUnit: mergeString(parent.BusinessUnit, child.BusinessUnit),
+		Division:     mergeString(parent.Division, child.Division),
+		Portfolio:    mergeString(parent.Portfolio, child.Portfolio),
+
+		TenantID: mergeString(parent.TenantID, child.TenantID),
+		Customer: mergeString(parent.Customer, child.Customer),
+
+		Enabled:      toggle("enabled", parent.Enabled, child.Enabled),
+		Availability: mergeString(parent.Availability, child.Availability),
+		ManagedBy:    mergeString(parent.ManagedBy, child.ManagedBy),
+		DeletionDate: mergeString(parent.DeletionDate, child.DeletionDate),
+
+		LifecycleAction: mergeString(parent.LifecycleAction, child.LifecycleAction),
+
+		MaintenanceWindow: mergeString(parent.MaintenanceWindow, child.MaintenanceWindow),
+		PatchGroup:        mergeString(parent.PatchGroup, child.PatchGroup),
+		Region:            mergeString(parent.Region, child.Region),
+
+		PMPlatform:      mergeString(parent.PMPlatform, child.PMPlatform),
+		PMProjectCode:   mergeString(parent.PMProjectCode, child.PMProjectCode),
+		ITSMPlatform:    mergeString(parent.ITSMPlatform, child.ITSMPlatform),
+		ITSMSystemID:    mergeString(parent.ITSMSystemID, child.ITSMSystemID),
+		ITSMComponentID: mergeString(parent.ITSMComponentID, child.ITSMComponentID),
+		ITSMInstanceID:  mergeString(parent.ITSMInstanceID, child.ITSMInstanceID),
+
+		CostCenter:     mergeString(parent.CostCenter, child.CostCenter),
+		MonthlyBudget:  mergeFloat(parent.MonthlyBudget, child.MonthlyBudget),
+		BudgetCurrency: mergeString(parent.BudgetCurrency, child.BudgetCurrency),
+		ProductOwners:  mergeList(parent.ProductOwners, child.ProductOwners),
+		CodeOwners:     mergeList(parent.CodeOwners, child.CodeOwners),
+		DataOwners:     mergeList(parent.DataOwners, child.DataOwners),
+
+		Sensitivity:    mergeString(parent.Sensitivity, child.Sensitivity),
+		DataRegs:       mergeList(parent.DataRegs, child.DataRegs),
+		SecurityReview: mergeString(parent.SecurityReview, child.SecurityReview),
+		PrivacyReview:  mergeString(parent.PrivacyReview, child.PrivacyReview),
+		DataRetention:  mergeString(parent.DataRetention, child.DataRetention),
+
+		DataResidency:           mergeList(parent.DataResidency, child.DataResidency),
+		SovereigntyRequirements: mergeList(parent.SovereigntyRequirements, child.SovereigntyRequirements),
+
+		ComplianceProfile: mergeString(parent.ComplianceProfile, child.ComplianceProfile),
+
+		SourceRepoTagsEnabled: toggle("source_repo_tags_enabled", parent.SourceRepoTagsEnabled, child.SourceRepoTagsEnabled),
+		SystemPrefixesEnabled: toggle("system_prefixes_enabled", parent.SystemPrefixesEnabled, child.SystemPrefixesEnabled),
+		NotApplicableEnabled:  toggle("not_applicable_enabled", parent.NotApplicableEnabled, child.NotApplicableEnabled),
+		OwnerTagsEnabled:      toggle("owner_tags_enabled", parent.OwnerTagsEnabled, child.OwnerTagsEnabled),
+		ToolingTagsEnabled:    toggle("tooling_tags_enabled", parent.ToolingTagsEnabled, child.ToolingTagsEnabled),
+		RegulationTagsEnabled: toggle("regulation_tags_enabled", parent.RegulationTagsEnabled, child.RegulationTagsEnabled),
+		DigestTagEnabled:      toggle("digest_tag_enabled", parent.DigestTagEnabled, child.DigestTagEnabled),
+		ProvenanceTagsEnabled: toggle("provenance_tags_enabled", parent.ProvenanceTagsEnabled, child.ProvenanceTagsEnabled),
+		GitRoot:               mergeString(parent.GitRoot, child.GitRoot),
+
+		SanitizationMode: mergeString(parent.SanitizationMode, child.SanitizationMode),
+		LengthOverflow:   mergeString(parent.LengthOverflow, child.LengthOverflow),
+
+		TagSchemaVersion: mergeInt(parent.TagSchemaVersion, child.TagSchemaVersion),
+
+		NAValue:  mergeString(parent.NAValue, child.NAValue),
+		NAFields: mergeList(parent.NAFields, child.NAFields),
+
+		TokenizeFields: mergeList(parent.TokenizeFields, child.TokenizeFields),
+
+		AzurePolicyInheritanceEnabled: toggle("azure_policy_inheritance_enabled", parent.AzurePolicyInheritanceEnabled, child.AzurePolicyInheritanceEnabled),
+		AzurePolicyInheritedTags:      mergeList(parent.AzurePolicyInheritedTags, child.AzurePolicyInheritedTags),
+
+		AdditionalTags:      MergeAdditionalTags(parent.AdditionalTags, child.AdditionalTags, caseInsensitiveKeys),
+		AdditionalDataTags:  MergeAdditionalTags(parent.AdditionalDataTags, child.AdditionalDataTags, caseInsensitiveKeys),
+		AdditionalTypedTags: MergeAdditionalTags(parent.AdditionalTypedTags, child.AdditionalTypedTags, caseInsensitiveKeys),
+		RawTags:             MergeAdditionalTags(parent.RawTags, child.RawTags, false),
+		LegacyTagMap:        MergeAdditionalTags(parent.LegacyTagMap, child.LegacyTagMap, false),
+		LegacyTagsUntil:     mergeString(parent.LegacyTagsUntil, child.LegacyTagsUntil),
+		CaseInsensitiveKeys: caseInsensitiveKeys,
+	}
+
+	// The merged config keeps which toggles either side set, so merging it
+	// into a grandchild inherits them
+	if parent.setToggles != nil || child.setToggles != nil {
+		values := map[string]bool{
+			"enabled":                          merged.Enabled,
+			"source_repo_tags_enabled":         merged.SourceRepoTagsEnabled,
+			"system_prefixes_enabled":          merged.SystemPrefixesEnabled,
+			"not_applicable_enabled":           merged.NotApplicableEnabled,
+			"owner_tags_enabled":               merged.OwnerTagsEnabled,
+			"tooling_tags_enabled":             merged.ToolingTagsEnabled,
+			"regulation_tags_enabled":          merged.RegulationTagsEnabled,
+			"digest_tag_enabled":               merged.DigestTagEnabled,
+			"provenance_tags_enabled":          merged.ProvenanceTagsEnabled,
+			"azure_policy_inheritance_enabled": merged.AzurePolicyInheritanceEnabled,
+			"case_insensitive_keys":            merged.CaseInsensitiveKeys,
+		}
+		merged.setToggles = map[string]bool{}
+		for field := range toggleFields {
+			if child.toggleSet(field, values[field]) || parent.toggleSet(field, values[field]) {
+				merged.setToggles[field] = true
+			}
+		}
+	}
+	return merged
+}
+
+// mergeString returns the child value if set, otherwise the parent value
+func mergeString(parent, child string) string {
+	if child != "" {
+		return child
+	}
+	return parent
+}
+
+// mergeFloat returns the child value if set, otherwise the parent value
+func mergeFloat(parent, child float64) float64 {
+	if child != 0 {
+		return child
+	}
+	return parent
+}
+
+// mergeInt returns the child value if set, otherwise the parent value
+func mergeInt(parent, child int) int {
+	if child != 0 {
+		return child
+	}
+	return parent
+}
+
+// mergeStringPtr returns a copy of the child value if set, otherwise of the parent value
+func mergeStringPtr(parent, child *string) *string {
+	if child != nil {
+		v := *child
+		return &v
+	}
+	if parent != nil {
+		v := *parent
+		return &v
+	}
+	return nil
+}
+
+// mergeList returns a copy of the child value if set, otherwise of the parent value
+func mergeList(parent, child []string) []string {
+	if child != nil {
+		return slices.Clone(child)
+	}
+	return slices.Clone(parent)
+}
+
+// MergeAdditionalTags returns the parent additional tags overlaid with the
+// child additional tags. With caseInsensitiveKeys, parent keys differing only
+// in case from a child key are replaced by the child entry too, so Team in
+// the parent and team in the child become the single tag team.
+func MergeAdditionalTags[V any](parent, child map[string]V, caseInsensitiveKeys bool) map[string]V {
+	merged := make(map[string]V, len(parent)+len(child))
+	maps.Copy(merged, parent)
+	if caseInsensitiveKeys {
+		childKeys := make(map[string]bool, len(child))
+		for key := range child {
+			childKeys[strings.ToLower(key)] = true
+		}
+		maps.DeleteFunc(merged, func(key string, _ V) bool {
+			return childKeys[strings.ToLower(key)]
+		})
+	}
+	maps.Copy(merged, child)
+	return merged
+}
@@ -0,0 +1,431 @@
+package context
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func testConfig() *DataSourceConfig {
+	config := NewDataSourceConfig()
+	config.Namespace = "myorg"
+	config.Name = "api"
+	config.Environment = "prod"
+	config.EnvironmentName = "Production"
+	noDelimiter := ""
+	config.NameDelimiter = &noDelimiter
+	config.Availability = "dedicated"
+	config.CostCenter = "cc-100"
+	config.ProductOwners = []string{"owner@example.com"}
+	config.DataRegs = []string{}
+	config.OwnerTagsEnabled = false
+	config.AdditionalTags = map[string]string{"team": "platform"}
+	return config
+}
+
+// allToggles returns setToggles with every toggle set
+func allToggles() map[string]bool {
+	set := map[string]bool{}
+	for field := range toggleFields {
+		set[field] = true
+	}
+	return set
+}
+
+func TestDataSourceConfig_JSON(t *testing.T) {
+	config := testConfig()
+
+	data, err := json.Marshal(config)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+
+	var got DataSourceConfig
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	// Every toggle is written, so every toggle is set once decoded
+	config.setToggles = allToggles()
+	if !reflect.DeepEqual(&got, config) {
+		t.Errorf("JSON round trip = %+v, want %+v", got, *config)
+	}
+}
+
+func TestDataSourceConfig_YAML(t *testing.T) {
+	config := testConfig()
+	// YAML omits empty lists, so they decode as unset
+	config.DataRegs = nil
+
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		t.Fatalf("yaml.Marshal() error = %v", err)
+	}
+
+	var got DataSourceConfig
+	if err := yaml.Unmarshal(data, &got); err != nil {
+		t.Fatalf("yaml.Unmarshal() error = %v", err)
+	}
+	config.setToggles = allToggles()
+	if !reflect.DeepEqual(&got, config) {
+		t.Errorf("YAML round trip = %+v, want %+v", got, *config)
+	}
+}
+
+func TestDataSourceConfig_UnmarshalDefaults(t *testing.T) {
+	tests := []struct {
+		name      string
+		unmarshal func([]byte, any) error
+		input     string
+	}{
+		{
+			name:      "json",
+			unmarshal: json.Unmarshal,
+			input:     `{"namespace": "myorg", "owner_tags_enabled": false}`,
+		},
+		{
+			name:      "yaml",
+			unmarshal: yaml.Unmarshal,
+			input:     "namespace: myorg\nowner_tags_enabled: false\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got DataSourceConfig
+			if err := tt.unmarshal([]byte(tt.input), &got); err != nil {
+				t.Fatalf("unmarshal error = %v", err)
+			}
+			if got.Namespace != "myorg" {
+				t.Errorf("Namespace = %v, want myorg", got.Namespace)
+			}
+			if !got.Enabled || !got.SourceRepoTagsEnabled || !got.SystemPrefixesEnabled || !got.NotApplicableEnabled {
+				t.Errorf("absent boolean fields should default to true: %+v", got)
+			}
+			if got.OwnerTagsEnabled {
+				t.Error("OwnerTagsEnabled should be false when explicitly disabled")
+			}
+			if got.ToolingTagsEnabled || got.RegulationTagsEnabled {
+				t.Error("absent ToolingTagsEnabled and RegulationTagsEnabled should default to false")
+			}
+			if got.ProductOwners != nil {
+				t.Errorf("ProductOwners = %v, want nil", got.ProductOwners)
+			}
+		})
+	}
+}
+
+func TestMerge(t *testing.T) {
+	parent := NewDataSourceConfig()
+	parent.Namespace = "myorg"
+	parent.Name = "parent"
+	parent.Environment = "prod"
+	parent.CostCenter = "cc-100"
+	parent.ProductOwners = []string{"owner@example.com"}
+	parent.CodeOwners = []string{"dev@example.com"}
+	parent.SourceRepoTagsEnabled = false
+	parent.ToolingTagsEnabled = true
+	parent.DigestTagEnabled = true
+	parent.ProvenanceTagsEnabled = true
+	parent.ReservedWordAction = "error"
+	parent.SanitizationMode = "warn"
+	parent.LengthOverflow = "error"
+	parent.TagSchemaVersion = 1
+	parent.NAFields = []string{"!sourcerepo"}
+	parent.TokenizeFields = []string{"instanceid"}
+	parent.ListDelimiter = "|"
+	parent.AzurePolicyInheritanceEnabled = true
+	parent.AzurePolicyInheritedTags = []string{"environment", "costcenter"}
+	parent.PRNumber = 42
+	parent.DecommissionApproved = true
+	parent.AdditionalTags = map[string]string{"team": "platform", "tier": "web"}
+	parent.LegacyTagMap = map[string]string{"costcenter": "CostCenter"}
+	parent.LegacyTagsUntil = "2026-03-31"
+	parent.Application = "checkout"
+	parent.MaintenanceWindow = "sun:03:00-sun:05:00"
+	parent.PatchGroup = "web-servers"
+	parent.GitRoot = "../live"
+	parent.Tier = "app"
+	parent.BusinessUnit = "retail"
+	parent.Division = "payments"
+	parent.TenantID = "t-0042"
+	parent.Customer = "globex"
+	parent.ComplianceProfile = "pci"
+	parent.Region = "eu-west-1"
+	parent.DataResidency = []string{"EU"}
+	parent.DataRetention = "P7Y"
+
+	child := NewDataSourceConfig()
+	child.Name = "api"
+	child.CostCenter = "cc-200"
+	noDelimiter := ""
+	child.NameDelimiter = &noDelimiter
+	child.CodeOwners = []string{}
+	child.OwnerTagsEnabled = false
+	child.AdditionalTags = map[string]string{"tier": "api"}
+	child.Portfolio = "cards"
+	child.Tier = "data"
+	child.Customer = "initech"
+	child.SovereigntyRequirements = []string{"EUCS"}
+
+	got := Merge(parent, child)
+
+	if got.Name != "api" {
+		t.Errorf("Name = %v, want api", got.Name)
+	}
+	if got.Namespace != "myorg" || got.Environment != "prod" {
+		t.Errorf("Namespace/Environment = %v/%v, want inherited myorg/prod", got.Namespace, got.Environment)
+	}
+	if got.PRNumber != 0 {
+		t.Errorf("PRNumber = %v, want 0 (not inherited)", got.PRNumber)
+	}
+	if got.DecommissionApproved {
+		t.Error("DecommissionApproved = true, want false (not inherited)")
+	}
+	if got.ReservedWordAction != "error" {
+		t.Errorf("ReservedWordAction = %v, want inherited error", got.ReservedWordAction)
+	}
+	if got.SanitizationMode != "warn" {
+		t.Errorf("SanitizationMode = %v, want inherited warn", got.SanitizationMode)
+	}
+	if got.LengthOverflow != "error" {
+		t.Errorf("LengthOverflow = %v, want inherited error", got.LengthOverflow)
+	}
+	if got.TagSchemaVersion != 1 {
+		t.Errorf("TagSchemaVersion = %v, want inherited 1", got.TagSchemaVersion)
+	}
+	if !reflect.DeepEqual(got.NAFields, []string{"!sourcerepo"}) {
+		t.Errorf("NAFields = %v, want inherited", got.NAFields)
+	}
+	if !reflect.DeepEqual(got.TokenizeFields, []string{"instanceid"}) {
+		t.Errorf("TokenizeFields = %v, want inherited", got.TokenizeFields)
+	}
+	if got.ListDelimiter != "|" {
+		t.Errorf("ListDelimiter = %v, want inherited |", got.ListDelimiter)
+	}
+	if !got.AzurePolicyInheritanceEnabled || !reflect.DeepEqual(got.AzurePolicyInheritedTags, []string{"environment", "costcenter"}) {
+		t.Errorf("AzurePolicyInheritanceEnabled/AzurePolicyInheritedTags = %v/%v, want inherited", got.AzurePolicyInheritanceEnabled, got.AzurePolicyInheritedTags)
+	}
+	if got.CostCenter != "cc-200" {
+		t.Errorf("CostCenter = %v, want cc-200", got.CostCenter)
+	}
+	if got.NameDelimiter == nil || *got.NameDelimiter != "" {
+		t.Errorf("NameDelimiter = %v, want empty override", got.NameDelimiter)
+	}
+	if !reflect.DeepEqual(got.ProductOwners, []string{"owner@example.com"}) {
+		t.Errorf("ProductOwners = %v, want inherited", got.ProductOwners)
+	}
+	if got.CodeOwners == nil || len(got.CodeOwners) != 0 {
+		t.Errorf("CodeOwners = %v, want empty override", got.CodeOwners)
+	}
+	if got.SourceRepoTagsEnabled {
+		t.Error("SourceRepoTagsEnabled should be inherited as false")
+	}
+	if got.OwnerTagsEnabled {
+		t.Error("OwnerTagsEnabled should be overridden to false")
+	}
+	if !got.Enabled || !got.NotApplicableEnabled {
+		t.Error("Enabled and NotApplicableEnabled should remain true")
+	}
+	if !got.ToolingTagsEnabled {
+		t.Error("ToolingTagsEnabled should be inherited as true")
+	}
+	if !got.DigestTagEnabled {
+		t.Error("DigestTagEnabled should be inherited as true")
+	}
+	if !got.ProvenanceTagsEnabled {
+		t.Error("ProvenanceTagsEnabled should be inherited as true")
+	}
+	wantTags := map[string]string{"team": "platform", "tier": "api"}
+	if !reflect.DeepEqual(got.AdditionalTags, wantTags) {
+		t.Errorf("AdditionalTags = %v, want %v", got.AdditionalTags, wantTags)
+	}
+	if got.LegacyTagMap["costcenter"] != "CostCenter" || got.LegacyTagsUntil != "2026-03-31" {
+		t.Errorf("LegacyTagMap/LegacyTagsUntil = %v/%v, want inherited", got.LegacyTagMap, got.LegacyTagsUntil)
+	}
+	if got.MaintenanceWindow != "sun:03:00-sun:05:00" || got.PatchGroup != "web-servers" {
+		t.Errorf("MaintenanceWindow/PatchGroup = %v/%v, want inherited", got.MaintenanceWindow, got.PatchGroup)
+	}
+	if got.GitRoot != "../live" {
+		t.Errorf("GitRoot = %v, want inherited", got.GitRoot)
+	}
+	if got.Application != "checkout" || got.Tier != "data" {
+		t.Errorf("Application/Tier = %v/%v, want inherited checkout and overridden data", got.Application, got.Tier)
+	}
+	if got.BusinessUnit != "retail" || got.Division != "payments" || got.Portfolio != "cards" {
+		t.Errorf("BusinessUnit/Division/Portfolio = %v/%v/%v, want inherited retail/payments and cards", got.BusinessUnit, got.Division, got.Portfolio)
+	}
+	if got.TenantID != "t-0042" || got.Customer != "initech" {
+		t.Errorf("TenantID/Customer = %v/%v, want inherited t-0042 and initech", got.TenantID, got.Customer)
+	}
+	if got.DataRetention != "P7Y" {
+		t.Errorf("DataRetention = %v, want inherited P7Y", got.DataRetention)
+	}
+	if got.ComplianceProfile != "pci" {
+		t.Errorf("ComplianceProfile = %v, want inherited pci", got.ComplianceProfile)
+	}
+	if got.Region != "eu-west-1" || !reflect.DeepEqual(got.DataResidency, []string{"EU"}) || !reflect.DeepEqual(got.SovereigntyRequirements, []string{"EUCS"}) {
+		t.Errorf("Region/DataResidency/SovereigntyRequirements = %v/%v/%v, want inherited eu-west-1 and [EU], and [EUCS]", got.Region, got.DataResidency, got.SovereigntyRequirements)
+	}
+
+	// Inputs are not modified
+	if parent.AdditionalTags["tier"] != "web" {
+		t.Error("Merge() modified the parent AdditionalTags")
+	}
+}
+
+func TestMerge_Nil(t *testing.T) {
+	child := NewDataSourceConfig()
+	child.Name = "api"
+
+	got := Merge(nil, child)
+	if got.Name != "api" || !got.Enabled {
+		t.Errorf("Merge(nil, child) = %+v", got)
+	}
+
+	got = Merge(child, nil)
+	if got.Name != "" {
+		t.Errorf("Merge(parent, nil).Name = %v, want empty", got.Name)
+	}
+	if got.NameDelimiter != nil {
+		t.Errorf("Merge(parent, nil).NameDelimiter = %v, want nil", *got.NameDelimiter)
+	}
+}
+
+// TestMerge_Toggles checks that a toggle the child sets wins over the
+// parent, whatever the values, as with parent_context in the data source
+func TestMerge_Toggles(t *testing.T) {
+	tests := []struct {
+		name, parent, child string
+		want                func(*DataSourceConfig) bool
+	}{
+		{"child re-enables a default-true toggle", `{"owner_tags_enabled": false}`, `{"owner_tags_enabled": true}`,
+			func(c *DataSourceConfig) bool { return c.OwnerTagsEnabled }},
+		{"child disables an opt-in toggle", `{"tooling_tags_enabled": true}`, `{"tooling_tags_enabled": false}`,
+			func(c *DataSourceConfig) bool { return !c.ToolingTagsEnabled }},
+		{"absent child toggle inherits", `{"digest_tag_enabled": true, "enabled": false}`, `{"name": "api"}`,
+			func(c *DataSourceConfig) bool { return c.DigestTagEnabled && !c.Enabled }},
+		{"null child toggle inherits", `{"case_insensitive_keys": true}`, `{"case_insensitive_keys": null}`,
+			func(c *DataSourceConfig) bool { return c.CaseInsensitiveKeys }},
+		{"absent toggles keep defaults", `{}`, `{}`,
+			func(c *DataSourceConfig) bool { return c.SourceRepoTagsEnabled && !c.ProvenanceTagsEnabled }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, format := range []string{"json", "yaml"} {
+				var parent, child DataSourceConfig
+				if err := unmarshalConfig(format, tt.parent, &parent); err != nil {
+					t.Fatalf("%s parent: %v", format, err)
+				}
+				if err := unmarshalConfig(format, tt.child, &child); err != nil {
+					t.Fatalf("%s child: %v", format, err)
+				}
+				if got := Merge(&parent, &child); !tt.want(got) {
+					t.Errorf("%s: Merge() = %+v", format, got)
+				}
+			}
+		})
+	}
+}
+
+// TestMerge_TogglesChained checks that a merged config passes the toggles
+// set on either side on to a grandchild
+func TestMerge_TogglesChained(t *testing.T) {
+	var root, parent, child DataSourceConfig
+	if err := json.Unmarshal([]byte(`{"owner_tags_enabled": false}`), &root); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(`{"name": "api"}`), &parent); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(`{}`), &child); err != nil {
+		t.Fatal(err)
+	}
+	if got := Merge(Merge(&root, &parent), &child); got.OwnerTagsEnabled {
+		t.Error("grandchild should inherit owner_tags_enabled = false")
+	}
+}
+
+// unmarshalConfig decodes a JSON document as JSON, or converted to YAML
+func unmarshalConfig(format, document string, config *DataSourceConfig) error {
+	if format == "json" {
+		return json.Unmarshal([]byte(document), config)
+	}
+	var fields map[string]any
+	if err := json.Unmarshal([]byte(document), &fields); err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	return yaml.Unmarshal(data, config)
+}
+
+func TestMergeAdditionalTags(t *testing.T) {
+	parent := map[string]string{"Team": "platform", "Tier": "web", "owner": "ops"}
+	child := map[string]string{"team": "payments", "OWNER": "dev"}
+
+	tests := []struct {
+		name                string
+		caseInsensitiveKeys bool
+		want                map[string]string
+	}{
+		{
+			name: "case sensitive",
+			want: map[string]string{"Team": "platform", "team": "payments", "Tier": "web", "owner": "ops", "OWNER": "dev"},
+		},
+		{
+			name:                "case insensitive",
+			caseInsensitiveKeys: true,
+			want:                map[string]string{"team": "payments", "Tier": "web", "OWNER": "dev"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MergeAdditionalTags(parent, child, tt.caseInsensitiveKeys); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MergeAdditionalTags() = %v, want %v", got, tt.want)
+			}
+			if parent["Team"] != "platform" || len(parent) != 3 {
+				t.Error("MergeAdditionalTags() modified the parent tags")
+			}
+		})
+	}
+}
+
+func TestMerge_CaseInsensitiveKeys(t *testing.T) {
+	parent := NewDataSourceConfig()
+	parent.CaseInsensitiveKeys = true
+	parent.AdditionalTags = map[string]string{"Team": "platform"}
+	parent.AdditionalDataTags = map[string]string{"Steward": "ops"}
+	parent.AdditionalTypedTags = map[string]TypedTag{"Replicas": {Value: "2", Type: "number"}, "backup": {Value: "true", Type: "bool"}}
+
+	child := NewDataSourceConfig()
+	child.AdditionalTags = map[string]string{"team": "payments"}
+	child.AdditionalDataTags = map[string]string{"steward": "dev"}
+	child.AdditionalTypedTags = map[string]TypedTag{"replicas": {Value: "3", Type: "number"}}
+	parent.RawTags = map[string]string{"Vendor": "acme"}
+	child.RawTags = map[string]string{"vendor": "initech"}
+
+	got := Merge(parent, child)
+	if !got.CaseInsensitiveKeys {
+		t.Error("CaseInsensitiveKeys = false, want inherited true")
+	}
+	if want := map[string]string{"team": "payments"}; !reflect.DeepEqual(got.AdditionalTags, want) {
+		t.Errorf("AdditionalTags = %v, want %v", got.AdditionalTags, want)
+	}
+	if want := map[string]string{"steward": "dev"}; !reflect.DeepEqual(got.AdditionalDataTags, want) {
+		t.Errorf("AdditionalDataTags = %v, want %v", got.AdditionalDataTags, want)
+	}
+	wantTyped := map[string]TypedTag{"replicas": {Value: "3", Type: "number"}, "backup": {Value: "true", Type: "bool"}}
+	if !reflect.DeepEqual(got.AdditionalTypedTags, wantTyped) {
+		t.Errorf("AdditionalTypedTags = %v, want %v", got.AdditionalTypedTags, wantTyped)
+	}
+	// Raw tag keys are verbatim, so they are merged case-sensitively
+	if want := map[string]string{"Vendor": "acme", "vendor": "initech"}; !reflect.DeepEqual(got.RawTags, want) {
+		t.Errorf("RawTags = %v, want %v", got.RawTags, want)
+	}
+}
@@ -0,0 +1,106 @@
+package context
+
+import (
+	"fmt"
+	"regexp"
+)
+
+const (
+	// DefaultComponentMaxLength is the default maximum length of the
+	// namespace and environment components
+	DefaultComponentMaxLength = 8
+	// MaxComponentLength is the largest namespace or environment length a
+	// NamingConstraints may allow
+	MaxComponentLength = 16
+)
+
+// componentRegex checks the characters of a component whose length has
+// already been checked against a non-default limit
+var componentRegex = regexp.MustCompile(`^[a-z](?:[a-z0-9-]*[a-z0-9])?$`)
+
+// NamingConstraints sets the length limits of the namespace and environment
+// components, for organizations whose identifiers do not fit the default of
+// 8 characters. Zero values use DefaultComponentMaxLength.
+type NamingConstraints struct {
+	NamespaceMaxLength   int
+	EnvironmentMaxLength int
+}
+
+// DefaultNamingConstraints returns the standard Brockhoff limits
+func DefaultNamingConstraints() NamingConstraints {
+	return NamingConstraints{
+		NamespaceMaxLength:   DefaultComponentMaxLength,
+		EnvironmentMaxLength: DefaultComponentMaxLength,
+	}
+}
+
+// withDefaults returns the constraints with zero values replaced by the defaults
+func (c NamingConstraints) withDefaults() NamingConstraints {
+	if c.NamespaceMaxLength == 0 {
+		c.NamespaceMaxLength = DefaultComponentMaxLength
+	}
+	if c.EnvironmentMaxLength == 0 {
+		c.EnvironmentMaxLength = DefaultComponentMaxLength
+	}
+	return c
+}
+
+// Validate checks that each limit is between 1 and MaxComponentLength
+func (c NamingConstraints) Validate() error {
+	c = c.withDefaults()
+	if err := validateMaxLength("namespace_max_length", c.NamespaceMaxLength); err != nil {
+		return err
+	}
+	return validateMaxLength("environment_max_length", c.EnvironmentMaxLength)
+}
+
+// validateMaxLength checks a component length limit
+func validateMaxLength(field string, maxLength int) error {
+	if maxLength < 1 || maxLength > MaxComponentLength {
+		return fmt.Errorf("%s must be between 1 and %d, got %d", field, MaxComponentLength, maxLength)
+	}
+	return nil
+}
+
+// ValidateNamespace validates namespace format against the namespace limit
+func (c NamingConstraints) ValidateNamespace(namespace string) error {
+	return validateComponent("namespace", namespace, c.withDefaults().NamespaceMaxLength, namespaceRegex)
+}
+
+// ValidateEnvironment validates environment format against the environment limit
+func (c NamingConstraints) ValidateEnvironment(environment string) error {
+	return validateComponent("environment", environment, c.withDefaults().EnvironmentMaxLength, environmentRegex)
+}
+
+// MaxNamePrefixLength returns MaxNamePrefixLength raised by the length the
+// namespace and environment limits add over the defaults, so that longer
+// identifiers do not take the room of the name component
+func (c NamingConstraints) MaxNamePrefixLength() int {
+	c = c.withDefaults()
+	return MaxNamePrefixLength +
+		max(0, c.NamespaceMaxLength-DefaultComponentMaxLength) +
+		max(0, c.EnvironmentMaxLength-DefaultComponentMaxLength)
+}
+
+// validateComponent checks an optional lowercase alphanumeric component of
+// at most maxLength characters. defaultRegex, which includes the length, is
+// used when maxLength is the default.
+func validateComponent(field, value string, maxLength int, defaultRegex *regexp.Regexp) error {
+	if value == "" {
+		return nil // Optional field
+	}
+
+	if len(value) > maxLength {
+		return fmt.Errorf("%s must be 1-%d characters, got %d: %s", field, maxLength, len(value), value)
+	}
+
+	pattern := componentRegex
+	if maxLength == DefaultComponentMaxLength {
+		pattern = defaultRegex
+	}
+	if !pattern.MatchString(value) {
+		return fmt.Errorf("%s must be lowercase alphanumeric with hyphens (1-%d chars): %s", field, maxLength, value)
+	}
+
+	return nil
+}
@@ -0,0 +1,147 @@
+package context
+
+import (
+	"testing"
+)
+
+func TestNamingConstraints_Validate(t *testing.T) {
+	tests := []struct {
+		name        string
+		constraints NamingConstraints
+		wantErr     string
+	}{
+		{name: "zero value uses defaults", constraints: NamingConstraints{}},
+		{name: "defaults", constraints: DefaultNamingConstraints()},
+		{name: "maximum", constraints: NamingConstraints{NamespaceMaxLength: MaxComponentLength, EnvironmentMaxLength: 1}},
+		{
+			name:        "namespace too long",
+			constraints: NamingConstraints{NamespaceMaxLength: 17},
+			wantErr:     "namespace_max_length must be between 1 and 16, got 17",
+		},
+		{
+			name:        "negative environment",
+			constraints: NamingConstraints{EnvironmentMaxLength: -1},
+			wantErr:     "environment_max_length must be between 1 and 16, got -1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.constraints.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Validate() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNamingConstraints_ValidateComponents(t *testing.T) {
+	constraints := NamingConstraints{NamespaceMaxLength: 12, EnvironmentMaxLength: 4}
+
+	tests := []struct {
+		name     string
+		validate func(string) error
+		value    string
+		wantErr  string
+	}{
+		{name: "long namespace", validate: constraints.ValidateNamespace, value: "platform-eng"},
+		{name: "single char namespace", validate: constraints.ValidateNamespace, value: "p"},
+		{
+			name:     "namespace over limit",
+			validate: constraints.ValidateNamespace,
+			value:    "platform-engr",
+			wantErr:  "namespace must be 1-12 characters, got 13: platform-engr",
+		},
+		{
+			name:     "namespace trailing hyphen",
+			validate: constraints.ValidateNamespace,
+			value:    "platform-",
+			wantErr:  "namespace must be lowercase alphanumeric with hyphens (1-12 chars): platform-",
+		},
+		{name: "short environment", validate: constraints.ValidateEnvironment, value: "prod"},
+		{
+			name:     "environment over lowered limit",
+			validate: constraints.ValidateEnvironment,
+			value:    "stage",
+			wantErr:  "environment must be 1-4 characters, got 5: stage",
+		},
+		{name: "empty environment", validate: constraints.ValidateEnvironment, value: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.validate(tt.value)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("validate(%q) error = %v", tt.value, err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("validate(%q) error = %v, want %q", tt.value, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNamingConstraints_MaxNamePrefixLength(t *testing.T) {
+	tests := []struct {
+		name        string
+		constraints NamingConstraints
+		want        int
+	}{
+		{name: "zero value", constraints: NamingConstraints{}, want: MaxNamePrefixLength},
+		{name: "defaults", constraints: DefaultNamingConstraints(), want: MaxNamePrefixLength},
+		{name: "longer namespace", constraints: NamingConstraints{NamespaceMaxLength: 12}, want: 28},
+		{name: "both longer", constraints: NamingConstraints{NamespaceMaxLength: 12, EnvironmentMaxLength: 10}, want: 30},
+		{name: "shorter limits keep default", constraints: NamingConstraints{NamespaceMaxLength: 4, EnvironmentMaxLength: 3}, want: MaxNamePrefixLength},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.constraints.MaxNamePrefixLength(); got != tt.want {
+				t.Errorf("MaxNamePrefixLength() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNamingConstraints_ProcessEphemeralEnvironment(t *testing.T) {
+	constraints := NamingConstraints{EnvironmentMaxLength: 12}
+
+	tests := []struct {
+		name            string
+		config          DataSourceConfig
+		wantEnvironment string
+	}{
+		{
+			name:            "environment kept within raised limit",
+			config:          DataSourceConfig{Environment: "preview", EnvironmentType: "Ephemeral", PRNumber: 123},
+			wantEnvironment: "previe-pr123",
+		},
+		{
+			name:            "branch suffix cut to raised limit",
+			config:          DataSourceConfig{EnvironmentType: "Ephemeral", EphemeralSuffix: "feature/Login-page"},
+			wantEnvironment: "feature-logi",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := tt.config
+			constraints.ProcessEphemeralEnvironment(&config)
+			if config.Environment != tt.wantEnvironment {
+				t.Errorf("Environment = %q, want %q", config.Environment, tt.wantEnvironment)
+			}
+			if err := constraints.ValidateEnvironment(config.Environment); err != nil {
+				t.Errorf("ValidateEnvironment(%q) = %v", config.Environment, err)
+			}
+		})
+	}
+}
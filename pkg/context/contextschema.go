@@ -0,0 +1,278 @@
+package context
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// ContextSchemaID is the $id of the context document JSON Schema
+const ContextSchemaID = "https://raw.githubusercontent.com/kbrockhoff/terraform-provider-context/main/pkg/context/context.schema.json"
+
+// contextSchema is the generated JSON Schema of context documents
+//
+//go:embed context.schema.json
+var contextSchema []byte
+
+// ContextSchema returns the JSON Schema (draft 2020-12) of context
+// documents: DataSourceConfig as JSON, the format of context files and of
+// the context published by the provider. It is generated by
+// GenerateContextSchema with the descriptions of the brockhoff_context data
+// source, so tools in other languages validate contexts the same way.
+func ContextSchema() []byte {
+	return slices.Clone(contextSchema)
+}
+
+// jsonSchema is a JSON Schema node. Type is a type name, or a list of type
+// names for nullable values.
+type jsonSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	ID                   string                 `json:"$id,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Type                 any                    `json:"type,omitempty"`
+	Enum                 []any                  `json:"enum,omitempty"`
+	Const                any                    `json:"const,omitempty"`
+	Pattern              string                 `json:"pattern,omitempty"`
+	Format               string                 `json:"format,omitempty"`
+	Minimum              *float64               `json:"minimum,omitempty"`
+	MinLength            int                    `json:"minLength,omitempty"`
+	Items                *jsonSchema            `json:"items,omitempty"`
+	UniqueItems          bool                   `json:"uniqueItems,omitempty"`
+	Properties           map[string]*jsonSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	AdditionalProperties *jsonSchema            `json:"additionalProperties,omitempty"`
+	AnyOf                []*jsonSchema          `json:"anyOf,omitempty"`
+	Not                  *jsonSchema            `json:"not,omitempty"`
+	Default              any                    `json:"default,omitempty"`
+}
+
+// stringEnum returns the keys of a validation table as enum values, sorted
+func stringEnum[K ~string](valid map[K]bool) []any {
+	values := slices.Sorted(maps.Keys(valid))
+	enum := make([]any, len(values))
+	for i, v := range values {
+		enum[i] = string(v)
+	}
+	return enum
+}
+
+// anyValues returns values as enum values
+func anyValues[T any](values []T) []any {
+	enum := make([]any, len(values))
+	for i, v := range values {
+		enum[i] = v
+	}
+	return enum
+}
+
+// optionalPattern returns the pattern of an optional attribute validated by
+// re: empty or matching re. The validation expressions are valid ECMA-262
+// patterns too.
+func optionalPattern(re *regexp.Regexp) string {
+	return "^$|" + re.String()
+}
+
+// contextSchemaConstraints returns the constraints of the context document
+// attributes validated by the Validate functions, keyed by JSON name
+func contextSchemaConstraints() map[string]*jsonSchema {
+	zero := 0.0
+	emails := &jsonSchema{Items: &jsonSchema{Format: "email"}}
+	tagSchemaVersions := []any{0}
+	for version := 1; version <= LatestTagSchemaVersion; version++ {
+		if _, err := GetTagSchema(version); err == nil {
+			tagSchemaVersions = append(tagSchemaVersions, version)
+		}
+	}
+	naFields := anyValues(NotApplicableTagKeys)
+	for _, key := range NotApplicableTagKeys {
+		naFields = append(naFields, "!"+key)
+	}
+
+	return map[string]*jsonSchema{
+		"namespace":            {Pattern: optionalPattern(namespaceRegex)},
+		"environment":          {Pattern: optionalPattern(environmentRegex)},
+		"tenant":               {Pattern: optionalPattern(tenantRegex)},
+		"attributes":           {Items: &jsonSchema{Pattern: attributeRegex.String()}},
+		"label_order":          {Items: &jsonSchema{Enum: stringEnum(ValidNameComponents)}, UniqueItems: true},
+		"environment_type":     {Enum: stringEnum(ValidEnvironmentTypes)},
+		"name_delimiter":       {Enum: stringEnum(ValidNameDelimiters)},
+		"reserved_word_action": {Enum: stringEnum(ValidReservedWordActions)},
+		"tier":                 {Enum: stringEnum(ValidTiers)},
+		"business_unit":        {Pattern: optionalPattern(orgUnitRegex)},
+		"division":             {Pattern: optionalPattern(orgUnitRegex)},
+		"portfolio":            {Pattern: optionalPattern(orgUnitRegex)},
+		"tenant_id":            {Pattern: optionalPattern(tenantIDRegex)},
+		"customer":             {Pattern: optionalPattern(customerRegex)},
+		"availability":         {Enum: stringEnum(ValidAvailabilityLevels)},
+		"deletion_date":        {Pattern: optionalPattern(dateRegex)},
+		"lifecycle_action":     {Enum: stringEnum(ValidLifecycleActions)},
+		"region":               {Pattern: optionalPattern(regionRegex)},
+		"monthly_budget":       {Minimum: &zero},
+		"budget_currency":      {Pattern: optionalPattern(currencyRegex)},
+		"product_owners":       emails,
+		"code_owners":          emails,
+		"data_owners":          emails,
+		"sensitivity":          {Enum: stringEnum(ValidSensitivityLevels)},
+		// P and durations ending in T match the expression but are rejected
+		"data_retention": {Pattern: optionalPattern(durationRegex), Not: &jsonSchema{Pattern: `^P$|T$`}},
+		"data_residency": {Items: &jsonSchema{AnyOf: []*jsonSchema{
+			{Const: DataResidencyEU},
+			{Pattern: residencyRegex.String()},
+		}}},
+		"compliance_profile": {Enum: append([]any{""}, anyValues(ComplianceProfileNames())...)},
+		"pr_number":          {Minimum: &zero},
+		"ephemeral_suffix":   {Pattern: "^$|[A-Za-z0-9]"},
+		"sanitization_mode":  {Enum: stringEnum(ValidSanitizationModes)},
+		"length_overflow":    {Enum: stringEnum(ValidLengthOverflowPolicies)},
+		"tag_schema_version": {Enum: tagSchemaVersions},
+		"na_fields":          {Items: &jsonSchema{Enum: naFields}},
+		"tokenize_fields":    {Items: &jsonSchema{Enum: anyValues(TokenizableTagKeys)}},
+		"legacy_tag_map":     {AdditionalProperties: &jsonSchema{MinLength: 1}},
+		"legacy_tags_until":  {Pattern: optionalPattern(dateRegex)},
+	}
+}
+
+// typedTagSchema returns the schema of a TypedTag
+func typedTagSchema() *jsonSchema {
+	return &jsonSchema{
+		Type: "object",
+		Properties: map[string]*jsonSchema{
+			"value": {Type: "string"},
+			"type":  {Type: "string", Enum: append([]any{""}, anyValues(TypedTagTypes)...)},
+		},
+		Required: []string{"value"},
+	}
+}
+
+// GenerateContextSchema returns the JSON Schema of context documents, with
+// the given descriptions keyed by JSON name. Every DataSourceConfig field
+// needs a description, so the schema cannot silently fall behind the config.
+func GenerateContextSchema(descriptions map[string]string) ([]byte, error) {
+	constraints := contextSchemaConstraints()
+	defaults := reflect.ValueOf(NewDataSourceConfig()).Elem()
+
+	root := &jsonSchema{
+		Schema:      "https://json-schema.org/draft/2020-12/schema",
+		ID:          ContextSchemaID,
+		Title:       "brockhoff_context context",
+		Description: "Inputs of the brockhoff_context data source as a JSON or YAML document, such as a contextctl context file. Attributes not listed are ignored.",
+		Type:        "object",
+		Properties:  map[string]*jsonSchema{},
+	}
+	for _, field := range reflect.VisibleFields(defaults.Type()) {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "" || name == "-" || !field.IsExported() {
+			continue
+		}
+		description, ok := descriptions[name]
+		if !ok {
+			return nil, fmt.Errorf("no description for %s", name)
+		}
+
+		property, err := schemaOfType(field.Type)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		property.Description = description
+		if field.Type.Kind() == reflect.Bool {
+			property.Default = defaults.FieldByIndex(field.Index).Bool()
+		}
+		if c, ok := constraints[name]; ok {
+			mergeSchema(property, c)
+		}
+		root.Properties[name] = property
+	}
+	for name := range constraints {
+		if _, ok := root.Properties[name]; !ok {
+			return nil, fmt.Errorf("constraints of unknown attribute %s", name)
+		}
+	}
+
+	out, err := json.MarshalIndent(root, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(out, '\n'), nil
+}
+
+// schemaOfType returns the schema of a DataSourceConfig field type. Lists are
+// nullable, as DataSourceConfig marshals empty lists as null.
+func schemaOfType(t reflect.Type) (*jsonSchema, error) {
+	switch t.Kind() {
+	case reflect.Pointer:
+		return schemaOfType(t.Elem())
+	case reflect.String:
+		return &jsonSchema{Type: "string"}, nil
+	case reflect.Bool:
+		return &jsonSchema{Type: "boolean"}, nil
+	case reflect.Int:
+		return &jsonSchema{Type: "integer"}, nil
+	case reflect.Float64:
+		return &jsonSchema{Type: "number"}, nil
+	case reflect.Slice:
+		items, err := schemaOfType(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &jsonSchema{Type: []string{"array", "null"}, Items: items}, nil
+	case reflect.Map:
+		values, err := schemaOfType(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &jsonSchema{Type: "object", AdditionalProperties: values}, nil
+	case reflect.Struct:
+		if t == reflect.TypeFor[TypedTag]() {
+			return typedTagSchema(), nil
+		}
+	}
+	return nil, fmt.Errorf("unsupported type %s", t)
+}
+
+// mergeSchema adds the constraints of c to s, descending into list items and
+// map values
+func mergeSchema(s, c *jsonSchema) {
+	if c.Items != nil && s.Items != nil {
+		mergeSchema(s.Items, c.Items)
+	}
+	if c.AdditionalProperties != nil && s.AdditionalProperties != nil {
+		mergeSchema(s.AdditionalProperties, c.AdditionalProperties)
+	}
+	if c.Enum != nil {
+		s.Enum = c.Enum
+	}
+	if c.Const != nil {
+		s.Const = c.Const
+	}
+	if c.Pattern != "" {
+		s.Pattern = c.Pattern
+	}
+	if c.Format != "" {
+		s.Format = c.Format
+	}
+	if c.Minimum != nil {
+		s.Minimum = c.Minimum
+	}
+	if c.MinLength != 0 {
+		s.MinLength = c.MinLength
+	}
+	if c.UniqueItems {
+		s.UniqueItems = true
+	}
+	if c.AnyOf != nil {
+		s.AnyOf = c.AnyOf
+	}
+	if c.Not != nil {
+		s.Not = c.Not
+	}
+	if c.Properties != nil {
+		s.Properties = c.Properties
+		s.Required = c.Required
+	}
+}
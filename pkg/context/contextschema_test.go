@@ -0,0 +1,170 @@
+package context
+
+import (
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// contextSchemaDescriptions returns a description for every context
+// document attribute
+func contextSchemaDescriptions(t *testing.T) map[string]string {
+	t.Helper()
+	descriptions := map[string]string{}
+	for _, field := range reflect.VisibleFields(reflect.TypeFor[DataSourceConfig]()) {
+		if name, _, _ := strings.Cut(field.Tag.Get("json"), ","); name != "" {
+			descriptions[name] = "description of " + name
+		}
+	}
+	return descriptions
+}
+
+// generatedSchema returns the generated schema decoded as jsonSchema
+func generatedSchema(t *testing.T) *jsonSchema {
+	t.Helper()
+	out, err := GenerateContextSchema(contextSchemaDescriptions(t))
+	if err != nil {
+		t.Fatalf("GenerateContextSchema() error = %v", err)
+	}
+	var schema jsonSchema
+	if err := json.Unmarshal(out, &schema); err != nil {
+		t.Fatalf("GenerateContextSchema() returned invalid JSON: %v", err)
+	}
+	return &schema
+}
+
+func TestGenerateContextSchema(t *testing.T) {
+	schema := generatedSchema(t)
+
+	if schema.ID != ContextSchemaID || schema.Type != "object" {
+		t.Errorf("$id, type = %q, %v", schema.ID, schema.Type)
+	}
+	for name, description := range contextSchemaDescriptions(t) {
+		property, ok := schema.Properties[name]
+		if !ok {
+			t.Errorf("schema has no property %s", name)
+			continue
+		}
+		if property.Description != description {
+			t.Errorf("%s description = %q, want %q", name, property.Description, description)
+		}
+	}
+
+	tests := []struct {
+		name  string
+		check func(p map[string]*jsonSchema) bool
+	}{
+		{"strings", func(p map[string]*jsonSchema) bool { return p["name"].Type == "string" }},
+		{"numbers", func(p map[string]*jsonSchema) bool {
+			return p["monthly_budget"].Type == "number" && *p["monthly_budget"].Minimum == 0
+		}},
+		{"integers", func(p map[string]*jsonSchema) bool { return p["pr_number"].Type == "integer" }},
+		{"nullable lists", func(p map[string]*jsonSchema) bool {
+			return slices.Equal(anyStrings(p["data_regs"].Type), []string{"array", "null"}) && p["data_regs"].Items.Type == "string"
+		}},
+		{"maps", func(p map[string]*jsonSchema) bool { return p["raw_tags"].AdditionalProperties.Type == "string" }},
+		{"typed tags", func(p map[string]*jsonSchema) bool {
+			return slices.Equal(p["additional_typed_tags"].AdditionalProperties.Required, []string{"value"})
+		}},
+		{"bool defaults", func(p map[string]*jsonSchema) bool {
+			return p["enabled"].Default == true && p["source_repo_tags_enabled"].Default == true && p["digest_tag_enabled"].Default == false
+		}},
+		{"enums", func(p map[string]*jsonSchema) bool {
+			return slices.Equal(anyStrings(p["tier"].Enum), []string{"", "app", "data", "web"})
+		}},
+		{"list item enums", func(p map[string]*jsonSchema) bool {
+			return slices.Contains(anyStrings(p["na_fields"].Items.Enum), "!costcenter")
+		}},
+		{"compliance profiles", func(p map[string]*jsonSchema) bool {
+			return slices.Equal(anyStrings(p["compliance_profile"].Enum), append([]string{""}, ComplianceProfileNames()...))
+		}},
+		{"email lists", func(p map[string]*jsonSchema) bool { return p["product_owners"].Items.Format == "email" }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !tt.check(schema.Properties) {
+				t.Error("unexpected schema")
+			}
+		})
+	}
+}
+
+// anyStrings returns the strings of a decoded JSON value: a string or a list
+func anyStrings(v any) []string {
+	var values []string
+	switch v := v.(type) {
+	case string:
+		values = append(values, v)
+	case []any:
+		for _, s := range v {
+			if s, ok := s.(string); ok {
+				values = append(values, s)
+			}
+		}
+	}
+	return values
+}
+
+// TestContextSchemaPatterns checks that the patterns accept the same values
+// as the Validate functions
+func TestContextSchemaPatterns(t *testing.T) {
+	schema := generatedSchema(t)
+
+	tests := []struct {
+		field    string
+		validate func(string) error
+		values   []string
+	}{
+		{"namespace", ValidateNamespace, []string{"", "ex", "a", "myorg-12", "Bad_NS", "toolongns", "ex-"}},
+		{"environment", ValidateEnvironment, []string{"", "dev", "prod-eu", "Prod", "toolongenv"}},
+		{"tenant", ValidateTenant, []string{"", "acme", "-acme", "acme1234x"}},
+		{"business_unit", ValidateBusinessUnit, []string{"", "retail-banking", "Retail", strings.Repeat("a", 33)}},
+		{"tenant_id", ValidateTenantID, []string{"", "550e8400-e29b-41d4-a716-446655440000", "_x", strings.Repeat("a", 65)}},
+		{"customer", ValidateCustomer, []string{"", "acme-corp", "ACME", strings.Repeat("a", 17)}},
+		{"region", ValidateRegion, []string{"", "eu-west-1", "westeurope", "EU-WEST-1"}},
+		{"budget_currency", ValidateBudgetCurrency, []string{"", "USD", "usd", "EURO"}},
+		{"deletion_date", ValidateDeletionDate, []string{"", "2026-01-31", "2026-1-31", "31/01/2026"}},
+		{"ephemeral_suffix", ValidateEphemeralSuffix, []string{"", "feature-x", "---"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			pattern := regexp.MustCompile(schema.Properties[tt.field].Pattern)
+			for _, value := range tt.values {
+				if matched, valid := pattern.MatchString(value), tt.validate(value) == nil; matched != valid {
+					t.Errorf("pattern matches %q = %v, validator accepts it = %v", value, matched, valid)
+				}
+			}
+		})
+	}
+
+	retention := schema.Properties["data_retention"]
+	pattern, not := regexp.MustCompile(retention.Pattern), regexp.MustCompile(retention.Not.Pattern)
+	for _, value := range []string{"", "P7Y", "P1Y6M", "PT12H", "P", "PT", "P1YT", "7Y"} {
+		matched := pattern.MatchString(value) && !not.MatchString(value)
+		if valid := ValidateDataRetention(value) == nil; matched != valid {
+			t.Errorf("data_retention schema accepts %q = %v, validator accepts it = %v", value, matched, valid)
+		}
+	}
+}
+
+func TestGenerateContextSchema_missingDescription(t *testing.T) {
+	descriptions := contextSchemaDescriptions(t)
+	delete(descriptions, "raw_tags")
+	if _, err := GenerateContextSchema(descriptions); err == nil || !strings.Contains(err.Error(), "raw_tags") {
+		t.Errorf("GenerateContextSchema() error = %v, want no description for raw_tags", err)
+	}
+}
+
+func TestContextSchema(t *testing.T) {
+	var schema map[string]any
+	if err := json.Unmarshal(ContextSchema(), &schema); err != nil {
+		t.Fatalf("ContextSchema() is not JSON: %v", err)
+	}
+	if schema["$id"] != ContextSchemaID {
+		t.Errorf("ContextSchema() $id = %v, want %s", schema["$id"], ContextSchemaID)
+	}
+}
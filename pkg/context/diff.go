@@ -0,0 +1,44 @@
+package context
+
+import (
+	"maps"
+	"slices"
+)
+
+// FieldDiff lists the fields that differ between two flattened contexts,
+// each sorted by field name
+type FieldDiff struct {
+	// Added are fields set only in the proposed context
+	Added []string
+	// Removed are fields set only in the current context
+	Removed []string
+	// Changed are fields set in both contexts with different values
+	Changed []string
+}
+
+// Empty reports whether the contexts have no differences
+func (d FieldDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// DiffFields compares the flattened current and proposed contexts, keyed by
+// field name. Empty values count as unset, since context outputs emit them
+// for fields that were never configured.
+func DiffFields(current, proposed map[string]string) FieldDiff {
+	diff := FieldDiff{Added: []string{}, Removed: []string{}, Changed: []string{}}
+	for _, field := range slices.Sorted(maps.Keys(proposed)) {
+		switch was := current[field]; {
+		case proposed[field] == "":
+		case was == "":
+			diff.Added = append(diff.Added, field)
+		case was != proposed[field]:
+			diff.Changed = append(diff.Changed, field)
+		}
+	}
+	for _, field := range slices.Sorted(maps.Keys(current)) {
+		if current[field] != "" && proposed[field] == "" {
+			diff.Removed = append(diff.Removed, field)
+		}
+	}
+	return diff
+}
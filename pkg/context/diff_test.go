@@ -0,0 +1,62 @@
+package context
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffFields(t *testing.T) {
+	tests := []struct {
+		name     string
+		current  map[string]string
+		proposed map[string]string
+		want     FieldDiff
+	}{
+		{
+			name:     "identical",
+			current:  map[string]string{"namespace": "ex", "enabled": "true"},
+			proposed: map[string]string{"namespace": "ex", "enabled": "true"},
+			want:     FieldDiff{Added: []string{}, Removed: []string{}, Changed: []string{}},
+		},
+		{
+			name: "added removed and changed",
+			current: map[string]string{
+				"namespace":                "ex",
+				"cost_center":              "cc-100",
+				"additional_tags.Team":     "payments",
+				"environment":              "dev",
+				"additional_tags.Retained": "yes",
+			},
+			proposed: map[string]string{
+				"namespace":                "ex",
+				"cost_center":              "cc-200",
+				"additional_tags.Team":     "billing",
+				"sensitivity":              "confidential",
+				"additional_tags.Retained": "yes",
+			},
+			want: FieldDiff{
+				Added:   []string{"sensitivity"},
+				Removed: []string{"environment"},
+				Changed: []string{"additional_tags.Team", "cost_center"},
+			},
+		},
+		{
+			name:     "empty values are unset",
+			current:  map[string]string{"tenant": "", "stack_name": "core"},
+			proposed: map[string]string{"tenant": "acme", "stack_name": ""},
+			want:     FieldDiff{Added: []string{"tenant"}, Removed: []string{"stack_name"}, Changed: []string{}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DiffFields(tt.current, tt.proposed)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DiffFields() = %+v, want %+v", got, tt.want)
+			}
+			if got.Empty() != (tt.name == "identical") {
+				t.Errorf("Empty() = %v", got.Empty())
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package context
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// enrichmentTimeout bounds each run of an enrichment program
+const enrichmentTimeout = 30 * time.Second
+
+// EnrichmentInput is the JSON object written to the standard input of an
+// enrichment program
+type EnrichmentInput struct {
+	NamePrefix    string `json:"name_prefix"`
+	CloudProvider string `json:"cloud_provider"`
+	TagPrefix     string `json:"tag_prefix"`
+	// Tags are the generated tags without the tag prefix, before
+	// sanitization, including additional_tags
+	Tags map[string]string `json:"tags"`
+}
+
+// RunEnrichmentProgram runs program, the executable followed by its
+// arguments, with input as JSON on its standard input, following the
+// contract of the Terraform external data source: the program writes a JSON
+// object with string values to its standard output, which is returned as
+// tags without the tag prefix, and reports errors by exiting with a
+// non-zero status and a message on its standard error.
+func RunEnrichmentProgram(ctx context.Context, program []string, input EnrichmentInput) (map[string]string, error) {
+	if len(program) == 0 || program[0] == "" {
+		return nil, errors.New("enrichment program is empty")
+	}
+
+	stdin, err := json.Marshal(input)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, enrichmentTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, program[0], program[1:]...)
+	cmd.Stdin = bytes.NewReader(stdin)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("enrichment program %s: %w: %s", program[0], err, msg)
+		}
+		return nil, fmt.Errorf("enrichment program %s: %w", program[0], err)
+	}
+
+	var tags map[string]string
+	if err := json.Unmarshal(stdout.Bytes(), &tags); err != nil {
+		return nil, fmt.Errorf("enrichment program %s must write a JSON object with string values: %w", program[0], err)
+	}
+	for key := range tags {
+		if key == "" {
+			return nil, fmt.Errorf("enrichment program %s returned a tag with an empty key", program[0])
+		}
+	}
+	return tags, nil
+}
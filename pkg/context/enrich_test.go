@@ -0,0 +1,125 @@
+package context
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestEnrichmentProgramHelper is the enrichment program run by the tests
+// below, acting as selected by the argument after "--"
+func TestEnrichmentProgramHelper(t *testing.T) {
+	if os.Getenv("CONTEXT_TEST_ENRICHMENT_PROGRAM") != "1" {
+		return
+	}
+	defer os.Exit(0)
+
+	var input EnrichmentInput
+	if err := json.NewDecoder(os.Stdin).Decode(&input); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	switch os.Args[len(os.Args)-1] {
+	case "lookup":
+		fmt.Printf(`{"cmdbowner":"team-%s","costcenter":"cc-%s"}`, input.Tags["environment"], input.NamePrefix)
+	case "fail":
+		fmt.Fprintln(os.Stderr, "CMDB unavailable")
+		os.Exit(2)
+	case "not-strings":
+		fmt.Print(`{"replicas":3}`)
+	case "empty-key":
+		fmt.Print(`{"":"value"}`)
+	}
+}
+
+// enrichmentProgram returns the command running TestEnrichmentProgramHelper
+func enrichmentProgram(t *testing.T, mode string) []string {
+	t.Helper()
+	t.Setenv("CONTEXT_TEST_ENRICHMENT_PROGRAM", "1")
+	return []string{os.Args[0], "-test.run=^TestEnrichmentProgramHelper$", "--", mode}
+}
+
+func TestRunEnrichmentProgram(t *testing.T) {
+	input := EnrichmentInput{
+		NamePrefix:    "myorg-app-prod",
+		CloudProvider: "aws",
+		TagPrefix:     "bc-",
+		Tags:          map[string]string{"environment": "prod"},
+	}
+
+	got, err := RunEnrichmentProgram(context.Background(), enrichmentProgram(t, "lookup"), input)
+	if err != nil {
+		t.Fatalf("RunEnrichmentProgram() error = %v", err)
+	}
+	want := map[string]string{"cmdbowner": "team-prod", "costcenter": "cc-myorg-app-prod"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("RunEnrichmentProgram() = %v, want %v", got, want)
+	}
+
+	tests := []struct {
+		name    string
+		program []string
+		wantErr string
+	}{
+		{name: "empty program", program: nil, wantErr: "empty"},
+		{name: "missing program", program: []string{"context-enrichment-program-that-does-not-exist"}, wantErr: "executable file not found"},
+		{name: "failure", program: enrichmentProgram(t, "fail"), wantErr: "CMDB unavailable"},
+		{name: "non-string values", program: enrichmentProgram(t, "not-strings"), wantErr: "string values"},
+		{name: "empty key", program: enrichmentProgram(t, "empty-key"), wantErr: "empty key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := RunEnrichmentProgram(context.Background(), tt.program, input)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("RunEnrichmentProgram() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTagProcessor_Enrich(t *testing.T) {
+	config := NewDataSourceConfig()
+	config.Environment = "prod"
+	config.CostCenter = "cc-100"
+	config.SourceRepoTagsEnabled = false
+	config.AdditionalTags = map[string]string{"team": "platform"}
+
+	var received map[string]string
+	processor := &TagProcessor{
+		CloudProvider: GetCloudProvider("aws"),
+		Config:        config,
+		TagPrefix:     "bc-",
+		Enrich: func(tags map[string]string) (map[string]string, error) {
+			received = tags
+			return map[string]string{"costcenter": "cc-200", "cmdbowner": "team payments!"}, nil
+		},
+	}
+	tags, err := processor.Process()
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+
+	if received["team"] != "platform" || received["costcenter"] != "cc-100" {
+		t.Errorf("Enrich received %v, want the unprefixed tags with additional tags", received)
+	}
+	// Returned tags override generated tags and are prefixed and sanitized
+	if tags["bc-costcenter"] != "cc-200" {
+		t.Errorf("bc-costcenter = %q, want cc-200", tags["bc-costcenter"])
+	}
+	if _, ok := tags["bc-cmdbowner"]; !ok {
+		t.Errorf("bc-cmdbowner is missing from %v", tags)
+	}
+
+	processor.Enrich = func(map[string]string) (map[string]string, error) {
+		return nil, fmt.Errorf("lookup failed")
+	}
+	if _, err := processor.Process(); err == nil {
+		t.Error("Process() error = nil, want the Enrich error")
+	}
+}
@@ -0,0 +1,65 @@
+package context
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// EnvironmentTypeDefaults holds the defaults of the contexts of one
+// environment type, such as dedicated availability and restricted
+// sensitivity for Production. Empty fields fall through to the generic
+// defaults.
+type EnvironmentTypeDefaults struct {
+	Availability  string `json:"availability,omitempty" yaml:"availability,omitempty"`
+	Sensitivity   string `json:"sensitivity,omitempty" yaml:"sensitivity,omitempty"`
+	DataRetention string `json:"data_retention,omitempty" yaml:"data_retention,omitempty"`
+}
+
+// Validate checks the default values
+func (d EnvironmentTypeDefaults) Validate() error {
+	if err := ValidateAvailability(d.Availability); err != nil {
+		return err
+	}
+	if err := ValidateSensitivity(d.Sensitivity); err != nil {
+		return err
+	}
+	return ValidateDataRetention(d.DataRetention)
+}
+
+// ValidateEnvironmentTypeDefaults checks that defaults is keyed by
+// environment types and holds valid values
+func ValidateEnvironmentTypeDefaults(defaults map[string]EnvironmentTypeDefaults) error {
+	for _, envType := range slices.Sorted(maps.Keys(defaults)) {
+		if envType == "" {
+			return fmt.Errorf("environment type must not be empty")
+		}
+		if err := ValidateEnvironmentType(envType); err != nil {
+			return err
+		}
+		if err := defaults[envType].Validate(); err != nil {
+			return fmt.Errorf("%s: %w", envType, err)
+		}
+	}
+	return nil
+}
+
+// ApplyEnvironmentTypeDefaults sets the availability, sensitivity and data
+// retention of config that are not set from the defaults of its environment
+// type. Apply it after ApplyComplianceProfile, whose sensitivity takes
+// precedence.
+func ApplyEnvironmentTypeDefaults(config *DataSourceConfig, defaults map[string]EnvironmentTypeDefaults) {
+	d, ok := defaults[config.EnvironmentType]
+	if !ok || config.EnvironmentType == "" {
+		return
+	}
+	if config.Availability == "" {
+		config.Availability = d.Availability
+	}
+	if config.Sensitivity == "" {
+		config.Sensitivity = d.Sensitivity
+	}
+	if config.DataRetention == "" {
+		config.DataRetention = d.DataRetention
+	}
+}
@@ -0,0 +1,91 @@
+package context
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestApplyEnvironmentTypeDefaults(t *testing.T) {
+	defaults := map[string]EnvironmentTypeDefaults{
+		"Production":  {Availability: "dedicated", Sensitivity: "restricted", DataRetention: "P7Y"},
+		"Development": {Availability: "preemptable", Sensitivity: "internal"},
+	}
+
+	tests := []struct {
+		name   string
+		config DataSourceConfig
+		want   DataSourceConfig
+	}{
+		{
+			name:   "defaults of the environment type",
+			config: DataSourceConfig{EnvironmentType: "Production"},
+			want:   DataSourceConfig{EnvironmentType: "Production", Availability: "dedicated", Sensitivity: "restricted", DataRetention: "P7Y"},
+		},
+		{
+			name:   "set values are kept",
+			config: DataSourceConfig{EnvironmentType: "Production", Availability: "spot", DataRetention: "P1Y"},
+			want:   DataSourceConfig{EnvironmentType: "Production", Availability: "spot", Sensitivity: "restricted", DataRetention: "P1Y"},
+		},
+		{
+			name:   "empty defaults are not applied",
+			config: DataSourceConfig{EnvironmentType: "Development"},
+			want:   DataSourceConfig{EnvironmentType: "Development", Availability: "preemptable", Sensitivity: "internal"},
+		},
+		{
+			name:   "other environment types",
+			config: DataSourceConfig{EnvironmentType: "Testing"},
+			want:   DataSourceConfig{EnvironmentType: "Testing"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := tt.config
+			ApplyEnvironmentTypeDefaults(&config, defaults)
+			if config.Availability != tt.want.Availability || config.Sensitivity != tt.want.Sensitivity || config.DataRetention != tt.want.DataRetention {
+				t.Errorf("availability, sensitivity, data_retention = %q, %q, %q, want %q, %q, %q",
+					config.Availability, config.Sensitivity, config.DataRetention,
+					tt.want.Availability, tt.want.Sensitivity, tt.want.DataRetention)
+			}
+		})
+	}
+}
+
+func TestApplyEnvironmentTypeDefaults_complianceProfile(t *testing.T) {
+	config := &DataSourceConfig{EnvironmentType: "Development", ComplianceProfile: "hipaa"}
+	ApplyComplianceProfile(config)
+	ApplyEnvironmentTypeDefaults(config, map[string]EnvironmentTypeDefaults{"Development": {Sensitivity: "internal"}})
+	if config.Sensitivity != ComplianceProfiles["hipaa"].DefaultSensitivity {
+		t.Errorf("Sensitivity = %q, want the hipaa default %q", config.Sensitivity, ComplianceProfiles["hipaa"].DefaultSensitivity)
+	}
+}
+
+func TestValidateEnvironmentTypeDefaults(t *testing.T) {
+	tests := []struct {
+		name     string
+		defaults map[string]EnvironmentTypeDefaults
+		wantErr  string
+	}{
+		{name: "valid", defaults: map[string]EnvironmentTypeDefaults{"Production": {Availability: "dedicated", Sensitivity: "restricted", DataRetention: "P7Y"}, "None": {}}},
+		{name: "empty environment type", defaults: map[string]EnvironmentTypeDefaults{"": {}}, wantErr: "environment type must not be empty"},
+		{name: "unknown environment type", defaults: map[string]EnvironmentTypeDefaults{"Staging": {}}, wantErr: "invalid environment type 'Staging'"},
+		{name: "invalid availability", defaults: map[string]EnvironmentTypeDefaults{"Production": {Availability: "always"}}, wantErr: "Production: invalid availability"},
+		{name: "invalid sensitivity", defaults: map[string]EnvironmentTypeDefaults{"Production": {Sensitivity: "secret"}}, wantErr: "Production: invalid sensitivity"},
+		{name: "invalid data retention", defaults: map[string]EnvironmentTypeDefaults{"Production": {DataRetention: "7 years"}}, wantErr: "Production: "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateEnvironmentTypeDefaults(tt.defaults)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateEnvironmentTypeDefaults() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ValidateEnvironmentTypeDefaults() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package context
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ConfigFromTags reverse-maps tags generated by TagProcessor, such as those
+// found on an existing resource, into a config. Keys without tagPrefix, N/A
+// values and tags derived at apply time (source repository and tool
+// versions) are ignored, and per-regulation tags, the digest tag and the
+// builder identity tags only enable RegulationTagsEnabled, DigestTagEnabled
+// and ProvenanceTagsEnabled. Prefixed keys that are not generated tags become
+// AdditionalTags with the prefix removed.
+//
+// Sanitization is not reversible, so values are returned as they appear in
+// tags, except the maintenance window, which is decoded with
+// DecodeMaintenanceWindow.
+func ConfigFromTags(tags map[string]string, tagPrefix string, cp CloudProvider) *DataSourceConfig {
+	config := NewDataSourceConfig()
+	delimiter := cp.GetDelimiter()
+	naValue := cp.GetNAValue()
+
+	for key, value := range tags {
+		if !strings.HasPrefix(key, tagPrefix) || value == "" || value == naValue {
+			continue
+		}
+
+		switch name := strings.TrimPrefix(key, tagPrefix); name {
+		case "environment":
+			config.EnvironmentName = value
+		case "availability":
+			config.Availability = value
+		case "managedby":
+			config.ManagedBy = value
+		case "deletiondate":
+			config.DeletionDate = value
+		case "expiryaction":
+			config.LifecycleAction = value
+		case "maintenancewindow":
+			config.MaintenanceWindow = DecodeMaintenanceWindow(value, cp)
+		case "patchgroup":
+			config.PatchGroup = value
+		case "region":
+			config.Region = value
+		case "costcenter":
+			config.CostCenter = value
+		case "monthlybudget":
+			if budget, err := strconv.ParseFloat(value, 64); err == nil {
+				config.MonthlyBudget = budget
+			}
+		case "budgetcurrency":
+			config.BudgetCurrency = value
+		case "tenant":
+			config.Tenant = value
+		case "attributes":
+			config.Attributes = strings.Split(value, delimiter)
+		case "stack":
+			config.StackName = value
+		case "component":
+			config.Component = value
+		case "application":
+			config.Application = value
+		case "service":
+			config.Service = value
+		case "tier":
+			config.Tier = value
+		case "businessunit":
+			config.BusinessUnit = value
+		case "division":
+			config.Division = value
+		case "portfolio":
+			config.Portfolio = value
+		case "tenantid":
+			config.TenantID = value
+		case "customer":
+			config.Customer = value
+		case "projectmgmtid":
+			config.PMPlatform, config.PMProjectCode = splitPlatformValue(value, delimiter)
+		case "systemid":
+			config.ITSMPlatform, config.ITSMSystemID = splitPlatformValue(value, delimiter)
+		case "componentid":
+			config.ITSMPlatform, config.ITSMComponentID = splitPlatformValue(value, delimiter)
+		case "instanceid":
+			config.ITSMPlatform, config.ITSMInstanceID = splitPlatformValue(value, delimiter)
+		case "productowners":
+			config.ProductOwners = strings.Split(value, delimiter)
+		case "codeowners":
+			config.CodeOwners = strings.Split(value, delimiter)
+		case "dataowners":
+			config.DataOwners = strings.Split(value, delimiter)
+		case "sensitivity":
+			config.Sensitivity = value
+		case "dataregulations":
+			config.DataRegs = strings.Split(value, delimiter)
+		case "dataretention":
+			config.DataRetention = value
+		case "dataresidency":
+			config.DataResidency = strings.Split(value, delimiter)
+		case "sovereignty":
+			config.SovereigntyRequirements = strings.Split(value, delimiter)
+		case "securityreview":
+			config.SecurityReview = value
+		case "privacyreview":
+			config.PrivacyReview = value
+		case "sourcerepo", "sourcecommit", "terraformversion", "contextproviderversion":
+			// Derived when the tags are generated
+		case AWSPatchGroupTagKey:
+			// Derived from patchgroup, only seen without a tag prefix
+		case digestTagKey:
+			// Derived from the other tags
+			config.DigestTagEnabled = true
+		case builderIDTagKey, buildInvocationTagKey, builderSubjectTagKey, builderAudienceTagKey:
+			// Derived from the environment of the run
+			config.ProvenanceTagsEnabled = true
+		default:
+			if strings.HasPrefix(name, "reg-") {
+				// Derived from dataregulations
+				config.RegulationTagsEnabled = true
+				continue
+			}
+			if config.AdditionalTags == nil {
+				config.AdditionalTags = make(map[string]string)
+			}
+			config.AdditionalTags[name] = value
+		}
+	}
+
+	return config
+}
+
+// splitPlatformValue splits a system-prefixed value such as "jira PROJ-1"
+// into its platform and identifier; values without the delimiter are
+// returned as the identifier alone
+func splitPlatformValue(value, delimiter string) (string, string) {
+	if platform, id, ok := strings.Cut(value, delimiter); ok {
+		return platform, id
+	}
+	return "", value
+}
@@ -0,0 +1,105 @@
+package context
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConfigFromTags(t *testing.T) {
+	config := &DataSourceConfig{
+		Tenant:                  "acme",
+		Attributes:              []string{"blue", "1"},
+		EnvironmentName:         "Production",
+		StackName:               "payments",
+		Application:             "checkout",
+		Service:                 "payments-api",
+		Tier:                    "app",
+		BusinessUnit:            "retail",
+		Division:                "payments",
+		Portfolio:               "cards",
+		TenantID:                "t-0042",
+		Customer:                "globex",
+		Availability:            "dedicated",
+		ManagedBy:               "terraform",
+		DeletionDate:            "2030-01-01",
+		LifecycleAction:         "notify",
+		MaintenanceWindow:       "0 3 * * SUN",
+		PatchGroup:              "web-servers",
+		Region:                  "eu-central-1",
+		PMPlatform:              "jira",
+		PMProjectCode:           "PAY",
+		ITSMPlatform:            "snow",
+		ITSMSystemID:            "sys-1",
+		CostCenter:              "cc-100",
+		MonthlyBudget:           2500,
+		BudgetCurrency:          "EUR",
+		ProductOwners:           []string{"owner@example.com", "lead@example.com"},
+		CodeOwners:              []string{"dev@example.com"},
+		DataOwners:              []string{"data@example.com"},
+		Sensitivity:             "restricted",
+		DataRegs:                []string{"GDPR"},
+		DataRetention:           "P1Y6M",
+		DataResidency:           []string{"DE", "FR"},
+		SovereigntyRequirements: []string{"EUCS", "SecNumCloud"},
+		SecurityReview:          "2024-01-01",
+		OwnerTagsEnabled:        true,
+		// Recovered from the per-regulation tags and the digest tag
+		RegulationTagsEnabled: true,
+		DigestTagEnabled:      true,
+		// The remaining toggles match NewDataSourceConfig
+		Enabled:               true,
+		SystemPrefixesEnabled: true,
+		NotApplicableEnabled:  true,
+		SourceRepoTagsEnabled: true,
+		AdditionalTags:        map[string]string{"team": "platform"},
+	}
+
+	processor := &TagProcessor{
+		CloudProvider: GetCloudProvider("dc"),
+		Config:        config,
+		TagPrefix:     "bc-",
+	}
+	tags, err := processor.Process()
+	if err != nil {
+		t.Fatalf("Failed to process tags: %v", err)
+	}
+	dataTags, err := processor.ProcessDataTags()
+	if err != nil {
+		t.Fatalf("Failed to process data tags: %v", err)
+	}
+	for k, v := range dataTags {
+		tags[k] = v
+	}
+	// Tags from other tooling are ignored
+	tags["Name"] = "legacy"
+
+	got := ConfigFromTags(tags, "bc-", GetCloudProvider("dc"))
+
+	if !reflect.DeepEqual(got, config) {
+		t.Errorf("ConfigFromTags() =\n%+v\nwant\n%+v", got, config)
+	}
+}
+
+func TestConfigFromTags_NotApplicable(t *testing.T) {
+	tags := map[string]string{
+		"bc-costcenter":    "N/A",
+		"bc-sourcecommit":  "abc123",
+		"bc-projectmgmtid": "PAY",
+		"bc-availability":  "spot",
+	}
+
+	got := ConfigFromTags(tags, "bc-", GetCloudProvider("aws"))
+
+	if got.CostCenter != "" {
+		t.Errorf("CostCenter = %q, want empty for N/A", got.CostCenter)
+	}
+	if got.PMPlatform != "" || got.PMProjectCode != "PAY" {
+		t.Errorf("PMPlatform/PMProjectCode = %q/%q, want \"\"/PAY", got.PMPlatform, got.PMProjectCode)
+	}
+	if got.Availability != "spot" {
+		t.Errorf("Availability = %q, want spot", got.Availability)
+	}
+	if len(got.AdditionalTags) != 0 {
+		t.Errorf("AdditionalTags = %v, want none", got.AdditionalTags)
+	}
+}
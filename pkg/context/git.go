@@ -1,9 +1,14 @@
 package context
 
 import (
+	"context"
+	"errors"
+	neturl "net/url"
 	"os/exec"
+	"path/filepath"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -11,21 +16,68 @@ import (
 type GitInfo struct {
 	RepoURL    string
 	CommitHash string
+	// TimedOut is set when git did not answer within the timeout, leaving
+	// the fields it had not returned empty
+	TimedOut bool
+}
+
+// DefaultGitTimeout bounds the git commands run by GetGitInfo
+const DefaultGitTimeout = 5 * time.Second
+
+// gitWaitDelay bounds the wait for the output of git once it is killed,
+// since its children, such as credential helpers, may keep it open
+const gitWaitDelay = time.Second
+
+// gitCacheTTL is how long GetGitInfo reuses the repository information,
+// including the empty results of directories outside a repository and of
+// timed out lookups, so a missing or slow repository is not looked up again
+// by every read
+const gitCacheTTL = 5 * time.Minute
+
+// gitCacheEntry is the repository information of a directory cached at time
+type gitCacheEntry struct {
+	info *GitInfo
+	time time.Time
 }
 
 var (
-	gitCache     *GitInfo
+	// gitCache is keyed by the directory looked up, "" being the working
+	// directory
+	gitCache     = map[string]gitCacheEntry{}
 	gitCacheLock sync.RWMutex
-	gitCacheTime time.Time
-	gitCacheTTL  = 5 * time.Minute
+
+	gitLookups   atomic.Int64
+	gitCacheHits atomic.Int64
 )
 
-// GetGitInfo retrieves git repository information with caching
+// GetGitInfo retrieves git repository information with caching, bounding
+// the git commands with DefaultGitTimeout. It is safe for concurrent use;
+// each call returns its own copy of the cached value.
 func GetGitInfo() (*GitInfo, error) {
+	return GetGitInfoWithTimeout(DefaultGitTimeout)
+}
+
+// GetGitInfoWithTimeout is GetGitInfo with the git commands bounded by
+// timeout, which must be positive. A lookup that times out returns the
+// fields found so far with TimedOut set and is cached like the others.
+func GetGitInfoWithTimeout(timeout time.Duration) (*GitInfo, error) {
+	return GetGitInfoInDir("", timeout)
+}
+
+// GetGitInfoInDir is GetGitInfoWithTimeout for the repository containing
+// dir, such as the parent repository of a module checked out as a
+// submodule, instead of the working directory when dir is empty. Each
+// directory is cached separately.
+func GetGitInfoInDir(dir string, timeout time.Duration) (*GitInfo, error) {
+	if dir != "" {
+		dir = filepath.Clean(dir)
+	}
+
 	gitCacheLock.RLock()
-	if gitCache != nil && time.Since(gitCacheTime) < gitCacheTTL {
-		info := *gitCache
+	if entry, ok := gitCache[dir]; ok && time.Since(entry.time) < gitCacheTTL {
+		info := *entry.info
 		gitCacheLock.RUnlock()
+		gitCacheHits.Add(1)
 		return &info, nil
 	}
 	gitCacheLock.RUnlock()
@@ -35,33 +87,85 @@ func GetGitInfo() (*GitInfo, error) {
 	defer gitCacheLock.Unlock()
 
 	// Check again in case another goroutine updated it
-	if gitCache != nil && time.Since(gitCacheTime) < gitCacheTTL {
-		info := *gitCache
+	if entry, ok := gitCache[dir]; ok && time.Since(entry.time) < gitCacheTTL {
+		info := *entry.info
+		gitCacheHits.Add(1)
 		return &info, nil
 	}
 
+	gitLookups.Add(1)
+
+	// The commands share the timeout, so a slow repository delays a read
+	// by at most timeout
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	info := lookupGitInfo(ctx, dir)
+
+	// Update cache, keeping the caller's copy separate
+	gitCache[dir] = gitCacheEntry{info: info, time: time.Now()}
+
+	result := *info
+	return &result, nil
+}
+
+// lookupGitInfo runs git in dir, or the working directory when empty, to
+// find the repository information. It does not depend on a branch being
+// checked out, so detached HEADs, shallow clones and linked worktrees, as
+// checked out by CI systems, are handled like other repositories.
+func lookupGitInfo(ctx context.Context, dir string) *GitInfo {
 	info := &GitInfo{}
 
 	// Get repository URL
-	cmd := exec.Command("git", "config", "--get", "remote.origin.url")
-	output, err := cmd.Output()
-	if err == nil {
-		repoURL := strings.TrimSpace(string(output))
-		info.RepoURL = convertSSHToHTTPS(repoURL)
+	if output, err := runGit(ctx, dir, "config", "--get-regexp", `^remote\..*\.url$`); err == nil {
+		info.RepoURL = convertSSHToHTTPS(selectRemoteURL(output))
 	}
 
-	// Get commit hash
-	cmd = exec.Command("git", "rev-parse", "HEAD")
-	output, err = cmd.Output()
-	if err == nil {
-		info.CommitHash = strings.TrimSpace(string(output))
+	// Get commit hash; an unborn HEAD, as in a new repository, has none
+	if ctx.Err() == nil {
+		if output, err := runGit(ctx, dir, "rev-parse", "--verify", "--quiet", "HEAD"); err == nil {
+			info.CommitHash = output
+		}
 	}
+	info.TimedOut = errors.Is(ctx.Err(), context.DeadlineExceeded)
+	return info
+}
 
-	// Update cache
-	gitCache = info
-	gitCacheTime = time.Now()
+// selectRemoteURL returns the URL of the origin remote from the output of
+// git config --get-regexp, or of the first remote configured when there is
+// no origin, such as in CI checkouts that name the remote after the fork
+func selectRemoteURL(output string) string {
+	first := ""
+	for line := range strings.Lines(output) {
+		key, url, ok := strings.Cut(strings.TrimSpace(line), " ")
+		if !ok {
+			continue
+		}
+		if key == "remote.origin.url" {
+			return url
+		}
+		if first == "" {
+			first = url
+		}
+	}
+	return first
+}
+
+// runGit runs git in dir with args and returns its trimmed standard output
+func runGit(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	cmd.WaitDelay = gitWaitDelay
+	output, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
 
-	return info, nil
+// GitStats returns the number of times GetGitInfo ran git and the number of
+// times it was served from the cache since the process started
+func GitStats() (lookups, cacheHits int64) {
+	return gitLookups.Load(), gitCacheHits.Load()
 }
 
 // convertSSHToHTTPS converts SSH git URLs to HTTPS format
@@ -85,7 +189,12 @@ func convertSSHToHTTPS(url string) string {
 		return url
 	}
 
-	// Already HTTPS or other format
+	// Already HTTPS or other format; drop credentials, such as the job
+	// tokens CI systems embed in the clone URL
+	if parsed, err := neturl.Parse(url); err == nil && parsed.User != nil {
+		parsed.User = nil
+		url = parsed.String()
+	}
 	url = strings.TrimSuffix(url, ".git")
 	return url
 }
@@ -94,6 +203,5 @@ func convertSSHToHTTPS(url string) string {
 func ClearGitCache() {
 	gitCacheLock.Lock()
 	defer gitCacheLock.Unlock()
-	gitCache = nil
-	gitCacheTime = time.Time{}
+	clear(gitCache)
 }
@@ -0,0 +1,288 @@
+package context
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// groupLookupTimeout bounds each request to a group directory
+const groupLookupTimeout = 10 * time.Second
+
+// Group directory types
+const (
+	GroupDirectoryMicrosoftGraph = "microsoft_graph"
+	GroupDirectoryGoogle         = "google"
+)
+
+// GroupDirectoryTokenEnvVars are the environment variables holding the
+// access token of each group directory type
+var GroupDirectoryTokenEnvVars = map[string]string{
+	GroupDirectoryMicrosoftGraph: "MSGRAPH_ACCESS_TOKEN",
+	GroupDirectoryGoogle:         "GOOGLE_OAUTH_ACCESS_TOKEN",
+}
+
+// Owner group tag modes, selecting how owner groups found in a directory
+// appear in the owner tags
+const (
+	// OwnerGroupTagsDisplayName replaces group addresses with the canonical
+	// group display name
+	OwnerGroupTagsDisplayName = "display_name"
+	// OwnerGroupTagsMemberCount keeps the addresses and adds a <key>members
+	// tag, such as productownersmembers, with the number of group members
+	OwnerGroupTagsMemberCount = "member_count"
+)
+
+// ValidOwnerGroupTags contains the list of valid owner group tag modes
+var ValidOwnerGroupTags = map[string]bool{
+	OwnerGroupTagsDisplayName: true,
+	OwnerGroupTagsMemberCount: true,
+}
+
+// Group is a distribution list or security group found in a directory
+type Group struct {
+	Email       string
+	DisplayName string
+	MemberCount int
+}
+
+// GroupDirectory looks up groups by email address. LookupGroup returns
+// false without an error for addresses that are not groups, such as
+// individual users.
+type GroupDirectory interface {
+	LookupGroup(ctx context.Context, email string) (Group, bool, error)
+}
+
+// NewGroupDirectory returns the directory of directoryType authenticated with
+// an OAuth access token. baseURL overrides the API endpoint when not empty,
+// such as for national clouds.
+func NewGroupDirectory(directoryType, token, baseURL string) (GroupDirectory, error) {
+	switch directoryType {
+	case GroupDirectoryMicrosoftGraph:
+		return &MicrosoftGraphDirectory{Token: token, BaseURL: baseURL}, nil
+	case GroupDirectoryGoogle:
+		return &GoogleDirectory{Token: token, BaseURL: baseURL}, nil
+	default:
+		return nil, fmt.Errorf("invalid group directory type '%s', must be one of: %s, %s",
+			directoryType, GroupDirectoryMicrosoftGraph, GroupDirectoryGoogle)
+	}
+}
+
+// MicrosoftGraphDirectory looks up Microsoft 365 and Entra ID groups by mail
+// address. The token needs the GroupMember.Read.All permission.
+type MicrosoftGraphDirectory struct {
+	Token string
+	// BaseURL defaults to https://graph.microsoft.com
+	BaseURL string
+}
+
+// LookupGroup returns the group with the mail address email
+func (d *MicrosoftGraphDirectory) LookupGroup(ctx context.Context, email string) (Group, bool, error) {
+	base := strings.TrimSuffix(d.BaseURL, "/")
+	if base == "" {
+		base = "https://graph.microsoft.com"
+	}
+
+	query := url.Values{
+		"$filter": {fmt.Sprintf("mail eq '%s'", strings.ReplaceAll(email, "'", "''"))},
+		"$select": {"id,displayName,mail"},
+	}
+	var found struct {
+		Value []struct {
+			ID          string `json:"id"`
+			DisplayName string `json:"displayName"`
+			Mail        string `json:"mail"`
+		} `json:"value"`
+	}
+	body, err := directoryGet(ctx, base+"/v1.0/groups?"+query.Encode(), d.Token, nil)
+	if err != nil {
+		return Group{}, false, err
+	}
+	if err := json.Unmarshal(body, &found); err != nil {
+		return Group{}, false, fmt.Errorf("decoding group %s: %w", email, err)
+	}
+	if len(found.Value) == 0 {
+		return Group{}, false, nil
+	}
+
+	// $count requires the eventual consistency level and returns plain text
+	group := found.Value[0]
+	body, err = directoryGet(ctx, base+"/v1.0/groups/"+url.PathEscape(group.ID)+"/members/$count", d.Token,
+		map[string]string{"ConsistencyLevel": "eventual"})
+	if err != nil {
+		return Group{}, false, err
+	}
+	count, err := strconv.Atoi(strings.TrimSpace(string(body)))
+	if err != nil {
+		return Group{}, false, fmt.Errorf("decoding member count of group %s: %w", email, err)
+	}
+
+	return Group{Email: group.Mail, DisplayName: group.DisplayName, MemberCount: count}, true, nil
+}
+
+// GoogleDirectory looks up Google Workspace groups with the Admin SDK
+// Directory API. The token needs the admin.directory.group.readonly scope.
+type GoogleDirectory struct {
+	Token string
+	// BaseURL defaults to https://admin.googleapis.com
+	BaseURL string
+}
+
+// LookupGroup returns the group with the address email
+func (d *GoogleDirectory) LookupGroup(ctx context.Context, email string) (Group, bool, error) {
+	base := strings.TrimSuffix(d.BaseURL, "/")
+	if base == "" {
+		base = "https://admin.googleapis.com"
+	}
+
+	body, err := directoryGet(ctx, base+"/admin/directory/v1/groups/"+url.PathEscape(email), d.Token, nil)
+	if errors.Is(err, errGroupNotFound) {
+		return Group{}, false, nil
+	}
+	if err != nil {
+		return Group{}, false, err
+	}
+
+	// directMembersCount is an int64 and therefore encoded as a string
+	var group struct {
+		Email              string `json:"email"`
+		Name               string `json:"name"`
+		DirectMembersCount string `json:"directMembersCount"`
+	}
+	if err := json.Unmarshal(body, &group); err != nil {
+		return Group{}, false, fmt.Errorf("decoding group %s: %w", email, err)
+	}
+	count, err := strconv.Atoi(group.DirectMembersCount)
+	if err != nil {
+		return Group{}, false, fmt.Errorf("decoding member count of group %s: %w", email, err)
+	}
+
+	return Group{Email: group.Email, DisplayName: group.Name, MemberCount: count}, true, nil
+}
+
+// errGroupNotFound reports a 404 response from a group directory
+var errGroupNotFound = errors.New("group not found")
+
+// directoryGet sends an authenticated GET request and returns the response body
+func directoryGet(ctx context.Context, requestURL, token string, header map[string]string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, groupLookupTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return io.ReadAll(resp.Body)
+	case http.StatusNotFound:
+		return nil, errGroupNotFound
+	default:
+		return nil, fmt.Errorf("fetching %s: %s", req.URL.Path, resp.Status)
+	}
+}
+
+// cachedGroupDirectory remembers lookups so that many data sources listing
+// the same owners query the directory once
+type cachedGroupDirectory struct {
+	directory GroupDirectory
+
+	mu     sync.Mutex
+	groups map[string]cachedGroup
+}
+
+type cachedGroup struct {
+	group Group
+	found bool
+}
+
+// groupLookups and groupCacheHits count the lookups of all cached directories
+var (
+	groupLookups   atomic.Int64
+	groupCacheHits atomic.Int64
+)
+
+// GroupDirectoryStats returns the number of lookups sent to group directories
+// and the number served from the cache by directories wrapped with
+// CacheGroupDirectory since the process started
+func GroupDirectoryStats() (lookups, cacheHits int64) {
+	return groupLookups.Load(), groupCacheHits.Load()
+}
+
+// CacheGroupDirectory wraps directory so that each address is looked up
+// once. Failed lookups are not cached.
+func CacheGroupDirectory(directory GroupDirectory) GroupDirectory {
+	return &cachedGroupDirectory{directory: directory, groups: map[string]cachedGroup{}}
+}
+
+// LookupGroup returns the cached group of email, looking it up when missing
+func (c *cachedGroupDirectory) LookupGroup(ctx context.Context, email string) (Group, bool, error) {
+	c.mu.Lock()
+	cached, ok := c.groups[email]
+	c.mu.Unlock()
+	if ok {
+		groupCacheHits.Add(1)
+		return cached.group, cached.found, nil
+	}
+
+	groupLookups.Add(1)
+	group, found, err := c.directory.LookupGroup(ctx, email)
+	if err != nil {
+		return Group{}, false, err
+	}
+
+	c.mu.Lock()
+	c.groups[email] = cachedGroup{group: group, found: found}
+	c.mu.Unlock()
+	return group, found, nil
+}
+
+// LookupOwnerGroups looks up each owner address in directory and returns the
+// groups found, keyed by owner address
+func LookupOwnerGroups(ctx context.Context, directory GroupDirectory, owners ...[]string) (map[string]Group, error) {
+	groups := map[string]Group{}
+	for _, list := range owners {
+		for _, owner := range list {
+			if _, ok := groups[owner]; ok {
+				continue
+			}
+			group, found, err := directory.LookupGroup(ctx, owner)
+			if err != nil {
+				return nil, fmt.Errorf("looking up %s: %w", owner, err)
+			}
+			if found {
+				groups[owner] = group
+			}
+		}
+	}
+	return groups, nil
+}
+
+// ValidateOwnerGroupTags validates an owner group tag mode
+func ValidateOwnerGroupTags(mode string) error {
+	if !ValidOwnerGroupTags[mode] {
+		return fmt.Errorf("invalid owner group tags '%s', must be one of: %s, %s",
+			mode, OwnerGroupTagsDisplayName, OwnerGroupTagsMemberCount)
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package context
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMicrosoftGraphDirectory_LookupGroup(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case r.URL.Path == "/v1.0/groups" && r.URL.Query().Get("$filter") == "mail eq 'team-payments@example.com'":
+			fmt.Fprint(w, `{"value":[{"id":"g1","displayName":"Team Payments","mail":"team-payments@example.com"}]}`)
+		case r.URL.Path == "/v1.0/groups":
+			fmt.Fprint(w, `{"value":[]}`)
+		case r.URL.Path == "/v1.0/groups/g1/members/$count" && r.Header.Get("ConsistencyLevel") == "eventual":
+			fmt.Fprint(w, "12")
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	directory := &MicrosoftGraphDirectory{Token: "secret", BaseURL: server.URL}
+
+	group, found, err := directory.LookupGroup(context.Background(), "team-payments@example.com")
+	if err != nil || !found {
+		t.Fatalf("LookupGroup() = %v, %v, want group", found, err)
+	}
+	want := Group{Email: "team-payments@example.com", DisplayName: "Team Payments", MemberCount: 12}
+	if group != want {
+		t.Errorf("LookupGroup() = %+v, want %+v", group, want)
+	}
+
+	if _, found, err := directory.LookupGroup(context.Background(), "user@example.com"); err != nil || found {
+		t.Errorf("LookupGroup(user) = %v, %v, want not found", found, err)
+	}
+
+	directory.Token = "wrong"
+	if _, _, err := directory.LookupGroup(context.Background(), "team-payments@example.com"); err == nil {
+		t.Error("LookupGroup() error = nil, want 401 error")
+	}
+}
+
+func TestGoogleDirectory_LookupGroup(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/admin/directory/v1/groups/team-payments@example.com" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, `{"email":"team-payments@example.com","name":"Team Payments","directMembersCount":"7"}`)
+	}))
+	defer server.Close()
+
+	directory := &GoogleDirectory{Token: "secret", BaseURL: server.URL}
+
+	group, found, err := directory.LookupGroup(context.Background(), "team-payments@example.com")
+	if err != nil || !found {
+		t.Fatalf("LookupGroup() = %v, %v, want group", found, err)
+	}
+	want := Group{Email: "team-payments@example.com", DisplayName: "Team Payments", MemberCount: 7}
+	if group != want {
+		t.Errorf("LookupGroup() = %+v, want %+v", group, want)
+	}
+
+	if _, found, err := directory.LookupGroup(context.Background(), "user@example.com"); err != nil || found {
+		t.Errorf("LookupGroup(user) = %v, %v, want not found", found, err)
+	}
+}
+
+func TestNewGroupDirectory(t *testing.T) {
+	if _, err := NewGroupDirectory(GroupDirectoryMicrosoftGraph, "t", ""); err != nil {
+		t.Errorf("NewGroupDirectory(microsoft_graph) error = %v", err)
+	}
+	if _, err := NewGroupDirectory(GroupDirectoryGoogle, "t", ""); err != nil {
+		t.Errorf("NewGroupDirectory(google) error = %v", err)
+	}
+	if _, err := NewGroupDirectory("ldap", "t", ""); err == nil {
+		t.Error("NewGroupDirectory(ldap) error = nil, want error")
+	}
+}
+
+// countingDirectory is a GroupDirectory returning groups from a map
+type countingDirectory struct {
+	groups  map[string]Group
+	lookups int
+}
+
+func (d *countingDirectory) LookupGroup(ctx context.Context, email string) (Group, bool, error) {
+	d.lookups++
+	group, ok := d.groups[email]
+	return group, ok, nil
+}
+
+func TestLookupOwnerGroups(t *testing.T) {
+	directory := &countingDirectory{groups: map[string]Group{
+		"team@example.com": {Email: "team@example.com", DisplayName: "Team", MemberCount: 3},
+	}}
+	cached := CacheGroupDirectory(directory)
+	lookups, hits := GroupDirectoryStats()
+
+	for range 2 {
+		groups, err := LookupOwnerGroups(context.Background(), cached,
+			[]string{"team@example.com", "user@example.com"}, []string{"team@example.com"})
+		if err != nil {
+			t.Fatalf("LookupOwnerGroups() error = %v", err)
+		}
+		if len(groups) != 1 || groups["team@example.com"].DisplayName != "Team" {
+			t.Errorf("LookupOwnerGroups() = %v, want the team group only", groups)
+		}
+	}
+	if directory.lookups != 2 {
+		t.Errorf("directory lookups = %d, want 2", directory.lookups)
+	}
+	if gotLookups, gotHits := GroupDirectoryStats(); gotLookups-lookups != 2 || gotHits-hits != 2 {
+		t.Errorf("GroupDirectoryStats() increased by %d lookups and %d hits, want 2 and 2", gotLookups-lookups, gotHits-hits)
+	}
+}
+
+func TestValidateOwnerGroupTags(t *testing.T) {
+	for _, mode := range []string{OwnerGroupTagsDisplayName, OwnerGroupTagsMemberCount} {
+		if err := ValidateOwnerGroupTags(mode); err != nil {
+			t.Errorf("ValidateOwnerGroupTags(%q) error = %v", mode, err)
+		}
+	}
+	if err := ValidateOwnerGroupTags("email"); err == nil {
+		t.Error("ValidateOwnerGroupTags(email) error = nil, want error")
+	}
+}
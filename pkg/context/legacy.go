@@ -0,0 +1,44 @@
+package context
+
+import "time"
+
+// LegacyTagsActive reports whether the tags of Config.LegacyTagMap are
+// emitted, which they are through the Config.LegacyTagsUntil date in UTC, or
+// indefinitely when it is empty
+func (tp *TagProcessor) LegacyTagsActive() bool {
+	if len(tp.Config.LegacyTagMap) == 0 {
+		return false
+	}
+	if tp.Config.LegacyTagsUntil == "" {
+		return true
+	}
+	until, err := time.Parse("2006-01-02", tp.Config.LegacyTagsUntil)
+	if err != nil {
+		return true
+	}
+	now := tp.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	return now.UTC().Before(until.AddDate(0, 0, 1))
+}
+
+// addLegacyTags copies the final tags keyed by Config.LegacyTagMap, without
+// the tag prefix, to their legacy keys while the migration window is open.
+// A legacy key already in tags keeps its value.
+func (tp *TagProcessor) addLegacyTags(tags map[string]string) {
+	if !tp.LegacyTagsActive() {
+		return
+	}
+	legacy := map[string]string{}
+	for key, legacyKey := range tp.Config.LegacyTagMap {
+		if value, ok := tags[tp.TagPrefix+key]; ok {
+			legacy[legacyKey] = value
+		}
+	}
+	for legacyKey, value := range legacy {
+		if _, ok := tags[legacyKey]; !ok {
+			tags[legacyKey] = value
+		}
+	}
+}
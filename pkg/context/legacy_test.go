@@ -0,0 +1,108 @@
+package context
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTagProcessor_LegacyTagsActive(t *testing.T) {
+	tests := []struct {
+		name         string
+		legacyTagMap map[string]string
+		until        string
+		now          time.Time
+		want         bool
+	}{
+		{
+			name: "no legacy tag map",
+			now:  time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+			want: false,
+		},
+		{
+			name:         "no end date",
+			legacyTagMap: map[string]string{"costcenter": "CostCenter"},
+			now:          time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+			want:         true,
+		},
+		{
+			name:         "last day of the window",
+			legacyTagMap: map[string]string{"costcenter": "CostCenter"},
+			until:        "2026-03-31",
+			now:          time.Date(2026, 3, 31, 23, 59, 0, 0, time.UTC),
+			want:         true,
+		},
+		{
+			name:         "after the window",
+			legacyTagMap: map[string]string{"costcenter": "CostCenter"},
+			until:        "2026-03-31",
+			now:          time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+			want:         false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processor := &TagProcessor{
+				Config: &DataSourceConfig{LegacyTagMap: tt.legacyTagMap, LegacyTagsUntil: tt.until},
+				Now:    tt.now,
+			}
+			if got := processor.LegacyTagsActive(); got != tt.want {
+				t.Errorf("LegacyTagsActive() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTagProcessor_LegacyTags(t *testing.T) {
+	config := &DataSourceConfig{
+		Namespace:   "test",
+		Environment: "dev",
+		CostCenter:  "cc 100",
+		Sensitivity: "confidential",
+		LegacyTagMap: map[string]string{
+			"costcenter":  "CostCenter",
+			"sensitivity": "DataClassification",
+			"stack":       "Stack",
+			"managedby":   "bc-Owner",
+		},
+		LegacyTagsUntil: "2026-03-31",
+		AdditionalTags:  map[string]string{"Owner": "platform"},
+	}
+	processor := &TagProcessor{
+		CloudProvider: GetCloudProvider("aws"),
+		Config:        config,
+		TagPrefix:     "bc-",
+		Now:           time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	tags, err := processor.Process()
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+	if tags["CostCenter"] != tags["bc-costcenter"] || tags["CostCenter"] == "" {
+		t.Errorf("CostCenter = %q, want the bc-costcenter value %q", tags["CostCenter"], tags["bc-costcenter"])
+	}
+	if _, ok := tags["Stack"]; ok {
+		t.Error("Stack legacy tag emitted without a stack tag")
+	}
+	if tags["bc-Owner"] != "platform" {
+		t.Errorf("bc-Owner = %q, want the additional tag value platform", tags["bc-Owner"])
+	}
+
+	dataTags, err := processor.ProcessDataTags()
+	if err != nil {
+		t.Fatalf("ProcessDataTags() error = %v", err)
+	}
+	if dataTags["DataClassification"] != "confidential" {
+		t.Errorf("DataClassification = %q, want confidential", dataTags["DataClassification"])
+	}
+
+	processor.Now = time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	tags, err = processor.Process()
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+	if _, ok := tags["CostCenter"]; ok {
+		t.Error("CostCenter legacy tag emitted after legacy_tags_until")
+	}
+}
@@ -0,0 +1,38 @@
+package context
+
+import (
+	"fmt"
+)
+
+// Soft limits of additional tags. AWS and Azure accept at most 50 tags on a
+// resource, so more additional tags than that, or values adding up to many
+// kilobytes, usually come from a generated source by mistake. Going over a
+// soft limit is reported as a warning; the tags are still processed.
+const (
+	AdditionalTagsSoftLimit      = 50
+	AdditionalTagsSoftLimitBytes = 16 * 1024
+)
+
+// CheckAdditionalTagLimits returns warnings when tags, the value of the
+// attribute field such as additional_tags, has more than
+// AdditionalTagsSoftLimit entries or values longer than
+// AdditionalTagsSoftLimitBytes in total
+func CheckAdditionalTagLimits(field string, tags map[string]string) []string {
+	var warnings []string
+
+	if len(tags) > AdditionalTagsSoftLimit {
+		warnings = append(warnings, fmt.Sprintf("%s has %d entries, more than the %d tags most cloud providers accept on a resource",
+			field, len(tags), AdditionalTagsSoftLimit))
+	}
+
+	size := 0
+	for _, v := range tags {
+		size += len(v)
+	}
+	if size > AdditionalTagsSoftLimitBytes {
+		warnings = append(warnings, fmt.Sprintf("%s values total %d KB, more than the soft limit of %d KB",
+			field, (size+1023)/1024, AdditionalTagsSoftLimitBytes/1024))
+	}
+
+	return warnings
+}
@@ -0,0 +1,42 @@
+package context
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestCheckAdditionalTagLimits(t *testing.T) {
+	manyTags := make(map[string]string)
+	for i := range AdditionalTagsSoftLimit + 1 {
+		manyTags[fmt.Sprintf("tag%d", i)] = "value"
+	}
+
+	tests := []struct {
+		name string
+		tags map[string]string
+		want []string
+	}{
+		{name: "nil", tags: nil},
+		{name: "within limits", tags: map[string]string{"purpose": "test"}},
+		{
+			name: "too many entries",
+			tags: manyTags,
+			want: []string{"additional_tags has 51 entries, more than the 50 tags most cloud providers accept on a resource"},
+		},
+		{
+			name: "values too long",
+			tags: map[string]string{"a": strings.Repeat("x", 10*1024), "b": strings.Repeat("x", 10*1024)},
+			want: []string{"additional_tags values total 20 KB, more than the soft limit of 16 KB"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CheckAdditionalTagLimits("additional_tags", tt.tags)
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("CheckAdditionalTagLimits() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package context
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// AWSPatchGroupTagKey is the tag key AWS Systems Manager Patch Manager reads
+// the patch group of an instance from. It cannot carry the tag prefix.
+const AWSPatchGroupTagKey = "PatchGroup"
+
+// maintenanceRangeRegex matches a weekly day/time range, such as
+// sun:03:00-sun:05:00, in the format of RDS and ElastiCache maintenance windows
+var maintenanceRangeRegex = regexp.MustCompile(`(?i)^(mon|tue|wed|thu|fri|sat|sun):([01]\d|2[0-3]):[0-5]\d-(mon|tue|wed|thu|fri|sat|sun):([01]\d|2[0-3]):[0-5]\d$`)
+
+// Maintenance window characters each cloud provider rejects in tag values
+// are replaced with characters the windows never contain, so the tag passes
+// sanitization unchanged and EncodeMaintenanceWindow is reversible. Spaces
+// only appear in cron expressions and colons only in ranges, so both are
+// encoded as _ and told apart when decoding.
+var (
+	// AWS rejects * and ,
+	awsMaintenanceWindowEncoding = []string{"*", "_", ",", "+"}
+	// Azure rejects spaces, colons and /
+	azureMaintenanceWindowEncoding = []string{" ", "_", ":", "_", "/", "+"}
+	// GCP only allows lowercase letters, digits, _ and -; x, k and z are
+	// not in any day or month name
+	gcpMaintenanceWindowEncoding = []string{" ", "_", ":", "_", "*", "x", ",", "k", "/", "z"}
+)
+
+// maintenanceWindowEncoding returns the replacements of
+// EncodeMaintenanceWindow for cp, as old, new pairs
+func maintenanceWindowEncoding(cp CloudProvider) []string {
+	switch cp.(type) {
+	case *AWSProvider:
+		return awsMaintenanceWindowEncoding
+	case *AzureProvider:
+		return azureMaintenanceWindowEncoding
+	case *GCPProvider:
+		return gcpMaintenanceWindowEncoding
+	}
+	return nil
+}
+
+// EncodeMaintenanceWindow returns window, a valid maintenance window, as the
+// value of the maintenancewindow tag for cp. The characters the cloud
+// provider rejects in tag values are replaced, so sanitization leaves the
+// value unchanged: on aws 0 3 * * SUN becomes 0 3 _ _ SUN and ranges are
+// kept, on az 0 3 * * SUN becomes 0_3_*_*_SUN and sun:03:00-sun:05:00
+// becomes sun_03_00-sun_05_00, and on gcp they become 0_3_x_x_sun and
+// sun_03_00-sun_05_00. DecodeMaintenanceWindow reverses it.
+func EncodeMaintenanceWindow(window string, cp CloudProvider) string {
+	encoding := maintenanceWindowEncoding(cp)
+	if encoding == nil {
+		return window
+	}
+	if _, ok := cp.(*GCPProvider); ok {
+		window = strings.ToLower(window)
+	}
+	return strings.NewReplacer(encoding...).Replace(strings.Join(strings.Fields(window), " "))
+}
+
+// DecodeMaintenanceWindow returns the maintenance window of a
+// maintenancewindow tag value encoded for cp by EncodeMaintenanceWindow.
+// Day and month names are lowercase for gcp.
+func DecodeMaintenanceWindow(value string, cp CloudProvider) string {
+	encoding := maintenanceWindowEncoding(cp)
+	if encoding == nil {
+		return value
+	}
+	var pairs []string
+	for i := 0; i < len(encoding); i += 2 {
+		if encoding[i] != ":" {
+			pairs = append(pairs, encoding[i+1], encoding[i])
+		}
+	}
+	window := strings.NewReplacer(pairs...).Replace(value)
+	if ranged := strings.ReplaceAll(window, " ", ":"); maintenanceRangeRegex.MatchString(ranged) {
+		return ranged
+	}
+	return window
+}
+
+// cronField describes the values allowed in one field of a cron expression
+type cronField struct {
+	name     string
+	min, max int
+	// names are the accepted aliases of min, min+1, and so on
+	names []string
+}
+
+// cronFields are the fields of a standard five field cron expression
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}},
+	// 7 is also Sunday
+	{name: "day of week", min: 0, max: 7, names: []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}},
+}
+
+// validateCron checks that expression is a standard five field cron
+// expression: minute, hour, day of month, month and day of week, each a
+// comma separated list of *, values and ranges with an optional /step
+func validateCron(expression string) error {
+	fields := strings.Fields(expression)
+	if len(fields) != len(cronFields) {
+		return fmt.Errorf("cron expression must have %d fields, got %d", len(cronFields), len(fields))
+	}
+	for i, field := range fields {
+		for _, item := range strings.Split(field, ",") {
+			if err := cronFields[i].validate(item); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// validate checks one item of a cron field list, such as *, 5, 1-5 or */15
+func (f cronField) validate(item string) error {
+	rangePart, step, hasStep := strings.Cut(item, "/")
+	if hasStep {
+		if n, err := strconv.Atoi(step); err != nil || n < 1 {
+			return fmt.Errorf("invalid %s step '%s'", f.name, step)
+		}
+	}
+	if rangePart == "*" {
+		return nil
+	}
+	first, last, isRange := strings.Cut(rangePart, "-")
+	low, err := f.value(first)
+	if err != nil {
+		return err
+	}
+	if isRange {
+		high, err := f.value(last)
+		if err != nil {
+			return err
+		}
+		if high < low {
+			return fmt.Errorf("invalid %s range '%s'", f.name, rangePart)
+		}
+	}
+	return nil
+}
+
+// value parses a number or name of the field and checks its bounds
+func (f cronField) value(s string) (int, error) {
+	for i, name := range f.names {
+		if strings.EqualFold(s, name) {
+			return f.min + i, nil
+		}
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < f.min || n > f.max {
+		return 0, fmt.Errorf("invalid %s '%s', must be %d-%d", f.name, s, f.min, f.max)
+	}
+	return n, nil
+}
+
+// addPatchGroupTag copies the patchgroup tag to AWSPatchGroupTagKey for AWS,
+// where Patch Manager only reads the unprefixed key. A PatchGroup tag
+// already in tags keeps its value.
+func (tp *TagProcessor) addPatchGroupTag(tags map[string]string) {
+	if _, ok := tp.CloudProvider.(*AWSProvider); !ok {
+		return
+	}
+	value, ok := tags[tp.TagPrefix+"patchgroup"]
+	if !ok {
+		return
+	}
+	if _, exists := tags[AWSPatchGroupTagKey]; !exists {
+		tags[AWSPatchGroupTagKey] = value
+	}
+}
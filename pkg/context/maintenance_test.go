@@ -0,0 +1,112 @@
+package context
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateCron(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		wantErr    bool
+	}{
+		{name: "every sunday at 3", expression: "0 3 * * SUN"},
+		{name: "lists ranges and steps", expression: "*/15 1-4,22 1,15 jan-mar 1-5"},
+		{name: "sunday as 7", expression: "30 2 * * 7"},
+		{name: "stepped range", expression: "0 0-12/2 * * *"},
+		{name: "too few fields", expression: "0 3 * *", wantErr: true},
+		{name: "minute out of range", expression: "60 3 * * *", wantErr: true},
+		{name: "unknown day name", expression: "0 3 * * sunday", wantErr: true},
+		{name: "reversed range", expression: "0 5-1 * * *", wantErr: true},
+		{name: "zero step", expression: "*/0 * * * *", wantErr: true},
+		{name: "day of month zero", expression: "0 3 0 * *", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCron(tt.expression)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateCron(%q) error = %v, wantErr %v", tt.expression, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAddPatchGroupTag(t *testing.T) {
+	config := &DataSourceConfig{PatchGroup: "web-servers", NotApplicableEnabled: true}
+	processor := &TagProcessor{CloudProvider: GetCloudProvider("aws"), Config: config, TagPrefix: "bc-"}
+
+	tags, err := processor.Process()
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+	if tags["bc-patchgroup"] != "web-servers" || tags[AWSPatchGroupTagKey] != "web-servers" {
+		t.Errorf("patch group tags = %q, %q, want web-servers for both", tags["bc-patchgroup"], tags[AWSPatchGroupTagKey])
+	}
+
+	// An additional tag with the key keeps its value
+	processor.TagPrefix = ""
+	config.AdditionalTags = map[string]string{AWSPatchGroupTagKey: "legacy"}
+	if tags, err = processor.Process(); err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+	if tags[AWSPatchGroupTagKey] != "legacy" {
+		t.Errorf("%s = %q, want the additional tag value legacy", AWSPatchGroupTagKey, tags[AWSPatchGroupTagKey])
+	}
+
+	// Other cloud providers only get the prefixed tag
+	processor = &TagProcessor{CloudProvider: GetCloudProvider("az"), Config: &DataSourceConfig{PatchGroup: "web-servers"}, TagPrefix: "bc-"}
+	if tags, err = processor.Process(); err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+	if _, ok := tags[AWSPatchGroupTagKey]; ok || tags["bc-patchgroup"] != "web-servers" {
+		t.Errorf("az tags = %v, want only bc-patchgroup", tags)
+	}
+}
+
+func TestMaintenanceWindowTag(t *testing.T) {
+	tests := []struct {
+		cloud  string
+		window string
+		want   string
+	}{
+		{"aws", "0 3 * * SUN", "0 3 _ _ SUN"},
+		{"aws", "sun:03:00-sun:05:00", "sun:03:00-sun:05:00"},
+		{"aws", "*/15 1-4,22 * * 1-5", "_/15 1-4+22 _ _ 1-5"},
+		{"az", "0 3 * * SUN", "0_3_*_*_SUN"},
+		{"az", "sun:03:00-sun:05:00", "sun_03_00-sun_05_00"},
+		{"az", "*/15 1-4,22 * * 1-5", "*+15_1-4,22_*_*_1-5"},
+		{"gcp", "0 3 * * SUN", "0_3_x_x_sun"},
+		{"gcp", "sun:03:00-sun:05:00", "sun_03_00-sun_05_00"},
+		{"gcp", "*/15 1-4,22 * * 1-5", "xz15_1-4k22_x_x_1-5"},
+		{"dc", "0 3 * * SUN", "0 3 * * SUN"},
+		{"dc", "sun:03:00-sun:05:00", "sun:03:00-sun:05:00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cloud+" "+tt.window, func(t *testing.T) {
+			cp := GetCloudProvider(tt.cloud)
+			processor := &TagProcessor{CloudProvider: cp, Config: &DataSourceConfig{MaintenanceWindow: tt.window, SanitizationMode: "error"}, TagPrefix: "bc-"}
+			tags, err := processor.Process()
+			if err != nil {
+				t.Fatalf("Process() error = %v", err)
+			}
+			got := tags["bc-maintenancewindow"]
+			if got != tt.want {
+				t.Errorf("maintenancewindow = %q, want %q", got, tt.want)
+			}
+			if sanitized := cp.SanitizeTagValue(got); sanitized != got {
+				t.Errorf("SanitizeTagValue(%q) = %q, want it unchanged", got, sanitized)
+			}
+
+			decoded := ConfigFromTags(tags, "bc-", cp).MaintenanceWindow
+			if !strings.EqualFold(decoded, tt.window) {
+				t.Errorf("decoded maintenance window = %q, want %q", decoded, tt.window)
+			}
+			if err := ValidateMaintenanceWindow(decoded); err != nil {
+				t.Errorf("ValidateMaintenanceWindow(%q) error = %v", decoded, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package context
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Name availability check actions, selecting the diagnostic reported for a
+// name taken by someone else
+const (
+	NameAvailabilityCheckWarn  = "warn"
+	NameAvailabilityCheckError = "error"
+)
+
+// ValidNameAvailabilityChecks contains the list of valid name availability
+// check actions
+var ValidNameAvailabilityChecks = map[string]bool{
+	NameAvailabilityCheckWarn:  true,
+	NameAvailabilityCheckError: true,
+}
+
+// ErrNameCheckNotConfigured is returned by the name checker constructors of
+// pkg/integrations when the credentials of the cloud provider are not set, so
+// checks are skipped where no credentials are available, such as in pull
+// request pipelines
+var ErrNameCheckNotConfigured = errors.New("cloud credentials for name availability checks are not set")
+
+// NameAvailability is the result of a name availability check
+type NameAvailability string
+
+const (
+	// NameAvailable is a name nobody uses
+	NameAvailable NameAvailability = "available"
+	// NameOwned is a name used by a resource the credentials can access,
+	// such as one created by an earlier apply
+	NameOwned NameAvailability = "owned"
+	// NameTaken is a name used by a resource of another account
+	NameTaken NameAvailability = "taken"
+)
+
+// NameChecker checks whether a globally unique resource name is available
+type NameChecker interface {
+	// Resource describes the kind of resource checked, such as S3 bucket
+	Resource() string
+	CheckName(ctx context.Context, name string) (NameAvailability, error)
+}
+
+// NameValidator is implemented by name checkers that know the naming rules
+// of their resource
+type NameValidator interface {
+	// ValidName reports whether name can be the name of the resource
+	ValidName(name string) bool
+}
+
+// ValidCheckName reports whether name can be the name of the resource of
+// checker, and true for checkers that do not implement NameValidator.
+// Name prefixes that cannot be such names, such as hyphenated ones for
+// storage accounts, are not worth checking.
+func ValidCheckName(checker NameChecker, name string) bool {
+	if v, ok := checker.(NameValidator); ok {
+		return v.ValidName(name)
+	}
+	return true
+}
+
+// ValidateNameAvailabilityCheck validates a name availability check action
+func ValidateNameAvailabilityCheck(action string) error {
+	if !ValidNameAvailabilityChecks[action] {
+		return fmt.Errorf("invalid name availability check '%s', must be one of: %s, %s",
+			action, NameAvailabilityCheckWarn, NameAvailabilityCheckError)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package context
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestValidateNameAvailabilityCheck(t *testing.T) {
+	for _, action := range []string{"warn", "error"} {
+		if err := ValidateNameAvailabilityCheck(action); err != nil {
+			t.Errorf("ValidateNameAvailabilityCheck(%q) error = %v", action, err)
+		}
+	}
+	if err := ValidateNameAvailabilityCheck("fail"); err == nil {
+		t.Error("ValidateNameAvailabilityCheck(fail) expected an error")
+	}
+}
+
+func TestValidCheckName(t *testing.T) {
+	lowercase := validatingNameChecker{nameCheckerFunc(nil), func(name string) bool { return strings.ToLower(name) == name }}
+	tests := []struct {
+		checker NameChecker
+		name    string
+		want    bool
+	}{
+		{lowercase, "myorg-logs", true},
+		{lowercase, "MyOrg-Logs", false},
+		{nameCheckerFunc(nil), "Any Name", true},
+	}
+	for _, tt := range tests {
+		if got := ValidCheckName(tt.checker, tt.name); got != tt.want {
+			t.Errorf("ValidCheckName(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+// validatingNameChecker is a NameChecker with NameValidator
+type validatingNameChecker struct {
+	nameCheckerFunc
+	valid func(name string) bool
+}
+
+func (c validatingNameChecker) ValidName(name string) bool { return c.valid(name) }
+
+// nameCheckerFunc is a NameChecker without NameValidator
+type nameCheckerFunc func(name string) NameAvailability
+
+func (f nameCheckerFunc) Resource() string { return "test resource" }
+
+func (f nameCheckerFunc) CheckName(ctx context.Context, name string) (NameAvailability, error) {
+	return f(name), nil
+}
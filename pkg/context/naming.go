@@ -1,57 +1,207 @@
 package context
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 )
 
 const (
 	MaxNamePrefixLength = 24
 	MinNamePrefixLength = 2
+
+	// DefaultShortNameLength is the default length of a shortened name prefix
+	DefaultShortNameLength = 12
+	// ShortNameHashLength is the number of hash characters in a shortened name prefix
+	ShortNameHashLength = 6
+	// MinShortNameLength leaves room for a one character fragment, a delimiter and the hash
+	MinShortNameLength = ShortNameHashLength + 2
+	// SurrogateIDHashLength is the number of hash characters in a surrogate ID
+	SurrogateIDHashLength = 16
 )
 
 var namePrefixRegex = regexp.MustCompile(`^[a-z][a-z0-9-]{0,22}[a-z0-9]$`)
 
+// NameComponent identifies one component of a generated name prefix
+type NameComponent string
+
+const (
+	ComponentNamespace   NameComponent = "namespace"
+	ComponentTenant      NameComponent = "tenant"
+	ComponentName        NameComponent = "name"
+	ComponentEnvironment NameComponent = "environment"
+	ComponentAttributes  NameComponent = "attributes"
+	ComponentCustomer    NameComponent = "customer"
+)
+
+// DefaultNameOrder is the component order of the standard name prefix format.
+// Tenant and attributes only appear in the prefix when set. Customer is left
+// out and only appears when added to a custom order.
+var DefaultNameOrder = []NameComponent{ComponentNamespace, ComponentTenant, ComponentName, ComponentEnvironment, ComponentAttributes}
+
+// ValidNameComponents contains the name components that may appear in a name order
+var ValidNameComponents = map[NameComponent]bool{
+	ComponentNamespace:   true,
+	ComponentTenant:      true,
+	ComponentName:        true,
+	ComponentEnvironment: true,
+	ComponentAttributes:  true,
+	ComponentCustomer:    true,
+}
+
+// DefaultReservedWords are terms that cloud providers reserve and reject
+// anywhere in resource names, in some cases (such as Azure storage
+// accounts) only at apply time
+var DefaultReservedWords = []string{"amazon", "aws", "azure", "google", "login", "microsoft", "windows", "xbox"}
+
+// TruncationStrategy selects how a name prefix longer than the maximum length is shortened
+type TruncationStrategy int
+
+const (
+	// TruncateName shortens the name component while preserving the others,
+	// falling back to TruncateEnd when that is not possible
+	TruncateName TruncationStrategy = iota
+	// TruncateEnd cuts the joined prefix at the maximum length
+	TruncateEnd
+	// TruncateNone rejects prefixes longer than the maximum length
+	TruncateNone
+)
+
+// NameOptions customizes name prefix generation
+type NameOptions struct {
+	// Delimiter joins the components; may be empty
+	Delimiter string
+	// MaxLength is the maximum prefix length; zero uses MaxNamePrefixLength
+	MaxLength int
+	// Order lists the components in output order; empty uses DefaultNameOrder
+	Order []NameComponent
+	// Truncation selects how over-long prefixes are shortened
+	Truncation TruncationStrategy
+}
+
+// DefaultNameOptions returns the options for the standard Brockhoff format
+func DefaultNameOptions() NameOptions {
+	return NameOptions{
+		Delimiter:  "-",
+		MaxLength:  MaxNamePrefixLength,
+		Order:      DefaultNameOrder,
+		Truncation: TruncateName,
+	}
+}
+
 // NameGenerator handles name prefix generation
 type NameGenerator struct {
 	Namespace   string
+	Tenant      string
 	Name        string
 	Environment string
+	Attributes  []string
+	Customer    string
+
+	// Options customizes generation; nil uses DefaultNameOptions
+	Options *NameOptions
+}
+
+// options returns the effective options with defaults applied
+func (ng *NameGenerator) options() NameOptions {
+	if ng.Options == nil {
+		return DefaultNameOptions()
+	}
+	opts := *ng.Options
+	if opts.MaxLength == 0 {
+		opts.MaxLength = MaxNamePrefixLength
+	}
+	if len(opts.Order) == 0 {
+		opts.Order = DefaultNameOrder
+	}
+	return opts
+}
+
+// pattern returns the regular expression a generated prefix must match
+func (o NameOptions) pattern() (*regexp.Regexp, error) {
+	if o.Delimiter == "-" && o.MaxLength == MaxNamePrefixLength {
+		return namePrefixRegex, nil
+	}
+	if o.MaxLength < MinNamePrefixLength {
+		return nil, fmt.Errorf("maximum name prefix length must be at least %d, got %d", MinNamePrefixLength, o.MaxLength)
+	}
+
+	middle := "a-z0-9-"
+	if o.Delimiter != "-" {
+		middle += regexp.QuoteMeta(o.Delimiter)
+	}
+	return regexp.Compile(fmt.Sprintf(`^[a-z][%s]{0,%d}[a-z0-9]$`, middle, o.MaxLength-2))
+}
+
+// component returns the value of a name component, joining attributes with the delimiter
+func (ng *NameGenerator) component(c NameComponent, delimiter string) string {
+	switch c {
+	case ComponentNamespace:
+		return separate(ng.Namespace, delimiter)
+	case ComponentTenant:
+		return separate(ng.Tenant, delimiter)
+	case ComponentName:
+		return separate(ng.Name, delimiter)
+	case ComponentEnvironment:
+		return separate(ng.Environment, delimiter)
+	case ComponentAttributes:
+		return separate(strings.Join(ng.Attributes, delimiter), delimiter)
+	case ComponentCustomer:
+		return separate(ng.Customer, delimiter)
+	default:
+		return ""
+	}
+}
+
+// separate replaces the hyphens inside a component with the delimiter, so
+// that a prefix joined with "_" or "" has no hyphens, such as my_org_app_prod
+// or myorgappprod for the namespace my-org
+func separate(value, delimiter string) string {
+	if delimiter == "-" {
+		return value
+	}
+	return strings.ReplaceAll(value, "-", delimiter)
 }
 
 // Generate creates a name prefix following Brockhoff standards
 func (ng *NameGenerator) Generate() (string, error) {
 	// If only name is provided, use it directly
-	if ng.Namespace == "" && ng.Environment == "" {
+	if ng.Namespace == "" && ng.Tenant == "" && ng.Customer == "" && ng.Environment == "" && len(ng.Attributes) == 0 {
 		if ng.Name == "" {
 			return "", fmt.Errorf("name is required when namespace and environment are not provided")
 		}
-		return ng.validateAndTruncate(ng.Name)
+		return ng.validateAndTruncate(ng.component(ComponentName, ng.options().Delimiter))
 	}
 
 	// Build the full name prefix
+	opts := ng.options()
 	parts := []string{}
-	if ng.Namespace != "" {
-		parts = append(parts, ng.Namespace)
-	}
-	if ng.Name != "" {
-		parts = append(parts, ng.Name)
-	}
-	if ng.Environment != "" {
-		parts = append(parts, ng.Environment)
+	for _, c := range opts.Order {
+		if value := ng.component(c, opts.Delimiter); value != "" {
+			parts = append(parts, value)
+		}
 	}
 
 	if len(parts) == 0 {
-		return "", fmt.Errorf("at least one of namespace, name, or environment must be provided")
+		return "", fmt.Errorf("at least one of namespace, tenant, customer, name, environment, or attributes must be provided")
 	}
 
-	namePrefix := strings.Join(parts, "-")
+	namePrefix := strings.Join(parts, opts.Delimiter)
 	return ng.validateAndTruncate(namePrefix)
 }
 
 // validateAndTruncate ensures the name prefix meets requirements
 func (ng *NameGenerator) validateAndTruncate(namePrefix string) (string, error) {
+	opts := ng.options()
+	pattern, err := opts.pattern()
+	if err != nil {
+		return "", err
+	}
+
 	// Convert to lowercase
 	namePrefix = strings.ToLower(namePrefix)
 
@@ -61,13 +211,16 @@ func (ng *NameGenerator) validateAndTruncate(namePrefix string) (string, error)
 	}
 
 	// Truncate if too long
-	if len(namePrefix) > MaxNamePrefixLength {
+	if len(namePrefix) > opts.MaxLength {
+		if opts.Truncation == TruncateNone {
+			return "", fmt.Errorf("name prefix must be at most %d characters, got %d: %s", opts.MaxLength, len(namePrefix), namePrefix)
+		}
 		namePrefix = ng.intelligentTruncate(namePrefix)
 	}
 
 	// Validate against regex
-	if !namePrefixRegex.MatchString(namePrefix) {
-		return "", fmt.Errorf("name prefix does not match required pattern /^[a-z][a-z0-9-]{0,22}[a-z0-9]$/: %s", namePrefix)
+	if !pattern.MatchString(namePrefix) {
+		return "", fmt.Errorf("name prefix does not match required pattern /%s/: %s", pattern, namePrefix)
 	}
 
 	return namePrefix, nil
@@ -75,34 +228,142 @@ func (ng *NameGenerator) validateAndTruncate(namePrefix string) (string, error)
 
 // intelligentTruncate applies smart truncation to fit within max length
 func (ng *NameGenerator) intelligentTruncate(namePrefix string) string {
-	if len(namePrefix) <= MaxNamePrefixLength {
+	opts := ng.options()
+	if len(namePrefix) <= opts.MaxLength {
 		return namePrefix
 	}
 
 	// If we have all three components, try to preserve namespace and environment
-	if ng.Namespace != "" && ng.Name != "" && ng.Environment != "" {
-		// Calculate available space for name
-		baseLen := len(ng.Namespace) + len(ng.Environment) + 2 // +2 for hyphens
-		availableForName := MaxNamePrefixLength - baseLen
+	if opts.Truncation == TruncateName && ng.Namespace != "" && ng.Name != "" && ng.Environment != "" {
+		// Calculate available space for name; components are lowercased to
+		// match the already-lowercased prefix
+		baseLen := 0
+		for _, c := range opts.Order {
+			if value := ng.component(c, opts.Delimiter); c != ComponentName && value != "" {
+				baseLen += len(strings.ToLower(value)) + len(opts.Delimiter)
+			}
+		}
+		availableForName := opts.MaxLength - baseLen
 
 		if availableForName >= 2 { // Minimum 2 chars for name
-			truncatedName := ng.Name
+			truncatedName := strings.ToLower(ng.component(ComponentName, opts.Delimiter))
 			if len(truncatedName) > availableForName {
 				truncatedName = truncatedName[:availableForName]
 			}
 			// Remove trailing hyphen if present
 			truncatedName = strings.TrimSuffix(truncatedName, "-")
-			return fmt.Sprintf("%s-%s-%s", ng.Namespace, truncatedName, ng.Environment)
+
+			parts := []string{}
+			for _, c := range opts.Order {
+				if c == ComponentName {
+					parts = append(parts, truncatedName)
+				} else if value := ng.component(c, opts.Delimiter); value != "" {
+					parts = append(parts, strings.ToLower(value))
+				}
+			}
+			return strings.Join(parts, opts.Delimiter)
 		}
 	}
 
 	// Simple truncation as fallback
-	result := namePrefix[:MaxNamePrefixLength]
+	result := namePrefix[:opts.MaxLength]
 
-	// Ensure we don't end with a hyphen
-	for strings.HasSuffix(result, "-") && len(result) > MinNamePrefixLength {
+	// Ensure we don't end with a hyphen or delimiter
+	for len(result) > MinNamePrefixLength && (strings.HasSuffix(result, "-") || (opts.Delimiter != "" && strings.HasSuffix(result, opts.Delimiter))) {
 		result = result[:len(result)-1]
 	}
 
 	return result
 }
+
+// ShortenNamePrefix returns a deterministic name of at most maxLength
+// characters for length-constrained resources. Prefixes that already fit are
+// returned unchanged; longer ones become <fragment><delimiter><hash>, where the
+// fragment is the start of the prefix and the hash is derived from the full
+// prefix so that prefixes sharing a fragment do not collide.
+func ShortenNamePrefix(namePrefix, delimiter string, maxLength int) (string, error) {
+	if maxLength < MinShortNameLength {
+		return "", fmt.Errorf("short name length must be at least %d, got %d", MinShortNameLength, maxLength)
+	}
+	if len(namePrefix) <= maxLength {
+		return namePrefix, nil
+	}
+
+	sum := sha256.Sum256([]byte(namePrefix))
+	hash := hex.EncodeToString(sum[:])[:ShortNameHashLength]
+
+	fragment := namePrefix[:maxLength-ShortNameHashLength-len(delimiter)]
+	// Don't leave a delimiter or hyphen at the end of the fragment
+	for len(fragment) > 1 && (strings.HasSuffix(fragment, "-") || (delimiter != "" && strings.HasSuffix(fragment, delimiter))) {
+		fragment = fragment[:len(fragment)-1]
+	}
+
+	return fragment + delimiter + hash, nil
+}
+
+// SurrogateID returns the ID of a context without a name, used for tags-only
+// contexts whose name prefix, such as namespace and environment alone, is
+// shared by many of them. The ID is <namePrefix>-<hash>, where the hash is
+// derived from the resolved config, so it is the same on every run and on
+// every parallel read of the same config and differs between configs.
+func SurrogateID(namePrefix string, config *DataSourceConfig) (string, error) {
+	// Struct fields marshal in order and map keys sorted
+	data, err := json.Marshal(config)
+	if err != nil {
+		return "", fmt.Errorf("hashing the context config: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return namePrefix + "-" + hex.EncodeToString(sum[:])[:SurrogateIDHashLength], nil
+}
+
+// reservedWords returns DefaultReservedWords plus the additional words,
+// lowercased, deduplicated and longest first so that overlapping words are
+// matched whole
+func reservedWords(additional []string) []string {
+	seen := make(map[string]bool)
+	var words []string
+	for _, w := range append(append([]string{}, DefaultReservedWords...), additional...) {
+		w = strings.ToLower(strings.TrimSpace(w))
+		if w == "" || seen[w] {
+			continue
+		}
+		seen[w] = true
+		words = append(words, w)
+	}
+	sort.SliceStable(words, func(i, j int) bool { return len(words[i]) > len(words[j]) })
+	return words
+}
+
+// FindReservedWords returns the reserved words contained in name, checking
+// DefaultReservedWords and the additional words case-insensitively
+func FindReservedWords(name string, additional []string) []string {
+	lower := strings.ToLower(name)
+	var found []string
+	for _, w := range reservedWords(additional) {
+		if strings.Contains(lower, w) {
+			found = append(found, w)
+		}
+	}
+	sort.Strings(found)
+	return found
+}
+
+// RemoveReservedWords removes the reserved words from name and collapses
+// the delimiters left behind, so "myorg-aws-app" becomes "myorg-app"
+func RemoveReservedWords(name, delimiter string, additional []string) string {
+	for _, w := range reservedWords(additional) {
+		name = regexp.MustCompile("(?i)"+regexp.QuoteMeta(w)).ReplaceAllString(name, "")
+	}
+
+	for _, d := range []string{delimiter, "-"} {
+		if d == "" {
+			continue
+		}
+		for strings.Contains(name, d+d) {
+			name = strings.ReplaceAll(name, d+d, d)
+		}
+		name = strings.Trim(name, d)
+	}
+
+	return name
+}
@@ -1,6 +1,9 @@
 package context
 
 import (
+	"math/rand"
+	"regexp"
+	"strings"
 	"testing"
 )
 
@@ -69,6 +72,14 @@ func TestNameGenerator_Generate(t *testing.T) {
 			want:         "myorg-myapp-prod",
 			wantErr:      false,
 		},
+		{
+			name:         "uppercase with truncation",
+			namespace:    "MyOrg",
+			resourceName: "VeryLongAppName",
+			environment:  "PROD",
+			want:         "myorg-verylongappna-prod",
+			wantErr:      false,
+		},
 		{
 			name:         "max length exact",
 			namespace:    "ab",
@@ -144,3 +155,557 @@ func TestNameGenerator_IntelligentTruncate(t *testing.T) {
 		})
 	}
 }
+
+func FuzzNameGenerator_Generate(f *testing.F) {
+	f.Add("myorg", "app", "prod")
+	f.Add("", "myapp", "")
+	f.Add("verylongorg", "verylongappname", "production")
+	f.Add("MyOrg", "MyApp", "PROD")
+	f.Add("ab", "app-name-", "cd")
+	f.Add("", "", "")
+	f.Add("値", "ünïcödé", "🚀")
+
+	f.Fuzz(func(t *testing.T, namespace, name, environment string) {
+		ng := &NameGenerator{
+			Namespace:   namespace,
+			Name:        name,
+			Environment: environment,
+		}
+		got, err := ng.Generate()
+		if err != nil {
+			return
+		}
+		if len(got) > MaxNamePrefixLength {
+			t.Errorf("Generate() = %q, length %d exceeds max %d", got, len(got), MaxNamePrefixLength)
+		}
+		if !namePrefixRegex.MatchString(got) {
+			t.Errorf("Generate() = %q does not match %s", got, namePrefixRegex)
+		}
+	})
+}
+
+func FuzzNameGenerator_IntelligentTruncate(f *testing.F) {
+	f.Add("myorg", "verylongappname", "prod")
+	f.Add("", "verylongapplicationnamethatshouldbetruncated", "")
+	f.Add("org", "app-name-test", "dev")
+	f.Add("averyveryverylongnamespace", "x", "averyveryverylongenvironment")
+
+	f.Fuzz(func(t *testing.T, namespace, name, environment string) {
+		ng := &NameGenerator{
+			Namespace:   namespace,
+			Name:        name,
+			Environment: environment,
+		}
+		input := strings.Join([]string{namespace, name, environment}, "-")
+		got := ng.intelligentTruncate(input)
+		if len(input) <= MaxNamePrefixLength {
+			if got != input {
+				t.Errorf("intelligentTruncate(%q) = %q, want input unchanged", input, got)
+			}
+			return
+		}
+		if len(got) > MaxNamePrefixLength {
+			t.Errorf("intelligentTruncate(%q) = %q, length %d exceeds max %d", input, got, len(got), MaxNamePrefixLength)
+		}
+	})
+}
+
+// randomNameComponent builds a component from the characters accepted in names,
+// including uppercase letters that Generate is expected to fold.
+func randomNameComponent(r *rand.Rand, maxLen int) string {
+	const alphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-"
+	n := r.Intn(maxLen + 1)
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = alphabet[r.Intn(len(alphabet))]
+	}
+	return string(b)
+}
+
+func TestNameGenerator_Properties(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 10000; i++ {
+		ng := &NameGenerator{
+			Namespace:   randomNameComponent(r, 10),
+			Name:        randomNameComponent(r, 30),
+			Environment: randomNameComponent(r, 10),
+		}
+
+		got, err := ng.Generate()
+
+		// Generation is deterministic
+		again, againErr := ng.Generate()
+		if got != again || (err != nil) != (againErr != nil) {
+			t.Fatalf("Generate() not stable for %+v: %q (%v) then %q (%v)", ng, got, err, again, againErr)
+		}
+
+		// Case of the inputs does not affect the outcome
+		lower := &NameGenerator{
+			Namespace:   strings.ToLower(ng.Namespace),
+			Name:        strings.ToLower(ng.Name),
+			Environment: strings.ToLower(ng.Environment),
+		}
+		lowerGot, lowerErr := lower.Generate()
+		if got != lowerGot || (err != nil) != (lowerErr != nil) {
+			t.Fatalf("Generate() differs by case for %+v: %q (%v) vs %q (%v)", ng, got, err, lowerGot, lowerErr)
+		}
+
+		if err != nil {
+			continue
+		}
+
+		// Feeding a generated prefix back in is a no-op
+		roundTrip, err := (&NameGenerator{Name: got}).Generate()
+		if err != nil {
+			t.Fatalf("Generate() of generated prefix %q failed: %v", got, err)
+		}
+		if roundTrip != got {
+			t.Fatalf("Generate() of generated prefix %q = %q, want unchanged", got, roundTrip)
+		}
+	}
+}
+
+func TestNameGenerator_Options(t *testing.T) {
+	tests := []struct {
+		name         string
+		namespace    string
+		resourceName string
+		environment  string
+		options      NameOptions
+		want         string
+		wantErr      bool
+	}{
+		{
+			name:         "defaults",
+			namespace:    "myorg",
+			resourceName: "app",
+			environment:  "prod",
+			options:      DefaultNameOptions(),
+			want:         "myorg-app-prod",
+		},
+		{
+			name:         "underscore delimiter",
+			namespace:    "myorg",
+			resourceName: "app",
+			environment:  "prod",
+			options:      NameOptions{Delimiter: "_"},
+			want:         "myorg_app_prod",
+		},
+		{
+			name:         "empty delimiter",
+			namespace:    "myorg",
+			resourceName: "app",
+			environment:  "prod",
+			options:      NameOptions{Delimiter: ""},
+			want:         "myorgappprod",
+		},
+		{
+			name:         "underscore delimiter replaces hyphens in components",
+			namespace:    "my-org",
+			resourceName: "web-app",
+			environment:  "prod",
+			options:      NameOptions{Delimiter: "_"},
+			want:         "my_org_web_app_prod",
+		},
+		{
+			name:         "empty delimiter strips hyphens in components",
+			namespace:    "my-org",
+			resourceName: "web-app",
+			environment:  "prod",
+			options:      NameOptions{Delimiter: ""},
+			want:         "myorgwebappprod",
+		},
+		{
+			name:         "empty delimiter strips hyphens in name only",
+			resourceName: "web-app",
+			options:      NameOptions{Delimiter: ""},
+			want:         "webapp",
+		},
+		{
+			name:         "custom max length truncates name without hyphens",
+			namespace:    "my-org",
+			resourceName: "web-application",
+			environment:  "dev",
+			options:      NameOptions{Delimiter: "", MaxLength: 16},
+			want:         "myorgwebapplidev",
+		},
+		{
+			name:         "custom order",
+			namespace:    "myorg",
+			resourceName: "app",
+			environment:  "prod",
+			options: NameOptions{
+				Delimiter: "-",
+				Order:     []NameComponent{ComponentNamespace, ComponentEnvironment, ComponentName},
+			},
+			want: "myorg-prod-app",
+		},
+		{
+			name:         "custom max length truncates name",
+			namespace:    "myorg",
+			resourceName: "application",
+			environment:  "prod",
+			options:      NameOptions{Delimiter: "-", MaxLength: 16},
+			want:         "myorg-appli-prod",
+		},
+		{
+			name:         "custom max length with underscore",
+			namespace:    "org",
+			resourceName: "application",
+			environment:  "dev",
+			options:      NameOptions{Delimiter: "_", MaxLength: 16},
+			want:         "org_applicat_dev",
+		},
+		{
+			name:         "truncate end",
+			namespace:    "myorg",
+			resourceName: "verylongappname",
+			environment:  "prod",
+			options:      NameOptions{Delimiter: "-", Truncation: TruncateEnd},
+			want:         "myorg-verylongappname-pr",
+		},
+		{
+			name:         "truncate none",
+			namespace:    "myorg",
+			resourceName: "verylongappname",
+			environment:  "prod",
+			options:      NameOptions{Delimiter: "-", Truncation: TruncateNone},
+			wantErr:      true,
+		},
+		{
+			name:         "max length below minimum",
+			resourceName: "app",
+			options:      NameOptions{Delimiter: "-", MaxLength: 1},
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := tt.options
+			ng := &NameGenerator{
+				Namespace:   tt.namespace,
+				Name:        tt.resourceName,
+				Environment: tt.environment,
+				Options:     &opts,
+			}
+			got, err := ng.Generate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NameGenerator.Generate() = %v, error = %v, wantErr %v", got, err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("NameGenerator.Generate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNameGenerator_TenantAndAttributes(t *testing.T) {
+	tests := []struct {
+		name    string
+		gen     NameGenerator
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "tenant",
+			gen:  NameGenerator{Namespace: "myorg", Tenant: "acme", Name: "app", Environment: "prod"},
+			want: "myorg-acme-app-prod",
+		},
+		{
+			name: "attributes",
+			gen:  NameGenerator{Namespace: "myorg", Name: "app", Environment: "prod", Attributes: []string{"blue", "1"}},
+			want: "myorg-app-prod-blue-1",
+		},
+		{
+			name: "attributes with underscore delimiter",
+			gen: NameGenerator{
+				Namespace:   "myorg",
+				Name:        "app",
+				Environment: "prod",
+				Attributes:  []string{"blue", "1"},
+				Options:     &NameOptions{Delimiter: "_"},
+			},
+			want: "myorg_app_prod_blue_1",
+		},
+		{
+			name: "tenant and name only",
+			gen:  NameGenerator{Tenant: "acme", Name: "app"},
+			want: "acme-app",
+		},
+		{
+			name: "custom order",
+			gen: NameGenerator{
+				Namespace:   "myorg",
+				Tenant:      "acme",
+				Name:        "app",
+				Environment: "prod",
+				Options: &NameOptions{
+					Delimiter: "-",
+					Order:     []NameComponent{ComponentTenant, ComponentName, ComponentEnvironment},
+				},
+			},
+			want: "acme-app-prod",
+		},
+		{
+			name: "customer is left out of the default order",
+			gen:  NameGenerator{Namespace: "myorg", Customer: "globex", Name: "app", Environment: "prod"},
+			want: "myorg-app-prod",
+		},
+		{
+			name: "customer in custom order",
+			gen: NameGenerator{
+				Namespace:   "myorg",
+				Customer:    "globex",
+				Name:        "app",
+				Environment: "prod",
+				Options: &NameOptions{
+					Delimiter: "-",
+					Order:     []NameComponent{ComponentNamespace, ComponentCustomer, ComponentName, ComponentEnvironment},
+				},
+			},
+			want: "myorg-globex-app-prod",
+		},
+		{
+			name: "truncation preserves tenant",
+			gen:  NameGenerator{Namespace: "myorg", Tenant: "acme", Name: "averyveryverylongname", Environment: "prod"},
+			want: "myorg-acme-averyver-prod",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.gen.Generate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Generate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Generate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestShortenNamePrefix(t *testing.T) {
+	tests := []struct {
+		name       string
+		namePrefix string
+		delimiter  string
+		maxLength  int
+		want       string
+		wantErr    bool
+	}{
+		{
+			name:       "fits unchanged",
+			namePrefix: "myorg-app",
+			delimiter:  "-",
+			maxLength:  DefaultShortNameLength,
+			want:       "myorg-app",
+		},
+		{
+			name:       "fragment and hash",
+			namePrefix: "myorg-webapp-prod",
+			delimiter:  "-",
+			maxLength:  DefaultShortNameLength,
+			want:       "myorg-36a3e2",
+		},
+		{
+			name:       "same fragment different hash",
+			namePrefix: "myorg-webapp-dev",
+			delimiter:  "-",
+			maxLength:  DefaultShortNameLength,
+			want:       "myorg-0702f8",
+		},
+		{
+			name:       "trailing delimiter trimmed from fragment",
+			namePrefix: "myorg-webapp-prod",
+			delimiter:  "-",
+			maxLength:  13,
+			want:       "myorg-36a3e2",
+		},
+		{
+			name:       "longer length keeps more of the prefix",
+			namePrefix: "myorg-a-verylongname-prod",
+			delimiter:  "-",
+			maxLength:  14,
+			want:       "myorg-a-3c5e27",
+		},
+		{
+			name:       "underscore delimiter",
+			namePrefix: "myorg_webapp_prod",
+			delimiter:  "_",
+			maxLength:  DefaultShortNameLength,
+			want:       "myorg_1c1945",
+		},
+		{
+			name:       "empty delimiter",
+			namePrefix: "myorgwebappprod",
+			delimiter:  "",
+			maxLength:  DefaultShortNameLength,
+			want:       "myorgwfb6154",
+		},
+		{
+			name:       "minimum length",
+			namePrefix: "myorg-webapp-prod",
+			delimiter:  "-",
+			maxLength:  MinShortNameLength,
+			want:       "m-36a3e2",
+		},
+		{
+			name:       "below minimum length",
+			namePrefix: "myorg-webapp-prod",
+			delimiter:  "-",
+			maxLength:  MinShortNameLength - 1,
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ShortenNamePrefix(tt.namePrefix, tt.delimiter, tt.maxLength)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ShortenNamePrefix() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ShortenNamePrefix() = %v, want %v", got, tt.want)
+			}
+			if !tt.wantErr && len(got) > tt.maxLength {
+				t.Errorf("ShortenNamePrefix() = %v exceeds %d chars", got, tt.maxLength)
+			}
+		})
+	}
+}
+
+func TestSurrogateID(t *testing.T) {
+	newConfig := func(layer string) *DataSourceConfig {
+		config := NewDataSourceConfig()
+		config.Namespace = "myorg"
+		config.Environment = "dev"
+		config.AdditionalTags = map[string]string{"layer": layer, "team": "platform"}
+		return config
+	}
+
+	id, err := SurrogateID("myorg-dev", newConfig("network"))
+	if err != nil {
+		t.Fatalf("SurrogateID() error = %v", err)
+	}
+	if !regexp.MustCompile(`^myorg-dev-[0-9a-f]{16}$`).MatchString(id) {
+		t.Errorf("SurrogateID() = %q, want myorg-dev-<hash>", id)
+	}
+
+	// Maps are hashed in key order, so the same config always gives the same ID
+	for range 10 {
+		if again, _ := SurrogateID("myorg-dev", newConfig("network")); again != id {
+			t.Errorf("SurrogateID() = %q, then %q", id, again)
+		}
+	}
+	if other, _ := SurrogateID("myorg-dev", newConfig("storage")); other == id {
+		t.Errorf("SurrogateID() = %q for different configs", id)
+	}
+}
+func TestFindReservedWords(t *testing.T) {
+	tests := []struct {
+		name       string
+		namePrefix string
+		additional []string
+		want       []string
+	}{
+		{
+			name:       "no reserved words",
+			namePrefix: "myorg-app-prod",
+			want:       nil,
+		},
+		{
+			name:       "built-in word",
+			namePrefix: "myorg-aws-prod",
+			want:       []string{"aws"},
+		},
+		{
+			name:       "substring",
+			namePrefix: "myorg-winlogin-prod",
+			want:       []string{"login"},
+		},
+		{
+			name:       "case insensitive additional word",
+			namePrefix: "myorg-darn-azure",
+			additional: []string{"DARN"},
+			want:       []string{"azure", "darn"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FindReservedWords(tt.namePrefix, tt.additional)
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("FindReservedWords() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRemoveReservedWords(t *testing.T) {
+	tests := []struct {
+		name       string
+		namePrefix string
+		delimiter  string
+		additional []string
+		want       string
+	}{
+		{
+			name:       "no reserved words",
+			namePrefix: "myorg-app-prod",
+			delimiter:  "-",
+			want:       "myorg-app-prod",
+		},
+		{
+			name:       "whole component",
+			namePrefix: "myorg-aws-app-prod",
+			delimiter:  "-",
+			want:       "myorg-app-prod",
+		},
+		{
+			name:       "leading component",
+			namePrefix: "azure-app-prod",
+			delimiter:  "-",
+			want:       "app-prod",
+		},
+		{
+			name:       "part of component",
+			namePrefix: "myorg-xboxstats-prod",
+			delimiter:  "-",
+			want:       "myorg-stats-prod",
+		},
+		{
+			name:       "underscore delimiter",
+			namePrefix: "myorg_microsoft_app",
+			delimiter:  "_",
+			want:       "myorg_app",
+		},
+		{
+			name:       "empty delimiter",
+			namePrefix: "myorgwindowsapp",
+			delimiter:  "",
+			want:       "myorgapp",
+		},
+		{
+			name:       "additional word",
+			namePrefix: "myorg-darn-app",
+			delimiter:  "-",
+			additional: []string{"darn"},
+			want:       "myorg-app",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RemoveReservedWords(tt.namePrefix, tt.delimiter, tt.additional)
+			if got != tt.want {
+				t.Errorf("RemoveReservedWords() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package context
+
+import (
+	"slices"
+	"strings"
+)
+
+// Tag limits of observability platforms
+const (
+	// DatadogTagMaxLength is the maximum length of a Datadog key:value tag
+	DatadogTagMaxLength = 200
+	// NewRelicTagKeyMaxLength is the maximum length of a New Relic tag key
+	NewRelicTagKeyMaxLength = 128
+	// NewRelicTagValueMaxLength is the maximum length of a New Relic tag value
+	NewRelicTagValueMaxLength = 256
+)
+
+// ConvertTagsToDatadog converts tags to sorted Datadog key:value tags.
+// Datadog lowercases tags and accepts only letters, digits and the characters
+// _-:./, so other characters are replaced with underscores, repeated
+// underscores are collapsed and tags are cut to DatadogTagMaxLength. Tags
+// with empty values or not starting with a letter are left out.
+func ConvertTagsToDatadog(tags map[string]string) []string {
+	keys, _ := sortedTagKeys(tags)
+
+	result := make([]string, 0, len(keys))
+	for _, k := range keys {
+		if tags[k] == "" {
+			continue
+		}
+		if tag := datadogTag(k + ":" + tags[k]); tag != "" {
+			result = append(result, tag)
+		}
+	}
+	slices.Sort(result)
+
+	return result
+}
+
+// datadogTag normalizes tag to the Datadog character set and length, or
+// returns "" when it does not start with a letter
+func datadogTag(tag string) string {
+	var b strings.Builder
+	b.Grow(len(tag))
+	for _, r := range strings.ToLower(tag) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', strings.ContainsRune("-:./", r):
+			b.WriteRune(r)
+		case !strings.HasSuffix(b.String(), "_"):
+			b.WriteByte('_')
+		}
+	}
+
+	normalized := b.String()
+	if len(normalized) > DatadogTagMaxLength {
+		normalized = normalized[:DatadogTagMaxLength]
+	}
+	normalized = strings.TrimRight(normalized, "_:")
+	if normalized == "" || normalized[0] < 'a' || normalized[0] > 'z' {
+		return ""
+	}
+	return normalized
+}
+
+// ConvertTagsToNewRelic converts tags to New Relic entity tags, cutting keys
+// to NewRelicTagKeyMaxLength and values to NewRelicTagValueMaxLength. Tags
+// with empty values are left out, and when cut keys collide the first key in
+// order is kept.
+func ConvertTagsToNewRelic(tags map[string]string) map[string]string {
+	keys, _ := sortedTagKeys(tags)
+
+	result := make(map[string]string, len(keys))
+	for _, k := range keys {
+		if tags[k] == "" {
+			continue
+		}
+		key := truncateTagValue(k, NewRelicTagKeyMaxLength)
+		if _, ok := result[key]; !ok {
+			result[key] = truncateTagValue(tags[k], NewRelicTagValueMaxLength)
+		}
+	}
+
+	return result
+}
+
+// EventFieldNamespace is the field namespace of ConvertToEventFields in the
+// data source event_fields output
+const EventFieldNamespace = "context"
+
+// ConvertToEventFields converts fields, such as a flattened context, to
+// custom event fields for Splunk HEC or Elastic Common Schema, keyed as
+// namespace.key. Keys are lowercased and characters other than letters,
+// digits, underscores and dots are replaced with underscores, so nested
+// values such as additional_tags.Team become context.additional_tags.team.
+// Empty values are left out.
+func ConvertToEventFields(fields map[string]string, namespace string) map[string]string {
+	keys, _ := sortedTagKeys(fields)
+
+	result := make(map[string]string, len(keys))
+	for _, k := range keys {
+		if fields[k] == "" {
+			continue
+		}
+		field := eventFieldName(k)
+		if namespace != "" {
+			field = namespace + "." + field
+		}
+		if _, ok := result[field]; !ok {
+			result[field] = fields[k]
+		}
+	}
+
+	return result
+}
+
+// eventFieldName lowercases key and replaces characters not allowed in
+// event field names with underscores
+func eventFieldName(key string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '_', r == '.':
+			return r
+		default:
+			return '_'
+		}
+	}, strings.ToLower(key))
+}
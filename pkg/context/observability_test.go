@@ -0,0 +1,129 @@
+package context
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestConvertTagsToDatadog(t *testing.T) {
+	tests := []struct {
+		name string
+		tags map[string]string
+		want []string
+	}{
+		{
+			name: "lowercased and sorted",
+			tags: map[string]string{"bc-environment": "Production", "bc-Team": "Platform", "bc-costcenter": "CC-100"},
+			want: []string{"bc-costcenter:cc-100", "bc-environment:production", "bc-team:platform"},
+		},
+		{
+			name: "unsupported characters replaced",
+			tags: map[string]string{"bc-productowners": "a@example.com b@example.com", "bc-sourcerepo": "https://github.com/example/repo"},
+			want: []string{"bc-productowners:a_example.com_b_example.com", "bc-sourcerepo:https://github.com/example/repo"},
+		},
+		{
+			name: "trailing underscores trimmed",
+			tags: map[string]string{"team": "platform (core)"},
+			want: []string{"team:platform_core"},
+		},
+		{
+			name: "empty values and keys not starting with a letter left out",
+			tags: map[string]string{"bc-empty": "", "1team": "platform", "_team": "platform"},
+			want: []string{},
+		},
+		{
+			name: "truncated",
+			tags: map[string]string{"team": strings.Repeat("x", 300)},
+			want: []string{"team:" + strings.Repeat("x", DatadogTagMaxLength-len("team:"))},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ConvertTagsToDatadog(tt.tags); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ConvertTagsToDatadog() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvertTagsToNewRelic(t *testing.T) {
+	longKey := strings.Repeat("k", 200)
+
+	tests := []struct {
+		name string
+		tags map[string]string
+		want map[string]string
+	}{
+		{
+			name: "kept as is",
+			tags: map[string]string{"bc-environment": "Production", "bc-productowners": "a@example.com b@example.com"},
+			want: map[string]string{"bc-environment": "Production", "bc-productowners": "a@example.com b@example.com"},
+		},
+		{
+			name: "empty values left out",
+			tags: map[string]string{"bc-empty": "", "team": "platform"},
+			want: map[string]string{"team": "platform"},
+		},
+		{
+			name: "truncated",
+			tags: map[string]string{longKey: strings.Repeat("v", 300)},
+			want: map[string]string{longKey[:NewRelicTagKeyMaxLength]: strings.Repeat("v", NewRelicTagValueMaxLength)},
+		},
+		{
+			name: "colliding truncated keys keep the first",
+			tags: map[string]string{longKey + "a": "first", longKey + "b": "second"},
+			want: map[string]string{longKey[:NewRelicTagKeyMaxLength]: "first"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ConvertTagsToNewRelic(tt.tags); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ConvertTagsToNewRelic() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvertToEventFields(t *testing.T) {
+	tests := []struct {
+		name      string
+		fields    map[string]string
+		namespace string
+		want      map[string]string
+	}{
+		{
+			name:      "namespaced and lowercased",
+			fields:    map[string]string{"environment": "dev", "additional_tags.Team": "payments"},
+			namespace: "context",
+			want:      map[string]string{"context.environment": "dev", "context.additional_tags.team": "payments"},
+		},
+		{
+			name:      "unsupported characters replaced",
+			fields:    map[string]string{"additional_tags.cost-center:id": "cc-100"},
+			namespace: "context",
+			want:      map[string]string{"context.additional_tags.cost_center_id": "cc-100"},
+		},
+		{
+			name:   "empty values left out",
+			fields: map[string]string{"tenant": "", "namespace": "ex"},
+			want:   map[string]string{"namespace": "ex"},
+		},
+		{
+			name:      "colliding keys keep the first",
+			fields:    map[string]string{"additional_tags.Team": "first", "additional_tags.team": "second"},
+			namespace: "context",
+			want:      map[string]string{"context.additional_tags.team": "first"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ConvertToEventFields(tt.fields, tt.namespace); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ConvertToEventFields() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
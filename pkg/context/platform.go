@@ -0,0 +1,96 @@
+package context
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Management platforms reported by DetectManagedBy
+const (
+	ManagedByTerraform    = "terraform"
+	ManagedByHCPTerraform = "hcp-terraform"
+	ManagedBySpacelift    = "spacelift"
+	ManagedByAtlantis     = "atlantis"
+)
+
+// managedByEnvVars are environment variables set by the runs of each
+// platform, in detection order
+var managedByEnvVars = []struct {
+	envVar   string
+	platform string
+}{
+	{"TFC_RUN_ID", ManagedByHCPTerraform},
+	{"TF_VAR_spacelift_run_id", ManagedBySpacelift},
+	{"ATLANTIS_TERRAFORM_VERSION", ManagedByAtlantis},
+}
+
+// DetectManagedBy returns the platform running Terraform, detected from the
+// environment of the provider process, or ManagedByTerraform for the
+// Terraform CLI and unrecognized platforms
+func DetectManagedBy() string {
+	for _, v := range managedByEnvVars {
+		if os.Getenv(v.envVar) != "" {
+			return v.platform
+		}
+	}
+	return ManagedByTerraform
+}
+
+// terragruntCacheDir is the default Terragrunt download directory, created
+// in the directory of each unit to hold the copy Terraform runs in
+const terragruntCacheDir = ".terragrunt-cache"
+
+// Environment variables of the Terragrunt options locating the units, in
+// precedence order of the current and legacy names
+var (
+	terragruntDownloadDirEnvVars = []string{"TG_DOWNLOAD_DIR", "TERRAGRUNT_DOWNLOAD"}
+	terragruntWorkingDirEnvVars  = []string{"TG_WORKING_DIR", "TERRAGRUNT_WORKING_DIR"}
+)
+
+// DetectTerragruntSourceDir returns the directory of the Terragrunt unit
+// whose copy in the Terragrunt download directory is wd, or "" when wd is
+// not such a copy. A download directory set to an absolute path, through
+// TG_DOWNLOAD_DIR or TERRAGRUNT_DOWNLOAD, holds the copies of every unit,
+// so the absolute working directory given to Terragrunt, through
+// TG_WORKING_DIR or TERRAGRUNT_WORKING_DIR, is returned instead when set.
+func DetectTerragruntSourceDir(wd string) string {
+	wd = filepath.Clean(wd)
+	download := firstEnv(terragruntDownloadDirEnvVars)
+	if download == "" {
+		download = terragruntCacheDir
+	}
+
+	if filepath.IsAbs(download) {
+		rel, err := filepath.Rel(filepath.Clean(download), wd)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return ""
+		}
+		if dir := firstEnv(terragruntWorkingDirEnvVars); filepath.IsAbs(dir) {
+			return filepath.Clean(dir)
+		}
+		return ""
+	}
+
+	// A relative download directory is created in the directory of the unit
+	for dir := wd; ; {
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		if filepath.Base(dir) == download {
+			return parent
+		}
+		dir = parent
+	}
+}
+
+// firstEnv returns the value of the first of envVars that is set
+func firstEnv(envVars []string) string {
+	for _, envVar := range envVars {
+		if value := os.Getenv(envVar); value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,97 @@
+package context
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestDetectManagedBy(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want string
+	}{
+		{name: "terraform cli", want: ManagedByTerraform},
+		{name: "hcp terraform", env: map[string]string{"TFC_RUN_ID": "run-abc123"}, want: ManagedByHCPTerraform},
+		{name: "spacelift", env: map[string]string{"TF_VAR_spacelift_run_id": "01HABC"}, want: ManagedBySpacelift},
+		{name: "atlantis", env: map[string]string{"ATLANTIS_TERRAFORM_VERSION": "1.9.5"}, want: ManagedByAtlantis},
+		{
+			name: "first match in detection order",
+			env:  map[string]string{"TFC_RUN_ID": "run-abc123", "ATLANTIS_TERRAFORM_VERSION": "1.9.5"},
+			want: ManagedByHCPTerraform,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, v := range managedByEnvVars {
+				t.Setenv(v.envVar, tt.env[v.envVar])
+			}
+
+			if got := DetectManagedBy(); got != tt.want {
+				t.Errorf("DetectManagedBy() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetectTerragruntSourceDir(t *testing.T) {
+	unit := filepath.FromSlash("/repo/live/prod/app")
+	cache := filepath.Join(unit, ".terragrunt-cache", "abc123", "def456", "modules", "app")
+
+	tests := []struct {
+		name string
+		wd   string
+		env  map[string]string
+		want string
+	}{
+		{name: "not terragrunt", wd: unit},
+		{name: "default download directory", wd: cache, want: unit},
+		{
+			name: "relative download directory",
+			wd:   filepath.Join(unit, ".tg", "abc123", "def456"),
+			env:  map[string]string{"TG_DOWNLOAD_DIR": ".tg"},
+			want: unit,
+		},
+		{
+			name: "legacy relative download directory",
+			wd:   filepath.Join(unit, ".tg", "abc123", "def456"),
+			env:  map[string]string{"TERRAGRUNT_DOWNLOAD": ".tg"},
+			want: unit,
+		},
+		{
+			name: "absolute download directory with working directory",
+			wd:   filepath.FromSlash("/tmp/tg/abc123/def456"),
+			env:  map[string]string{"TG_DOWNLOAD_DIR": filepath.FromSlash("/tmp/tg"), "TERRAGRUNT_WORKING_DIR": unit},
+			want: unit,
+		},
+		{
+			name: "absolute download directory without working directory",
+			wd:   filepath.FromSlash("/tmp/tg/abc123/def456"),
+			env:  map[string]string{"TG_DOWNLOAD_DIR": filepath.FromSlash("/tmp/tg")},
+		},
+		{
+			name: "outside absolute download directory",
+			wd:   unit,
+			env:  map[string]string{"TG_DOWNLOAD_DIR": filepath.FromSlash("/tmp/tg"), "TG_WORKING_DIR": unit},
+		},
+		{
+			name: "relative working directory",
+			wd:   filepath.FromSlash("/tmp/tg/abc123/def456"),
+			env:  map[string]string{"TG_DOWNLOAD_DIR": filepath.FromSlash("/tmp/tg"), "TG_WORKING_DIR": "live/prod/app"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, envVar := range slices.Concat(terragruntDownloadDirEnvVars, terragruntWorkingDirEnvVars) {
+				t.Setenv(envVar, tt.env[envVar])
+			}
+
+			if got := DetectTerragruntSourceDir(tt.wd); got != tt.want {
+				t.Errorf("DetectTerragruntSourceDir(%q) = %q, want %q", tt.wd, got, tt.want)
+			}
+		})
+	}
+}
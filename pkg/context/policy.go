@@ -0,0 +1,147 @@
+package context
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/open-policy-agent/opa/v1/ast"
+	"github.com/open-policy-agent/opa/v1/rego"
+)
+
+// PolicyPackage is the package of the rego rules evaluated by a Policy
+const PolicyPackage = "brockhoff.context"
+
+// Policy evaluates rego rules of PolicyPackage against resolved contexts.
+// Its rules are:
+//
+//   - deny, a set of messages rejecting the context when not empty
+//   - tags, an object of derived tags, keyed without the tag prefix
+//
+// It is safe for concurrent use.
+type Policy struct {
+	query rego.PreparedEvalQuery
+}
+
+// PolicyInput is the input document of the rules of a Policy
+type PolicyInput struct {
+	NamePrefix    string            `json:"name_prefix"`
+	CloudProvider string            `json:"cloud_provider"`
+	TagPrefix     string            `json:"tag_prefix"`
+	Context       *DataSourceConfig `json:"context"`
+	// Tags are the final tags, with the tag prefix
+	Tags map[string]string `json:"tags"`
+}
+
+// PolicyDeniedError reports the deny messages of a Policy
+type PolicyDeniedError struct {
+	Messages []string
+}
+
+func (e *PolicyDeniedError) Error() string {
+	return "denied by policy: " + strings.Join(e.Messages, "; ")
+}
+
+// LoadPolicy compiles the .rego files at path, a file or a directory read
+// recursively, leaving out _test.rego files. At least one file must declare
+// PolicyPackage.
+func LoadPolicy(ctx context.Context, path string) (*Policy, error) {
+	var files []string
+	err := filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && filepath.Ext(p) == ".rego" && !strings.HasSuffix(p, "_test.rego") {
+			files = append(files, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reading policy: %w", err)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no .rego files found at %s", path)
+	}
+
+	options := []func(*rego.Rego){rego.Query("data." + PolicyPackage)}
+	found := false
+	for _, file := range files {
+		src, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("reading policy: %w", err)
+		}
+		module, err := ast.ParseModule(file, string(src))
+		if err != nil {
+			return nil, err
+		}
+		if module.Package.Path.String() == "data."+PolicyPackage {
+			found = true
+		}
+		options = append(options, rego.ParsedModule(module))
+	}
+	if !found {
+		return nil, fmt.Errorf("no policy declares package %s", PolicyPackage)
+	}
+
+	query, err := rego.New(options...).PrepareForEval(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Policy{query: query}, nil
+}
+
+// Evaluate evaluates the rules against input and returns the derived tags,
+// or a *PolicyDeniedError holding the sorted deny messages
+func (p *Policy) Evaluate(ctx context.Context, input PolicyInput) (map[string]string, error) {
+	results, err := p.query.Eval(ctx, rego.EvalInput(input))
+	if err != nil {
+		return nil, fmt.Errorf("evaluating policy: %w", err)
+	}
+	if len(results) == 0 || len(results[0].Expressions) == 0 {
+		return nil, nil
+	}
+	document, ok := results[0].Expressions[0].Value.(map[string]any)
+	if !ok {
+		return nil, nil
+	}
+
+	if deny, ok := document["deny"]; ok {
+		values, ok := deny.([]any)
+		if !ok {
+			return nil, errors.New("policy deny must be a set of strings")
+		}
+		var messages []string
+		for _, v := range values {
+			message, ok := v.(string)
+			if !ok {
+				return nil, fmt.Errorf("policy deny message %v is not a string", v)
+			}
+			messages = append(messages, message)
+		}
+		if len(messages) > 0 {
+			slices.Sort(messages)
+			return nil, &PolicyDeniedError{Messages: messages}
+		}
+	}
+
+	derived := map[string]string{}
+	if tags, ok := document["tags"]; ok {
+		values, ok := tags.(map[string]any)
+		if !ok {
+			return nil, errors.New("policy tags must be an object")
+		}
+		for key, v := range values {
+			value, ok := v.(string)
+			if !ok {
+				return nil, fmt.Errorf("policy tag %s value %v is not a string", key, v)
+			}
+			derived[key] = value
+		}
+	}
+	return derived, nil
+}
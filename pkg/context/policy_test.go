@@ -0,0 +1,179 @@
+package context
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testPolicy = `package brockhoff.context
+
+deny contains msg if {
+	input.context.environment == "prod"
+	input.tags["bc-costcenter"] == "N/A"
+	msg := "production contexts need a cost center"
+}
+
+deny contains msg if {
+	not input.tags["bc-managedby"]
+	msg := "managedby is required"
+}
+
+tags["tier"] := "critical" if input.context.availability == "dedicated"
+
+tags["chargeback"] := input.tags["bc-costcenter"] if input.tags["bc-costcenter"] != "N/A"
+`
+
+// writePolicy writes rego files into a temporary directory
+func writePolicy(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, src := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestPolicy_Evaluate(t *testing.T) {
+	dir := writePolicy(t, map[string]string{
+		"context.rego": testPolicy,
+		// Tests of the policy are not loaded
+		"context_test.rego": "package brockhoff.context_test\n\ntest_fails if false\n",
+	})
+	policy, err := LoadPolicy(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("LoadPolicy() error = %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		config      *DataSourceConfig
+		tags        map[string]string
+		wantTags    map[string]string
+		wantDenials []string
+	}{
+		{
+			name:     "derived tags",
+			config:   &DataSourceConfig{Environment: "prod", Availability: "dedicated"},
+			tags:     map[string]string{"bc-costcenter": "cc-100", "bc-managedby": "terraform"},
+			wantTags: map[string]string{"tier": "critical", "chargeback": "cc-100"},
+		},
+		{
+			name:     "no derived tags",
+			config:   &DataSourceConfig{Environment: "dev"},
+			tags:     map[string]string{"bc-costcenter": "N/A", "bc-managedby": "terraform"},
+			wantTags: map[string]string{},
+		},
+		{
+			name:        "denied",
+			config:      &DataSourceConfig{Environment: "prod"},
+			tags:        map[string]string{"bc-costcenter": "N/A"},
+			wantDenials: []string{"managedby is required", "production contexts need a cost center"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := policy.Evaluate(context.Background(), PolicyInput{TagPrefix: "bc-", Context: tt.config, Tags: tt.tags})
+			if tt.wantDenials != nil {
+				var denied *PolicyDeniedError
+				if !errors.As(err, &denied) || !reflect.DeepEqual(denied.Messages, tt.wantDenials) {
+					t.Errorf("Evaluate() error = %v, want denials %v", err, tt.wantDenials)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Evaluate() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.wantTags) {
+				t.Errorf("Evaluate() = %v, want %v", got, tt.wantTags)
+			}
+		})
+	}
+}
+
+func TestLoadPolicy_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string
+		wantErr string
+	}{
+		{name: "no rego files", files: map[string]string{"README.md": "policies"}, wantErr: "no .rego files"},
+		{name: "other package", files: map[string]string{"other.rego": "package other\n\nallow := true\n"}, wantErr: "package brockhoff.context"},
+		{name: "syntax error", files: map[string]string{"context.rego": "package brockhoff.context\n\ndeny contains msg if {\n"}, wantErr: "context.rego"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadPolicy(context.Background(), writePolicy(t, tt.files))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("LoadPolicy() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := LoadPolicy(context.Background(), filepath.Join(t.TempDir(), "missing.rego")); err == nil {
+		t.Error("LoadPolicy() error = nil for a missing path")
+	}
+}
+
+func TestPolicy_EvaluateInvalidRules(t *testing.T) {
+	policy, err := LoadPolicy(context.Background(), writePolicy(t, map[string]string{
+		"context.rego": "package brockhoff.context\n\ntags[\"replicas\"] := 3\n",
+	}))
+	if err != nil {
+		t.Fatalf("LoadPolicy() error = %v", err)
+	}
+	if _, err := policy.Evaluate(context.Background(), PolicyInput{}); err == nil || !strings.Contains(err.Error(), "not a string") {
+		t.Errorf("Evaluate() error = %v, want non-string tag error", err)
+	}
+}
+
+func TestTagProcessor_ApplyPolicy(t *testing.T) {
+	policy, err := LoadPolicy(context.Background(), writePolicy(t, map[string]string{"context.rego": testPolicy}))
+	if err != nil {
+		t.Fatalf("LoadPolicy() error = %v", err)
+	}
+
+	config := NewDataSourceConfig()
+	config.Environment = "prod"
+	config.Availability = "dedicated"
+	config.CostCenter = "cc-100 {eu}"
+	config.SourceRepoTagsEnabled = false
+	config.DigestTagEnabled = true
+	processor := &TagProcessor{
+		CloudProvider: GetCloudProvider("aws"),
+		Config:        config,
+		TagPrefix:     "bc-",
+	}
+	processor.ApplyPolicy = func(tags map[string]string) (map[string]string, error) {
+		return policy.Evaluate(context.Background(), PolicyInput{TagPrefix: "bc-", Context: config, Tags: tags})
+	}
+
+	tags, err := processor.Process()
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+	if tags["bc-tier"] != "critical" {
+		t.Errorf("bc-tier = %q, want critical", tags["bc-tier"])
+	}
+	// Derived tags are sanitized and covered by the digest
+	if tags["bc-chargeback"] != tags["bc-costcenter"] {
+		t.Errorf("bc-chargeback = %q, want the sanitized cost center %q", tags["bc-chargeback"], tags["bc-costcenter"])
+	}
+	if want := processor.ContextDigest(tags); tags["bc-contextdigest"] != want {
+		t.Errorf("bc-contextdigest = %q, want %q", tags["bc-contextdigest"], want)
+	}
+
+	config.CostCenter = ""
+	var denied *PolicyDeniedError
+	if _, err := processor.Process(); !errors.As(err, &denied) {
+		t.Errorf("Process() error = %v, want *PolicyDeniedError", err)
+	}
+}
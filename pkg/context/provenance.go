@@ -0,0 +1,189 @@
+package context
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// oidcTokenTimeout bounds the request for a GitHub Actions OIDC token
+const oidcTokenTimeout = 10 * time.Second
+
+// Provenance tag keys, without the tag prefix, named after the SLSA
+// provenance fields they hold
+const (
+	// builderIDTagKey holds runDetails.builder.id, the workflow that ran
+	// Terraform
+	builderIDTagKey = "builderid"
+	// buildInvocationTagKey holds runDetails.metadata.invocationId, the run
+	// of the workflow
+	buildInvocationTagKey = "buildinvocation"
+	// builderSubjectTagKey and builderAudienceTagKey hold the sub and aud
+	// claims of the OIDC token identifying the workflow
+	builderSubjectTagKey  = "buildersubject"
+	builderAudienceTagKey = "builderaudience"
+)
+
+// ProvenanceInfo identifies the builder that ran Terraform, following the
+// runDetails fields of SLSA provenance. Fields are empty when unknown.
+type ProvenanceInfo struct {
+	// BuilderID is the workflow, such as
+	// https://github.com/example/infra/.github/workflows/deploy.yml@refs/heads/main
+	BuilderID string
+	// InvocationID is the workflow run, such as
+	// https://github.com/example/infra/actions/runs/123/attempts/1
+	InvocationID string
+	// Subject and Audience are the sub and aud claims of the OIDC token of
+	// the run, available to GitHub Actions jobs with the id-token: write
+	// permission
+	Subject  string
+	Audience string
+}
+
+var (
+	provenanceCache     *ProvenanceInfo
+	provenanceCacheLock sync.Mutex
+)
+
+// GetProvenanceInfo returns the builder identity detected from the GitHub
+// Actions environment of the process, requesting an OIDC token when the job
+// may. The environment does not change during a run, so the result is cached
+// for the life of the process. It is safe for concurrent use; each call
+// returns its own copy of the cached value.
+func GetProvenanceInfo() *ProvenanceInfo {
+	provenanceCacheLock.Lock()
+	defer provenanceCacheLock.Unlock()
+
+	if provenanceCache == nil {
+		provenanceCache = detectProvenance()
+	}
+	info := *provenanceCache
+	return &info
+}
+
+// ClearProvenanceCache clears the builder identity cache
+func ClearProvenanceCache() {
+	provenanceCacheLock.Lock()
+	defer provenanceCacheLock.Unlock()
+	provenanceCache = nil
+}
+
+// detectProvenance reads the GitHub Actions default environment variables.
+// A failed OIDC token request leaves Subject and Audience empty.
+func detectProvenance() *ProvenanceInfo {
+	info := &ProvenanceInfo{}
+	if os.Getenv("GITHUB_ACTIONS") != "true" {
+		return info
+	}
+
+	server := strings.TrimSuffix(os.Getenv("GITHUB_SERVER_URL"), "/")
+	if server == "" {
+		server = "https://github.com"
+	}
+	if ref := os.Getenv("GITHUB_WORKFLOW_REF"); ref != "" {
+		info.BuilderID = server + "/" + ref
+	}
+	if repo, runID := os.Getenv("GITHUB_REPOSITORY"), os.Getenv("GITHUB_RUN_ID"); repo != "" && runID != "" {
+		info.InvocationID = fmt.Sprintf("%s/%s/actions/runs/%s", server, repo, runID)
+		if attempt := os.Getenv("GITHUB_RUN_ATTEMPT"); attempt != "" {
+			info.InvocationID += "/attempts/" + attempt
+		}
+	}
+
+	requestURL, requestToken := os.Getenv("ACTIONS_ID_TOKEN_REQUEST_URL"), os.Getenv("ACTIONS_ID_TOKEN_REQUEST_TOKEN")
+	if requestURL != "" && requestToken != "" {
+		if claims, err := requestOIDCClaims(requestURL, requestToken); err == nil {
+			info.Subject = claims.Subject
+			info.Audience = strings.Join(claims.Audience, ",")
+		}
+	}
+	return info
+}
+
+// oidcClaims are the claims of an OIDC token used for provenance
+type oidcClaims struct {
+	Subject  string       `json:"sub"`
+	Audience oidcAudience `json:"aud"`
+}
+
+// oidcAudience is an aud claim, which is either a string or an array
+type oidcAudience []string
+
+// UnmarshalJSON accepts both forms of the aud claim
+func (a *oidcAudience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = oidcAudience{single}
+		return nil
+	}
+	return json.Unmarshal(data, (*[]string)(a))
+}
+
+// requestOIDCClaims requests an OIDC token for the GitHub Actions job and
+// returns its claims. The token is only used for its claims, so its
+// signature is not verified.
+func requestOIDCClaims(requestURL, requestToken string) (*oidcClaims, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), oidcTokenTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+requestToken)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("requesting OIDC token: %s", resp.Status)
+	}
+
+	var body struct {
+		Value string `json:"value"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("decoding OIDC token response: %w", err)
+	}
+
+	parts := strings.Split(body.Value, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("OIDC token is not a JWT")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("decoding OIDC token claims: %w", err)
+	}
+	var claims oidcClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, fmt.Errorf("decoding OIDC token claims: %w", err)
+	}
+	return &claims, nil
+}
+
+// addProvenanceTags adds the tags of the detected builder identity; no tag
+// is added for unknown fields
+func (tp *TagProcessor) addProvenanceTags(tags map[string]string) {
+	info := GetProvenanceInfo()
+	for key, value := range map[string]string{
+		builderIDTagKey:       info.BuilderID,
+		buildInvocationTagKey: info.InvocationID,
+		builderSubjectTagKey:  info.Subject,
+		builderAudienceTagKey: info.Audience,
+	} {
+		if value != "" {
+			tags[key] = value
+		}
+	}
+}
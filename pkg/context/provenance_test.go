@@ -0,0 +1,148 @@
+package context
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// setGitHubActionsEnv sets the GitHub Actions environment of a workflow
+// run, with an OIDC token endpoint when requestURL is not empty
+func setGitHubActionsEnv(t *testing.T, requestURL string) {
+	t.Helper()
+	t.Setenv("GITHUB_ACTIONS", "true")
+	t.Setenv("GITHUB_SERVER_URL", "https://github.com")
+	t.Setenv("GITHUB_REPOSITORY", "example/infra")
+	t.Setenv("GITHUB_WORKFLOW_REF", "example/infra/.github/workflows/deploy.yml@refs/heads/main")
+	t.Setenv("GITHUB_RUN_ID", "123")
+	t.Setenv("GITHUB_RUN_ATTEMPT", "2")
+	t.Setenv("ACTIONS_ID_TOKEN_REQUEST_URL", requestURL)
+	t.Setenv("ACTIONS_ID_TOKEN_REQUEST_TOKEN", "request-token")
+	ClearProvenanceCache()
+	t.Cleanup(ClearProvenanceCache)
+}
+
+// newOIDCServer returns a fake GitHub Actions OIDC token endpoint issuing a
+// token with claims
+func newOIDCServer(t *testing.T, claims string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer request-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		payload := base64.RawURLEncoding.EncodeToString([]byte(claims))
+		fmt.Fprintf(w, `{"value":"eyJhbGciOiJSUzI1NiJ9.%s.c2lnbmF0dXJl"}`, payload)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestGetProvenanceInfo(t *testing.T) {
+	tests := []struct {
+		name   string
+		claims string
+		want   ProvenanceInfo
+	}{
+		{
+			name:   "string audience",
+			claims: `{"sub":"repo:example/infra:environment:production","aud":"https://github.com/example"}`,
+			want: ProvenanceInfo{
+				BuilderID:    "https://github.com/example/infra/.github/workflows/deploy.yml@refs/heads/main",
+				InvocationID: "https://github.com/example/infra/actions/runs/123/attempts/2",
+				Subject:      "repo:example/infra:environment:production",
+				Audience:     "https://github.com/example",
+			},
+		},
+		{
+			name:   "list audience",
+			claims: `{"sub":"repo:example/infra:ref:refs/heads/main","aud":["sigstore","https://github.com/example"]}`,
+			want: ProvenanceInfo{
+				BuilderID:    "https://github.com/example/infra/.github/workflows/deploy.yml@refs/heads/main",
+				InvocationID: "https://github.com/example/infra/actions/runs/123/attempts/2",
+				Subject:      "repo:example/infra:ref:refs/heads/main",
+				Audience:     "sigstore,https://github.com/example",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setGitHubActionsEnv(t, newOIDCServer(t, tt.claims).URL)
+			if got := GetProvenanceInfo(); *got != tt.want {
+				t.Errorf("GetProvenanceInfo() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetProvenanceInfo_WithoutOIDC(t *testing.T) {
+	// Jobs without the id-token: write permission have no request URL
+	setGitHubActionsEnv(t, "")
+	got := GetProvenanceInfo()
+	if got.BuilderID == "" || got.InvocationID == "" {
+		t.Errorf("GetProvenanceInfo() = %+v, want builder and invocation", *got)
+	}
+	if got.Subject != "" || got.Audience != "" {
+		t.Errorf("GetProvenanceInfo() = %+v, want no OIDC claims", *got)
+	}
+
+	// A failing token endpoint also leaves the claims empty
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+	setGitHubActionsEnv(t, server.URL)
+	if got := GetProvenanceInfo(); got.Subject != "" || got.BuilderID == "" {
+		t.Errorf("GetProvenanceInfo() = %+v, want builder without OIDC claims", *got)
+	}
+
+	// Outside GitHub Actions nothing is known
+	t.Setenv("GITHUB_ACTIONS", "")
+	ClearProvenanceCache()
+	if got := GetProvenanceInfo(); *got != (ProvenanceInfo{}) {
+		t.Errorf("GetProvenanceInfo() = %+v, want empty outside GitHub Actions", *got)
+	}
+}
+
+func TestTagProcessor_ProvenanceTags(t *testing.T) {
+	setGitHubActionsEnv(t, newOIDCServer(t, `{"sub":"repo:example/infra:ref:refs/heads/main","aud":"https://github.com/example"}`).URL)
+
+	config := NewDataSourceConfig()
+	config.SourceRepoTagsEnabled = false
+	config.ProvenanceTagsEnabled = true
+	processor := &TagProcessor{
+		CloudProvider: GetCloudProvider("aws"),
+		Config:        config,
+		TagPrefix:     "bc-",
+	}
+	tags, err := processor.Process()
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+
+	for _, key := range []string{"bc-builderid", "bc-buildinvocation", "bc-buildersubject", "bc-builderaudience"} {
+		if tags[key] == "" {
+			t.Errorf("%s is missing from %v", key, tags)
+		}
+	}
+	if tags["bc-buildersubject"] != "repo:example/infra:ref:refs/heads/main" {
+		t.Errorf("bc-buildersubject = %q", tags["bc-buildersubject"])
+	}
+
+	got := ConfigFromTags(tags, "bc-", GetCloudProvider("aws"))
+	if !got.ProvenanceTagsEnabled || got.AdditionalTags["builderid"] != "" {
+		t.Errorf("ConfigFromTags() = %+v, want ProvenanceTagsEnabled without additional tags", got)
+	}
+
+	config.ProvenanceTagsEnabled = false
+	tags, err = processor.Process()
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+	if _, ok := tags["bc-builderid"]; ok {
+		t.Errorf("bc-builderid = %q, want no provenance tags when disabled", tags["bc-builderid"])
+	}
+}
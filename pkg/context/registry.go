@@ -0,0 +1,71 @@
+package context
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// registryTimeout bounds the download of a namespace registry
+const registryTimeout = 10 * time.Second
+
+// LoadNamespaceRegistry reads the allowed namespaces from source, a local
+// file path or an http(s) URL, in the format of ParseNamespaceRegistry
+func LoadNamespaceRegistry(ctx context.Context, source string, constraints NamingConstraints) ([]string, error) {
+	if !strings.HasPrefix(source, "https://") && !strings.HasPrefix(source, "http://") {
+		f, err := os.Open(source)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		return ParseNamespaceRegistry(f, constraints)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, registryTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, source, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching %s: %s", source, resp.Status)
+	}
+	return ParseNamespaceRegistry(resp.Body, constraints)
+}
+
+// ParseNamespaceRegistry reads one namespace per line, ignoring blank lines
+// and # comments, and validates each against the namespace limit of
+// constraints
+func ParseNamespaceRegistry(r io.Reader, constraints NamingConstraints) ([]string, error) {
+	var namespaces []string
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		namespace, _, _ := strings.Cut(scanner.Text(), "#")
+		namespace = strings.TrimSpace(namespace)
+		if namespace == "" {
+			continue
+		}
+		if err := constraints.ValidateNamespace(namespace); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		namespaces = append(namespaces, namespace)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return namespaces, nil
+}
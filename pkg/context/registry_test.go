@@ -0,0 +1,77 @@
+package context
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testRegistry = `# Official business units
+myorg
+payments   # card processing
+
+data-eng
+`
+
+func TestParseNamespaceRegistry(t *testing.T) {
+	got, err := ParseNamespaceRegistry(strings.NewReader(testRegistry), NamingConstraints{})
+	if err != nil {
+		t.Fatalf("ParseNamespaceRegistry() error = %v", err)
+	}
+	want := []string{"myorg", "payments", "data-eng"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseNamespaceRegistry() = %v, want %v", got, want)
+	}
+
+	_, err = ParseNamespaceRegistry(strings.NewReader("myorg\nNotValid\n"), NamingConstraints{})
+	if err == nil || !strings.HasPrefix(err.Error(), "line 2:") {
+		t.Errorf("ParseNamespaceRegistry() error = %v, want line 2 error", err)
+	}
+}
+
+func TestLoadNamespaceRegistry(t *testing.T) {
+	want := []string{"myorg", "payments", "data-eng"}
+
+	t.Run("file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "namespaces.txt")
+		if err := os.WriteFile(path, []byte(testRegistry), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := LoadNamespaceRegistry(context.Background(), path, NamingConstraints{})
+		if err != nil {
+			t.Fatalf("LoadNamespaceRegistry() error = %v", err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("LoadNamespaceRegistry() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("url", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/namespaces.txt" {
+				http.NotFound(w, r)
+				return
+			}
+			w.Write([]byte(testRegistry))
+		}))
+		defer server.Close()
+
+		got, err := LoadNamespaceRegistry(context.Background(), server.URL+"/namespaces.txt", NamingConstraints{})
+		if err != nil {
+			t.Fatalf("LoadNamespaceRegistry() error = %v", err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("LoadNamespaceRegistry() = %v, want %v", got, want)
+		}
+
+		if _, err := LoadNamespaceRegistry(context.Background(), server.URL+"/missing", NamingConstraints{}); err == nil {
+			t.Error("LoadNamespaceRegistry() error = nil, want 404 error")
+		}
+	})
+}
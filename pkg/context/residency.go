@@ -0,0 +1,92 @@
+package context
+
+import "strings"
+
+// isoCountryCodes are the ISO 3166-1 alpha-2 country codes
+var isoCountryCodes = toSet(strings.Fields(`
+	AD AE AF AG AI AL AM AO AQ AR AS AT AU AW AX AZ BA BB BD BE BF BG BH BI BJ
+	BL BM BN BO BQ BR BS BT BV BW BY BZ CA CC CD CF CG CH CI CK CL CM CN CO CR
+	CU CV CW CX CY CZ DE DJ DK DM DO DZ EC EE EG EH ER ES ET FI FJ FK FM FO FR
+	GA GB GD GE GF GG GH GI GL GM GN GP GQ GR GS GT GU GW GY HK HM HN HR HT HU
+	ID IE IL IM IN IO IQ IR IS IT JE JM JO JP KE KG KH KI KM KN KP KR KW KY KZ
+	LA LB LC LI LK LR LS LT LU LV LY MA MC MD ME MF MG MH MK ML MM MN MO MP MQ
+	MR MS MT MU MV MW MX MY MZ NA NC NE NF NG NI NL NO NP NR NU NZ OM PA PE PF
+	PG PH PK PL PM PN PR PS PT PW PY QA RE RO RS RU RW SA SB SC SD SE SG SH SI
+	SJ SK SL SM SN SO SR SS ST SV SX SY SZ TC TD TF TG TH TJ TK TL TM TN TO TR
+	TT TV TW TZ UA UG UM US UY UZ VA VC VE VG VI VN VU WF WS YE YT ZA ZM ZW`))
+
+// DataResidencyEU is the ISO 3166-1 exceptionally reserved code of the
+// European Union, covering the countries of euMemberStates
+const DataResidencyEU = "EU"
+
+// euMemberStates are the ISO 3166-1 alpha-2 codes of the member states of
+// the European Union
+var euMemberStates = toSet(strings.Fields(`
+	AT BE BG CY CZ DE DK EE ES FI FR GR HR HU IE IT LT LU LV MT NL PL PT RO SE SI SK`))
+
+// regionCountries maps the cloud regions of AWS, Azure and GCP to the ISO
+// 3166-1 alpha-2 code of the country hosting them
+var regionCountries = map[string]string{
+	// AWS
+	"us-east-1": "US", "us-east-2": "US", "us-west-1": "US", "us-west-2": "US",
+	"us-gov-east-1": "US", "us-gov-west-1": "US", "ca-central-1": "CA", "ca-west-1": "CA",
+	"sa-east-1": "BR", "mx-central-1": "MX",
+	"eu-west-1": "IE", "eu-west-2": "GB", "eu-west-3": "FR", "eu-central-1": "DE",
+	"eu-central-2": "CH", "eu-north-1": "SE", "eu-south-1": "IT", "eu-south-2": "ES",
+	"il-central-1": "IL", "me-south-1": "BH", "me-central-1": "AE", "af-south-1": "ZA",
+	"ap-east-1": "HK", "ap-south-1": "IN", "ap-south-2": "IN", "ap-northeast-1": "JP",
+	"ap-northeast-2": "KR", "ap-northeast-3": "JP", "ap-southeast-1": "SG",
+	"ap-southeast-2": "AU", "ap-southeast-3": "ID", "ap-southeast-4": "AU", "ap-southeast-5": "MY",
+
+	// Azure
+	"eastus": "US", "eastus2": "US", "centralus": "US", "northcentralus": "US",
+	"southcentralus": "US", "westcentralus": "US", "westus": "US", "westus2": "US", "westus3": "US",
+	"canadacentral": "CA", "canadaeast": "CA", "brazilsouth": "BR", "mexicocentral": "MX",
+	"northeurope": "IE", "westeurope": "NL", "uksouth": "GB", "ukwest": "GB",
+	"francecentral": "FR", "francesouth": "FR", "germanywestcentral": "DE", "germanynorth": "DE",
+	"swedencentral": "SE", "switzerlandnorth": "CH", "switzerlandwest": "CH",
+	"norwayeast": "NO", "norwaywest": "NO", "italynorth": "IT", "polandcentral": "PL", "spaincentral": "ES",
+	"uaenorth": "AE", "qatarcentral": "QA", "israelcentral": "IL", "southafricanorth": "ZA",
+	"centralindia": "IN", "southindia": "IN", "japaneast": "JP", "japanwest": "JP",
+	"koreacentral": "KR", "koreasouth": "KR", "southeastasia": "SG", "eastasia": "HK",
+	"australiaeast": "AU", "australiasoutheast": "AU", "australiacentral": "AU",
+
+	// GCP
+	"us-central1": "US", "us-east1": "US", "us-east4": "US", "us-east5": "US", "us-south1": "US",
+	"us-west1": "US", "us-west2": "US", "us-west3": "US", "us-west4": "US",
+	"northamerica-northeast1": "CA", "northamerica-northeast2": "CA",
+	"southamerica-east1": "BR", "southamerica-west1": "CL",
+	"europe-west1": "BE", "europe-west2": "GB", "europe-west3": "DE", "europe-west4": "NL",
+	"europe-west6": "CH", "europe-west8": "IT", "europe-west9": "FR", "europe-west10": "DE",
+	"europe-west12": "IT", "europe-north1": "FI", "europe-central2": "PL", "europe-southwest1": "ES",
+	"me-west1": "IL", "me-central1": "QA", "me-central2": "SA", "africa-south1": "ZA",
+	"asia-east1": "TW", "asia-east2": "HK", "asia-northeast1": "JP", "asia-northeast2": "JP",
+	"asia-northeast3": "KR", "asia-south1": "IN", "asia-south2": "IN", "asia-southeast1": "SG",
+	"asia-southeast2": "ID", "australia-southeast1": "AU", "australia-southeast2": "AU",
+}
+
+// RegionCountry returns the ISO 3166-1 alpha-2 code of the country hosting
+// a cloud region, such as IE for eu-west-1, and false for unknown regions
+func RegionCountry(region string) (string, bool) {
+	country, ok := regionCountries[strings.ToLower(region)]
+	return country, ok
+}
+
+// residencyCovers reports whether the data residency code, a country, a
+// country subdivision such as US-CA, or EU, covers country
+func residencyCovers(code, country string) bool {
+	if code == DataResidencyEU {
+		return euMemberStates[country]
+	}
+	codeCountry, _, _ := strings.Cut(code, "-")
+	return codeCountry == country
+}
+
+// toSet returns a set of values
+func toSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
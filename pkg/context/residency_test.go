@@ -0,0 +1,45 @@
+package context
+
+import "testing"
+
+func TestRegionCountry(t *testing.T) {
+	tests := []struct {
+		region string
+		want   string
+		wantOK bool
+	}{
+		{region: "eu-west-1", want: "IE", wantOK: true},
+		{region: "germanywestcentral", want: "DE", wantOK: true},
+		{region: "europe-west4", want: "NL", wantOK: true},
+		{region: "EU-WEST-3", want: "FR", wantOK: true},
+		{region: "moon-base-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.region, func(t *testing.T) {
+			got, ok := RegionCountry(tt.region)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("RegionCountry(%q) = %q, %v, want %q, %v", tt.region, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestResidencyData(t *testing.T) {
+	for region, country := range regionCountries {
+		if !isoCountryCodes[country] {
+			t.Errorf("region %s maps to %s, which is not an ISO 3166-1 country code", region, country)
+		}
+		if err := ValidateRegion(region); err != nil {
+			t.Errorf("region %s: %v", region, err)
+		}
+	}
+	for country := range euMemberStates {
+		if !isoCountryCodes[country] {
+			t.Errorf("EU member state %s is not an ISO 3166-1 country code", country)
+		}
+	}
+	if len(euMemberStates) != 27 {
+		t.Errorf("euMemberStates has %d countries, want 27", len(euMemberStates))
+	}
+}
@@ -0,0 +1,107 @@
+package context
+
+import (
+	"container/list"
+	"sync"
+	"sync/atomic"
+)
+
+// sanitizeCacheSize is the number of values each cloud provider keeps
+// sanitized. Tag values repeat heavily across the contexts of a plan, such
+// as owner emails and cost centers, so a small cache serves most lookups.
+const sanitizeCacheSize = 4096
+
+// maxCachedValueLength bounds the values cached, so a few very long values,
+// which are truncated later anyway, cannot hold on to much memory
+const maxCachedValueLength = 512
+
+// sanitizeCache is a least recently used cache of the sanitized values of
+// one cloud provider. It is safe for concurrent use.
+type sanitizeCache struct {
+	sanitize func(string) string
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List // of *sanitizeCacheEntry, most recently used first
+}
+
+// sanitizeCacheEntry is a value and its sanitized form
+type sanitizeCacheEntry struct {
+	value, sanitized string
+}
+
+var (
+	awsSanitizeCache     = newSanitizeCache(func(v string) string { return awsSanitizeRegex.ReplaceAllString(v, "_") })
+	azureSanitizeCache   = newSanitizeCache(func(v string) string { return azureSanitizeRegex.ReplaceAllString(v, "") })
+	gcpSanitizeCache     = newSanitizeCache(sanitizeGCPTagValue)
+	defaultSanitizeCache = newSanitizeCache(func(v string) string { return defaultSanitizeRegex.ReplaceAllString(v, "_") })
+
+	sanitizeLookups   atomic.Int64
+	sanitizeCacheHits atomic.Int64
+)
+
+// newSanitizeCache returns an empty cache of the values sanitized by sanitize
+func newSanitizeCache(sanitize func(string) string) *sanitizeCache {
+	return &sanitizeCache{
+		sanitize: sanitize,
+		entries:  map[string]*list.Element{},
+		order:    list.New(),
+	}
+}
+
+// get returns the sanitized value, from the cache when it holds it
+func (c *sanitizeCache) get(value string) string {
+	sanitizeLookups.Add(1)
+	if len(value) > maxCachedValueLength {
+		return c.sanitize(value)
+	}
+
+	c.mu.Lock()
+	if e, ok := c.entries[value]; ok {
+		c.order.MoveToFront(e)
+		c.mu.Unlock()
+		sanitizeCacheHits.Add(1)
+		return e.Value.(*sanitizeCacheEntry).sanitized
+	}
+	c.mu.Unlock()
+
+	// Sanitize outside the lock; concurrent misses of one value store the
+	// same result
+	sanitized := c.sanitize(value)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[value]; ok {
+		c.order.MoveToFront(e)
+		return sanitized
+	}
+	c.entries[value] = c.order.PushFront(&sanitizeCacheEntry{value: value, sanitized: sanitized})
+	if c.order.Len() > sanitizeCacheSize {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*sanitizeCacheEntry).value)
+	}
+	return sanitized
+}
+
+// clear empties the cache
+func (c *sanitizeCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.entries)
+	c.order.Init()
+}
+
+// SanitizeStats returns the number of tag values sanitized by the cloud
+// providers and the number served from their caches since the process
+// started
+func SanitizeStats() (lookups, cacheHits int64) {
+	return sanitizeLookups.Load(), sanitizeCacheHits.Load()
+}
+
+// ClearSanitizeCache clears the sanitized value caches of the cloud providers
+func ClearSanitizeCache() {
+	for _, c := range []*sanitizeCache{awsSanitizeCache, azureSanitizeCache, gcpSanitizeCache, defaultSanitizeCache} {
+		c.clear()
+	}
+}
@@ -0,0 +1,124 @@
+package context
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestSanitizeCache(t *testing.T) {
+	calls := 0
+	c := newSanitizeCache(func(v string) string {
+		calls++
+		return strings.ToUpper(v)
+	})
+
+	for range 3 {
+		if got := c.get("team-a"); got != "TEAM-A" {
+			t.Errorf("get() = %q, want TEAM-A", got)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("sanitize called %d times, want 1", calls)
+	}
+
+	long := strings.Repeat("a", maxCachedValueLength+1)
+	c.get(long)
+	c.get(long)
+	if calls != 3 {
+		t.Errorf("sanitize called %d times for a long value, want 2", calls-1)
+	}
+	if _, ok := c.entries[long]; ok {
+		t.Error("long value was cached")
+	}
+
+	c.clear()
+	c.get("team-a")
+	if calls != 4 {
+		t.Errorf("sanitize called %d times after clear, want 4", calls)
+	}
+}
+
+func TestSanitizeCache_eviction(t *testing.T) {
+	c := newSanitizeCache(strings.ToUpper)
+
+	c.get("first")
+	for i := range sanitizeCacheSize - 1 {
+		c.get(fmt.Sprintf("value-%d", i))
+	}
+	// Using first keeps it while the next value evicts value-0
+	c.get("first")
+	c.get("next")
+
+	if c.order.Len() != sanitizeCacheSize || len(c.entries) != sanitizeCacheSize {
+		t.Errorf("cache holds %d, %d values, want %d", c.order.Len(), len(c.entries), sanitizeCacheSize)
+	}
+	if _, ok := c.entries["first"]; !ok {
+		t.Error("recently used value was evicted")
+	}
+	if _, ok := c.entries["value-0"]; ok {
+		t.Error("least recently used value was not evicted")
+	}
+}
+
+func TestSanitizeStats(t *testing.T) {
+	ClearSanitizeCache()
+	defer ClearSanitizeCache()
+
+	lookups, hits := SanitizeStats()
+	for _, provider := range []CloudProvider{&AWSProvider{}, &AzureProvider{}, &GCPProvider{}, &DefaultProvider{}} {
+		provider.SanitizeTagValue("Team <A>")
+		provider.SanitizeTagValue("Team <A>")
+	}
+
+	gotLookups, gotHits := SanitizeStats()
+	if gotLookups-lookups != 8 || gotHits-hits != 4 {
+		t.Errorf("SanitizeStats() increased by %d lookups and %d hits, want 8 and 4", gotLookups-lookups, gotHits-hits)
+	}
+}
+
+// TestSanitizeTagValue_cached checks that cached values are sanitized for
+// their own provider
+func TestSanitizeTagValue_cached(t *testing.T) {
+	ClearSanitizeCache()
+	defer ClearSanitizeCache()
+
+	tests := []struct {
+		provider CloudProvider
+		want     string
+	}{
+		{&AWSProvider{}, "Team _A_ _1"},
+		{&AzureProvider{}, "TeamA1"},
+		{&GCPProvider{}, "team--a---1"},
+		{&DefaultProvider{}, "Team _A_ #1"},
+	}
+	for range 2 {
+		for _, tt := range tests {
+			if got := tt.provider.SanitizeTagValue("Team <A> #1"); got != tt.want {
+				t.Errorf("%T.SanitizeTagValue() = %q, want %q", tt.provider, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestSanitizeCache_concurrent(t *testing.T) {
+	c := newSanitizeCache(strings.ToUpper)
+
+	var wg sync.WaitGroup
+	for g := range 8 {
+		wg.Go(func() {
+			for i := range 1000 {
+				value := fmt.Sprintf("value-%d", (g*1000+i)%100)
+				if got := c.get(value); got != strings.ToUpper(value) {
+					t.Errorf("get(%q) = %q", value, got)
+				}
+			}
+		})
+	}
+	wg.Wait()
+
+	if c.order.Len() != 100 || len(c.entries) != 100 {
+		t.Errorf("cache holds %d, %d values, want 100", c.order.Len(), len(c.entries))
+	}
+}
@@ -1,71 +1,252 @@
 package context
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"maps"
-	"sort"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
+	"unicode/utf8"
 )
 
-// TagProcessor handles tag generation and processing
+// DefaultBudgetCurrency is the currency of MonthlyBudget when BudgetCurrency is not set
+const DefaultBudgetCurrency = "USD"
+
+// TagProcessor handles tag generation and processing. Its methods may be
+// called concurrently as long as the fields, including Config, are not
+// modified meanwhile. Read Warnings once the calls have returned.
 type TagProcessor struct {
 	CloudProvider CloudProvider
 	Config        *DataSourceConfig
 	TagPrefix     string
+
+	// Tool versions reported when Config.ToolingTagsEnabled is set
+	TerraformVersion string
+	ProviderVersion  string
+
+	// OwnerGroups are the owner addresses found in a group directory, shown
+	// in the owner tags as selected by OwnerGroupTags
+	OwnerGroups    map[string]Group
+	OwnerGroupTags string
+
+	// TokenizationKey is the HMAC key of the Config.TokenizeFields tokens
+	TokenizationKey []byte
+
+	// GitTimeout bounds the git commands of the source repository tags;
+	// zero uses DefaultGitTimeout
+	GitTimeout time.Duration
+
+	// GitRoot is the directory of the repository of the source repository
+	// tags, such as the parent repository of a module checked out as a
+	// submodule; empty uses the working directory
+	GitRoot string
+
+	// Now is the time compared with Config.LegacyTagsUntil; zero uses the
+	// current time
+	Now time.Time
+
+	// Enrich, when set, receives a copy of the tags without the tag prefix,
+	// after AdditionalTags are merged, and returns tags to merge over them,
+	// such as those of RunEnrichmentProgram. It must be safe for concurrent
+	// use when Process runs concurrently.
+	Enrich func(tags map[string]string) (map[string]string, error)
+
+	// ApplyPolicy, when set, receives a copy of the final tags and returns
+	// derived tags without the tag prefix, such as those of
+	// Policy.Evaluate, or an error rejecting the tags. It must be safe for
+	// concurrent use when Process runs concurrently.
+	ApplyPolicy func(tags map[string]string) (map[string]string, error)
+
+	// Warnings describes the tag values changed by sanitization when
+	// Config.SanitizationMode is warn
+	Warnings []string
+
+	// warningsMu guards Warnings when Process runs concurrently
+	warningsMu sync.Mutex
 }
 
 // DataSourceConfig contains all configuration fields from the data source
 type DataSourceConfig struct {
 	// Naming
-	Namespace       string
-	Name            string
-	Environment     string
-	EnvironmentName string
-	EnvironmentType string
+	Namespace       string   `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	Tenant          string   `json:"tenant,omitempty" yaml:"tenant,omitempty"`
+	Name            string   `json:"name,omitempty" yaml:"name,omitempty"`
+	Environment     string   `json:"environment,omitempty" yaml:"environment,omitempty"`
+	Attributes      []string `json:"attributes" yaml:"attributes,omitempty"`
+	EnvironmentName string   `json:"environment_name,omitempty" yaml:"environment_name,omitempty"`
+	EnvironmentType string   `json:"environment_type,omitempty" yaml:"environment_type,omitempty"`
+	// NameDelimiter joins the name prefix components; nil uses the default "-"
+	NameDelimiter *string `json:"name_delimiter,omitempty" yaml:"name_delimiter,omitempty"`
+	// ListDelimiter joins list values in tags; empty uses the cloud provider delimiter
+	ListDelimiter string `json:"list_join_delimiter,omitempty" yaml:"list_join_delimiter,omitempty"`
+	// LabelOrder lists the name components in output order; empty uses DefaultNameOrder
+	LabelOrder []string `json:"label_order" yaml:"label_order,omitempty"`
+	// PRNumber and EphemeralSuffix are appended to Environment when
+	// EnvironmentType is Ephemeral; PRNumber takes precedence when set
+	PRNumber        int    `json:"pr_number,omitempty" yaml:"pr_number,omitempty"`
+	EphemeralSuffix string `json:"ephemeral_suffix,omitempty" yaml:"ephemeral_suffix,omitempty"`
+	// ReservedWords are checked in addition to DefaultReservedWords
+	ReservedWords []string `json:"reserved_words" yaml:"reserved_words,omitempty"`
+	// ReservedWordAction is error or remove; empty disables the reserved word check
+	ReservedWordAction string `json:"reserved_word_action,omitempty" yaml:"reserved_word_action,omitempty"`
+
+	// Stack Identity
+	StackName string `json:"stack_name,omitempty" yaml:"stack_name,omitempty"`
+	Component string `json:"component,omitempty" yaml:"component,omitempty"`
+
+	// Application: the application, service and tier the resources belong to,
+	// as recorded in the CMDB
+	Application string `json:"application,omitempty" yaml:"application,omitempty"`
+	Service     string `json:"service,omitempty" yaml:"service,omitempty"`
+	// Tier is web, app or data
+	Tier string `json:"tier,omitempty" yaml:"tier,omitempty"`
+
+	// Organization Hierarchy, from the widest to the narrowest unit
+	BusinessUnit string `json:"business_unit,omitempty" yaml:"business_unit,omitempty"`
+	Division     string `json:"division,omitempty" yaml:"division,omitempty"`
+	Portfolio    string `json:"portfolio,omitempty" yaml:"portfolio,omitempty"`
+
+	// SaaS Tenancy: the tenant or customer served by the resources, for
+	// per-tenant cost attribution
+	TenantID string `json:"tenant_id,omitempty" yaml:"tenant_id,omitempty"`
+	Customer string `json:"customer,omitempty" yaml:"customer,omitempty"`
 
 	// Resource Management
-	Enabled      bool
-	Availability string
-	ManagedBy    string
-	DeletionDate string
+	Enabled      bool   `json:"enabled" yaml:"enabled"`
+	Availability string `json:"availability,omitempty" yaml:"availability,omitempty"`
+	ManagedBy    string `json:"managedby,omitempty" yaml:"managedby,omitempty"`
+	DeletionDate string `json:"deletion_date,omitempty" yaml:"deletion_date,omitempty"`
+	// DecommissionApproved allows a DeletionDate on the
+	// DecommissionEnvironmentTypes; it is never inherited
+	DecommissionApproved bool `json:"decommission_approved,omitempty" yaml:"decommission_approved,omitempty"`
+	// LifecycleAction is the action taken at DeletionDate: delete, stop or notify
+	LifecycleAction string `json:"lifecycle_action,omitempty" yaml:"lifecycle_action,omitempty"`
+	// MaintenanceWindow is a five field cron expression or a weekly range
+	// such as sun:03:00-sun:05:00
+	MaintenanceWindow string `json:"maintenance_window,omitempty" yaml:"maintenance_window,omitempty"`
+	PatchGroup        string `json:"patch_group,omitempty" yaml:"patch_group,omitempty"`
+	// Region is the cloud region of the resources, such as eu-west-1
+	Region string `json:"region,omitempty" yaml:"region,omitempty"`
 
 	// Integration
-	PMPlatform      string
-	PMProjectCode   string
-	ITSMPlatform    string
-	ITSMSystemID    string
-	ITSMComponentID string
-	ITSMInstanceID  string
+	PMPlatform      string `json:"pm_platform,omitempty" yaml:"pm_platform,omitempty"`
+	PMProjectCode   string `json:"pm_project_code,omitempty" yaml:"pm_project_code,omitempty"`
+	ITSMPlatform    string `json:"itsm_platform,omitempty" yaml:"itsm_platform,omitempty"`
+	ITSMSystemID    string `json:"itsm_system_id,omitempty" yaml:"itsm_system_id,omitempty"`
+	ITSMComponentID string `json:"itsm_component_id,omitempty" yaml:"itsm_component_id,omitempty"`
+	ITSMInstanceID  string `json:"itsm_instance_id,omitempty" yaml:"itsm_instance_id,omitempty"`
 
 	// Ownership
-	CostCenter    string
-	ProductOwners []string
-	CodeOwners    []string
-	DataOwners    []string
+	CostCenter    string   `json:"cost_center,omitempty" yaml:"cost_center,omitempty"`
+	ProductOwners []string `json:"product_owners" yaml:"product_owners,omitempty"`
+	CodeOwners    []string `json:"code_owners" yaml:"code_owners,omitempty"`
+	DataOwners    []string `json:"data_owners" yaml:"data_owners,omitempty"`
+	// MonthlyBudget is the budget amount in BudgetCurrency; zero means no budget
+	MonthlyBudget  float64 `json:"monthly_budget,omitempty" yaml:"monthly_budget,omitempty"`
+	BudgetCurrency string  `json:"budget_currency,omitempty" yaml:"budget_currency,omitempty"`
 
 	// Data Classification
-	Sensitivity    string
-	DataRegs       []string
-	SecurityReview string
-	PrivacyReview  string
+	Sensitivity    string   `json:"sensitivity,omitempty" yaml:"sensitivity,omitempty"`
+	DataRegs       []string `json:"data_regs" yaml:"data_regs,omitempty"`
+	SecurityReview string   `json:"security_review,omitempty" yaml:"security_review,omitempty"`
+	PrivacyReview  string   `json:"privacy_review,omitempty" yaml:"privacy_review,omitempty"`
+	// DataRetention is an ISO 8601 duration, such as P7Y or P90D
+	DataRetention string `json:"data_retention,omitempty" yaml:"data_retention,omitempty"`
+	// DataResidency lists where the data may reside, as ISO 3166 country or
+	// subdivision codes or EU; Region must be inside it
+	DataResidency []string `json:"data_residency" yaml:"data_residency,omitempty"`
+	// SovereigntyRequirements are the sovereignty frameworks the data falls
+	// under, such as EUCS or SecNumCloud
+	SovereigntyRequirements []string `json:"sovereignty_requirements" yaml:"sovereignty_requirements,omitempty"`
+	// ComplianceProfile names one of ComplianceProfiles, such as pci
+	ComplianceProfile string `json:"compliance_profile,omitempty" yaml:"compliance_profile,omitempty"`
 
 	// Feature Toggles
-	SourceRepoTagsEnabled bool
-	SystemPrefixesEnabled bool
-	NotApplicableEnabled  bool
-	OwnerTagsEnabled      bool
+	SourceRepoTagsEnabled bool `json:"source_repo_tags_enabled" yaml:"source_repo_tags_enabled"`
+	SystemPrefixesEnabled bool `json:"system_prefixes_enabled" yaml:"system_prefixes_enabled"`
+	NotApplicableEnabled  bool `json:"not_applicable_enabled" yaml:"not_applicable_enabled"`
+	OwnerTagsEnabled      bool `json:"owner_tags_enabled" yaml:"owner_tags_enabled"`
+	ToolingTagsEnabled    bool `json:"tooling_tags_enabled" yaml:"tooling_tags_enabled"`
+	RegulationTagsEnabled bool `json:"regulation_tags_enabled" yaml:"regulation_tags_enabled"`
+	// DigestTagEnabled adds a contextdigest tag holding ContextDigest
+	DigestTagEnabled bool `json:"digest_tag_enabled" yaml:"digest_tag_enabled"`
+	// ProvenanceTagsEnabled adds the builder identity of GetProvenanceInfo
+	// and the source repository tags, aligned with SLSA provenance
+	ProvenanceTagsEnabled bool `json:"provenance_tags_enabled" yaml:"provenance_tags_enabled"`
+	// GitRoot, when set, is the directory of the repository of the source
+	// repository tags instead of TagProcessor.GitRoot, such as the parent
+	// repository of a module in a submodule
+	GitRoot string `json:"git_root,omitempty" yaml:"git_root,omitempty"`
+
+	// NAValue replaces the cloud provider N/A placeholder when set
+	NAValue string `json:"na_value_override,omitempty" yaml:"na_value_override,omitempty"`
+	// NAFields limits which NotApplicableTagKeys get the N/A placeholder:
+	// when it has plain keys only those are included, and keys prefixed with
+	// "!" are always excluded. Empty includes every key.
+	NAFields []string `json:"na_fields" yaml:"na_fields,omitempty"`
+
+	// TokenizeFields are TokenizableTagKeys whose values are replaced with
+	// an HMAC token of TagProcessor.TokenizationKey
+	TokenizeFields []string `json:"tokenize_fields" yaml:"tokenize_fields,omitempty"`
+
+	// AzurePolicyInheritanceEnabled omits the tags applied by resource group
+	// Azure Policy inheritance, AzurePolicyInheritedTags or InheritableTagKeys
+	// when empty, from Azure resource tags
+	AzurePolicyInheritanceEnabled bool     `json:"azure_policy_inheritance_enabled" yaml:"azure_policy_inheritance_enabled"`
+	AzurePolicyInheritedTags      []string `json:"azure_policy_inherited_tags" yaml:"azure_policy_inherited_tags,omitempty"`
+
+	// SanitizationMode is fix, warn or error; empty behaves as fix
+	SanitizationMode string `json:"sanitization_mode,omitempty" yaml:"sanitization_mode,omitempty"`
+	// LengthOverflow is truncate, truncate_with_ellipsis_hash or error; empty
+	// behaves as truncate
+	LengthOverflow string `json:"length_overflow,omitempty" yaml:"length_overflow,omitempty"`
+	// TagSchemaVersion pins the generated tag names and defaults to those of
+	// an earlier release; zero uses LatestTagSchemaVersion
+	TagSchemaVersion int `json:"tag_schema_version,omitempty" yaml:"tag_schema_version,omitempty"`
 
 	// Additional Tags
-	AdditionalTags     map[string]string
-	AdditionalDataTags map[string]string
+	AdditionalTags     map[string]string `json:"additional_tags,omitempty" yaml:"additional_tags,omitempty"`
+	AdditionalDataTags map[string]string `json:"additional_data_tags,omitempty" yaml:"additional_data_tags,omitempty"`
+	// AdditionalTypedTags are additional tags with number or bool values,
+	// merged into the tags after AdditionalTags
+	AdditionalTypedTags map[string]TypedTag `json:"additional_typed_tags,omitempty" yaml:"additional_typed_tags,omitempty"`
+	// RawTags are added to the final tags verbatim, without the tag prefix,
+	// sanitization or validation, for vendor-mandated tags that the
+	// sanitizer would change
+	RawTags map[string]string `json:"raw_tags,omitempty" yaml:"raw_tags,omitempty"`
+	// LegacyTagMap maps tag keys, without the tag prefix, to legacy keys
+	// that get a copy of the tag during a taxonomy migration
+	LegacyTagMap map[string]string `json:"legacy_tag_map,omitempty" yaml:"legacy_tag_map,omitempty"`
+	// LegacyTagsUntil is the last day, as YYYY-MM-DD, the legacy tags are
+	// emitted; empty emits them until LegacyTagMap is removed
+	LegacyTagsUntil string `json:"legacy_tags_until,omitempty" yaml:"legacy_tags_until,omitempty"`
+	// CaseInsensitiveKeys merges parent additional tag keys differing only in
+	// case from a child key into the child entry, as Azure treats them as
+	// the same tag
+	CaseInsensitiveKeys bool `json:"case_insensitive_keys" yaml:"case_insensitive_keys"`
+
+	// setToggles holds the toggleFields present in the decoded JSON or YAML
+	// document, so Merge can tell a toggle set to its default value from an
+	// absent one. It is nil for configs built in code.
+	setToggles map[string]bool
 }
 
 // Process generates the main tags map
 func (tp *TagProcessor) Process() (map[string]string, error) {
+	schema, err := GetTagSchema(tp.Config.TagSchemaVersion)
+	if err != nil {
+		return nil, err
+	}
 	tags := make(map[string]string)
 	delimiter := tp.CloudProvider.GetDelimiter()
-	naValue := tp.CloudProvider.GetNAValue()
+	naValue := tp.naValue()
 
 	// Environment and resource tags
 	tp.addTag(tags, "environment", tp.Config.EnvironmentName, naValue)
@@ -74,9 +255,76 @@ func (tp *TagProcessor) Process() (map[string]string, error) {
 	tp.addTag(tags, "availability", tp.Config.Availability, naValue)
 	tp.addTag(tags, "managedby", tp.Config.ManagedBy, naValue)
 	tp.addTag(tags, "deletiondate", tp.Config.DeletionDate, naValue)
+	if tp.Config.LifecycleAction != "" {
+		tags["expiryaction"] = tp.Config.LifecycleAction
+	}
+
+	// Operations automation (only when set)
+	if tp.Config.MaintenanceWindow != "" {
+		tags["maintenancewindow"] = EncodeMaintenanceWindow(tp.Config.MaintenanceWindow, tp.CloudProvider)
+	}
+	if tp.Config.PatchGroup != "" {
+		tags["patchgroup"] = tp.Config.PatchGroup
+	}
+	if tp.Config.Region != "" {
+		tags["region"] = tp.Config.Region
+	}
 
 	// Billing
 	tp.addTag(tags, "costcenter", tp.Config.CostCenter, naValue)
+	if tp.Config.MonthlyBudget > 0 {
+		tags["monthlybudget"] = strconv.FormatFloat(tp.Config.MonthlyBudget, 'f', -1, 64)
+		tags["budgetcurrency"] = tp.Config.BudgetCurrency
+		if tags["budgetcurrency"] == "" {
+			tags["budgetcurrency"] = DefaultBudgetCurrency
+		}
+	}
+
+	// Tenancy (only when set)
+	if tp.Config.Tenant != "" {
+		tags["tenant"] = tp.Config.Tenant
+	}
+	if len(tp.Config.Attributes) > 0 {
+		tags["attributes"] = tp.joinList(tp.Config.Attributes)
+	}
+
+	// Stack identity (only when set)
+	if tp.Config.StackName != "" {
+		tags["stack"] = tp.Config.StackName
+	}
+	if tp.Config.Component != "" {
+		tags["component"] = tp.Config.Component
+	}
+
+	// Application (only when set)
+	if tp.Config.Application != "" {
+		tags["application"] = tp.Config.Application
+	}
+	if tp.Config.Service != "" {
+		tags["service"] = tp.Config.Service
+	}
+	if tp.Config.Tier != "" {
+		tags["tier"] = tp.Config.Tier
+	}
+
+	// Organization hierarchy (only when set)
+	if tp.Config.BusinessUnit != "" {
+		tags["businessunit"] = tp.Config.BusinessUnit
+	}
+	if tp.Config.Division != "" {
+		tags["division"] = tp.Config.Division
+	}
+	if tp.Config.Portfolio != "" {
+		tags["portfolio"] = tp.Config.Portfolio
+	}
+
+	// SaaS tenancy (only when set)
+	if tp.Config.TenantID != "" {
+		tags["tenantid"] = tp.Config.TenantID
+	}
+	if tp.Config.Customer != "" {
+		tags["customer"] = tp.Config.Customer
+	}
 
 	// Project Management
 	if tp.Config.SystemPrefixesEnabled && tp.Config.PMPlatform != "" && tp.Config.PMProjectCode != "" {
@@ -105,14 +353,16 @@ func (tp *TagProcessor) Process() (map[string]string, error) {
 	// Ownership (if enabled)
 	if tp.Config.OwnerTagsEnabled {
 		if len(tp.Config.ProductOwners) > 0 {
-			tags["productowners"] = strings.Join(tp.Config.ProductOwners, delimiter)
-		} else if tp.Config.NotApplicableEnabled {
+			tags["productowners"] = tp.ownerList(tp.Config.ProductOwners)
+			tp.addOwnerMembersTag(tags, "productownersmembers", tp.Config.ProductOwners)
+		} else if tp.notApplicable("productowners") {
 			tags["productowners"] = naValue
 		}
 
 		if len(tp.Config.CodeOwners) > 0 {
-			tags["codeowners"] = strings.Join(tp.Config.CodeOwners, delimiter)
-		} else if tp.Config.NotApplicableEnabled {
+			tags["codeowners"] = tp.ownerList(tp.Config.CodeOwners)
+			tp.addOwnerMembersTag(tags, "codeownersmembers", tp.Config.CodeOwners)
+		} else if tp.notApplicable("codeowners") {
 			tags["codeowners"] = naValue
 		}
 	}
@@ -121,71 +371,671 @@ func (tp *TagProcessor) Process() (map[string]string, error) {
 	tp.addTag(tags, "securityreview", tp.Config.SecurityReview, naValue)
 	tp.addTag(tags, "privacyreview", tp.Config.PrivacyReview, naValue)
 
-	// Git repository tags (if enabled)
-	if tp.Config.SourceRepoTagsEnabled {
-		gitInfo, err := GetGitInfo()
+	// Git repository tags (if enabled), which provenance includes
+	if tp.Config.SourceRepoTagsEnabled || tp.Config.ProvenanceTagsEnabled {
+		gitInfo, err := GetGitInfoInDir(tp.gitRoot(), tp.gitTimeout())
 		if err == nil && gitInfo != nil {
 			tp.addTag(tags, "sourcerepo", gitInfo.RepoURL, naValue)
 			tp.addTag(tags, "sourcecommit", gitInfo.CommitHash, naValue)
 		}
 	}
 
+	// Builder identity tags (if enabled)
+	if tp.Config.ProvenanceTagsEnabled {
+		tp.addProvenanceTags(tags)
+	}
+
+	// Tooling version tags (if enabled)
+	if tp.Config.ToolingTagsEnabled {
+		tp.addTag(tags, "terraformversion", tp.TerraformVersion, naValue)
+		tp.addTag(tags, "contextproviderversion", tp.ProviderVersion, naValue)
+	}
+
+	// Generated tags as named by the pinned tag schema version
+	schema.apply(tags)
+
 	// Merge additional tags
+	if err := tp.checkReservedKeys(tp.Config.AdditionalTags); err != nil {
+		return nil, err
+	}
 	maps.Copy(tags, tp.Config.AdditionalTags)
+	typedTags, err := tp.typedTagValues()
+	if err != nil {
+		return nil, err
+	}
+	if err := tp.checkReservedKeys(typedTags); err != nil {
+		return nil, err
+	}
+	maps.Copy(tags, typedTags)
 
-	// Apply tag prefix and sanitization
-	prefixedTags := make(map[string]string)
-	for k, v := range tags {
-		key := tp.TagPrefix + k
-		value := tp.CloudProvider.SanitizeTagValue(v)
+	// Merge the tags of external lookups
+	if tp.Enrich != nil {
+		enriched, err := tp.Enrich(maps.Clone(tags))
+		if err != nil {
+			return nil, err
+		}
+		maps.Copy(tags, enriched)
+	}
 
-		// Truncate if necessary
-		maxLen := tp.CloudProvider.GetMaxTagLength()
-		if len(value) > maxLen {
-			value = value[:maxLen]
+	if err := tp.tokenize(tags); err != nil {
+		return nil, err
+	}
+	finalTags, err := tp.finalizeTags(tags)
+	if err != nil {
+		return nil, err
+	}
+
+	// Policies see the final tags; derived tags are sanitized like the others
+	if tp.ApplyPolicy != nil {
+		derived, err := tp.ApplyPolicy(maps.Clone(finalTags))
+		if err != nil {
+			return nil, err
 		}
+		derivedTags, err := tp.finalizeTags(derived)
+		if err != nil {
+			return nil, err
+		}
+		maps.Copy(finalTags, derivedTags)
+	}
 
-		prefixedTags[key] = value
+	// Legacy keys carry the final values, so reports keyed on them match
+	tp.addLegacyTags(finalTags)
+	tp.addPatchGroupTag(finalTags)
+
+	// Raw tags bypass the tag prefix and sanitization, replacing any tag of
+	// the same key
+	maps.Copy(finalTags, tp.Config.RawTags)
+
+	// The digest covers the final values, so it is added last, cut to the
+	// cloud provider limit since GCP labels hold 63 characters
+	if tp.Config.DigestTagEnabled {
+		finalTags[tp.TagPrefix+digestTagKey] = truncateTagValue(tp.ContextDigest(finalTags), tp.CloudProvider.GetMaxTagLength())
 	}
 
-	return prefixedTags, nil
+	// Raw tags bypass validation too, so their collisions are only reported
+	collisions := slices.DeleteFunc(tp.TagKeyCollisions(finalTags), func(c TagKeyCollision) bool {
+		return slices.Contains(c.Sources, "raw_tags")
+	})
+	if err := tp.checkKeyCollisions(collisions); err != nil {
+		return nil, err
+	}
+	if err := tp.checkInvalidKeys(finalTags); err != nil {
+		return nil, err
+	}
+	return finalTags, nil
+}
+
+// digestTagKey is the key, without the tag prefix, of the digest tag
+const digestTagKey = "contextdigest"
+
+// ContextDigest returns the hex SHA-256 of the tags generated by Process,
+// leaving out the contextdigest tag, as computed by TagDigest. Drift
+// detection can recompute it over the prefixed tags of a resource to find
+// context-managed tags modified out of band.
+func (tp *TagProcessor) ContextDigest(tags map[string]string) string {
+	if _, ok := tags[tp.TagPrefix+digestTagKey]; ok {
+		tags = maps.Clone(tags)
+		delete(tags, tp.TagPrefix+digestTagKey)
+	}
+	return TagDigest(tags)
 }
 
 // ProcessDataTags generates data-specific tags
 func (tp *TagProcessor) ProcessDataTags() (map[string]string, error) {
+	schema, err := GetTagSchema(tp.Config.TagSchemaVersion)
+	if err != nil {
+		return nil, err
+	}
 	tags := make(map[string]string)
-	delimiter := tp.CloudProvider.GetDelimiter()
-	naValue := tp.CloudProvider.GetNAValue()
+	naValue := tp.naValue()
 
 	// Data classification
 	tp.addTag(tags, "sensitivity", tp.Config.Sensitivity, naValue)
 
 	if len(tp.Config.DataRegs) > 0 {
-		tags["dataregulations"] = strings.Join(tp.Config.DataRegs, delimiter)
-	} else if tp.Config.NotApplicableEnabled {
+		tags["dataregulations"] = tp.joinList(tp.Config.DataRegs)
+	} else if tp.notApplicable("dataregulations") {
 		tags["dataregulations"] = naValue
 	}
 
+	// Per-regulation tags for queries that cannot split the joined list
+	if tp.Config.RegulationTagsEnabled {
+		for _, reg := range tp.Config.DataRegs {
+			if key := RegulationTagKey(reg); key != "" {
+				tags[key] = "true"
+			}
+		}
+	}
+
+	if tp.Config.DataRetention != "" {
+		tags["dataretention"] = tp.Config.DataRetention
+	}
+
+	// Data residency and sovereignty (only when set)
+	if len(tp.Config.DataResidency) > 0 {
+		tags["dataresidency"] = tp.joinList(tp.Config.DataResidency)
+	}
+	if len(tp.Config.SovereigntyRequirements) > 0 {
+		tags["sovereignty"] = tp.joinList(tp.Config.SovereigntyRequirements)
+	}
+
 	// Data ownership
 	if tp.Config.OwnerTagsEnabled && len(tp.Config.DataOwners) > 0 {
-		tags["dataowners"] = strings.Join(tp.Config.DataOwners, delimiter)
-	} else if tp.Config.NotApplicableEnabled {
+		tags["dataowners"] = tp.ownerList(tp.Config.DataOwners)
+		tp.addOwnerMembersTag(tags, "dataownersmembers", tp.Config.DataOwners)
+	} else if tp.notApplicable("dataowners") {
 		tags["dataowners"] = naValue
 	}
 
+	// Generated tags as named by the pinned tag schema version
+	schema.apply(tags)
+
 	// Merge additional data tags
+	if err := tp.checkReservedKeys(tp.Config.AdditionalDataTags); err != nil {
+		return nil, err
+	}
 	maps.Copy(tags, tp.Config.AdditionalDataTags)
 
-	// Apply tag prefix and sanitization
+	if err := tp.tokenize(tags); err != nil {
+		return nil, err
+	}
+	finalTags, err := tp.finalizeTags(tags)
+	if err != nil {
+		return nil, err
+	}
+	tp.addLegacyTags(finalTags)
+	if err := tp.checkKeyCollisions(tp.DataTagKeyCollisions(finalTags)); err != nil {
+		return nil, err
+	}
+	if err := tp.checkInvalidKeys(finalTags); err != nil {
+		return nil, err
+	}
+	return finalTags, nil
+}
+
+// UnprefixedTags returns tags keyed without the tag prefix, such as
+// environment instead of bc-environment, for tools that need the canonical
+// key. Keys without the prefix are kept as they are.
+func (tp *TagProcessor) UnprefixedTags(tags map[string]string) map[string]string {
+	unprefixed := make(map[string]string, len(tags))
+	for key, value := range tags {
+		unprefixed[strings.TrimPrefix(key, tp.TagPrefix)] = value
+	}
+	return unprefixed
+}
+
+// FilterTags returns the tags whose key, without the tag prefix, matches any
+// of patterns. A pattern enclosed in slashes, such as /owners$/, is a regular
+// expression; any other pattern is a glob in path.Match syntax.
+func (tp *TagProcessor) FilterTags(tags map[string]string, patterns []string) (map[string]string, error) {
+	matchers := make([]func(string) bool, 0, len(patterns))
+	for _, pattern := range patterns {
+		matcher, err := tagKeyMatcher(pattern)
+		if err != nil {
+			return nil, err
+		}
+		matchers = append(matchers, matcher)
+	}
+
+	filtered := make(map[string]string)
+	for key, value := range tags {
+		name := strings.TrimPrefix(key, tp.TagPrefix)
+		if slices.ContainsFunc(matchers, func(match func(string) bool) bool { return match(name) }) {
+			filtered[key] = value
+		}
+	}
+	return filtered, nil
+}
+
+// tagKeyMatcher compiles a FilterTags pattern.
+func tagKeyMatcher(pattern string) (func(string) bool, error) {
+	if expr, ok := strings.CutPrefix(pattern, "/"); ok && len(expr) > 0 && strings.HasSuffix(expr, "/") {
+		re, err := regexp.Compile(strings.TrimSuffix(expr, "/"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid tag filter %q: %w", pattern, err)
+		}
+		return re.MatchString, nil
+	}
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, fmt.Errorf("invalid tag filter %q: %w", pattern, err)
+	}
+	return func(name string) bool {
+		matched, _ := path.Match(pattern, name)
+		return matched
+	}, nil
+}
+
+// OwnerTagKeys are the ownership tag keys, without the tag prefix, checked
+// by HasTags for the data source has_owner_tags output.
+var OwnerTagKeys = []string{"productowners", "codeowners", "dataowners"}
+
+// SourceTagKeys are the source repository tag keys, without the tag prefix.
+var SourceTagKeys = []string{"sourcerepo", "sourcecommit"}
+
+// HasTags reports whether tags hold any of keys, without the tag prefix, with
+// a value that is neither empty nor the N/A placeholder.
+func (tp *TagProcessor) HasTags(tags map[string]string, keys []string) bool {
+	naValue := tp.CloudProvider.SanitizeTagValue(tp.naValue())
+	return slices.ContainsFunc(keys, func(key string) bool {
+		value := tags[tp.TagPrefix+key]
+		return value != "" && value != naValue
+	})
+}
+
+// TagCompliance summarizes whether tags meet common tagging policies, for
+// Terraform check blocks.
+type TagCompliance struct {
+	// OwnersPresent is true when a product, code or data owner is set
+	OwnersPresent bool
+	// CostCenterPresent is true when the costcenter tag is set
+	CostCenterPresent bool
+	// ExpirySetForEphemeral is true unless an Ephemeral environment has no
+	// deletiondate tag
+	ExpirySetForEphemeral bool
+	// WithinTagLimits is true when neither tags nor dataTags exceed the
+	// cloud provider's maximum number of tags on a resource
+	WithinTagLimits bool
+}
+
+// Compliance checks tags and dataTags, as returned by Process and
+// ProcessDataTags, against common tagging policies. Not applicable values
+// count as not set.
+func (tp *TagProcessor) Compliance(tags, dataTags map[string]string) TagCompliance {
+	maxTags := MaxTagCount(tp.CloudProvider)
+	return TagCompliance{
+		OwnersPresent:         tp.HasTags(tags, OwnerTagKeys) || tp.HasTags(dataTags, OwnerTagKeys),
+		CostCenterPresent:     tp.HasTags(tags, []string{"costcenter"}),
+		ExpirySetForEphemeral: tp.Config.EnvironmentType != "Ephemeral" || tp.HasTags(tags, []string{"deletiondate"}),
+		WithinTagLimits:       len(tags) <= maxTags && len(dataTags) <= maxTags,
+	}
+}
+
+// RequiredTagKeys are the tag keys, without the tag prefix, that every
+// resource should carry. Other generated and additional tags are optional.
+var RequiredTagKeys = []string{"environment", "availability", "managedby", "deletiondate", "expiryaction", "costcenter"}
+
+// SplitRequiredTags splits tags into the RequiredTagKeys and the remaining
+// optional tags, so optional tags can be left off resources with tight tag
+// count limits
+func (tp *TagProcessor) SplitRequiredTags(tags map[string]string) (map[string]string, map[string]string) {
+	required := make(map[string]string)
+	optional := make(map[string]string)
+
+	for key, value := range tags {
+		if name, ok := strings.CutPrefix(key, tp.TagPrefix); ok && slices.Contains(RequiredTagKeys, name) {
+			required[key] = value
+		} else {
+			optional[key] = value
+		}
+	}
+
+	return required, optional
+}
+
+// InheritableTagKeys are the tag keys, without the tag prefix, that describe
+// a whole environment or stack rather than a single resource, so they can be
+// set once at container scope (Azure resource group, GCP project or AWS
+// account) and inherited by the resources in it
+var InheritableTagKeys = []string{
+	"environment", "managedby", "deletiondate", "expiryaction",
+	"costcenter", "monthlybudget", "budgetcurrency", "tenant", "stack",
+	"application", "businessunit", "division", "portfolio", "tenantid", "customer", "region",
+	"projectmgmtid", "systemid", "productowners",
+}
+
+// InheritableTags returns the InheritableTagKeys present in tags
+func (tp *TagProcessor) InheritableTags(tags map[string]string) map[string]string {
+	inheritable := make(map[string]string)
+	for _, key := range InheritableTagKeys {
+		if value, ok := tags[tp.TagPrefix+key]; ok {
+			inheritable[tp.TagPrefix+key] = value
+		}
+	}
+	return inheritable
+}
+
+// ProviderDefaultTagKeys are the tag keys, without the tag prefix, to set in
+// the AWS provider default_tags: the organization and stack InheritableTagKeys,
+// which are the same for every resource of a stack and stable across applies.
+// The deletion date, expiry action, budget, customer and region tags may vary
+// per resource or over time, so they stay on the resources.
+var ProviderDefaultTagKeys = []string{
+	"environment", "managedby", "costcenter", "tenant", "tenantid", "stack",
+	"application", "businessunit", "division", "portfolio",
+	"projectmgmtid", "systemid", "productowners",
+}
+
+// ProviderDefaultTags returns the ProviderDefaultTagKeys present in tags
+func (tp *TagProcessor) ProviderDefaultTags(tags map[string]string) map[string]string {
+	defaultTags := make(map[string]string)
+	for _, key := range ProviderDefaultTagKeys {
+		if value, ok := tags[tp.TagPrefix+key]; ok {
+			defaultTags[tp.TagPrefix+key] = value
+		}
+	}
+	return defaultTags
+}
+
+// DefaultTagConflicts returns the sorted tag keys that Config.AdditionalTags
+// sets and that are also in defaultTags, the ProviderDefaultTags fed to the
+// AWS provider default_tags. Setting such a key on a resource as well causes perpetual
+// diffs, so nil is returned for other cloud providers.
+func (tp *TagProcessor) DefaultTagConflicts(defaultTags map[string]string) []string {
+	if _, ok := tp.CloudProvider.(*AWSProvider); !ok {
+		return nil
+	}
+
+	var conflicts []string
+	for key := range tp.Config.AdditionalTags {
+		if _, ok := defaultTags[tp.TagPrefix+key]; ok {
+			conflicts = append(conflicts, tp.TagPrefix+key)
+		}
+	}
+	slices.Sort(conflicts)
+	return conflicts
+}
+
+// ReservedTagKeys returns the sorted tag keys, with the tag prefix, that
+// Config.AdditionalTags and Config.AdditionalDataTags set with a prefix
+// reserved by the cloud provider, such as aws: or goog-. Such keys fail or
+// behave unexpectedly at apply time; Process and ProcessDataTags reject them
+// when Config.SanitizationMode is error. Only the keys as emitted, with the
+// tag prefix, are checked: with a tag prefix that is not reserved, such as
+// the default bc-, aws:team becomes bc-aws:team, which the cloud provider
+// accepts, so no key is reserved.
+func (tp *TagProcessor) ReservedTagKeys() []string {
+	keys := append(tp.reservedKeys(tp.Config.AdditionalTags), tp.reservedKeys(tp.Config.AdditionalDataTags)...)
+	slices.Sort(keys)
+	return slices.Compact(keys)
+}
+
+// reservedKeys returns the keys of tags, with the tag prefix, using a prefix
+// reserved by the cloud provider
+func (tp *TagProcessor) reservedKeys(tags map[string]string) []string {
+	var reserved []string
+	for key := range tags {
+		if ReservedTagKeyPrefix(tp.CloudProvider, tp.TagPrefix+key) != "" {
+			reserved = append(reserved, tp.TagPrefix+key)
+		}
+	}
+	slices.Sort(reserved)
+	return reserved
+}
+
+// checkReservedKeys rejects the keys of tags using a reserved prefix when
+// Config.SanitizationMode is error
+func (tp *TagProcessor) checkReservedKeys(tags map[string]string) error {
+	if tp.Config.SanitizationMode != "error" {
+		return nil
+	}
+	if reserved := tp.reservedKeys(tags); len(reserved) > 0 {
+		return fmt.Errorf("tag %s uses the prefix %q reserved by the cloud provider", reserved[0], ReservedTagKeyPrefix(tp.CloudProvider, reserved[0]))
+	}
+	return nil
+}
+
+// TagKeyCollision is a set of different tag keys that a cloud provider
+// treats as the same key, so only one of them ends up on a resource
+type TagKeyCollision struct {
+	// Keys are the colliding keys, with the tag prefix, sorted
+	Keys []string
+	// Sources are where each of Keys comes from: additional_tags,
+	// additional_data_tags or raw_tags for the keys set there, and generated
+	// for the others, including enriched and policy tags
+	Sources []string
+}
+
+// String describes the collision, listing the keys with their sources
+func (c TagKeyCollision) String() string {
+	keys := make([]string, len(c.Keys))
+	for i, key := range c.Keys {
+		keys[i] = fmt.Sprintf("%s (%s)", key, c.Sources[i])
+	}
+	return strings.Join(keys, ", ")
+}
+
+// TagKeyCollisions returns the keys of tags, as returned by Process, that
+// collide for the cloud provider once normalized by normalizeTagKey: Azure
+// tag names are case-insensitive, and GCP label keys are lowercase with
+// spaces and other invalid characters fixed to underscores, so Cost Center
+// and cost_center collide there. Process rejects collisions when
+// Config.SanitizationMode is error.
+func (tp *TagProcessor) TagKeyCollisions(tags map[string]string) []TagKeyCollision {
+	return tp.keyCollisions(tags, tp.Config.AdditionalTags, "additional_tags", tp.Config.RawTags)
+}
+
+// DataTagKeyCollisions is TagKeyCollisions for the tags returned by
+// ProcessDataTags
+func (tp *TagProcessor) DataTagKeyCollisions(dataTags map[string]string) []TagKeyCollision {
+	return tp.keyCollisions(dataTags, tp.Config.AdditionalDataTags, "additional_data_tags", nil)
+}
+
+// keyCollisions returns the collisions of the keys of tags, with the keys
+// of additional reported as coming from source and those of raw as coming
+// from raw_tags
+func (tp *TagProcessor) keyCollisions(tags, additional map[string]string, source string, raw map[string]string) []TagKeyCollision {
+	normalized := map[string][]string{}
+	for _, key := range slices.Sorted(maps.Keys(tags)) {
+		normal := tp.normalizeTagKey(key)
+		normalized[normal] = append(normalized[normal], key)
+	}
+
+	var collisions []TagKeyCollision
+	for _, normal := range slices.Sorted(maps.Keys(normalized)) {
+		keys := normalized[normal]
+		if len(keys) < 2 {
+			continue
+		}
+		collision := TagKeyCollision{Keys: keys}
+		for _, key := range keys {
+			if _, ok := raw[key]; ok {
+				collision.Sources = append(collision.Sources, "raw_tags")
+			} else if _, ok := additional[strings.TrimPrefix(key, tp.TagPrefix)]; ok {
+				collision.Sources = append(collision.Sources, source)
+			} else {
+				collision.Sources = append(collision.Sources, "generated")
+			}
+		}
+		collisions = append(collisions, collision)
+	}
+	return collisions
+}
+
+// normalizeTagKey returns the key that key is stored as, or fixed to, by
+// the cloud provider: lowercase on Azure, whose tag names are
+// case-insensitive, and lowercase with characters other than letters,
+// digits, underscores and hyphens replaced by underscores on GCP, whose label
+// keys allow no others. Other cloud providers keep keys as they are.
+func (tp *TagProcessor) normalizeTagKey(key string) string {
+	switch tp.CloudProvider.(type) {
+	case *AzureProvider:
+		return strings.ToLower(key)
+	case *GCPProvider:
+		return gcpSanitizeRegex.ReplaceAllString(strings.ToLower(key), "_")
+	default:
+		return key
+	}
+}
+
+// InvalidTagKeys returns the sorted keys of tags, as returned by Process or
+// ProcessDataTags, that the cloud provider rejects according to
+// ValidateTagKey, such as keys with spaces or capitals on GCP. The keys of
+// Config.RawTags, which bypass validation, are left out. Process and
+// ProcessDataTags reject invalid keys when Config.SanitizationMode is error.
+func (tp *TagProcessor) InvalidTagKeys(tags map[string]string) []string {
+	var invalid []string
+	for key := range tags {
+		if _, ok := tp.Config.RawTags[key]; !ok && !tp.CloudProvider.ValidateTagKey(key) {
+			invalid = append(invalid, key)
+		}
+	}
+	slices.Sort(invalid)
+	return invalid
+}
+
+// checkInvalidKeys rejects the keys of tags the cloud provider rejects when
+// Config.SanitizationMode is error
+func (tp *TagProcessor) checkInvalidKeys(tags map[string]string) error {
+	if tp.Config.SanitizationMode != "error" {
+		return nil
+	}
+	if invalid := tp.InvalidTagKeys(tags); len(invalid) > 0 {
+		return fmt.Errorf("tag key %q is not valid for the cloud provider", invalid[0])
+	}
+	return nil
+}
+
+// checkKeyCollisions rejects collisions when Config.SanitizationMode is
+// error
+func (tp *TagProcessor) checkKeyCollisions(collisions []TagKeyCollision) error {
+	if tp.Config.SanitizationMode != "error" || len(collisions) == 0 {
+		return nil
+	}
+	return fmt.Errorf("tag keys %s collide for the cloud provider, which keeps only one of them", collisions[0])
+}
+
+// OmitPolicyInheritedTags returns tags without the keys that Azure Policy
+// copies from the resource group, so Terraform and the policy do not fight
+// over them on every apply. Tags are returned unchanged unless the cloud
+// provider is Azure and Config.AzurePolicyInheritanceEnabled is set.
+func (tp *TagProcessor) OmitPolicyInheritedTags(tags map[string]string) map[string]string {
+	if _, ok := tp.CloudProvider.(*AzureProvider); !ok || !tp.Config.AzurePolicyInheritanceEnabled {
+		return tags
+	}
+
+	keys := tp.Config.AzurePolicyInheritedTags
+	if len(keys) == 0 {
+		keys = InheritableTagKeys
+	}
+
+	omitted := maps.Clone(tags)
+	for _, key := range keys {
+		delete(omitted, tp.TagPrefix+key)
+	}
+	return omitted
+}
+
+// FOCUSTagAliases maps generated tag keys, without the tag prefix, to the
+// FinOps FOCUS custom column names used when normalizing cost exports
+var FOCUSTagAliases = map[string]string{
+	"environment":    "x_Environment",
+	"costcenter":     "x_CostCenter",
+	"monthlybudget":  "x_Budget",
+	"budgetcurrency": "x_BudgetCurrency",
+	"productowners":  "x_Owner",
+	"codeowners":     "x_CodeOwner",
+	"projectmgmtid":  "x_Project",
+	"systemid":       "x_Application",
+	"componentid":    "x_ApplicationComponent",
+	"tenant":         "x_Tenant",
+	"stack":          "x_Stack",
+	"component":      "x_Component",
+	"businessunit":   "x_BusinessUnit",
+	"division":       "x_Division",
+	"portfolio":      "x_Portfolio",
+	"tenantid":       "x_TenantId",
+	"customer":       "x_Customer",
+	"managedby":      "x_ManagedBy",
+}
+
+// FOCUSTags returns the values of tags that have a FOCUSTagAliases entry,
+// keyed by FOCUS column name. Not applicable values are left out.
+func (tp *TagProcessor) FOCUSTags(tags map[string]string) map[string]string {
+	focus := make(map[string]string)
+	naValue := tp.CloudProvider.SanitizeTagValue(tp.naValue())
+
+	for key, alias := range FOCUSTagAliases {
+		if value, ok := tags[tp.TagPrefix+key]; ok && value != "" && value != naValue {
+			focus[alias] = value
+		}
+	}
+
+	return focus
+}
+
+// IAM condition keys for attribute-based access control on tags
+const (
+	IAMResourceTag = "aws:ResourceTag"
+	IAMRequestTag  = "aws:RequestTag"
+)
+
+// IAMConditionTagKeys are the tag keys, without the tag prefix, included in
+// IAM tag conditions. Values that change on every apply, such as the source
+// commit, are left out so the conditions stay stable.
+var IAMConditionTagKeys = []string{"environment", "costcenter", "tenant", "tenantid", "stack", "component", "projectmgmtid", "systemid"}
+
+// IAMTagCondition renders the IAMConditionTagKeys present in tags as an IAM
+// policy condition block, such as
+// {"StringEquals":{"aws:ResourceTag/bc-costcenter":"cc-100"}}, using
+// conditionKey (IAMResourceTag or IAMRequestTag). Not applicable values are
+// left out and "{}" is returned when no tags apply.
+func (tp *TagProcessor) IAMTagCondition(tags map[string]string, conditionKey string) (string, error) {
+	naValue := tp.CloudProvider.SanitizeTagValue(tp.naValue())
+	equals := make(map[string]string)
+
+	for _, key := range IAMConditionTagKeys {
+		key = tp.TagPrefix + key
+		if value, ok := tags[key]; ok && value != "" && value != naValue {
+			equals[conditionKey+"/"+key] = value
+		}
+	}
+
+	condition := map[string]map[string]string{}
+	if len(equals) > 0 {
+		condition["StringEquals"] = equals
+	}
+
+	out, err := json.Marshal(condition)
+	if err != nil {
+		return "", fmt.Errorf("failed to render IAM tag condition: %w", err)
+	}
+	return string(out), nil
+}
+
+// AWSBudgetsFilter returns cost filters for an aws_budgets_budget that match
+// resources carrying the cost center tag from tags, keyed by filter name.
+// The result is empty when tags has no cost center value.
+func (tp *TagProcessor) AWSBudgetsFilter(tags map[string]string) map[string][]string {
+	filter := make(map[string][]string)
+
+	costCenter, ok := tags[tp.TagPrefix+"costcenter"]
+	if !ok || costCenter == "" || costCenter == tp.CloudProvider.SanitizeTagValue(tp.naValue()) {
+		return filter
+	}
+
+	filter["TagKeyValue"] = []string{fmt.Sprintf("user:%scostcenter$%s", tp.TagPrefix, costCenter)}
+	return filter
+}
+
+// finalizeTags applies the tag prefix, sanitization and truncation to tags.
+// Values changed by sanitization are rejected when Config.SanitizationMode
+// is error and recorded in Warnings when it is warn. Values longer than the
+// cloud provider limit are handled according to Config.LengthOverflow.
+func (tp *TagProcessor) finalizeTags(tags map[string]string) (map[string]string, error) {
 	prefixedTags := make(map[string]string)
-	for k, v := range tags {
+	maxLen := tp.CloudProvider.GetMaxTagLength()
+	for _, k := range slices.Sorted(maps.Keys(tags)) {
 		key := tp.TagPrefix + k
-		value := tp.CloudProvider.SanitizeTagValue(v)
+		value := tp.CloudProvider.SanitizeTagValue(tags[k])
+
+		if value != tags[k] {
+			switch tp.Config.SanitizationMode {
+			case "error":
+				return nil, fmt.Errorf("tag %s value '%s' contains characters not allowed by the cloud provider", key, tags[k])
+			case "warn":
+				tp.addWarning(fmt.Sprintf("tag %s value '%s' was sanitized to '%s'", key, tags[k], value))
+			}
+		}
 
-		// Truncate if necessary
-		maxLen := tp.CloudProvider.GetMaxTagLength()
 		if len(value) > maxLen {
-			value = value[:maxLen]
+			switch tp.Config.LengthOverflow {
+			case "error":
+				return nil, fmt.Errorf("tag %s value is %d characters, exceeding the cloud provider limit of %d", key, len(value), maxLen)
+			case "truncate_with_ellipsis_hash":
+				value = tp.CloudProvider.SanitizeTagValue(truncateTagValueWithHash(value, maxLen))
+			default:
+				value = truncateTagValue(value, maxLen)
+			}
 		}
 
 		prefixedTags[key] = value
@@ -194,58 +1044,317 @@ func (tp *TagProcessor) ProcessDataTags() (map[string]string, error) {
 	return prefixedTags, nil
 }
 
-// addTag adds a tag if value is not empty or N/A is enabled
+// RegulationTagKey returns the per-regulation tag key, without the tag
+// prefix, for a data regulation: reg- followed by the regulation lowercased
+// with other characters replaced by hyphens, so "PCI DSS" becomes
+// reg-pci-dss. It returns "" when the regulation has no letters or digits.
+func RegulationTagKey(regulation string) string {
+	slug := strings.Trim(slugInvalidChars.ReplaceAllString(strings.ToLower(regulation), "-"), "-")
+	if slug == "" {
+		return ""
+	}
+	return "reg-" + slug
+}
+
+// NotApplicableTagKeys are the tag keys, without the tag prefix, that get the
+// N/A placeholder when their value is empty
+var NotApplicableTagKeys = []string{
+	"environment", "availability", "managedby", "deletiondate", "costcenter",
+	"projectmgmtid", "systemid", "componentid", "instanceid",
+	"productowners", "codeowners", "securityreview", "privacyreview",
+	"sourcerepo", "sourcecommit", "terraformversion", "contextproviderversion",
+	"sensitivity", "dataregulations", "dataowners",
+}
+
+// addWarning appends warning to Warnings
+func (tp *TagProcessor) addWarning(warning string) {
+	tp.warningsMu.Lock()
+	defer tp.warningsMu.Unlock()
+	tp.Warnings = append(tp.Warnings, warning)
+}
+
+// addTag adds a tag if value is not empty or N/A is enabled for key
 func (tp *TagProcessor) addTag(tags map[string]string, key, value, naValue string) {
 	if value != "" {
 		tags[key] = value
-	} else if tp.Config.NotApplicableEnabled {
+	} else if tp.notApplicable(key) {
 		tags[key] = naValue
 	}
 }
 
+// ListDelimiter returns the delimiter joining list values in tags,
+// Config.ListDelimiter when set
+func (tp *TagProcessor) ListDelimiter() string {
+	if tp.Config.ListDelimiter != "" {
+		return tp.Config.ListDelimiter
+	}
+	return tp.CloudProvider.GetDelimiter()
+}
+
+// joinList joins values with ListDelimiter. Occurrences of the delimiter
+// within a value are replaced with an underscore, or a hyphen when the
+// delimiter is an underscore, so the result splits back into the same
+// number of values.
+func (tp *TagProcessor) joinList(values []string) string {
+	delimiter := tp.ListDelimiter()
+	substitute := "_"
+	if delimiter == "_" {
+		substitute = "-"
+	}
+
+	escaped := make([]string, len(values))
+	for i, value := range values {
+		escaped[i] = strings.ReplaceAll(value, delimiter, substitute)
+	}
+	return strings.Join(escaped, delimiter)
+}
+
+// ownerList joins owner addresses, replacing those of groups with the group
+// display name when OwnerGroupTags is display_name
+func (tp *TagProcessor) ownerList(owners []string) string {
+	if tp.OwnerGroupTags != OwnerGroupTagsDisplayName {
+		return tp.joinList(owners)
+	}
+
+	values := make([]string, len(owners))
+	for i, owner := range owners {
+		values[i] = owner
+		if group, ok := tp.OwnerGroups[owner]; ok && group.DisplayName != "" {
+			values[i] = group.DisplayName
+		}
+	}
+	return tp.joinList(values)
+}
+
+// addOwnerMembersTag sets key to the total member count of the owner groups
+// when OwnerGroupTags is member_count and at least one owner is a group
+func (tp *TagProcessor) addOwnerMembersTag(tags map[string]string, key string, owners []string) {
+	if tp.OwnerGroupTags != OwnerGroupTagsMemberCount {
+		return
+	}
+
+	count, found := 0, false
+	for _, owner := range owners {
+		if group, ok := tp.OwnerGroups[owner]; ok {
+			count += group.MemberCount
+			found = true
+		}
+	}
+	if found {
+		tags[key] = strconv.Itoa(count)
+	}
+}
+
+// gitRoot returns Config.GitRoot, or GitRoot when it is not set
+func (tp *TagProcessor) gitRoot() string {
+	if tp.Config.GitRoot != "" {
+		return tp.Config.GitRoot
+	}
+	return tp.GitRoot
+}
+
+// gitTimeout returns GitTimeout, or DefaultGitTimeout when it is not set
+func (tp *TagProcessor) gitTimeout() time.Duration {
+	if tp.GitTimeout > 0 {
+		return tp.GitTimeout
+	}
+	return DefaultGitTimeout
+}
+
+// naValue returns the N/A placeholder, Config.NAValue when set
+func (tp *TagProcessor) naValue() string {
+	if tp.Config.NAValue != "" {
+		return tp.Config.NAValue
+	}
+	return tp.CloudProvider.GetNAValue()
+}
+
+// notApplicable reports whether key gets the N/A placeholder when its value
+// is empty, according to Config.NotApplicableEnabled and Config.NAFields
+func (tp *TagProcessor) notApplicable(key string) bool {
+	if !tp.Config.NotApplicableEnabled {
+		return false
+	}
+
+	allowList, listed := false, false
+	for _, field := range tp.Config.NAFields {
+		if name, denied := strings.CutPrefix(field, "!"); denied {
+			if name == key {
+				return false
+			}
+			continue
+		}
+		allowList = true
+		listed = listed || field == key
+	}
+	return !allowList || listed
+}
+
+// truncateTagValue shortens value to at most maxLen bytes without splitting a
+// multi-byte UTF-8 character
+func truncateTagValue(value string, maxLen int) string {
+	if len(value) <= maxLen {
+		return value
+	}
+	value = value[:maxLen]
+	for len(value) > 0 && !utf8.ValidString(value) {
+		value = value[:len(value)-1]
+	}
+	return value
+}
+
+// tagValueEllipsis marks a value shortened by truncateTagValueWithHash
+const tagValueEllipsis = "..."
+
+// truncateTagValueWithHash shortens value to at most maxLen bytes, replacing
+// the end with an ellipsis and a hash of the full value so that long values
+// sharing a prefix stay distinct
+func truncateTagValueWithHash(value string, maxLen int) string {
+	if len(value) <= maxLen {
+		return value
+	}
+
+	sum := sha256.Sum256([]byte(value))
+	suffix := tagValueEllipsis + hex.EncodeToString(sum[:])[:ShortNameHashLength]
+	if maxLen <= len(suffix) {
+		return truncateTagValue(value, maxLen)
+	}
+	return truncateTagValue(value, maxLen-len(suffix)) + suffix
+}
+
+var slugInvalidChars = regexp.MustCompile(`[^a-z0-9]+`)
+
 // ProcessEphemeralEnvironment handles ephemeral environment special logic
+// with the default environment limit of DefaultNamingConstraints
 func ProcessEphemeralEnvironment(config *DataSourceConfig) {
-	if config.EnvironmentType == "Ephemeral" && config.DeletionDate == "" {
+	DefaultNamingConstraints().ProcessEphemeralEnvironment(config)
+}
+
+// ProcessEphemeralEnvironment handles ephemeral environment special logic.
+// The suffixed environment is kept within the environment limit, so it stays
+// valid when inherited by child contexts.
+func (c NamingConstraints) ProcessEphemeralEnvironment(config *DataSourceConfig) {
+	if config.EnvironmentType != "Ephemeral" {
+		return
+	}
+
+	if config.DeletionDate == "" {
 		// Calculate deletion date as 90 days from now
 		deletionDate := time.Now().Add(90 * 24 * time.Hour)
 		config.DeletionDate = deletionDate.Format("2006-01-02")
 	}
+
+	maxLength := c.withDefaults().EnvironmentMaxLength
+	slug := ephemeralSlug(config.PRNumber, config.EphemeralSuffix, maxLength)
+	config.Environment = appendEnvironmentSlug(config.Environment, slug, maxLength)
 }
 
-// ConvertTagsToListOfMaps converts tags map to list of maps for AWS
-func ConvertTagsToListOfMaps(tags map[string]string) []map[string]string {
-	result := make([]map[string]string, 0, len(tags))
+// EphemeralSlug returns the environment suffix of an ephemeral environment:
+// pr<number> when prNumber is set, otherwise suffix lowercased with other
+// characters replaced by hyphens, so "feat/Login" becomes "feat-log" after
+// truncation to the default environment length limit
+func EphemeralSlug(prNumber int, suffix string) string {
+	return ephemeralSlug(prNumber, suffix, DefaultComponentMaxLength)
+}
+
+// ephemeralSlug returns the environment suffix cut to maxLength characters
+func ephemeralSlug(prNumber int, suffix string, maxLength int) string {
+	var slug string
+	if prNumber > 0 {
+		slug = fmt.Sprintf("pr%d", prNumber)
+	} else {
+		slug = strings.Trim(slugInvalidChars.ReplaceAllString(strings.ToLower(suffix), "-"), "-")
+	}
+
+	if len(slug) > maxLength {
+		slug = strings.TrimRight(slug[:maxLength], "-")
+	}
+	return slug
+}
+
+// appendEnvironmentSlug joins environment and slug with a hyphen, shortening
+// environment rather than the slug when the result would be longer than
+// maxLength
+func appendEnvironmentSlug(environment, slug string, maxLength int) string {
+	if slug == "" {
+		return environment
+	}
+	if environment == "" {
+		return slug
+	}
+
+	room := maxLength - len(slug) - 1
+	if room < 1 {
+		return slug
+	}
+	if len(environment) > room {
+		environment = strings.TrimRight(environment[:room], "-")
+	}
+	return environment + "-" + slug
+}
+
+// MergeTags merges override into base, with override values taking precedence,
+// and sanitizes and truncates the resulting values for the cloud provider
+func MergeTags(base, override map[string]string, cp CloudProvider) map[string]string {
+	merged := make(map[string]string, len(base)+len(override))
+	maps.Copy(merged, base)
+	maps.Copy(merged, override)
 
-	// Sort keys for consistent output
+	for k, v := range merged {
+		merged[k] = truncateTagValue(cp.SanitizeTagValue(v), cp.GetMaxTagLength())
+	}
+
+	return merged
+}
+
+// sortedTagKeys returns the keys of tags in order, for consistent output,
+// and the total length of the keys and values for sizing buffers
+func sortedTagKeys(tags map[string]string) ([]string, int) {
 	keys := make([]string, 0, len(tags))
-	for k := range tags {
+	size := 0
+	for k, v := range tags {
 		keys = append(keys, k)
+		size += len(k) + len(v)
 	}
-	sort.Strings(keys)
+	slices.Sort(keys)
+	return keys, size
+}
 
-	for _, k := range keys {
-		result = append(result, map[string]string{
+// ConvertTagsToListOfMaps converts tags map to list of maps for AWS
+func ConvertTagsToListOfMaps(tags map[string]string) []map[string]string {
+	keys, _ := sortedTagKeys(tags)
+
+	result := make([]map[string]string, len(keys))
+	for i, k := range keys {
+		result[i] = map[string]string{
 			"key":   k,
 			"value": tags[k],
-		})
+		}
 	}
 
 	return result
 }
 
-// ConvertTagsToKVPList converts tags to key=value pairs
+// ConvertTagsToKVPList converts tags to key=value pairs. The pairs are
+// written to one buffer and returned as substrings of it.
 func ConvertTagsToKVPList(tags map[string]string) []string {
-	result := make([]string, 0, len(tags))
+	keys, size := sortedTagKeys(tags)
 
-	// Sort keys for consistent output
-	keys := make([]string, 0, len(tags))
-	for k := range tags {
-		keys = append(keys, k)
+	var b strings.Builder
+	b.Grow(size + len(keys))
+	for _, k := range keys {
+		b.WriteString(k)
+		b.WriteByte('=')
+		b.WriteString(tags[k])
 	}
-	sort.Strings(keys)
 
-	for _, k := range keys {
-		result = append(result, fmt.Sprintf("%s=%s", k, tags[k]))
+	buf := b.String()
+	result := make([]string, len(keys))
+	offset := 0
+	for i, k := range keys {
+		n := len(k) + 1 + len(tags[k])
+		result[i] = buf[offset : offset+n]
+		offset += n
 	}
 
 	return result
@@ -253,6 +1362,18 @@ func ConvertTagsToKVPList(tags map[string]string) []string {
 
 // ConvertTagsToCommaSeparated converts tags to comma-separated string
 func ConvertTagsToCommaSeparated(tags map[string]string) string {
-	kvpList := ConvertTagsToKVPList(tags)
-	return strings.Join(kvpList, ",")
+	keys, size := sortedTagKeys(tags)
+
+	var b strings.Builder
+	b.Grow(size + 2*len(keys))
+	for i, k := range keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(k)
+		b.WriteByte('=')
+		b.WriteString(tags[k])
+	}
+
+	return b.String()
 }
@@ -0,0 +1,105 @@
+package context
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var updateGolden = flag.Bool("update", false, "update golden files in testdata/golden")
+
+// goldenConfig returns the canonical configuration rendered by the golden tests.
+// It deliberately includes spaces, punctuation and mixed case so that every
+// provider's sanitization rules are exercised.
+func goldenConfig() *DataSourceConfig {
+	return &DataSourceConfig{
+		Namespace:             "myorg",
+		Name:                  "payments",
+		Environment:           "prod",
+		EnvironmentName:       "Production East",
+		EnvironmentType:       "Production",
+		Enabled:               true,
+		Availability:          "dedicated",
+		ManagedBy:             "terraform",
+		DeletionDate:          "2030-12-31",
+		PMPlatform:            "JIRA",
+		PMProjectCode:         "PAY",
+		ITSMPlatform:          "SNOW",
+		ITSMSystemID:          "SYS-001",
+		ITSMComponentID:       "COMP-002",
+		ITSMInstanceID:        "",
+		CostCenter:            "CC 1234/Finance",
+		ProductOwners:         []string{"owner@example.com", "pm@example.com"},
+		CodeOwners:            []string{"dev@example.com"},
+		DataOwners:            []string{"data@example.com"},
+		Sensitivity:           "confidential",
+		DataRegs:              []string{"PCI", "SOX"},
+		SecurityReview:        "2024-01-15",
+		PrivacyReview:         "",
+		SourceRepoTagsEnabled: false,
+		SystemPrefixesEnabled: true,
+		NotApplicableEnabled:  true,
+		OwnerTagsEnabled:      true,
+		AdditionalTags: map[string]string{
+			"team":  "Platform & Payments",
+			"notes": "uses <special> chars? yes: 100%",
+		},
+		AdditionalDataTags: map[string]string{
+			"retention": "7 years",
+		},
+	}
+}
+
+type goldenOutput struct {
+	Tags     map[string]string `json:"tags"`
+	DataTags map[string]string `json:"data_tags"`
+}
+
+func TestTagProcessor_Golden(t *testing.T) {
+	providers := []string{"aws", "az", "gcp", "dc"}
+
+	for _, provider := range providers {
+		t.Run(provider, func(t *testing.T) {
+			processor := &TagProcessor{
+				CloudProvider: GetCloudProvider(provider),
+				Config:        goldenConfig(),
+				TagPrefix:     "bc-",
+			}
+
+			tags, err := processor.Process()
+			if err != nil {
+				t.Fatalf("Failed to process tags: %v", err)
+			}
+			dataTags, err := processor.ProcessDataTags()
+			if err != nil {
+				t.Fatalf("Failed to process data tags: %v", err)
+			}
+
+			got, err := json.MarshalIndent(goldenOutput{Tags: tags, DataTags: dataTags}, "", "  ")
+			if err != nil {
+				t.Fatalf("Failed to marshal output: %v", err)
+			}
+			got = append(got, '\n')
+
+			path := filepath.Join("testdata", "golden", provider+".json")
+			if *updateGolden {
+				if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+					t.Fatalf("Failed to create golden directory: %v", err)
+				}
+				if err := os.WriteFile(path, got, 0o644); err != nil {
+					t.Fatalf("Failed to write golden file: %v", err)
+				}
+			}
+
+			want, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("Failed to read golden file %s (run with -update to create it): %v", path, err)
+			}
+			if string(got) != string(want) {
+				t.Errorf("Output for %s does not match %s (run with -update to accept changes)\ngot:\n%s\nwant:\n%s", provider, path, got, want)
+			}
+		})
+	}
+}
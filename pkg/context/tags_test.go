@@ -1,6 +1,11 @@
 package context
 
 import (
+	"fmt"
+	"maps"
+	"reflect"
+	"strings"
+	"sync"
 	"testing"
 )
 
@@ -132,3 +137,1903 @@ func TestTagProcessor_RequiredTags(t *testing.T) {
 		}
 	}
 }
+
+func TestTagProcessor_UnprefixedTags(t *testing.T) {
+	processor := &TagProcessor{
+		CloudProvider: GetCloudProvider("aws"),
+		Config:        &DataSourceConfig{},
+		TagPrefix:     "bc-",
+	}
+
+	got := processor.UnprefixedTags(map[string]string{
+		"bc-environment": "Production",
+		"bc-team":        "platform",
+		"Name":           "legacy",
+	})
+
+	want := map[string]string{"environment": "Production", "team": "platform", "Name": "legacy"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("UnprefixedTags() = %v, want %v", got, want)
+	}
+}
+
+func TestTagProcessor_FilterTags(t *testing.T) {
+	processor := &TagProcessor{
+		CloudProvider: GetCloudProvider("aws"),
+		Config:        &DataSourceConfig{},
+		TagPrefix:     "bc-",
+	}
+	tags := map[string]string{
+		"bc-environment":   "Production",
+		"bc-productowners": "a@example.com",
+		"bc-codeowners":    "b@example.com",
+		"bc-costcenter":    "cc-100",
+		"team":             "platform",
+	}
+
+	tests := []struct {
+		name     string
+		patterns []string
+		want     map[string]string
+		wantErr  bool
+	}{
+		{
+			name:     "no patterns",
+			patterns: nil,
+			want:     map[string]string{},
+		},
+		{
+			name:     "glob matches unprefixed key",
+			patterns: []string{"*owners"},
+			want:     map[string]string{"bc-productowners": "a@example.com", "bc-codeowners": "b@example.com"},
+		},
+		{
+			name:     "any pattern matches",
+			patterns: []string{"environment", "team"},
+			want:     map[string]string{"bc-environment": "Production", "team": "platform"},
+		},
+		{
+			name:     "regular expression",
+			patterns: []string{"/^c(ode|ost)/"},
+			want:     map[string]string{"bc-codeowners": "b@example.com", "bc-costcenter": "cc-100"},
+		},
+		{
+			name:     "invalid glob",
+			patterns: []string{"[owners"},
+			wantErr:  true,
+		},
+		{
+			name:     "invalid regular expression",
+			patterns: []string{"/(owners/"},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := processor.FilterTags(tags, tt.patterns)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FilterTags() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FilterTags() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTagProcessor_HasTags(t *testing.T) {
+	processor := &TagProcessor{
+		CloudProvider: GetCloudProvider("aws"),
+		Config:        &DataSourceConfig{},
+		TagPrefix:     "bc-",
+	}
+
+	tests := []struct {
+		name string
+		tags map[string]string
+		keys []string
+		want bool
+	}{
+		{
+			name: "owner tag set",
+			tags: map[string]string{"bc-codeowners": "a@example.com"},
+			keys: OwnerTagKeys,
+			want: true,
+		},
+		{
+			name: "owner tags not applicable",
+			tags: map[string]string{"bc-productowners": "N/A", "bc-codeowners": ""},
+			keys: OwnerTagKeys,
+			want: false,
+		},
+		{
+			name: "unprefixed key ignored",
+			tags: map[string]string{"sourcerepo": "https://github.com/example/repo"},
+			keys: SourceTagKeys,
+			want: false,
+		},
+		{
+			name: "source tag set",
+			tags: map[string]string{"bc-sourcecommit": "abc123"},
+			keys: SourceTagKeys,
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := processor.HasTags(tt.tags, tt.keys); got != tt.want {
+				t.Errorf("HasTags() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTagProcessor_Compliance(t *testing.T) {
+	manyTags := map[string]string{}
+	for i := range 51 {
+		manyTags[fmt.Sprintf("tag%d", i)] = "value"
+	}
+
+	tests := []struct {
+		name            string
+		environmentType string
+		tags            map[string]string
+		dataTags        map[string]string
+		want            TagCompliance
+	}{
+		{
+			name:     "compliant",
+			tags:     map[string]string{"bc-costcenter": "cc-100", "bc-codeowners": "a@example.com"},
+			dataTags: map[string]string{},
+			want:     TagCompliance{OwnersPresent: true, CostCenterPresent: true, ExpirySetForEphemeral: true, WithinTagLimits: true},
+		},
+		{
+			name:     "not applicable values",
+			tags:     map[string]string{"bc-costcenter": "N/A", "bc-productowners": "N/A"},
+			dataTags: map[string]string{"bc-dataowners": "N/A"},
+			want:     TagCompliance{ExpirySetForEphemeral: true, WithinTagLimits: true},
+		},
+		{
+			name:     "data owner only",
+			tags:     map[string]string{},
+			dataTags: map[string]string{"bc-dataowners": "a@example.com"},
+			want:     TagCompliance{OwnersPresent: true, ExpirySetForEphemeral: true, WithinTagLimits: true},
+		},
+		{
+			name:            "ephemeral without deletion date",
+			environmentType: "Ephemeral",
+			tags:            map[string]string{"bc-deletiondate": "N/A"},
+			want:            TagCompliance{WithinTagLimits: true},
+		},
+		{
+			name:            "ephemeral with deletion date",
+			environmentType: "Ephemeral",
+			tags:            map[string]string{"bc-deletiondate": "2026-01-31"},
+			want:            TagCompliance{ExpirySetForEphemeral: true, WithinTagLimits: true},
+		},
+		{
+			name: "too many tags",
+			tags: manyTags,
+			want: TagCompliance{ExpirySetForEphemeral: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processor := &TagProcessor{
+				CloudProvider: GetCloudProvider("aws"),
+				Config:        &DataSourceConfig{EnvironmentType: tt.environmentType},
+				TagPrefix:     "bc-",
+			}
+			if got := processor.Compliance(tt.tags, tt.dataTags); got != tt.want {
+				t.Errorf("Compliance() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTagProcessor_InheritableTags(t *testing.T) {
+	processor := &TagProcessor{
+		CloudProvider: GetCloudProvider("az"),
+		Config: &DataSourceConfig{
+			EnvironmentName:      "Production",
+			CostCenter:           "cc-100",
+			StackName:            "payments",
+			Component:            "api",
+			Availability:         "dedicated",
+			AdditionalTags:       map[string]string{"team": "platform"},
+			NotApplicableEnabled: false,
+		},
+		TagPrefix: "bc-",
+	}
+
+	tags, err := processor.Process()
+	if err != nil {
+		t.Fatalf("Failed to process tags: %v", err)
+	}
+
+	got := processor.InheritableTags(tags)
+	want := map[string]string{
+		"bc-environment": "Production",
+		"bc-costcenter":  "cc-100",
+		"bc-stack":       "payments",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("InheritableTags() = %v, want %v", got, want)
+	}
+}
+
+func TestTagProcessor_ProviderDefaultTags(t *testing.T) {
+	processor := &TagProcessor{
+		CloudProvider: GetCloudProvider("aws"),
+		Config: &DataSourceConfig{
+			EnvironmentName:      "Production",
+			CostCenter:           "cc-100",
+			StackName:            "payments",
+			DeletionDate:         "2026-12-31",
+			LifecycleAction:      "delete",
+			MonthlyBudget:        100,
+			Region:               "us-east-1",
+			NotApplicableEnabled: false,
+		},
+		TagPrefix: "bc-",
+	}
+
+	tags, err := processor.Process()
+	if err != nil {
+		t.Fatalf("Failed to process tags: %v", err)
+	}
+
+	got := processor.ProviderDefaultTags(tags)
+	want := map[string]string{
+		"bc-environment": "Production",
+		"bc-costcenter":  "cc-100",
+		"bc-stack":       "payments",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ProviderDefaultTags() = %v, want %v", got, want)
+	}
+
+	// The volatile and per-resource tags are inheritable but not defaults
+	inheritable := processor.InheritableTags(tags)
+	for _, key := range []string{"bc-deletiondate", "bc-expiryaction", "bc-monthlybudget", "bc-region"} {
+		if _, ok := inheritable[key]; !ok {
+			t.Errorf("InheritableTags() is missing %s", key)
+		}
+		if _, ok := got[key]; ok {
+			t.Errorf("ProviderDefaultTags() contains %s", key)
+		}
+	}
+}
+
+func TestTagProcessor_DefaultTagConflicts(t *testing.T) {
+	defaultTags := map[string]string{
+		"bc-environment": "Production",
+		"bc-costcenter":  "cc-100",
+	}
+	additionalTags := map[string]string{
+		"team":        "platform",
+		"environment": "Production",
+		"costcenter":  "cc-100",
+	}
+
+	tests := []struct {
+		name          string
+		cloudProvider string
+		want          []string
+	}{
+		{name: "aws", cloudProvider: "aws", want: []string{"bc-costcenter", "bc-environment"}},
+		{name: "not aws", cloudProvider: "az", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processor := &TagProcessor{
+				CloudProvider: GetCloudProvider(tt.cloudProvider),
+				Config:        &DataSourceConfig{AdditionalTags: additionalTags},
+				TagPrefix:     "bc-",
+			}
+
+			got := processor.DefaultTagConflicts(defaultTags)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DefaultTagConflicts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTagProcessor_ReservedTagKeys(t *testing.T) {
+	config := &DataSourceConfig{
+		AdditionalTags:     map[string]string{"aws:team": "platform", "goog-team": "platform", "team": "platform"},
+		AdditionalDataTags: map[string]string{"aws:team": "platform", "k8s.io/role": "data"},
+	}
+
+	tests := []struct {
+		name          string
+		cloudProvider string
+		tagPrefix     string
+		want          []string
+	}{
+		{name: "aws", cloudProvider: "aws", want: []string{"aws:team", "k8s.io/role"}},
+		{name: "gcp", cloudProvider: "gcp", want: []string{"goog-team", "k8s.io/role"}},
+		{name: "default tag prefix", cloudProvider: "aws", tagPrefix: "bc-", want: nil},
+		{name: "default tag prefix az", cloudProvider: "az", tagPrefix: "bc-", want: nil},
+		{name: "reserved tag prefix", cloudProvider: "az", tagPrefix: "azure-", want: []string{"azure-aws:team", "azure-goog-team", "azure-k8s.io/role", "azure-team"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processor := &TagProcessor{
+				CloudProvider: GetCloudProvider(tt.cloudProvider),
+				Config:        config,
+				TagPrefix:     tt.tagPrefix,
+			}
+
+			if got := processor.ReservedTagKeys(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReservedTagKeys() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTagProcessor_InvalidTagKeys(t *testing.T) {
+	tests := []struct {
+		name             string
+		cloudProvider    string
+		sanitizationMode string
+		want             []string
+		wantErr          bool
+	}{
+		{name: "gcp", cloudProvider: "gcp", want: []string{"bc-Cost Center", "bc-Team", "bc-a/b"}},
+		{name: "gcp error", cloudProvider: "gcp", sanitizationMode: "error", wantErr: true},
+		{name: "aws", cloudProvider: "aws"},
+		{name: "azure", cloudProvider: "az", want: []string{"bc-a/b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processor := &TagProcessor{
+				CloudProvider: GetCloudProvider(tt.cloudProvider),
+				Config: &DataSourceConfig{
+					AdditionalTags:   map[string]string{"Cost Center": "cc-200", "Team": "platform", "a/b": "c", "team": "platform"},
+					RawTags:          map[string]string{"Vendor ID": "acme"},
+					SanitizationMode: tt.sanitizationMode,
+				},
+				TagPrefix: "bc-",
+			}
+
+			tags, err := processor.Process()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Process() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := processor.InvalidTagKeys(tags); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("InvalidTagKeys() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTagProcessor_Process_reservedTagKeys(t *testing.T) {
+	tests := []struct {
+		name             string
+		sanitizationMode string
+		tagPrefix        string
+		config           DataSourceConfig
+		wantErr          bool
+		wantDataErr      bool
+	}{
+		{name: "fix", config: DataSourceConfig{AdditionalTags: map[string]string{"aws:team": "platform"}}},
+		{name: "warn", sanitizationMode: "warn", config: DataSourceConfig{AdditionalTags: map[string]string{"aws:team": "platform"}}},
+		{name: "error", sanitizationMode: "error", config: DataSourceConfig{AdditionalTags: map[string]string{"aws:team": "platform"}}, wantErr: true},
+		{name: "error data tags", sanitizationMode: "error", config: DataSourceConfig{AdditionalDataTags: map[string]string{"aws:team": "platform"}}, wantDataErr: true},
+		{name: "error not reserved", sanitizationMode: "error", config: DataSourceConfig{AdditionalTags: map[string]string{"team": "platform"}}},
+		// The emitted key bc-aws:team is not reserved
+		{name: "error default tag prefix", sanitizationMode: "error", tagPrefix: "bc-", config: DataSourceConfig{AdditionalTags: map[string]string{"aws:team": "platform"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := tt.config
+			config.SanitizationMode = tt.sanitizationMode
+			processor := &TagProcessor{
+				CloudProvider: GetCloudProvider("aws"),
+				Config:        &config,
+				TagPrefix:     tt.tagPrefix,
+			}
+
+			tags, err := processor.Process()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Process() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && tags[tt.tagPrefix+"aws:team"] == "" && config.AdditionalTags["aws:team"] != "" {
+				t.Errorf("Process() = %v, want the reserved key kept", tags)
+			}
+			if _, err := processor.ProcessDataTags(); (err != nil) != tt.wantDataErr {
+				t.Errorf("ProcessDataTags() error = %v, wantErr %v", err, tt.wantDataErr)
+			}
+		})
+	}
+}
+
+func TestTagProcessor_TagKeyCollisions(t *testing.T) {
+	tests := []struct {
+		name          string
+		cloudProvider string
+		additional    map[string]string
+		want          []TagKeyCollision
+	}{
+		{
+			name:          "azure generated",
+			cloudProvider: "az",
+			additional:    map[string]string{"CostCenter": "cc-200"},
+			want: []TagKeyCollision{
+				{Keys: []string{"bc-CostCenter", "bc-costcenter"}, Sources: []string{"additional_tags", "generated"}},
+			},
+		},
+		{
+			name:          "gcp additional",
+			cloudProvider: "gcp",
+			additional:    map[string]string{"team": "a", "Team": "b", "TEAM": "c"},
+			want: []TagKeyCollision{
+				{Keys: []string{"bc-TEAM", "bc-Team", "bc-team"}, Sources: []string{"additional_tags", "additional_tags", "additional_tags"}},
+			},
+		},
+		{
+			name:          "gcp normalized",
+			cloudProvider: "gcp",
+			additional:    map[string]string{"Cost Center": "cc-200", "cost_center": "cc-300"},
+			want: []TagKeyCollision{
+				{Keys: []string{"bc-Cost Center", "bc-cost_center"}, Sources: []string{"additional_tags", "additional_tags"}},
+			},
+		},
+		{
+			name:          "azure keeps spaces",
+			cloudProvider: "az",
+			additional:    map[string]string{"Cost Center": "cc-200", "cost_center": "cc-300"},
+		},
+		{
+			name:          "aws keys are case-sensitive",
+			cloudProvider: "aws",
+			additional:    map[string]string{"CostCenter": "cc-200"},
+		},
+		{
+			name:          "azure no collision",
+			cloudProvider: "az",
+			additional:    map[string]string{"team": "platform"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processor := &TagProcessor{
+				CloudProvider: GetCloudProvider(tt.cloudProvider),
+				Config:        &DataSourceConfig{CostCenter: "cc-100", AdditionalTags: tt.additional},
+				TagPrefix:     "bc-",
+			}
+
+			tags, err := processor.Process()
+			if err != nil {
+				t.Fatalf("Process() error = %v", err)
+			}
+			if got := processor.TagKeyCollisions(tags); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TagKeyCollisions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTagProcessor_Process_tagKeyCollisions(t *testing.T) {
+	config := &DataSourceConfig{
+		CostCenter:         "cc-100",
+		AdditionalTags:     map[string]string{"CostCenter": "cc-200"},
+		AdditionalDataTags: map[string]string{"Owner": "a", "owner": "b"},
+		SanitizationMode:   "error",
+	}
+	processor := &TagProcessor{
+		CloudProvider: GetCloudProvider("az"),
+		Config:        config,
+		TagPrefix:     "bc-",
+	}
+
+	_, err := processor.Process()
+	if err == nil || !strings.Contains(err.Error(), "bc-CostCenter (additional_tags), bc-costcenter (generated)") {
+		t.Errorf("Process() error = %v, want the colliding keys", err)
+	}
+	_, err = processor.ProcessDataTags()
+	if err == nil || !strings.Contains(err.Error(), "bc-Owner (additional_data_tags), bc-owner (additional_data_tags)") {
+		t.Errorf("ProcessDataTags() error = %v, want the colliding keys", err)
+	}
+}
+
+func TestTagProcessor_RawTags(t *testing.T) {
+	config := &DataSourceConfig{
+		CostCenter:       "cc-100",
+		AdditionalTags:   map[string]string{"Vendor": "additional"},
+		RawTags:          map[string]string{"Vendor": "ACME Corp/ID=42", "bc-costcenter": "CC 100!"},
+		DigestTagEnabled: true,
+	}
+	processor := &TagProcessor{
+		CloudProvider: GetCloudProvider("gcp"),
+		Config:        config,
+		TagPrefix:     "bc-",
+	}
+
+	tags, err := processor.Process()
+	if err != nil {
+		t.Fatalf("Failed to process tags: %v", err)
+	}
+	// Raw tags keep their keys and values, even those GCP labels reject
+	if tags["Vendor"] != "ACME Corp/ID=42" {
+		t.Errorf("tags[Vendor] = %q, want the raw value", tags["Vendor"])
+	}
+	if tags["bc-costcenter"] != "CC 100!" {
+		t.Errorf("tags[bc-costcenter] = %q, want the raw value replacing the generated one", tags["bc-costcenter"])
+	}
+	if tags["bc-Vendor"] != "additional" {
+		t.Errorf("tags[bc-Vendor] = %q, want the additional tag kept", tags["bc-Vendor"])
+	}
+	if got := tags["bc-contextdigest"]; got != truncateTagValue(processor.ContextDigest(tags), 63) {
+		t.Errorf("tags[bc-contextdigest] = %q, want the digest covering the raw tags", got)
+	}
+}
+
+func TestTagProcessor_RawTagsCollisions(t *testing.T) {
+	processor := &TagProcessor{
+		CloudProvider: GetCloudProvider("az"),
+		Config: &DataSourceConfig{
+			CostCenter:       "cc-100",
+			RawTags:          map[string]string{"BC-CostCenter": "CC-100"},
+			SanitizationMode: "error",
+		},
+		TagPrefix: "bc-",
+	}
+
+	// Raw tags bypass validation, so their collisions are reported only
+	tags, err := processor.Process()
+	if err != nil {
+		t.Fatalf("Process() error = %v, want raw tag collisions left unchecked", err)
+	}
+	want := []TagKeyCollision{{Keys: []string{"BC-CostCenter", "bc-costcenter"}, Sources: []string{"raw_tags", "generated"}}}
+	if got := processor.TagKeyCollisions(tags); !reflect.DeepEqual(got, want) {
+		t.Errorf("TagKeyCollisions() = %v, want %v", got, want)
+	}
+}
+
+func TestTagProcessor_OmitPolicyInheritedTags(t *testing.T) {
+	tags := map[string]string{
+		"bc-environment": "Production",
+		"bc-costcenter":  "cc-100",
+		"bc-stack":       "payments",
+		"bc-component":   "api",
+	}
+
+	tests := []struct {
+		name          string
+		cloudProvider string
+		enabled       bool
+		inherited     []string
+		want          map[string]string
+	}{
+		{
+			name:          "disabled",
+			cloudProvider: "az",
+			want:          tags,
+		},
+		{
+			name:          "not azure",
+			cloudProvider: "aws",
+			enabled:       true,
+			want:          tags,
+		},
+		{
+			name:          "default inheritable keys",
+			cloudProvider: "az",
+			enabled:       true,
+			want:          map[string]string{"bc-component": "api"},
+		},
+		{
+			name:          "custom keys",
+			cloudProvider: "az",
+			enabled:       true,
+			inherited:     []string{"costcenter"},
+			want: map[string]string{
+				"bc-environment": "Production",
+				"bc-stack":       "payments",
+				"bc-component":   "api",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processor := &TagProcessor{
+				CloudProvider: GetCloudProvider(tt.cloudProvider),
+				Config: &DataSourceConfig{
+					AzurePolicyInheritanceEnabled: tt.enabled,
+					AzurePolicyInheritedTags:      tt.inherited,
+				},
+				TagPrefix: "bc-",
+			}
+
+			got := processor.OmitPolicyInheritedTags(tags)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("OmitPolicyInheritedTags() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if len(tags) != 4 {
+		t.Errorf("OmitPolicyInheritedTags() modified its input: %v", tags)
+	}
+}
+
+func TestTagProcessor_SplitRequiredTags(t *testing.T) {
+	processor := &TagProcessor{
+		CloudProvider: GetCloudProvider("aws"),
+		Config:        &DataSourceConfig{},
+		TagPrefix:     "bc-",
+	}
+
+	tags := map[string]string{
+		"bc-environment":   "Production",
+		"bc-costcenter":    "cc-100",
+		"bc-stack":         "payments",
+		"bc-productowners": "owner@example.com",
+		"costcenter":       "unprefixed",
+	}
+
+	required, optional := processor.SplitRequiredTags(tags)
+
+	wantRequired := map[string]string{"bc-environment": "Production", "bc-costcenter": "cc-100"}
+	if !reflect.DeepEqual(required, wantRequired) {
+		t.Errorf("required = %v, want %v", required, wantRequired)
+	}
+	wantOptional := map[string]string{"bc-stack": "payments", "bc-productowners": "owner@example.com", "costcenter": "unprefixed"}
+	if !reflect.DeepEqual(optional, wantOptional) {
+		t.Errorf("optional = %v, want %v", optional, wantOptional)
+	}
+}
+
+func TestTagProcessor_StackIdentityTags(t *testing.T) {
+	tests := []struct {
+		name      string
+		stackName string
+		component string
+		want      map[string]string
+	}{
+		{
+			name:      "both set",
+			stackName: "payments",
+			component: "api",
+			want:      map[string]string{"bc-stack": "payments", "bc-component": "api"},
+		},
+		{
+			name:      "stack only",
+			stackName: "payments",
+			want:      map[string]string{"bc-stack": "payments"},
+		},
+		{
+			name: "neither set",
+			want: map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &DataSourceConfig{
+				Namespace:            "myorg",
+				Environment:          "prod",
+				StackName:            tt.stackName,
+				Component:            tt.component,
+				NotApplicableEnabled: true,
+			}
+
+			processor := &TagProcessor{
+				CloudProvider: GetCloudProvider("aws"),
+				Config:        config,
+				TagPrefix:     "bc-",
+			}
+
+			tags, err := processor.Process()
+			if err != nil {
+				t.Fatalf("Failed to process tags: %v", err)
+			}
+
+			for _, key := range []string{"bc-stack", "bc-component"} {
+				got, ok := tags[key]
+				want, wantOK := tt.want[key]
+				if ok != wantOK || got != want {
+					t.Errorf("tags[%s] = %q (present %v), want %q (present %v)", key, got, ok, want, wantOK)
+				}
+			}
+		})
+	}
+}
+
+func TestTagProcessor_ToolingTags(t *testing.T) {
+	tests := []struct {
+		name     string
+		enabled  bool
+		tfVer    string
+		want     map[string]string
+		wantNone bool
+	}{
+		{
+			name:    "enabled",
+			enabled: true,
+			tfVer:   "1.9.5",
+			want:    map[string]string{"bc-terraformversion": "1.9.5", "bc-contextproviderversion": "0.3.0"},
+		},
+		{
+			name:    "enabled without terraform version",
+			enabled: true,
+			want:    map[string]string{"bc-terraformversion": "N/A", "bc-contextproviderversion": "0.3.0"},
+		},
+		{
+			name:     "disabled",
+			tfVer:    "1.9.5",
+			wantNone: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processor := &TagProcessor{
+				CloudProvider: GetCloudProvider("aws"),
+				Config: &DataSourceConfig{
+					Namespace:            "myorg",
+					Environment:          "prod",
+					NotApplicableEnabled: true,
+					ToolingTagsEnabled:   tt.enabled,
+				},
+				TagPrefix:        "bc-",
+				TerraformVersion: tt.tfVer,
+				ProviderVersion:  "0.3.0",
+			}
+
+			tags, err := processor.Process()
+			if err != nil {
+				t.Fatalf("Failed to process tags: %v", err)
+			}
+
+			if tt.wantNone {
+				for _, key := range []string{"bc-terraformversion", "bc-contextproviderversion"} {
+					if _, ok := tags[key]; ok {
+						t.Errorf("Expected %s tag to be absent when disabled", key)
+					}
+				}
+				return
+			}
+			for key, want := range tt.want {
+				if tags[key] != want {
+					t.Errorf("tags[%s] = %q, want %q", key, tags[key], want)
+				}
+			}
+		})
+	}
+}
+
+func TestTagProcessor_ExpiryActionTag(t *testing.T) {
+	config := &DataSourceConfig{
+		EnvironmentType:      "Ephemeral",
+		DeletionDate:         "2030-01-01",
+		LifecycleAction:      "stop",
+		NotApplicableEnabled: true,
+	}
+
+	processor := &TagProcessor{
+		CloudProvider: GetCloudProvider("aws"),
+		Config:        config,
+		TagPrefix:     "bc-",
+	}
+
+	tags, err := processor.Process()
+	if err != nil {
+		t.Fatalf("Failed to process tags: %v", err)
+	}
+	if tags["bc-expiryaction"] != "stop" {
+		t.Errorf("bc-expiryaction = %q, want stop", tags["bc-expiryaction"])
+	}
+
+	// The tag is only emitted when set
+	config.LifecycleAction = ""
+	tags, err = processor.Process()
+	if err != nil {
+		t.Fatalf("Failed to process tags: %v", err)
+	}
+	if _, ok := tags["bc-expiryaction"]; ok {
+		t.Error("Expected bc-expiryaction tag to be absent when lifecycle_action is not set")
+	}
+}
+
+func TestTagProcessor_SanitizationMode(t *testing.T) {
+	tests := []struct {
+		name         string
+		mode         string
+		wantErr      bool
+		wantWarnings int
+		wantValue    string
+	}{
+		{
+			name:      "fix",
+			mode:      "fix",
+			wantValue: "R_D",
+		},
+		{
+			name:      "empty defaults to fix",
+			mode:      "",
+			wantValue: "R_D",
+		},
+		{
+			name:         "warn",
+			mode:         "warn",
+			wantWarnings: 1,
+			wantValue:    "R_D",
+		},
+		{
+			name:    "error",
+			mode:    "error",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processor := &TagProcessor{
+				CloudProvider: GetCloudProvider("dc"),
+				Config: &DataSourceConfig{
+					CostCenter:       "R&D",
+					ManagedBy:        "terraform",
+					SanitizationMode: tt.mode,
+				},
+				TagPrefix: "bc-",
+			}
+
+			tags, err := processor.Process()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Process() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if tags["bc-costcenter"] != tt.wantValue {
+				t.Errorf("bc-costcenter = %q, want %q", tags["bc-costcenter"], tt.wantValue)
+			}
+			if len(processor.Warnings) != tt.wantWarnings {
+				t.Errorf("len(Warnings) = %d, want %d: %v", len(processor.Warnings), tt.wantWarnings, processor.Warnings)
+			}
+		})
+	}
+}
+
+func TestTagProcessor_LengthOverflow(t *testing.T) {
+	policies := []string{"", "truncate", "truncate_with_ellipsis_hash", "error"}
+
+	for _, cloud := range []string{"aws", "az", "gcp", "dc"} {
+		cp := GetCloudProvider(cloud)
+		maxLen := cp.GetMaxTagLength()
+
+		for _, length := range []int{maxLen - 1, maxLen, maxLen + 1} {
+			for _, policy := range policies {
+				t.Run(fmt.Sprintf("%s/%d/%s", cloud, length, policy), func(t *testing.T) {
+					value := strings.Repeat("a", length)
+					processor := &TagProcessor{
+						CloudProvider: cp,
+						Config: &DataSourceConfig{
+							LengthOverflow: policy,
+							AdditionalTags: map[string]string{"long": value},
+						},
+					}
+
+					tags, err := processor.Process()
+					overflow := length > maxLen
+					if (err != nil) != (overflow && policy == "error") {
+						t.Fatalf("Process() error = %v, want error %v", err, overflow && policy == "error")
+					}
+					if err != nil {
+						return
+					}
+
+					got := tags["long"]
+					switch {
+					case !overflow:
+						if got != value {
+							t.Errorf("value at or under the limit was changed to %q", got)
+						}
+					case policy == "truncate_with_ellipsis_hash":
+						if len(got) != maxLen {
+							t.Errorf("len = %d, want %d", len(got), maxLen)
+						}
+						if strings.HasSuffix(got, "aaaaaa") {
+							t.Errorf("value %q does not end with an ellipsis and hash", got)
+						}
+					default:
+						if got != value[:maxLen] {
+							t.Errorf("value = %q, want truncated to %d characters", got, maxLen)
+						}
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestTruncateTagValueWithHash(t *testing.T) {
+	a := truncateTagValueWithHash(strings.Repeat("a", 20)+"-first", 16)
+	b := truncateTagValueWithHash(strings.Repeat("a", 20)+"-second", 16)
+	if len(a) != 16 || len(b) != 16 {
+		t.Fatalf("lengths = %d, %d, want 16", len(a), len(b))
+	}
+	if !strings.HasPrefix(a, "aaaaaaa...") {
+		t.Errorf("truncateTagValueWithHash() = %q, want aaaaaaa...<hash>", a)
+	}
+	if a == b {
+		t.Errorf("values sharing a prefix were shortened to the same %q", a)
+	}
+
+	// Limits too small for the suffix fall back to plain truncation
+	if got := truncateTagValueWithHash("abcdefghijkl", 5); got != "abcde" {
+		t.Errorf("truncateTagValueWithHash() = %q, want abcde", got)
+	}
+}
+
+func TestTagProcessor_NotApplicableFields(t *testing.T) {
+	tests := []struct {
+		name     string
+		naValue  string
+		naFields []string
+		wantNA   []string
+		wantNone []string
+	}{
+		{
+			name:   "all fields",
+			wantNA: []string{"costcenter", "securityreview", "productowners", "sensitivity", "dataowners"},
+		},
+		{
+			name:     "allow list",
+			naFields: []string{"costcenter", "dataowners"},
+			wantNA:   []string{"costcenter", "dataowners"},
+			wantNone: []string{"securityreview", "productowners", "sensitivity"},
+		},
+		{
+			name:     "deny list",
+			naFields: []string{"!securityreview", "!productowners"},
+			wantNA:   []string{"costcenter", "sensitivity", "dataowners"},
+			wantNone: []string{"securityreview", "productowners"},
+		},
+		{
+			name:     "deny wins over allow",
+			naFields: []string{"costcenter", "!costcenter", "sensitivity"},
+			wantNA:   []string{"sensitivity"},
+			wantNone: []string{"costcenter", "dataowners"},
+		},
+		{
+			name:     "override placeholder",
+			naValue:  "none",
+			naFields: []string{"costcenter"},
+			wantNA:   []string{"costcenter"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processor := &TagProcessor{
+				CloudProvider: GetCloudProvider("aws"),
+				Config: &DataSourceConfig{
+					NotApplicableEnabled: true,
+					OwnerTagsEnabled:     true,
+					NAValue:              tt.naValue,
+					NAFields:             tt.naFields,
+				},
+			}
+
+			tags, err := processor.Process()
+			if err != nil {
+				t.Fatalf("Failed to process tags: %v", err)
+			}
+			dataTags, err := processor.ProcessDataTags()
+			if err != nil {
+				t.Fatalf("Failed to process data tags: %v", err)
+			}
+			maps.Copy(tags, dataTags)
+
+			want := "N/A"
+			if tt.naValue != "" {
+				want = tt.naValue
+			}
+			for _, key := range tt.wantNA {
+				if tags[key] != want {
+					t.Errorf("%s = %q, want %q", key, tags[key], want)
+				}
+			}
+			for _, key := range tt.wantNone {
+				if value, ok := tags[key]; ok {
+					t.Errorf("%s = %q, want absent", key, value)
+				}
+			}
+		})
+	}
+}
+
+func TestTagProcessor_NotApplicableOverrideOutputs(t *testing.T) {
+	processor := &TagProcessor{
+		CloudProvider: GetCloudProvider("gcp"),
+		Config: &DataSourceConfig{
+			NotApplicableEnabled: true,
+			NAValue:              "None",
+		},
+		TagPrefix: "bc-",
+	}
+
+	tags, err := processor.Process()
+	if err != nil {
+		t.Fatalf("Failed to process tags: %v", err)
+	}
+	if tags["bc-costcenter"] != "none" {
+		t.Fatalf("bc-costcenter = %q, want sanitized override none", tags["bc-costcenter"])
+	}
+
+	// The override is recognized as not applicable by the derived outputs
+	if focus := processor.FOCUSTags(tags); len(focus) != 0 {
+		t.Errorf("FOCUSTags() = %v, want empty", focus)
+	}
+	if filter := processor.AWSBudgetsFilter(tags); len(filter) != 0 {
+		t.Errorf("AWSBudgetsFilter() = %v, want empty", filter)
+	}
+}
+
+func TestRegulationTagKey(t *testing.T) {
+	tests := []struct {
+		regulation string
+		want       string
+	}{
+		{regulation: "GDPR", want: "reg-gdpr"},
+		{regulation: "PCI DSS", want: "reg-pci-dss"},
+		{regulation: "SOC-2", want: "reg-soc-2"},
+		{regulation: " ISO/IEC 27001 ", want: "reg-iso-iec-27001"},
+		{regulation: "--", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.regulation, func(t *testing.T) {
+			if got := RegulationTagKey(tt.regulation); got != tt.want {
+				t.Errorf("RegulationTagKey(%q) = %q, want %q", tt.regulation, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTagProcessor_RegulationTags(t *testing.T) {
+	config := &DataSourceConfig{
+		DataRegs:             []string{"GDPR", "PCI DSS"},
+		NotApplicableEnabled: true,
+	}
+
+	processor := &TagProcessor{
+		CloudProvider: GetCloudProvider("gcp"),
+		Config:        config,
+		TagPrefix:     "bc-",
+	}
+
+	dataTags, err := processor.ProcessDataTags()
+	if err != nil {
+		t.Fatalf("Failed to process data tags: %v", err)
+	}
+	if _, ok := dataTags["bc-reg-gdpr"]; ok {
+		t.Error("Expected no per-regulation tags when RegulationTagsEnabled is false")
+	}
+
+	config.RegulationTagsEnabled = true
+	dataTags, err = processor.ProcessDataTags()
+	if err != nil {
+		t.Fatalf("Failed to process data tags: %v", err)
+	}
+	for _, key := range []string{"bc-reg-gdpr", "bc-reg-pci-dss"} {
+		if dataTags[key] != "true" {
+			t.Errorf("%s = %q, want true", key, dataTags[key])
+		}
+	}
+	if dataTags["bc-dataregulations"] != "gdpr_pci-dss" {
+		t.Errorf("bc-dataregulations = %q, want the joined list gdpr_pci-dss", dataTags["bc-dataregulations"])
+	}
+}
+
+func TestTagProcessor_ListDelimiter(t *testing.T) {
+	tests := []struct {
+		name          string
+		cloud         string
+		listDelimiter string
+		dataRegs      []string
+		wantDelimiter string
+		wantValue     string
+	}{
+		{
+			name:          "provider delimiter",
+			cloud:         "dc",
+			dataRegs:      []string{"GDPR", "PCI DSS"},
+			wantDelimiter: ";",
+			wantValue:     "GDPR;PCI DSS",
+		},
+		{
+			name:          "delimiter in value is substituted",
+			cloud:         "aws",
+			dataRegs:      []string{"GDPR", "PCI DSS"},
+			wantDelimiter: " ",
+			wantValue:     "GDPR PCI_DSS",
+		},
+		{
+			name:          "underscore delimiter substitutes hyphen",
+			cloud:         "gcp",
+			dataRegs:      []string{"gdpr", "iso_27001"},
+			wantDelimiter: "_",
+			wantValue:     "gdpr_iso-27001",
+		},
+		{
+			name:          "override",
+			cloud:         "aws",
+			listDelimiter: "/",
+			dataRegs:      []string{"GDPR", "PCI DSS", "A/B"},
+			wantDelimiter: "/",
+			wantValue:     "GDPR/PCI DSS/A_B",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processor := &TagProcessor{
+				CloudProvider: GetCloudProvider(tt.cloud),
+				Config: &DataSourceConfig{
+					DataRegs:      tt.dataRegs,
+					ListDelimiter: tt.listDelimiter,
+				},
+			}
+
+			if got := processor.ListDelimiter(); got != tt.wantDelimiter {
+				t.Errorf("ListDelimiter() = %q, want %q", got, tt.wantDelimiter)
+			}
+
+			dataTags, err := processor.ProcessDataTags()
+			if err != nil {
+				t.Fatalf("Failed to process data tags: %v", err)
+			}
+			if got := dataTags["dataregulations"]; got != tt.wantValue {
+				t.Errorf("dataregulations = %q, want %q", got, tt.wantValue)
+			}
+			if got := len(strings.Split(dataTags["dataregulations"], tt.wantDelimiter)); got != len(tt.dataRegs) {
+				t.Errorf("dataregulations splits into %d values, want %d", got, len(tt.dataRegs))
+			}
+		})
+	}
+}
+
+func TestMergeTags(t *testing.T) {
+	tests := []struct {
+		name     string
+		base     map[string]string
+		override map[string]string
+		cloud    string
+		want     map[string]string
+	}{
+		{
+			name:     "override wins",
+			base:     map[string]string{"bc-environment": "Production", "team": "platform"},
+			override: map[string]string{"team": "payments", "role": "db"},
+			cloud:    "aws",
+			want:     map[string]string{"bc-environment": "Production", "team": "payments", "role": "db"},
+		},
+		{
+			name:     "gcp sanitization",
+			base:     map[string]string{"bc-environment": "Production"},
+			override: map[string]string{"owner": "Team Payments"},
+			cloud:    "gcp",
+			want:     map[string]string{"bc-environment": "production", "owner": "team-payments"},
+		},
+		{
+			name:  "nil maps",
+			cloud: "dc",
+			want:  map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := MergeTags(tt.base, tt.override, GetCloudProvider(tt.cloud))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MergeTags() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeTags_Truncation(t *testing.T) {
+	cp := GetCloudProvider("gcp")
+	got := MergeTags(nil, map[string]string{"long": strings.Repeat("a", 100)}, cp)
+	if len(got["long"]) != cp.GetMaxTagLength() {
+		t.Errorf("len(MergeTags()[long]) = %d, want %d", len(got["long"]), cp.GetMaxTagLength())
+	}
+}
+
+func TestTagProcessor_TenancyTags(t *testing.T) {
+	processor := &TagProcessor{
+		CloudProvider: GetCloudProvider("aws"),
+		Config: &DataSourceConfig{
+			Tenant:               "acme",
+			Attributes:           []string{"blue", "1"},
+			NotApplicableEnabled: true,
+		},
+		TagPrefix: "bc-",
+	}
+
+	tags, err := processor.Process()
+	if err != nil {
+		t.Fatalf("Failed to process tags: %v", err)
+	}
+	if tags["bc-tenant"] != "acme" {
+		t.Errorf("bc-tenant = %q, want acme", tags["bc-tenant"])
+	}
+	if tags["bc-attributes"] != "blue 1" {
+		t.Errorf("bc-attributes = %q, want %q", tags["bc-attributes"], "blue 1")
+	}
+
+	// The tags are only emitted when set
+	processor.Config = &DataSourceConfig{NotApplicableEnabled: true}
+	tags, err = processor.Process()
+	if err != nil {
+		t.Fatalf("Failed to process tags: %v", err)
+	}
+	for _, key := range []string{"bc-tenant", "bc-attributes"} {
+		if _, ok := tags[key]; ok {
+			t.Errorf("Expected %s tag to be absent when not set", key)
+		}
+	}
+}
+
+func TestTagProcessor_ApplicationTags(t *testing.T) {
+	processor := &TagProcessor{
+		CloudProvider: GetCloudProvider("aws"),
+		Config: &DataSourceConfig{
+			Application:          "checkout",
+			Service:              "payments-api",
+			Tier:                 "web",
+			NotApplicableEnabled: true,
+		},
+		TagPrefix: "bc-",
+	}
+
+	tags, err := processor.Process()
+	if err != nil {
+		t.Fatalf("Failed to process tags: %v", err)
+	}
+	want := map[string]string{"bc-application": "checkout", "bc-service": "payments-api", "bc-tier": "web"}
+	for key, value := range want {
+		if tags[key] != value {
+			t.Errorf("%s = %q, want %q", key, tags[key], value)
+		}
+	}
+
+	// The tags are only emitted when set
+	processor.Config = &DataSourceConfig{NotApplicableEnabled: true}
+	tags, err = processor.Process()
+	if err != nil {
+		t.Fatalf("Failed to process tags: %v", err)
+	}
+	for key := range want {
+		if _, ok := tags[key]; ok {
+			t.Errorf("Expected %s tag to be absent when not set", key)
+		}
+	}
+}
+
+func TestTagProcessor_DataResidencyTags(t *testing.T) {
+	processor := &TagProcessor{
+		CloudProvider: GetCloudProvider("aws"),
+		Config: &DataSourceConfig{
+			Region:                  "eu-central-1",
+			DataResidency:           []string{"DE", "FR"},
+			SovereigntyRequirements: []string{"EUCS", "SecNumCloud"},
+			NotApplicableEnabled:    true,
+		},
+		TagPrefix: "bc-",
+	}
+
+	tags, err := processor.Process()
+	if err != nil {
+		t.Fatalf("Failed to process tags: %v", err)
+	}
+	if tags["bc-region"] != "eu-central-1" {
+		t.Errorf("bc-region = %q, want eu-central-1", tags["bc-region"])
+	}
+	dataTags, err := processor.ProcessDataTags()
+	if err != nil {
+		t.Fatalf("Failed to process data tags: %v", err)
+	}
+	want := map[string]string{"bc-dataresidency": "DE FR", "bc-sovereignty": "EUCS SecNumCloud"}
+	for key, value := range want {
+		if dataTags[key] != value {
+			t.Errorf("%s = %q, want %q", key, dataTags[key], value)
+		}
+	}
+
+	// The tags are only emitted when set
+	processor.Config = &DataSourceConfig{NotApplicableEnabled: true}
+	if tags, err = processor.Process(); err != nil {
+		t.Fatalf("Failed to process tags: %v", err)
+	}
+	if dataTags, err = processor.ProcessDataTags(); err != nil {
+		t.Fatalf("Failed to process data tags: %v", err)
+	}
+	if _, ok := tags["bc-region"]; ok {
+		t.Error("Expected bc-region tag to be absent when not set")
+	}
+	for key := range want {
+		if _, ok := dataTags[key]; ok {
+			t.Errorf("Expected %s data tag to be absent when not set", key)
+		}
+	}
+}
+
+func TestTagProcessor_DataRetentionTag(t *testing.T) {
+	processor := &TagProcessor{
+		CloudProvider: GetCloudProvider("aws"),
+		Config:        &DataSourceConfig{DataRetention: "P7Y", NotApplicableEnabled: true},
+		TagPrefix:     "bc-",
+	}
+
+	dataTags, err := processor.ProcessDataTags()
+	if err != nil {
+		t.Fatalf("Failed to process data tags: %v", err)
+	}
+	if dataTags["bc-dataretention"] != "P7Y" {
+		t.Errorf("bc-dataretention = %q, want P7Y", dataTags["bc-dataretention"])
+	}
+
+	processor.Config = &DataSourceConfig{NotApplicableEnabled: true}
+	if dataTags, err = processor.ProcessDataTags(); err != nil {
+		t.Fatalf("Failed to process data tags: %v", err)
+	}
+	if _, ok := dataTags["bc-dataretention"]; ok {
+		t.Error("Expected bc-dataretention data tag to be absent when not set")
+	}
+}
+
+func TestTagProcessor_OrgHierarchyTags(t *testing.T) {
+	processor := &TagProcessor{
+		CloudProvider: GetCloudProvider("aws"),
+		Config: &DataSourceConfig{
+			BusinessUnit:         "retail",
+			Division:             "payments",
+			Portfolio:            "cards",
+			NotApplicableEnabled: true,
+		},
+		TagPrefix: "bc-",
+	}
+
+	tags, err := processor.Process()
+	if err != nil {
+		t.Fatalf("Failed to process tags: %v", err)
+	}
+	want := map[string]string{"bc-businessunit": "retail", "bc-division": "payments", "bc-portfolio": "cards"}
+	for key, value := range want {
+		if tags[key] != value {
+			t.Errorf("%s = %q, want %q", key, tags[key], value)
+		}
+	}
+
+	// The tags are only emitted when set
+	processor.Config = &DataSourceConfig{Division: "payments", NotApplicableEnabled: true}
+	tags, err = processor.Process()
+	if err != nil {
+		t.Fatalf("Failed to process tags: %v", err)
+	}
+	for _, key := range []string{"bc-businessunit", "bc-portfolio"} {
+		if _, ok := tags[key]; ok {
+			t.Errorf("Expected %s tag to be absent when not set", key)
+		}
+	}
+}
+
+func TestTagProcessor_SaaSTenancyTags(t *testing.T) {
+	processor := &TagProcessor{
+		CloudProvider: GetCloudProvider("aws"),
+		Config: &DataSourceConfig{
+			TenantID:             "3f2a9c1e-0042",
+			Customer:             "globex",
+			NotApplicableEnabled: true,
+		},
+		TagPrefix: "bc-",
+	}
+
+	tags, err := processor.Process()
+	if err != nil {
+		t.Fatalf("Failed to process tags: %v", err)
+	}
+	if tags["bc-tenantid"] != "3f2a9c1e-0042" {
+		t.Errorf("bc-tenantid = %q, want 3f2a9c1e-0042", tags["bc-tenantid"])
+	}
+	if tags["bc-customer"] != "globex" {
+		t.Errorf("bc-customer = %q, want globex", tags["bc-customer"])
+	}
+	focus := processor.FOCUSTags(tags)
+	if focus["x_TenantId"] != "3f2a9c1e-0042" || focus["x_Customer"] != "globex" {
+		t.Errorf("FOCUSTags() = %v, want x_TenantId and x_Customer", focus)
+	}
+
+	// The tags are only emitted when set
+	processor.Config = &DataSourceConfig{NotApplicableEnabled: true}
+	tags, err = processor.Process()
+	if err != nil {
+		t.Fatalf("Failed to process tags: %v", err)
+	}
+	for _, key := range []string{"bc-tenantid", "bc-customer"} {
+		if _, ok := tags[key]; ok {
+			t.Errorf("Expected %s tag to be absent when not set", key)
+		}
+	}
+}
+
+func TestProcessEphemeralEnvironment(t *testing.T) {
+	tests := []struct {
+		name            string
+		config          DataSourceConfig
+		wantEnvironment string
+	}{
+		{
+			name:            "pr number",
+			config:          DataSourceConfig{Environment: "dev", EnvironmentType: "Ephemeral", PRNumber: 42},
+			wantEnvironment: "dev-pr42",
+		},
+		{
+			name:            "environment shortened to fit slug",
+			config:          DataSourceConfig{Environment: "preview", EnvironmentType: "Ephemeral", PRNumber: 123},
+			wantEnvironment: "pr-pr123",
+		},
+		{
+			name:            "slug only without environment",
+			config:          DataSourceConfig{EnvironmentType: "Ephemeral", PRNumber: 123},
+			wantEnvironment: "pr123",
+		},
+		{
+			name:            "slug too long for environment",
+			config:          DataSourceConfig{Environment: "dev", EnvironmentType: "Ephemeral", PRNumber: 123456},
+			wantEnvironment: "pr123456",
+		},
+		{
+			name:            "pr number takes precedence over suffix",
+			config:          DataSourceConfig{Environment: "dev", EnvironmentType: "Ephemeral", PRNumber: 7, EphemeralSuffix: "feature"},
+			wantEnvironment: "dev-pr7",
+		},
+		{
+			name:            "branch suffix",
+			config:          DataSourceConfig{Environment: "e", EnvironmentType: "Ephemeral", EphemeralSuffix: "feat/Login"},
+			wantEnvironment: "feat-log",
+		},
+		{
+			name:            "short branch suffix",
+			config:          DataSourceConfig{Environment: "e", EnvironmentType: "Ephemeral", EphemeralSuffix: "Fix_12"},
+			wantEnvironment: "e-fix-12",
+		},
+		{
+			name:            "not ephemeral",
+			config:          DataSourceConfig{Environment: "dev", EnvironmentType: "Development", PRNumber: 42},
+			wantEnvironment: "dev",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := tt.config
+			ProcessEphemeralEnvironment(&config)
+			if config.Environment != tt.wantEnvironment {
+				t.Errorf("Environment = %q, want %q", config.Environment, tt.wantEnvironment)
+			}
+			if err := ValidateEnvironment(config.Environment); err != nil {
+				t.Errorf("ValidateEnvironment(%q) = %v", config.Environment, err)
+			}
+			if tt.config.EnvironmentType == "Ephemeral" && config.DeletionDate == "" {
+				t.Error("Expected deletion date to be set for ephemeral environment")
+			}
+		})
+	}
+}
+
+func TestTagProcessor_BudgetTags(t *testing.T) {
+	processor := &TagProcessor{
+		CloudProvider: GetCloudProvider("aws"),
+		Config: &DataSourceConfig{
+			CostCenter:           "cc-100",
+			MonthlyBudget:        1500.5,
+			NotApplicableEnabled: true,
+		},
+		TagPrefix: "bc-",
+	}
+
+	tags, err := processor.Process()
+	if err != nil {
+		t.Fatalf("Failed to process tags: %v", err)
+	}
+	if tags["bc-monthlybudget"] != "1500.5" {
+		t.Errorf("bc-monthlybudget = %q, want 1500.5", tags["bc-monthlybudget"])
+	}
+	if tags["bc-budgetcurrency"] != DefaultBudgetCurrency {
+		t.Errorf("bc-budgetcurrency = %q, want %s", tags["bc-budgetcurrency"], DefaultBudgetCurrency)
+	}
+
+	want := map[string][]string{"TagKeyValue": {"user:bc-costcenter$cc-100"}}
+	if got := processor.AWSBudgetsFilter(tags); !reflect.DeepEqual(got, want) {
+		t.Errorf("AWSBudgetsFilter() = %v, want %v", got, want)
+	}
+
+	processor.Config.BudgetCurrency = "EUR"
+	tags, err = processor.Process()
+	if err != nil {
+		t.Fatalf("Failed to process tags: %v", err)
+	}
+	if tags["bc-budgetcurrency"] != "EUR" {
+		t.Errorf("bc-budgetcurrency = %q, want EUR", tags["bc-budgetcurrency"])
+	}
+
+	// The budget tags and filter are only present when set
+	processor.Config = &DataSourceConfig{NotApplicableEnabled: true}
+	tags, err = processor.Process()
+	if err != nil {
+		t.Fatalf("Failed to process tags: %v", err)
+	}
+	for _, key := range []string{"bc-monthlybudget", "bc-budgetcurrency"} {
+		if _, ok := tags[key]; ok {
+			t.Errorf("Expected %s tag to be absent when not set", key)
+		}
+	}
+	if got := processor.AWSBudgetsFilter(tags); len(got) != 0 {
+		t.Errorf("AWSBudgetsFilter() = %v, want empty without a cost center", got)
+	}
+}
+
+func TestTagProcessor_OwnerGroupTags(t *testing.T) {
+	config := &DataSourceConfig{
+		ProductOwners:    []string{"lead@example.com", "team@example.com"},
+		DataOwners:       []string{"data@example.com", "team@example.com"},
+		OwnerTagsEnabled: true,
+	}
+	groups := map[string]Group{
+		"team@example.com": {Email: "team@example.com", DisplayName: "Team Payments", MemberCount: 4},
+		"data@example.com": {Email: "data@example.com", DisplayName: "Data Stewards", MemberCount: 2},
+	}
+
+	processor := &TagProcessor{
+		CloudProvider:  GetCloudProvider("aws"),
+		Config:         config,
+		TagPrefix:      "bc-",
+		OwnerGroups:    groups,
+		OwnerGroupTags: OwnerGroupTagsDisplayName,
+	}
+	tags, err := processor.Process()
+	if err != nil {
+		t.Fatalf("Failed to process tags: %v", err)
+	}
+	// The AWS list delimiter is a space, so spaces in values become underscores
+	if tags["bc-productowners"] != "lead@example.com Team_Payments" {
+		t.Errorf("bc-productowners = %q, want group display name", tags["bc-productowners"])
+	}
+	if _, ok := tags["bc-productownersmembers"]; ok {
+		t.Error("Expected no member count tag with display_name")
+	}
+
+	processor.OwnerGroupTags = OwnerGroupTagsMemberCount
+	tags, err = processor.Process()
+	if err != nil {
+		t.Fatalf("Failed to process tags: %v", err)
+	}
+	if tags["bc-productowners"] != "lead@example.com team@example.com" {
+		t.Errorf("bc-productowners = %q, want addresses", tags["bc-productowners"])
+	}
+	if tags["bc-productownersmembers"] != "4" {
+		t.Errorf("bc-productownersmembers = %q, want 4", tags["bc-productownersmembers"])
+	}
+	if _, ok := tags["bc-codeownersmembers"]; ok {
+		t.Error("Expected no member count tag without code owner groups")
+	}
+
+	dataTags, err := processor.ProcessDataTags()
+	if err != nil {
+		t.Fatalf("Failed to process data tags: %v", err)
+	}
+	if dataTags["bc-dataownersmembers"] != "6" {
+		t.Errorf("bc-dataownersmembers = %q, want 6", dataTags["bc-dataownersmembers"])
+	}
+}
+
+func TestTagProcessor_FOCUSTags(t *testing.T) {
+	processor := &TagProcessor{
+		CloudProvider: GetCloudProvider("aws"),
+		Config: &DataSourceConfig{
+			EnvironmentName:      "Production",
+			CostCenter:           "cc-100",
+			ProductOwners:        []string{"owner@example.com"},
+			OwnerTagsEnabled:     true,
+			NotApplicableEnabled: true,
+		},
+		TagPrefix: "bc-",
+	}
+
+	tags, err := processor.Process()
+	if err != nil {
+		t.Fatalf("Failed to process tags: %v", err)
+	}
+
+	// Unset values such as managedby are N/A and left out
+	got := processor.FOCUSTags(tags)
+	want := map[string]string{
+		"x_Environment": "Production",
+		"x_CostCenter":  "cc-100",
+		"x_Owner":       "owner@example.com",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FOCUSTags() = %v, want %v", got, want)
+	}
+}
+
+func TestTagProcessor_IAMTagCondition(t *testing.T) {
+	processor := &TagProcessor{
+		CloudProvider: GetCloudProvider("aws"),
+		Config: &DataSourceConfig{
+			CostCenter:           "cc-100",
+			StackName:            "payments",
+			DeletionDate:         "2030-01-01",
+			NotApplicableEnabled: true,
+		},
+		TagPrefix: "bc-",
+	}
+
+	tags, err := processor.Process()
+	if err != nil {
+		t.Fatalf("Failed to process tags: %v", err)
+	}
+
+	tests := []struct {
+		conditionKey string
+		want         string
+	}{
+		{
+			conditionKey: IAMResourceTag,
+			want:         `{"StringEquals":{"aws:ResourceTag/bc-costcenter":"cc-100","aws:ResourceTag/bc-stack":"payments"}}`,
+		},
+		{
+			conditionKey: IAMRequestTag,
+			want:         `{"StringEquals":{"aws:RequestTag/bc-costcenter":"cc-100","aws:RequestTag/bc-stack":"payments"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.conditionKey, func(t *testing.T) {
+			got, err := processor.IAMTagCondition(tags, tt.conditionKey)
+			if err != nil {
+				t.Fatalf("IAMTagCondition() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("IAMTagCondition() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	got, err := processor.IAMTagCondition(map[string]string{}, IAMResourceTag)
+	if err != nil {
+		t.Fatalf("IAMTagCondition() error = %v", err)
+	}
+	if got != "{}" {
+		t.Errorf("IAMTagCondition() = %s, want {} without tags", got)
+	}
+}
+
+// concurrentTagProcessor returns a processor exercising the git cache, owner
+// groups and sanitization warnings
+func concurrentTagProcessor() *TagProcessor {
+	return &TagProcessor{
+		CloudProvider: GetCloudProvider("aws"),
+		Config: &DataSourceConfig{
+			Namespace:             "test",
+			Environment:           "dev",
+			EnvironmentName:       "Development",
+			Availability:          "standard",
+			ManagedBy:             "terraform",
+			CostCenter:            "cc<100>",
+			OwnerTagsEnabled:      true,
+			ProductOwners:         []string{"team@example.com"},
+			DataOwners:            []string{"team@example.com"},
+			SourceRepoTagsEnabled: true,
+			SanitizationMode:      "warn",
+			AdditionalTags:        map[string]string{"purpose": "test"},
+		},
+		TagPrefix:      "bc-",
+		OwnerGroups:    map[string]Group{"team@example.com": {Email: "team@example.com", DisplayName: "Team", MemberCount: 3}},
+		OwnerGroupTags: OwnerGroupTagsMemberCount,
+	}
+}
+
+// TestTagProcessor_ConcurrentProcess shares one processor between the
+// parallel reads of many data sources; run with -race
+func TestTagProcessor_ConcurrentProcess(t *testing.T) {
+	const calls = 1000
+
+	ClearGitCache()
+	processor := concurrentTagProcessor()
+	want, err := concurrentTagProcessor().Process()
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, calls)
+	for range calls {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tags, err := processor.Process()
+			if err != nil {
+				errs <- err
+				return
+			}
+			if _, err := processor.ProcessDataTags(); err != nil {
+				errs <- err
+				return
+			}
+			if !maps.Equal(tags, want) {
+				errs <- fmt.Errorf("Process() = %v, want %v", tags, want)
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Fatal(err)
+	}
+	if len(processor.Warnings) != calls {
+		t.Errorf("len(Warnings) = %d, want %d", len(processor.Warnings), calls)
+	}
+}
+
+func BenchmarkTagProcessor_ProcessParallel(b *testing.B) {
+	processor := concurrentTagProcessor()
+	processor.Config.SanitizationMode = ""
+
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := processor.Process(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func TestConvertTags(t *testing.T) {
+	tags := map[string]string{"bc-environment": "Production", "bc-costcenter": "cc-100", "bc-empty": ""}
+
+	wantListOfMaps := []map[string]string{
+		{"key": "bc-costcenter", "value": "cc-100"},
+		{"key": "bc-empty", "value": ""},
+		{"key": "bc-environment", "value": "Production"},
+	}
+	if got := ConvertTagsToListOfMaps(tags); !reflect.DeepEqual(got, wantListOfMaps) {
+		t.Errorf("ConvertTagsToListOfMaps() = %v, want %v", got, wantListOfMaps)
+	}
+
+	wantKVP := []string{"bc-costcenter=cc-100", "bc-empty=", "bc-environment=Production"}
+	if got := ConvertTagsToKVPList(tags); !reflect.DeepEqual(got, wantKVP) {
+		t.Errorf("ConvertTagsToKVPList() = %v, want %v", got, wantKVP)
+	}
+
+	wantCSV := "bc-costcenter=cc-100,bc-empty=,bc-environment=Production"
+	if got := ConvertTagsToCommaSeparated(tags); got != wantCSV {
+		t.Errorf("ConvertTagsToCommaSeparated() = %q, want %q", got, wantCSV)
+	}
+
+	if got := ConvertTagsToListOfMaps(nil); got == nil || len(got) != 0 {
+		t.Errorf("ConvertTagsToListOfMaps(nil) = %#v, want empty list", got)
+	}
+	if got := ConvertTagsToKVPList(nil); got == nil || len(got) != 0 {
+		t.Errorf("ConvertTagsToKVPList(nil) = %#v, want empty list", got)
+	}
+	if got := ConvertTagsToCommaSeparated(nil); got != "" {
+		t.Errorf("ConvertTagsToCommaSeparated(nil) = %q, want empty", got)
+	}
+}
+
+// benchmarkTags returns n tags shaped like the output of Process
+func benchmarkTags(n int) map[string]string {
+	tags := make(map[string]string, n)
+	for i := range n {
+		tags[fmt.Sprintf("bc-tag%03d", i)] = fmt.Sprintf("value-%d-%s", i, strings.Repeat("x", i%32))
+	}
+	return tags
+}
+
+func BenchmarkConvertTagsToListOfMaps(b *testing.B) {
+	tags := benchmarkTags(25)
+	b.ReportAllocs()
+	for b.Loop() {
+		ConvertTagsToListOfMaps(tags)
+	}
+}
+
+func BenchmarkConvertTagsToKVPList(b *testing.B) {
+	tags := benchmarkTags(25)
+	b.ReportAllocs()
+	for b.Loop() {
+		ConvertTagsToKVPList(tags)
+	}
+}
+
+func BenchmarkConvertTagsToCommaSeparated(b *testing.B) {
+	tags := benchmarkTags(25)
+	b.ReportAllocs()
+	for b.Loop() {
+		ConvertTagsToCommaSeparated(tags)
+	}
+}
+
+// BenchmarkTagProcessor_ProcessLargeAdditionalTags shows that processing
+// grows linearly with the number of additional tags
+func BenchmarkTagProcessor_ProcessLargeAdditionalTags(b *testing.B) {
+	for _, n := range []int{100, 1000, 10000} {
+		b.Run(fmt.Sprintf("tags=%d", n), func(b *testing.B) {
+			processor := concurrentTagProcessor()
+			processor.Config.SanitizationMode = ""
+			processor.Config.AdditionalTags = benchmarkTags(n)
+
+			b.ReportAllocs()
+			for b.Loop() {
+				if _, err := processor.Process(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestTagProcessor_ContextDigest(t *testing.T) {
+	config := &DataSourceConfig{
+		EnvironmentName:  "Production",
+		CostCenter:       "cc-100",
+		DigestTagEnabled: true,
+	}
+
+	tests := []struct {
+		cloud   string
+		wantLen int
+	}{
+		{cloud: "aws", wantLen: 64},
+		{cloud: "gcp", wantLen: 63},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cloud, func(t *testing.T) {
+			processor := &TagProcessor{CloudProvider: GetCloudProvider(tt.cloud), Config: config, TagPrefix: "bc-"}
+			tags, err := processor.Process()
+			if err != nil {
+				t.Fatalf("Process() error = %v", err)
+			}
+
+			digest := processor.ContextDigest(tags)
+			withoutTag := maps.Clone(tags)
+			delete(withoutTag, "bc-contextdigest")
+			if digest != TagDigest(withoutTag) {
+				t.Errorf("ContextDigest() = %s, want the digest of the other tags", digest)
+			}
+			if got := tags["bc-contextdigest"]; len(got) != tt.wantLen || !strings.HasPrefix(digest, got) {
+				t.Errorf("bc-contextdigest = %q, want %d characters of %s", got, tt.wantLen, digest)
+			}
+
+			// Any modified tag changes the digest
+			tags["bc-costcenter"] = "cc-200"
+			if processor.ContextDigest(tags) == digest {
+				t.Error("ContextDigest() did not change with a modified tag")
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package context
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// LatestTagSchemaVersion is the tag schema version generated when
+// DataSourceConfig.TagSchemaVersion is zero
+const LatestTagSchemaVersion = 1
+
+// TagSchema describes how a tag schema version differs from the latest
+// version, so stacks pinned to it keep their tags when a release renames a
+// generated tag or adds one by default
+type TagSchema struct {
+	// KeyRenames maps generated tag keys of the latest version, without the
+	// tag prefix, to the keys this version emits instead
+	KeyRenames map[string]string
+	// OmittedKeys are generated tag keys of the latest version, without the
+	// tag prefix, that this version does not emit
+	OmittedKeys []string
+}
+
+// tagSchemas holds the behavior of every supported tag schema version. A
+// release changing generated tags adds a version with the new behavior and
+// records the old behavior in the earlier versions, which are never removed
+// within a major release.
+var tagSchemas = map[int]TagSchema{
+	1: {},
+}
+
+// GetTagSchema returns the behavior of a tag schema version; zero is
+// LatestTagSchemaVersion
+func GetTagSchema(version int) (TagSchema, error) {
+	if version == 0 {
+		version = LatestTagSchemaVersion
+	}
+	schema, ok := tagSchemas[version]
+	if !ok {
+		return TagSchema{}, fmt.Errorf("unsupported tag schema version %d, must be between 1 and %d", version, LatestTagSchemaVersion)
+	}
+	return schema, nil
+}
+
+// apply renames and removes the generated tags, keyed without the tag
+// prefix, that differ in this version
+func (s TagSchema) apply(tags map[string]string) {
+	for _, key := range s.OmittedKeys {
+		delete(tags, key)
+	}
+	for _, key := range slices.Sorted(maps.Keys(s.KeyRenames)) {
+		if value, ok := tags[key]; ok {
+			delete(tags, key)
+			tags[s.KeyRenames[key]] = value
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package context
+
+import (
+	"maps"
+	"reflect"
+	"testing"
+)
+
+func TestGetTagSchema(t *testing.T) {
+	for _, version := range []int{0, 1, LatestTagSchemaVersion} {
+		if _, err := GetTagSchema(version); err != nil {
+			t.Errorf("GetTagSchema(%d) error = %v", version, err)
+		}
+	}
+	for _, version := range []int{-1, LatestTagSchemaVersion + 1} {
+		if _, err := GetTagSchema(version); err == nil {
+			t.Errorf("GetTagSchema(%d) = nil error, want unsupported", version)
+		}
+	}
+}
+
+func TestTagSchema_Apply(t *testing.T) {
+	schema := TagSchema{
+		KeyRenames:  map[string]string{"expiryaction": "lifecycleaction"},
+		OmittedKeys: []string{"budgetcurrency"},
+	}
+	tags := map[string]string{"expiryaction": "delete", "budgetcurrency": "USD", "costcenter": "cc-100"}
+	schema.apply(tags)
+
+	want := map[string]string{"lifecycleaction": "delete", "costcenter": "cc-100"}
+	if !reflect.DeepEqual(tags, want) {
+		t.Errorf("apply() = %v, want %v", tags, want)
+	}
+}
+
+func TestTagProcessor_TagSchemaVersion(t *testing.T) {
+	// A later version renaming expiryaction and adding budgetcurrency by
+	// default leaves version 1 as the pinned behavior
+	saved := maps.Clone(tagSchemas)
+	t.Cleanup(func() { tagSchemas = saved })
+	tagSchemas[LatestTagSchemaVersion+1] = TagSchema{
+		KeyRenames:  map[string]string{"expiryaction": "lifecycleaction"},
+		OmittedKeys: []string{"budgetcurrency"},
+	}
+
+	config := &DataSourceConfig{
+		Namespace:       "test",
+		Environment:     "dev",
+		LifecycleAction: "delete",
+		MonthlyBudget:   100,
+		AdditionalTags:  map[string]string{"budgetcurrency": "EUR"},
+	}
+	processor := &TagProcessor{CloudProvider: GetCloudProvider("dc"), Config: config, TagPrefix: "bc-"}
+
+	config.TagSchemaVersion = LatestTagSchemaVersion
+	pinned, err := processor.Process()
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+	if pinned["bc-expiryaction"] != "delete" || pinned["bc-budgetcurrency"] != "EUR" {
+		t.Errorf("Process() with version %d = %v", LatestTagSchemaVersion, pinned)
+	}
+
+	config.TagSchemaVersion = LatestTagSchemaVersion + 1
+	renamed, err := processor.Process()
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+	if _, ok := renamed["bc-expiryaction"]; ok || renamed["bc-lifecycleaction"] != "delete" {
+		t.Errorf("Process() did not rename expiryaction: %v", renamed)
+	}
+	if renamed["bc-budgetcurrency"] != "EUR" {
+		t.Errorf("Process() omitted the additional tag budgetcurrency: %v", renamed)
+	}
+
+	config.TagSchemaVersion = LatestTagSchemaVersion + 2
+	if _, err := processor.Process(); err == nil {
+		t.Error("Process() with an unsupported version = nil error")
+	}
+	if _, err := processor.ProcessDataTags(); err == nil {
+		t.Error("ProcessDataTags() with an unsupported version = nil error")
+	}
+}
@@ -0,0 +1,121 @@
+package context
+
+import "fmt"
+
+// Outputs of the context data source holding generated tags
+const (
+	TagOutputTags     = "tags"
+	TagOutputDataTags = "data_tags"
+)
+
+// TagSpec describes a tag generated by TagProcessor. TagSpecs is the source
+// of the field to tag mapping in the registry documentation.
+type TagSpec struct {
+	// Key is the tag key without the tag prefix
+	Key string
+	// Output is the data source output holding the tag, TagOutputTags or
+	// TagOutputDataTags
+	Output string
+	// Fields are the context data source attributes the value comes from;
+	// empty for tags derived from the environment of the run
+	Fields []string
+	// Condition describes when the tag is generated; "always" tags hold the
+	// N/A value when their fields are not set, subject to
+	// not_applicable_enabled and na_fields
+	Condition string
+}
+
+// TagSpecs are the tags generated by Process and ProcessDataTags, in output
+// order of the documentation
+var TagSpecs = []TagSpec{
+	{Key: "environment", Output: TagOutputTags, Fields: []string{"environment_name"}, Condition: "always"},
+	{Key: "availability", Output: TagOutputTags, Fields: []string{"availability"}, Condition: "always"},
+	{Key: "managedby", Output: TagOutputTags, Fields: []string{"managed_by"}, Condition: "always"},
+	{Key: "deletiondate", Output: TagOutputTags, Fields: []string{"deletion_date"}, Condition: "always"},
+	{Key: "expiryaction", Output: TagOutputTags, Fields: []string{"lifecycle_action"}, Condition: "when set"},
+	{Key: "maintenancewindow", Output: TagOutputTags, Fields: []string{"maintenance_window"}, Condition: "when set"},
+	{Key: "patchgroup", Output: TagOutputTags, Fields: []string{"patch_group"}, Condition: "when set"},
+	{Key: "region", Output: TagOutputTags, Fields: []string{"region"}, Condition: "when set"},
+	{Key: AWSPatchGroupTagKey, Output: TagOutputTags, Fields: []string{"patch_group"}, Condition: "when set for aws, without the tag prefix, for Systems Manager Patch Manager"},
+	{Key: "costcenter", Output: TagOutputTags, Fields: []string{"cost_center"}, Condition: "always"},
+	{Key: "monthlybudget", Output: TagOutputTags, Fields: []string{"monthly_budget"}, Condition: "when greater than zero"},
+	{Key: "budgetcurrency", Output: TagOutputTags, Fields: []string{"budget_currency"}, Condition: "with monthlybudget, " + DefaultBudgetCurrency + " when not set"},
+	{Key: "tenant", Output: TagOutputTags, Fields: []string{"tenant"}, Condition: "when set"},
+	{Key: "attributes", Output: TagOutputTags, Fields: []string{"attributes"}, Condition: "when set, joined with the list delimiter"},
+	{Key: "stack", Output: TagOutputTags, Fields: []string{"stack_name"}, Condition: "when set"},
+	{Key: "component", Output: TagOutputTags, Fields: []string{"component"}, Condition: "when set"},
+	{Key: "application", Output: TagOutputTags, Fields: []string{"application"}, Condition: "when set"},
+	{Key: "service", Output: TagOutputTags, Fields: []string{"service"}, Condition: "when set"},
+	{Key: "tier", Output: TagOutputTags, Fields: []string{"tier"}, Condition: "when set"},
+	{Key: "businessunit", Output: TagOutputTags, Fields: []string{"business_unit"}, Condition: "when set"},
+	{Key: "division", Output: TagOutputTags, Fields: []string{"division"}, Condition: "when set"},
+	{Key: "portfolio", Output: TagOutputTags, Fields: []string{"portfolio"}, Condition: "when set"},
+	{Key: "tenantid", Output: TagOutputTags, Fields: []string{"tenant_id"}, Condition: "when set"},
+	{Key: "customer", Output: TagOutputTags, Fields: []string{"customer"}, Condition: "when set"},
+	{Key: "projectmgmtid", Output: TagOutputTags, Fields: []string{"pm_platform", "pm_project_code"}, Condition: "always, prefixed with the platform when system_prefixes_enabled"},
+	{Key: "systemid", Output: TagOutputTags, Fields: []string{"itsm_platform", "itsm_system_id"}, Condition: "always, prefixed with the platform when system_prefixes_enabled"},
+	{Key: "componentid", Output: TagOutputTags, Fields: []string{"itsm_platform", "itsm_component_id"}, Condition: "always, prefixed with the platform when system_prefixes_enabled"},
+	{Key: "instanceid", Output: TagOutputTags, Fields: []string{"itsm_platform", "itsm_instance_id"}, Condition: "always, prefixed with the platform when system_prefixes_enabled"},
+	{Key: "productowners", Output: TagOutputTags, Fields: []string{"product_owners"}, Condition: "when owner_tags_enabled"},
+	{Key: "productownersmembers", Output: TagOutputTags, Fields: []string{"product_owners"}, Condition: "when group_directory owner_tags is member_count and an owner is a directory group"},
+	{Key: "codeowners", Output: TagOutputTags, Fields: []string{"code_owners"}, Condition: "when owner_tags_enabled"},
+	{Key: "codeownersmembers", Output: TagOutputTags, Fields: []string{"code_owners"}, Condition: "when group_directory owner_tags is member_count and an owner is a directory group"},
+	{Key: "securityreview", Output: TagOutputTags, Fields: []string{"security_review"}, Condition: "always"},
+	{Key: "privacyreview", Output: TagOutputTags, Fields: []string{"privacy_review"}, Condition: "always"},
+	{Key: "sourcerepo", Output: TagOutputTags, Condition: "when source_repo_tags_enabled or provenance_tags_enabled, from the git checkout"},
+	{Key: "sourcecommit", Output: TagOutputTags, Condition: "when source_repo_tags_enabled or provenance_tags_enabled, from the git checkout"},
+	{Key: builderIDTagKey, Output: TagOutputTags, Condition: "when provenance_tags_enabled in GitHub Actions"},
+	{Key: buildInvocationTagKey, Output: TagOutputTags, Condition: "when provenance_tags_enabled in GitHub Actions"},
+	{Key: builderSubjectTagKey, Output: TagOutputTags, Condition: "when provenance_tags_enabled in GitHub Actions with an OIDC token"},
+	{Key: builderAudienceTagKey, Output: TagOutputTags, Condition: "when provenance_tags_enabled in GitHub Actions with an OIDC token"},
+	{Key: "terraformversion", Output: TagOutputTags, Condition: "when tooling_tags_enabled"},
+	{Key: "contextproviderversion", Output: TagOutputTags, Condition: "when tooling_tags_enabled"},
+	{Key: digestTagKey, Output: TagOutputTags, Condition: "when digest_tag_enabled, the digest of the other tags"},
+	{Key: "sensitivity", Output: TagOutputDataTags, Fields: []string{"sensitivity"}, Condition: "always"},
+	{Key: "dataregulations", Output: TagOutputDataTags, Fields: []string{"data_regs"}, Condition: "always, joined with the list delimiter"},
+	{Key: "reg-<regulation>", Output: TagOutputDataTags, Fields: []string{"data_regs"}, Condition: "when regulation_tags_enabled, one tag per regulation set to true"},
+	{Key: "dataretention", Output: TagOutputDataTags, Fields: []string{"data_retention"}, Condition: "when set"},
+	{Key: "dataresidency", Output: TagOutputDataTags, Fields: []string{"data_residency"}, Condition: "when set, joined with the list delimiter"},
+	{Key: "sovereignty", Output: TagOutputDataTags, Fields: []string{"sovereignty_requirements"}, Condition: "when set, joined with the list delimiter"},
+	{Key: "dataowners", Output: TagOutputDataTags, Fields: []string{"data_owners"}, Condition: "always, N/A when owner_tags_enabled is false"},
+	{Key: "dataownersmembers", Output: TagOutputDataTags, Fields: []string{"data_owners"}, Condition: "when group_directory owner_tags is member_count and an owner is a directory group"},
+}
+
+// CloudTagRules are the rules a cloud provider applies to tag values
+type CloudTagRules struct {
+	CloudProvider string
+	// MaxLength is the maximum length of a tag value
+	MaxLength int
+	// Delimiter joins list values
+	Delimiter string
+	// NAValue is the placeholder of tags without a value
+	NAValue string
+	// Sanitization describes how values are sanitized
+	Sanitization string
+}
+
+// sanitizationRules describe the SanitizeTagValue implementations, keyed by
+// cloud provider
+var sanitizationRules = map[string]string{
+	"aws": fmt.Sprintf("characters matching `%s` are replaced with `_`", awsSanitizeRegex),
+	"az":  fmt.Sprintf("characters matching `%s` are removed", azureSanitizeRegex),
+	"gcp": fmt.Sprintf("values are lowercased and characters matching `%s` are replaced with `-`", gcpSanitizeRegex),
+	"dc":  fmt.Sprintf("characters matching `%s` are replaced with `_`", defaultSanitizeRegex),
+}
+
+// TagRules returns the tag value rules of a cloud provider; providers
+// without their own rules use those of dc
+func TagRules(cloudProvider string) CloudTagRules {
+	cp := GetCloudProvider(cloudProvider)
+	sanitization, ok := sanitizationRules[cloudProvider]
+	if !ok {
+		sanitization = sanitizationRules["dc"]
+	}
+	return CloudTagRules{
+		CloudProvider: cloudProvider,
+		MaxLength:     cp.GetMaxTagLength(),
+		Delimiter:     cp.GetDelimiter(),
+		NAValue:       cp.GetNAValue(),
+		Sanitization:  sanitization,
+	}
+}
@@ -0,0 +1,131 @@
+package context
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestTagSpecs checks that TagSpecs matches the tags generated from a config
+// setting every field, so the documentation generated from it cannot drift
+func TestTagSpecs(t *testing.T) {
+	config := &DataSourceConfig{
+		Tenant:                  "acme",
+		Attributes:              []string{"blue"},
+		EnvironmentName:         "Production",
+		StackName:               "web",
+		Component:               "api",
+		Application:             "checkout",
+		Service:                 "payments-api",
+		Tier:                    "app",
+		BusinessUnit:            "retail",
+		Division:                "payments",
+		Portfolio:               "cards",
+		TenantID:                "t-0042",
+		Customer:                "globex",
+		Availability:            "critical",
+		ManagedBy:               "terraform",
+		DeletionDate:            "2030-01-01",
+		LifecycleAction:         "delete",
+		MaintenanceWindow:       "sun:03:00-sun:05:00",
+		PatchGroup:              "web-servers",
+		Region:                  "eu-central-1",
+		PMPlatform:              "jira",
+		PMProjectCode:           "PROJ",
+		ITSMPlatform:            "snow",
+		ITSMSystemID:            "sys",
+		ITSMComponentID:         "comp",
+		ITSMInstanceID:          "inst",
+		CostCenter:              "cc-100",
+		ProductOwners:           []string{"team@example.com"},
+		CodeOwners:              []string{"team@example.com"},
+		DataOwners:              []string{"team@example.com"},
+		MonthlyBudget:           100,
+		Sensitivity:             "confidential",
+		DataRegs:                []string{"GDPR"},
+		DataRetention:           "P7Y",
+		DataResidency:           []string{"EU"},
+		SovereigntyRequirements: []string{"EUCS"},
+		SecurityReview:          "2024-01-01",
+		PrivacyReview:           "2024-01-01",
+		SystemPrefixesEnabled:   true,
+		OwnerTagsEnabled:        true,
+		ToolingTagsEnabled:      true,
+		RegulationTagsEnabled:   true,
+		DigestTagEnabled:        true,
+	}
+	processor := &TagProcessor{
+		CloudProvider:    GetCloudProvider("aws"),
+		Config:           config,
+		TagPrefix:        "bc-",
+		TerraformVersion: "1.9.0",
+		ProviderVersion:  "1.0.0",
+		OwnerGroups:      map[string]Group{"team@example.com": {Email: "team@example.com", MemberCount: 3}},
+		OwnerGroupTags:   OwnerGroupTagsMemberCount,
+	}
+
+	tags, err := processor.Process()
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+	dataTags, err := processor.ProcessDataTags()
+	if err != nil {
+		t.Fatalf("ProcessDataTags() error = %v", err)
+	}
+	generated := map[string]map[string]string{
+		TagOutputTags:     processor.UnprefixedTags(tags),
+		TagOutputDataTags: processor.UnprefixedTags(dataTags),
+	}
+
+	specs := map[string]TagSpec{}
+	for _, spec := range TagSpecs {
+		if _, ok := specs[spec.Key]; ok {
+			t.Errorf("duplicate TagSpec %s", spec.Key)
+		}
+		specs[spec.Key] = spec
+	}
+
+	for output, tags := range generated {
+		for key := range tags {
+			specKey := key
+			if strings.HasPrefix(key, "reg-") {
+				specKey = "reg-<regulation>"
+			}
+			spec, ok := specs[specKey]
+			if !ok {
+				t.Errorf("%s tag %s has no TagSpec", output, key)
+			} else if spec.Output != output {
+				t.Errorf("TagSpec %s output = %s, generated in %s", key, spec.Output, output)
+			}
+		}
+	}
+
+	// Tags derived from the environment of the run may be missing
+	for _, spec := range TagSpecs {
+		if len(spec.Fields) == 0 || spec.Key == "reg-<regulation>" {
+			continue
+		}
+		if _, ok := generated[spec.Output][spec.Key]; !ok {
+			t.Errorf("TagSpec %s is not generated in %s", spec.Key, spec.Output)
+		}
+	}
+}
+
+func TestTagRules(t *testing.T) {
+	tests := []struct {
+		cloudProvider string
+		want          CloudTagRules
+	}{
+		{"aws", CloudTagRules{CloudProvider: "aws", MaxLength: 256, Delimiter: " ", NAValue: "N/A", Sanitization: sanitizationRules["aws"]}},
+		{"az", CloudTagRules{CloudProvider: "az", MaxLength: 256, Delimiter: ";", NAValue: "NotApplicable", Sanitization: sanitizationRules["az"]}},
+		{"gcp", CloudTagRules{CloudProvider: "gcp", MaxLength: 63, Delimiter: "_", NAValue: "not_applicable", Sanitization: sanitizationRules["gcp"]}},
+		{"oci", CloudTagRules{CloudProvider: "oci", MaxLength: 63, Delimiter: ";", NAValue: "N/A", Sanitization: sanitizationRules["dc"]}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cloudProvider, func(t *testing.T) {
+			if got := TagRules(tt.cloudProvider); got != tt.want {
+				t.Errorf("TagRules() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
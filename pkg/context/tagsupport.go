@@ -0,0 +1,23 @@
+package context
+
+// TagSupport describes how a Terraform resource type accepts tags
+type TagSupport struct {
+	// Supported is false for resource types that cannot be tagged
+	Supported bool
+	// Attribute is the argument that takes the tags, such as tags, labels
+	// or tag for repeated blocks
+	Attribute string
+	// MaxTags is the maximum number of tags on one resource
+	MaxTags int
+	// Notes describes special constraints
+	Notes string
+}
+
+// LookupTagSupport returns the tag support of a Terraform resource type, such
+// as aws_s3_object, and whether the type is in the table generated from
+// tagsupport.csv. Types not in the table return false, and callers should
+// not assume they can be tagged.
+func LookupTagSupport(resourceType string) (TagSupport, bool) {
+	support, ok := tagSupportTable[resourceType]
+	return support, ok
+}
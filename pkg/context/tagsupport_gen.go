@@ -0,0 +1,52 @@
+// Code generated by gentagsupport from tagsupport.csv; DO NOT EDIT.
+
+package context
+
+var tagSupportTable = map[string]TagSupport{
+	"aws_autoscaling_group":               {Supported: true, Attribute: "tag", MaxTags: 50, Notes: "Uses repeated tag blocks with propagate_at_launch instead of a tags map"},
+	"aws_cloudwatch_log_group":            {Supported: true, Attribute: "tags", MaxTags: 50, Notes: ""},
+	"aws_db_instance":                     {Supported: true, Attribute: "tags", MaxTags: 50, Notes: ""},
+	"aws_dynamodb_table":                  {Supported: true, Attribute: "tags", MaxTags: 50, Notes: ""},
+	"aws_ecs_cluster":                     {Supported: true, Attribute: "tags", MaxTags: 50, Notes: ""},
+	"aws_eks_cluster":                     {Supported: true, Attribute: "tags", MaxTags: 50, Notes: ""},
+	"aws_iam_policy":                      {Supported: true, Attribute: "tags", MaxTags: 50, Notes: ""},
+	"aws_iam_role":                        {Supported: true, Attribute: "tags", MaxTags: 50, Notes: ""},
+	"aws_iam_role_policy":                 {Supported: false, Attribute: "", MaxTags: 0, Notes: "Inline policies cannot be tagged; tag the role"},
+	"aws_iam_role_policy_attachment":      {Supported: false, Attribute: "", MaxTags: 0, Notes: "Attachments cannot be tagged"},
+	"aws_iam_user":                        {Supported: true, Attribute: "tags", MaxTags: 50, Notes: ""},
+	"aws_instance":                        {Supported: true, Attribute: "tags", MaxTags: 50, Notes: "volume_tags applies the tags to attached volumes"},
+	"aws_kms_key":                         {Supported: true, Attribute: "tags", MaxTags: 50, Notes: ""},
+	"aws_lambda_function":                 {Supported: true, Attribute: "tags", MaxTags: 50, Notes: ""},
+	"aws_lambda_permission":               {Supported: false, Attribute: "", MaxTags: 0, Notes: "Permissions cannot be tagged"},
+	"aws_route":                           {Supported: false, Attribute: "", MaxTags: 0, Notes: "Routes cannot be tagged; tag the route table"},
+	"aws_route_table_association":         {Supported: false, Attribute: "", MaxTags: 0, Notes: "Associations cannot be tagged"},
+	"aws_s3_bucket":                       {Supported: true, Attribute: "tags", MaxTags: 50, Notes: ""},
+	"aws_s3_bucket_policy":                {Supported: false, Attribute: "", MaxTags: 0, Notes: "Bucket policies cannot be tagged; tag the bucket"},
+	"aws_s3_object":                       {Supported: true, Attribute: "tags", MaxTags: 10, Notes: "S3 objects allow at most 10 tags"},
+	"aws_security_group":                  {Supported: true, Attribute: "tags", MaxTags: 50, Notes: ""},
+	"aws_security_group_rule":             {Supported: false, Attribute: "", MaxTags: 0, Notes: "Cannot be tagged; aws_vpc_security_group_ingress_rule and aws_vpc_security_group_egress_rule support tags"},
+	"aws_sns_topic":                       {Supported: true, Attribute: "tags", MaxTags: 50, Notes: ""},
+	"aws_sqs_queue":                       {Supported: true, Attribute: "tags", MaxTags: 50, Notes: ""},
+	"aws_subnet":                          {Supported: true, Attribute: "tags", MaxTags: 50, Notes: ""},
+	"aws_volume_attachment":               {Supported: false, Attribute: "", MaxTags: 0, Notes: "Attachments cannot be tagged"},
+	"aws_vpc":                             {Supported: true, Attribute: "tags", MaxTags: 50, Notes: ""},
+	"aws_vpc_security_group_egress_rule":  {Supported: true, Attribute: "tags", MaxTags: 50, Notes: ""},
+	"aws_vpc_security_group_ingress_rule": {Supported: true, Attribute: "tags", MaxTags: 50, Notes: ""},
+	"azurerm_key_vault":                   {Supported: true, Attribute: "tags", MaxTags: 50, Notes: ""},
+	"azurerm_linux_virtual_machine":       {Supported: true, Attribute: "tags", MaxTags: 50, Notes: ""},
+	"azurerm_network_security_rule":       {Supported: false, Attribute: "", MaxTags: 0, Notes: "Rules cannot be tagged; tag the network security group"},
+	"azurerm_network_security_group":      {Supported: true, Attribute: "tags", MaxTags: 50, Notes: ""},
+	"azurerm_resource_group":              {Supported: true, Attribute: "tags", MaxTags: 50, Notes: "Tags are not inherited by resources unless an Azure Policy copies them"},
+	"azurerm_role_assignment":             {Supported: false, Attribute: "", MaxTags: 0, Notes: "Role assignments cannot be tagged"},
+	"azurerm_storage_account":             {Supported: true, Attribute: "tags", MaxTags: 50, Notes: ""},
+	"azurerm_subnet":                      {Supported: false, Attribute: "", MaxTags: 0, Notes: "Subnets cannot be tagged; tag the virtual network"},
+	"azurerm_virtual_network":             {Supported: true, Attribute: "tags", MaxTags: 50, Notes: ""},
+	"google_compute_firewall":             {Supported: false, Attribute: "", MaxTags: 0, Notes: "Firewall rules do not support labels"},
+	"google_compute_instance":             {Supported: true, Attribute: "labels", MaxTags: 64, Notes: ""},
+	"google_project":                      {Supported: true, Attribute: "labels", MaxTags: 64, Notes: ""},
+	"google_project_iam_member":           {Supported: false, Attribute: "", MaxTags: 0, Notes: "IAM bindings do not support labels"},
+	"google_pubsub_topic":                 {Supported: true, Attribute: "labels", MaxTags: 64, Notes: ""},
+	"google_service_account":              {Supported: false, Attribute: "", MaxTags: 0, Notes: "Service accounts do not support labels"},
+	"google_sql_database_instance":        {Supported: true, Attribute: "settings.user_labels", MaxTags: 64, Notes: "Labels are set in the user_labels of the settings block"},
+	"google_storage_bucket":               {Supported: true, Attribute: "labels", MaxTags: 64, Notes: ""},
+}
@@ -0,0 +1,79 @@
+package context
+
+import (
+	"encoding/csv"
+	"os"
+	"strconv"
+	"testing"
+)
+
+func TestLookupTagSupport(t *testing.T) {
+	tests := []struct {
+		resourceType string
+		wantKnown    bool
+		want         TagSupport
+	}{
+		{
+			resourceType: "aws_s3_bucket",
+			wantKnown:    true,
+			want:         TagSupport{Supported: true, Attribute: "tags", MaxTags: 50},
+		},
+		{
+			resourceType: "aws_s3_object",
+			wantKnown:    true,
+			want:         TagSupport{Supported: true, Attribute: "tags", MaxTags: 10, Notes: "S3 objects allow at most 10 tags"},
+		},
+		{
+			resourceType: "google_storage_bucket",
+			wantKnown:    true,
+			want:         TagSupport{Supported: true, Attribute: "labels", MaxTags: 64},
+		},
+		{
+			resourceType: "aws_iam_role_policy_attachment",
+			wantKnown:    true,
+			want:         TagSupport{Notes: "Attachments cannot be tagged"},
+		},
+		{
+			resourceType: "aws_unknown_thing",
+			wantKnown:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.resourceType, func(t *testing.T) {
+			got, known := LookupTagSupport(tt.resourceType)
+			if known != tt.wantKnown {
+				t.Fatalf("LookupTagSupport() known = %v, want %v", known, tt.wantKnown)
+			}
+			if got != tt.want {
+				t.Errorf("LookupTagSupport() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestTagSupportTableGenerated fails when tagsupport.csv was changed without
+// running go generate in tools
+func TestTagSupportTableGenerated(t *testing.T) {
+	f, err := os.Open("tagsupport.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	records, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(records)-1 != len(tagSupportTable) {
+		t.Errorf("tagSupportTable has %d entries, tagsupport.csv has %d", len(tagSupportTable), len(records)-1)
+	}
+	for _, record := range records[1:] {
+		maxTags, _ := strconv.Atoi(record[2])
+		want := TagSupport{Supported: record[1] != "", Attribute: record[1], MaxTags: maxTags, Notes: record[3]}
+		if got := tagSupportTable[record[0]]; got != want {
+			t.Errorf("tagSupportTable[%s] = %+v, want %+v", record[0], got, want)
+		}
+	}
+}
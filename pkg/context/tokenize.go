@@ -0,0 +1,66 @@
+package context
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// TokenizationKeyEnvVar is the environment variable holding the HMAC key of
+// tokenized tag values
+const TokenizationKeyEnvVar = "CONTEXT_PROVIDER_TOKENIZATION_KEY"
+
+// tokenPrefix marks tokenized tag values
+const tokenPrefix = "tok-"
+
+// TokenizableTagKeys are the tag keys, without the tag prefix, whose values
+// can be replaced with a token because they identify systems, budgets or
+// people rather than describe the resource
+var TokenizableTagKeys = []string{
+	"costcenter", "projectmgmtid", "systemid", "componentid", "instanceid",
+	"tenant", "tenantid", "customer", "productowners", "codeowners", "dataowners",
+}
+
+// TokenizeValue returns the token of value: tok- followed by the first 16
+// hex characters of its HMAC-SHA256 with key. The same value and key always
+// give the same token, so tagged resources stay correlatable without the
+// raw value appearing in cloud consoles.
+func TokenizeValue(key []byte, value string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(value))
+	return tokenPrefix + hex.EncodeToString(mac.Sum(nil))[:16]
+}
+
+// ValidateTokenizeFields validates tokenize field list entries
+func ValidateTokenizeFields(fields []string) error {
+	for _, field := range fields {
+		if !slices.Contains(TokenizableTagKeys, field) {
+			return fmt.Errorf("invalid tokenize field '%s', must be one of %s", field, strings.Join(TokenizableTagKeys, ", "))
+		}
+	}
+
+	return nil
+}
+
+// tokenize replaces the values of the Config.TokenizeFields keys in tags
+// with their token. Empty and N/A values are kept.
+func (tp *TagProcessor) tokenize(tags map[string]string) error {
+	if len(tp.Config.TokenizeFields) == 0 {
+		return nil
+	}
+	if len(tp.TokenizationKey) == 0 {
+		return errors.New("tokenize_fields requires the " + TokenizationKeyEnvVar + " environment variable")
+	}
+
+	naValue := tp.naValue()
+	for _, key := range tp.Config.TokenizeFields {
+		if value, ok := tags[key]; ok && value != "" && value != naValue {
+			tags[key] = TokenizeValue(tp.TokenizationKey, value)
+		}
+	}
+	return nil
+}
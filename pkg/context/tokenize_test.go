@@ -0,0 +1,79 @@
+package context
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTokenizeValue(t *testing.T) {
+	key := []byte("secret")
+
+	token := TokenizeValue(key, "INC-12345")
+	if !strings.HasPrefix(token, "tok-") || len(token) != 20 {
+		t.Errorf("TokenizeValue() = %q, want tok- and 16 hex characters", token)
+	}
+	if again := TokenizeValue(key, "INC-12345"); again != token {
+		t.Errorf("TokenizeValue() = %q then %q, want a stable token", token, again)
+	}
+	if other := TokenizeValue(key, "INC-12346"); other == token {
+		t.Error("TokenizeValue() is the same for different values")
+	}
+	if otherKey := TokenizeValue([]byte("other"), "INC-12345"); otherKey == token {
+		t.Error("TokenizeValue() is the same for different keys")
+	}
+}
+
+func TestValidateTokenizeFields(t *testing.T) {
+	if err := ValidateTokenizeFields([]string{"instanceid", "costcenter"}); err != nil {
+		t.Errorf("ValidateTokenizeFields() error = %v", err)
+	}
+	if err := ValidateTokenizeFields([]string{"environment"}); err == nil {
+		t.Error("ValidateTokenizeFields(environment) error = nil, want error")
+	}
+}
+
+func TestTagProcessor_Tokenize(t *testing.T) {
+	key := []byte("secret")
+	config := &DataSourceConfig{
+		EnvironmentName:      "Production",
+		ITSMInstanceID:       "INC-12345",
+		ITSMSystemID:         "SYS-1",
+		NotApplicableEnabled: true,
+		OwnerTagsEnabled:     true,
+		DataOwners:           []string{"data@example.com"},
+		TokenizeFields:       []string{"instanceid", "costcenter", "dataowners"},
+	}
+	processor := &TagProcessor{
+		CloudProvider:   GetCloudProvider("aws"),
+		Config:          config,
+		TagPrefix:       "bc-",
+		TokenizationKey: key,
+	}
+
+	tags, err := processor.Process()
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+	if got, want := tags["bc-instanceid"], TokenizeValue(key, "INC-12345"); got != want {
+		t.Errorf("bc-instanceid = %q, want %q", got, want)
+	}
+	if got := tags["bc-systemid"]; got != "SYS-1" {
+		t.Errorf("bc-systemid = %q, want the raw value", got)
+	}
+	if got := tags["bc-costcenter"]; got != "N/A" {
+		t.Errorf("bc-costcenter = %q, want the N/A placeholder kept", got)
+	}
+
+	dataTags, err := processor.ProcessDataTags()
+	if err != nil {
+		t.Fatalf("ProcessDataTags() error = %v", err)
+	}
+	if got, want := dataTags["bc-dataowners"], TokenizeValue(key, "data@example.com"); got != want {
+		t.Errorf("bc-dataowners = %q, want %q", got, want)
+	}
+
+	processor.TokenizationKey = nil
+	if _, err := processor.Process(); err == nil || !strings.Contains(err.Error(), TokenizationKeyEnvVar) {
+		t.Errorf("Process() error = %v, want missing key error", err)
+	}
+}
@@ -0,0 +1,117 @@
+package context
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Types of TypedTag values
+const (
+	TypedTagString = "string"
+	TypedTagNumber = "number"
+	TypedTagBool   = "bool"
+)
+
+// TypedTagTypes are the types of TypedTag values
+var TypedTagTypes = []string{TypedTagString, TypedTagNumber, TypedTagBool}
+
+// TypedTag is an additional tag whose value is a string, a number or a
+// bool. Its canonical string form goes into the tags, and numbers and bools
+// are also returned typed by NumberTags and BoolTags for platforms that
+// accept them, so callers do not stringify them each their own way.
+type TypedTag struct {
+	Value string `json:"value" yaml:"value"`
+	// Type is string, number or bool; empty behaves as string
+	Type string `json:"type,omitempty" yaml:"type,omitempty"`
+}
+
+// TagValue returns the canonical string form of the value: numbers in
+// decimal notation without superfluous zeros, so 01.50 and 1.5e0 become
+// 1.5, and bools as true or false
+func (t TypedTag) TagValue() (string, error) {
+	switch t.Type {
+	case "", TypedTagString:
+		return t.Value, nil
+	case TypedTagNumber:
+		n, err := t.number()
+		if err != nil {
+			return "", err
+		}
+		return strconv.FormatFloat(n, 'f', -1, 64), nil
+	case TypedTagBool:
+		b, err := t.bool()
+		if err != nil {
+			return "", err
+		}
+		return strconv.FormatBool(b), nil
+	}
+	return "", fmt.Errorf("typed tag type '%s' must be one of %s", t.Type, strings.Join(TypedTagTypes, ", "))
+}
+
+// number parses the value as a finite number
+func (t TypedTag) number() (float64, error) {
+	n, err := strconv.ParseFloat(strings.TrimSpace(t.Value), 64)
+	if err != nil || math.IsInf(n, 0) || math.IsNaN(n) {
+		return 0, fmt.Errorf("typed tag value '%s' is not a number", t.Value)
+	}
+	return n, nil
+}
+
+// bool parses the value as true or false, in any case
+func (t TypedTag) bool() (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(t.Value)) {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	}
+	return false, fmt.Errorf("typed tag value '%s' is not true or false", t.Value)
+}
+
+// typedTagValues returns Config.AdditionalTypedTags in their canonical
+// string form
+func (tp *TagProcessor) typedTagValues() (map[string]string, error) {
+	values := make(map[string]string, len(tp.Config.AdditionalTypedTags))
+	for key, tag := range tp.Config.AdditionalTypedTags {
+		value, err := tag.TagValue()
+		if err != nil {
+			return nil, fmt.Errorf("additional typed tag %s: %w", key, err)
+		}
+		values[key] = value
+	}
+	return values, nil
+}
+
+// NumberTags returns the number values of the Config.AdditionalTypedTags
+// in tags, as returned by Process, keyed with the tag prefix. Values that
+// are not numbers are left out.
+func (tp *TagProcessor) NumberTags(tags map[string]string) map[string]float64 {
+	numbers := map[string]float64{}
+	for key, tag := range tp.Config.AdditionalTypedTags {
+		if _, ok := tags[tp.TagPrefix+key]; !ok || tag.Type != TypedTagNumber {
+			continue
+		}
+		if n, err := tag.number(); err == nil {
+			numbers[tp.TagPrefix+key] = n
+		}
+	}
+	return numbers
+}
+
+// BoolTags returns the bool values of the Config.AdditionalTypedTags in
+// tags, as returned by Process, keyed with the tag prefix. Values that are
+// not bools are left out.
+func (tp *TagProcessor) BoolTags(tags map[string]string) map[string]bool {
+	bools := map[string]bool{}
+	for key, tag := range tp.Config.AdditionalTypedTags {
+		if _, ok := tags[tp.TagPrefix+key]; !ok || tag.Type != TypedTagBool {
+			continue
+		}
+		if b, err := tag.bool(); err == nil {
+			bools[tp.TagPrefix+key] = b
+		}
+	}
+	return bools
+}
@@ -0,0 +1,103 @@
+package context
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestTypedTag_TagValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		tag     TypedTag
+		want    string
+		wantErr bool
+	}{
+		{name: "untyped", tag: TypedTag{Value: "Team A"}, want: "Team A"},
+		{name: "string", tag: TypedTag{Value: "01.50", Type: "string"}, want: "01.50"},
+		{name: "number", tag: TypedTag{Value: "01.50", Type: "number"}, want: "1.5"},
+		{name: "integer", tag: TypedTag{Value: "42", Type: "number"}, want: "42"},
+		{name: "exponent", tag: TypedTag{Value: "1.5e3", Type: "number"}, want: "1500"},
+		{name: "negative", tag: TypedTag{Value: " -3 ", Type: "number"}, want: "-3"},
+		{name: "bool", tag: TypedTag{Value: "TRUE", Type: "bool"}, want: "true"},
+		{name: "false", tag: TypedTag{Value: "false", Type: "bool"}, want: "false"},
+		{name: "not a number", tag: TypedTag{Value: "ten", Type: "number"}, wantErr: true},
+		{name: "infinity", tag: TypedTag{Value: "Inf", Type: "number"}, wantErr: true},
+		{name: "not a bool", tag: TypedTag{Value: "yes", Type: "bool"}, wantErr: true},
+		{name: "unknown type", tag: TypedTag{Value: "1", Type: "int"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.tag.TagValue()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TagValue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("TagValue() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTagProcessor_TypedTags(t *testing.T) {
+	processor := &TagProcessor{
+		CloudProvider: GetCloudProvider("aws"),
+		Config: &DataSourceConfig{
+			AdditionalTags: map[string]string{"replicas": "2", "team": "core"},
+			AdditionalTypedTags: map[string]TypedTag{
+				"replicas":   {Value: "3", Type: "number"},
+				"ratio":      {Value: "0.50", Type: "number"},
+				"backup":     {Value: "True", Type: "bool"},
+				"costcentre": {Value: "cc-1"},
+			},
+		},
+		TagPrefix: "bc-",
+	}
+
+	tags, err := processor.Process()
+	if err != nil {
+		t.Fatalf("Failed to process tags: %v", err)
+	}
+	want := map[string]string{"bc-replicas": "3", "bc-ratio": "0.5", "bc-backup": "true", "bc-costcentre": "cc-1", "bc-team": "core"}
+	for key, value := range want {
+		if tags[key] != value {
+			t.Errorf("tags[%s] = %q, want %q", key, tags[key], value)
+		}
+	}
+
+	if got, want := processor.NumberTags(tags), map[string]float64{"bc-replicas": 3, "bc-ratio": 0.5}; !maps.Equal(got, want) {
+		t.Errorf("NumberTags() = %v, want %v", got, want)
+	}
+	if got, want := processor.BoolTags(tags), map[string]bool{"bc-backup": true}; !maps.Equal(got, want) {
+		t.Errorf("BoolTags() = %v, want %v", got, want)
+	}
+	if got := processor.BoolTags(map[string]string{}); len(got) != 0 {
+		t.Errorf("BoolTags() of tags without the typed tags = %v, want empty", got)
+	}
+
+	processor.Config.AdditionalTypedTags["replicas"] = TypedTag{Value: "three", Type: "number"}
+	if _, err := processor.Process(); err == nil {
+		t.Error("Expected an error for a number typed tag that is not a number")
+	}
+}
+
+func TestTagProcessor_TypedTagsGCP(t *testing.T) {
+	processor := &TagProcessor{
+		CloudProvider: GetCloudProvider("gcp"),
+		Config: &DataSourceConfig{
+			AdditionalTypedTags: map[string]TypedTag{"ratio": {Value: "0.5", Type: "number"}},
+		},
+	}
+
+	tags, err := processor.Process()
+	if err != nil {
+		t.Fatalf("Failed to process tags: %v", err)
+	}
+	// GCP labels are strings of restricted characters, while the typed value is kept
+	if tags["ratio"] == "" {
+		t.Error("Expected the ratio label to be set")
+	}
+	if got := processor.NumberTags(tags)["ratio"]; got != 0.5 {
+		t.Errorf("NumberTags()[ratio] = %v, want 0.5", got)
+	}
+}
@@ -2,14 +2,31 @@ package context
 
 import (
 	"fmt"
+	"maps"
+	"math"
+	"net/mail"
 	"regexp"
+	"slices"
+	"strings"
 	"time"
+	"unicode"
+
+	"golang.org/x/net/idna"
 )
 
 var (
 	namespaceRegex   = regexp.MustCompile(`^[a-z][a-z0-9-]{0,6}[a-z0-9]$|^[a-z]$`)
 	environmentRegex = regexp.MustCompile(`^[a-z][a-z0-9-]{0,6}[a-z0-9]$|^[a-z]$`)
+	tenantRegex      = regexp.MustCompile(`^[a-z][a-z0-9-]{0,6}[a-z0-9]$|^[a-z]$`)
+	orgUnitRegex     = regexp.MustCompile(`^[a-z][a-z0-9-]{0,30}[a-z0-9]$|^[a-z]$`)
+	tenantIDRegex    = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,63}$`)
+	customerRegex    = regexp.MustCompile(`^[a-z][a-z0-9-]{0,14}[a-z0-9]$|^[a-z]$`)
+	regionRegex      = regexp.MustCompile(`^[a-z][a-z0-9-]{0,30}[a-z0-9]$`)
+	residencyRegex   = regexp.MustCompile(`^([A-Z]{2})(-[A-Z0-9]{1,3})?$`)
+	durationRegex    = regexp.MustCompile(`^P(\d+Y)?(\d+M)?(\d+W)?(\d+D)?(T(\d+H)?(\d+M)?(\d+S)?)?$`)
+	attributeRegex   = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,6}[a-z0-9]$|^[a-z0-9]$`)
 	dateRegex        = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+	currencyRegex    = regexp.MustCompile(`^[A-Z]{3}$`)
 	emailRegex       = regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
 )
 
@@ -59,37 +76,187 @@ var ValidSensitivityLevels = map[string]bool{
 	"critical":     true,
 }
 
-// ValidateNamespace validates namespace format
+// ValidTiers contains the list of valid application tiers
+var ValidTiers = map[string]bool{
+	"":     true, // Allow empty
+	"web":  true,
+	"app":  true,
+	"data": true,
+}
+
+// ValidLifecycleActions contains the list of valid lifecycle actions taken
+// when a resource reaches its deletion date
+var ValidLifecycleActions = map[string]bool{
+	"":       true, // Allow empty
+	"delete": true,
+	"stop":   true,
+	"notify": true,
+}
+
+// ValidNameDelimiters contains the list of valid name prefix delimiters
+var ValidNameDelimiters = map[string]bool{
+	"-": true,
+	"_": true,
+	"":  true, // No delimiter, e.g. for Azure storage accounts
+}
+
+// ValidReservedWordActions contains the list of valid actions taken when a
+// name prefix contains a reserved word
+var ValidReservedWordActions = map[string]bool{
+	"":       true, // Allow empty, no check
+	"error":  true,
+	"remove": true,
+}
+
+// ValidSanitizationModes contains the list of valid handling modes for tag
+// values changed by cloud provider sanitization
+var ValidSanitizationModes = map[string]bool{
+	"":      true, // Allow empty, same as fix
+	"fix":   true,
+	"warn":  true,
+	"error": true,
+}
+
+// ValidLengthOverflowPolicies contains the list of valid handling policies for
+// tag values longer than the cloud provider limit
+var ValidLengthOverflowPolicies = map[string]bool{
+	"":                            true, // Allow empty, same as truncate
+	"truncate":                    true,
+	"truncate_with_ellipsis_hash": true,
+	"error":                       true,
+}
+
+// ValidateNamespace validates namespace format with the default limit of
+// DefaultNamingConstraints
 func ValidateNamespace(namespace string) error {
-	if namespace == "" {
+	return DefaultNamingConstraints().ValidateNamespace(namespace)
+}
+
+// ValidateAllowedNamespace checks namespace against the allowed namespaces of
+// a registry, such as the official business units. Empty namespaces and an
+// empty allowed list are accepted; registry, when set, is included in the
+// error so users can find the valid values.
+func ValidateAllowedNamespace(namespace string, allowed []string, registry string) error {
+	if namespace == "" || len(allowed) == 0 || slices.Contains(allowed, namespace) {
+		return nil
+	}
+
+	if registry != "" {
+		return fmt.Errorf("namespace %s is not in the namespace registry, see %s", namespace, registry)
+	}
+	return fmt.Errorf("namespace %s is not one of the allowed namespaces: %s", namespace, strings.Join(allowed, ", "))
+}
+
+// ValidateEnvironment validates environment format with the default limit of
+// DefaultNamingConstraints
+func ValidateEnvironment(environment string) error {
+	return DefaultNamingConstraints().ValidateEnvironment(environment)
+}
+
+// ValidateTenant validates tenant format
+func ValidateTenant(tenant string) error {
+	if tenant == "" {
 		return nil // Optional field
 	}
 
-	if len(namespace) > 8 {
-		return fmt.Errorf("namespace must be 1-8 characters, got %d: %s", len(namespace), namespace)
+	if len(tenant) > 8 {
+		return fmt.Errorf("tenant must be 1-8 characters, got %d: %s", len(tenant), tenant)
 	}
 
-	if !namespaceRegex.MatchString(namespace) {
-		return fmt.Errorf("namespace must be lowercase alphanumeric with hyphens (1-8 chars): %s", namespace)
+	if !tenantRegex.MatchString(tenant) {
+		return fmt.Errorf("tenant must be lowercase alphanumeric with hyphens (1-8 chars): %s", tenant)
 	}
 
 	return nil
 }
 
-// ValidateEnvironment validates environment format
-func ValidateEnvironment(environment string) error {
-	if environment == "" {
+// ValidateTenantID validates the SaaS tenant identifier, such as a UUID or
+// account number
+func ValidateTenantID(tenantID string) error {
+	if tenantID == "" {
 		return nil // Optional field
 	}
 
-	if len(environment) > 8 {
-		return fmt.Errorf("environment must be 1-8 characters, got %d: %s", len(environment), environment)
+	if !tenantIDRegex.MatchString(tenantID) {
+		return fmt.Errorf("tenant id must be 1-64 letters, digits, dots, underscores or hyphens, starting with a letter or digit: %s", tenantID)
 	}
 
-	if !environmentRegex.MatchString(environment) {
-		return fmt.Errorf("environment must be lowercase alphanumeric with hyphens (1-8 chars): %s", environment)
+	return nil
+}
+
+// ValidateCustomer validates customer format. Customers can appear in the
+// name prefix, so they follow the same rules as tenant with a longer limit.
+func ValidateCustomer(customer string) error {
+	if customer == "" {
+		return nil // Optional field
 	}
 
+	if len(customer) > 16 {
+		return fmt.Errorf("customer must be 1-16 characters, got %d: %s", len(customer), customer)
+	}
+
+	if !customerRegex.MatchString(customer) {
+		return fmt.Errorf("customer must be lowercase alphanumeric with hyphens (1-16 chars): %s", customer)
+	}
+
+	return nil
+}
+
+// ValidateBusinessUnit validates business unit format
+func ValidateBusinessUnit(businessUnit string) error {
+	return validateOrgUnit("business unit", businessUnit)
+}
+
+// ValidateDivision validates division format
+func ValidateDivision(division string) error {
+	return validateOrgUnit("division", division)
+}
+
+// ValidatePortfolio validates portfolio format
+func ValidatePortfolio(portfolio string) error {
+	return validateOrgUnit("portfolio", portfolio)
+}
+
+// validateOrgUnit validates a level of the organization hierarchy. Values
+// are kebab-case so they are valid tag and label values on every cloud.
+func validateOrgUnit(level, value string) error {
+	if value == "" {
+		return nil // Optional field
+	}
+
+	if len(value) > 32 {
+		return fmt.Errorf("%s must be 1-32 characters, got %d: %s", level, len(value), value)
+	}
+
+	if !orgUnitRegex.MatchString(value) {
+		return fmt.Errorf("%s must be lowercase alphanumeric with hyphens (1-32 chars): %s", level, value)
+	}
+
+	return nil
+}
+
+// ValidateAttributes validates a list of name attributes
+func ValidateAttributes(attributes []string) error {
+	for _, attribute := range attributes {
+		if !attributeRegex.MatchString(attribute) {
+			return fmt.Errorf("attribute must be lowercase alphanumeric with hyphens (1-8 chars): %q", attribute)
+		}
+	}
+	return nil
+}
+
+// ValidateLabelOrder validates a name component order
+func ValidateLabelOrder(order []string) error {
+	seen := make(map[string]bool, len(order))
+	for _, label := range order {
+		if !ValidNameComponents[NameComponent(label)] {
+			return fmt.Errorf("invalid label '%s', must be one of: namespace, tenant, name, environment, attributes, customer", label)
+		}
+		if seen[label] {
+			return fmt.Errorf("duplicate label '%s'", label)
+		}
+		seen[label] = true
+	}
 	return nil
 }
 
@@ -124,6 +291,15 @@ func ValidateAvailability(availability string) error {
 	return nil
 }
 
+// ValidateTier validates application tier
+func ValidateTier(tier string) error {
+	if !ValidTiers[tier] {
+		return fmt.Errorf("invalid tier '%s', must be one of: web, app, data", tier)
+	}
+
+	return nil
+}
+
 // ValidateSensitivity validates data sensitivity level
 func ValidateSensitivity(sensitivity string) error {
 	if !ValidSensitivityLevels[sensitivity] {
@@ -133,6 +309,85 @@ func ValidateSensitivity(sensitivity string) error {
 	return nil
 }
 
+// ValidateDataRetention validates the data retention period, an ISO 8601
+// duration such as P7Y, P1Y6M or P90D
+func ValidateDataRetention(retention string) error {
+	if retention == "" {
+		return nil // Optional field
+	}
+
+	if !durationRegex.MatchString(retention) || retention == "P" || strings.HasSuffix(retention, "T") {
+		return fmt.Errorf("data retention '%s' must be an ISO 8601 duration, such as P7Y, P1Y6M or P90D", retention)
+	}
+
+	return nil
+}
+
+// ValidateDataResidency validates data residency codes: ISO 3166-1 alpha-2
+// country codes such as DE, ISO 3166-2 subdivision codes such as US-CA, or EU
+func ValidateDataResidency(codes []string) error {
+	for _, code := range codes {
+		if code == DataResidencyEU {
+			continue
+		}
+		match := residencyRegex.FindStringSubmatch(code)
+		if match == nil {
+			return fmt.Errorf("data residency '%s' must be an ISO 3166 country or subdivision code, such as DE or US-CA, or EU", code)
+		}
+		if !isoCountryCodes[match[1]] {
+			return fmt.Errorf("data residency '%s' is not an ISO 3166-1 country code", code)
+		}
+	}
+
+	return nil
+}
+
+// ValidateRegion validates the cloud region format
+func ValidateRegion(region string) error {
+	if region == "" {
+		return nil // Optional field
+	}
+
+	if !regionRegex.MatchString(region) {
+		return fmt.Errorf("region must be lowercase alphanumeric with hyphens, such as eu-west-1, westeurope or europe-west3")
+	}
+
+	return nil
+}
+
+// ValidateRegionResidency checks that a known region is inside the data
+// residency. Regions that RegionCountry does not know are not checked.
+func ValidateRegionResidency(region string, residency []string) error {
+	if region == "" || len(residency) == 0 {
+		return nil
+	}
+
+	country, ok := RegionCountry(region)
+	if !ok {
+		return nil
+	}
+	for _, code := range residency {
+		if residencyCovers(code, country) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("region %s is in %s, outside the data residency %s", region, country, strings.Join(residency, ", "))
+}
+
+// ValidateComplianceProfile validates the compliance profile name
+func ValidateComplianceProfile(profile string) error {
+	if profile == "" {
+		return nil // Optional field
+	}
+
+	if _, ok := ComplianceProfiles[profile]; !ok {
+		return fmt.Errorf("invalid compliance profile '%s', must be one of: %s", profile, strings.Join(ComplianceProfileNames(), ", "))
+	}
+
+	return nil
+}
+
 // ValidateDeletionDate validates deletion date format
 func ValidateDeletionDate(date string) error {
 	if date == "" {
@@ -152,12 +407,231 @@ func ValidateDeletionDate(date string) error {
 	return nil
 }
 
-// ValidateEmail validates email format
+// DecommissionEnvironmentTypes are the environment types of long-lived
+// resources, whose deletion date must be approved
+var DecommissionEnvironmentTypes = []string{"Production", "MissionCritical"}
+
+// ValidateDecommission checks that a deletion date on one of the
+// DecommissionEnvironmentTypes is approved
+func ValidateDecommission(envType, deletionDate string, approved bool) error {
+	if deletionDate == "" || approved || !slices.Contains(DecommissionEnvironmentTypes, envType) {
+		return nil
+	}
+	return fmt.Errorf("deletion_date %s on a %s environment requires decommission_approved = true", deletionDate, envType)
+}
+
+// ValidateLifecycleAction validates lifecycle action
+func ValidateLifecycleAction(action string) error {
+	if !ValidLifecycleActions[action] {
+		return fmt.Errorf("invalid lifecycle action '%s', must be one of: delete, stop, notify", action)
+	}
+
+	return nil
+}
+
+// ValidateMaintenanceWindow validates maintenance window format
+func ValidateMaintenanceWindow(window string) error {
+	if window == "" {
+		return nil // Optional field
+	}
+
+	if maintenanceRangeRegex.MatchString(window) {
+		return nil
+	}
+	if !strings.Contains(window, " ") {
+		return fmt.Errorf("maintenance window must be a cron expression or a day/time range such as sun:03:00-sun:05:00: %s", window)
+	}
+	if err := validateCron(window); err != nil {
+		return fmt.Errorf("invalid maintenance window cron expression '%s': %w", window, err)
+	}
+
+	return nil
+}
+
+// ValidateNameDelimiter validates name prefix delimiter
+func ValidateNameDelimiter(delimiter string) error {
+	if !ValidNameDelimiters[delimiter] {
+		return fmt.Errorf("invalid name delimiter '%s', must be one of: \"-\", \"_\", \"\"", delimiter)
+	}
+
+	return nil
+}
+
+// ValidateReservedWordAction validates reserved word action
+func ValidateReservedWordAction(action string) error {
+	if !ValidReservedWordActions[action] {
+		return fmt.Errorf("invalid reserved word action '%s', must be one of: error, remove", action)
+	}
+
+	return nil
+}
+
+// ValidateSanitizationMode validates sanitization mode
+func ValidateSanitizationMode(mode string) error {
+	if !ValidSanitizationModes[mode] {
+		return fmt.Errorf("invalid sanitization mode '%s', must be one of: fix, warn, error", mode)
+	}
+
+	return nil
+}
+
+// ValidateLengthOverflow validates length overflow policy
+func ValidateLengthOverflow(policy string) error {
+	if !ValidLengthOverflowPolicies[policy] {
+		return fmt.Errorf("invalid length overflow policy '%s', must be one of: truncate, truncate_with_ellipsis_hash, error", policy)
+	}
+
+	return nil
+}
+
+// ValidateNAFields validates N/A field list entries
+func ValidateNAFields(fields []string) error {
+	for _, field := range fields {
+		if !slices.Contains(NotApplicableTagKeys, strings.TrimPrefix(field, "!")) {
+			return fmt.Errorf("invalid N/A field '%s', must be one of %s, optionally prefixed with '!' to exclude it", field, strings.Join(NotApplicableTagKeys, ", "))
+		}
+	}
+
+	return nil
+}
+
+// ValidateListDelimiter validates list join delimiter for a cloud provider
+func ValidateListDelimiter(delimiter string, cp CloudProvider) error {
+	if delimiter == "" {
+		return nil // Optional field, defaults to the cloud provider delimiter
+	}
+
+	if strings.IndexFunc(delimiter, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) >= 0 {
+		return fmt.Errorf("list delimiter must not contain letters or digits: %s", delimiter)
+	}
+
+	if cp.SanitizeTagValue(delimiter) != delimiter {
+		return fmt.Errorf("list delimiter '%s' is not allowed in tag values for this cloud provider", delimiter)
+	}
+
+	return nil
+}
+
+// ValidatePRNumber validates pull request number
+func ValidatePRNumber(prNumber int) error {
+	if prNumber < 0 {
+		return fmt.Errorf("pr number must be positive, got %d", prNumber)
+	}
+
+	return nil
+}
+
+// ValidateEphemeralSuffix validates ephemeral environment suffix
+func ValidateEphemeralSuffix(suffix string) error {
+	if suffix == "" {
+		return nil // Optional field
+	}
+
+	if EphemeralSlug(0, suffix) == "" {
+		return fmt.Errorf("ephemeral suffix must contain at least one letter or digit: %s", suffix)
+	}
+
+	return nil
+}
+
+// ValidateMonthlyBudget validates monthly budget amount
+func ValidateMonthlyBudget(budget float64) error {
+	if math.IsNaN(budget) || math.IsInf(budget, 0) || budget < 0 {
+		return fmt.Errorf("monthly budget must be a non-negative number, got %v", budget)
+	}
+
+	return nil
+}
+
+// ValidateBudgetCurrency validates budget currency code
+func ValidateBudgetCurrency(currency string) error {
+	if currency == "" {
+		return nil // Optional field, defaults to DefaultBudgetCurrency
+	}
+
+	if !currencyRegex.MatchString(currency) {
+		return fmt.Errorf("budget currency must be a 3 letter ISO 4217 code such as USD: %s", currency)
+	}
+
+	return nil
+}
+
+// ValidateTagSchemaVersion validates tag schema version
+func ValidateTagSchemaVersion(version int) error {
+	if version == 0 {
+		return nil // Optional field, defaults to LatestTagSchemaVersion
+	}
+
+	_, err := GetTagSchema(version)
+	return err
+}
+
+// ValidateLegacyTagMap validates the mapping of tag keys to legacy keys
+func ValidateLegacyTagMap(legacyTagMap map[string]string) error {
+	seen := map[string]string{}
+	for key, legacyKey := range legacyTagMap {
+		if key == "" || legacyKey == "" {
+			return fmt.Errorf("legacy tag map keys and values must not be empty: %q = %q", key, legacyKey)
+		}
+		if other, ok := seen[legacyKey]; ok {
+			return fmt.Errorf("legacy tag key '%s' is mapped from both '%s' and '%s'", legacyKey, min(key, other), max(key, other))
+		}
+		seen[legacyKey] = key
+	}
+
+	return nil
+}
+
+// ValidateAdditionalTypedTags validates that the value of each typed tag
+// is of its type, reporting the first invalid key in sorted order
+func ValidateAdditionalTypedTags(tags map[string]TypedTag) error {
+	for _, key := range slices.Sorted(maps.Keys(tags)) {
+		if key == "" {
+			return fmt.Errorf("additional typed tag keys must not be empty")
+		}
+		if _, err := tags[key].TagValue(); err != nil {
+			return fmt.Errorf("additional typed tag %s: %w", key, err)
+		}
+	}
+
+	return nil
+}
+
+// ValidateLegacyTagsUntil validates the end date of the legacy tag migration window
+func ValidateLegacyTagsUntil(date string) error {
+	if date == "" {
+		return nil // Optional field, legacy tags are emitted until removed
+	}
+
+	if !dateRegex.MatchString(date) {
+		return fmt.Errorf("legacy tags until date must be in YYYY-MM-DD format: %s", date)
+	}
+	if _, err := time.Parse("2006-01-02", date); err != nil {
+		return fmt.Errorf("invalid legacy tags until date: %s", date)
+	}
+
+	return nil
+}
+
+// ValidateEmail validates email format with net/mail, accepting
+// internationalized local parts and domains such as user@bücher.example.
+// The domain must be a valid IDNA name with at least two labels.
 func ValidateEmail(email string) error {
 	if email == "" {
 		return nil // Optional field
 	}
 
+	_, err := NormalizeEmail(email)
+	return err
+}
+
+// ValidateEmailStrict validates email format against an ASCII-only pattern
+// with a top level domain of at least two letters
+func ValidateEmailStrict(email string) error {
+	if email == "" {
+		return nil // Optional field
+	}
+
 	if !emailRegex.MatchString(email) {
 		return fmt.Errorf("invalid email format: %s", email)
 	}
@@ -174,3 +648,67 @@ func ValidateEmails(emails []string) error {
 	}
 	return nil
 }
+
+// ValidateEmailsStrict validates a list of email addresses with ValidateEmailStrict
+func ValidateEmailsStrict(emails []string) error {
+	for _, email := range emails {
+		if err := ValidateEmailStrict(email); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NormalizeEmail returns email with its domain converted to punycode and
+// lowercased, so user@Bücher.example becomes user@xn--bcher-kva.example.
+// The local part is left unchanged. Display names and angle brackets are
+// rejected.
+func NormalizeEmail(email string) (string, error) {
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Name != "" || addr.Address != email {
+		return "", fmt.Errorf("invalid email format: %s", email)
+	}
+
+	at := strings.LastIndex(email, "@")
+	domain, err := idna.Lookup.ToASCII(email[at+1:])
+	if err != nil || !strings.Contains(domain, ".") {
+		return "", fmt.Errorf("invalid email domain: %s", email)
+	}
+
+	return email[:at] + "@" + domain, nil
+}
+
+// NormalizeOwners trims and lowercases owner email addresses, drops empty
+// and duplicate entries and sorts the result, so that listing an owner twice
+// or with different casing does not change the joined tag value
+func NormalizeOwners(owners []string) []string {
+	if owners == nil {
+		return nil
+	}
+
+	normalized := make([]string, 0, len(owners))
+	for _, owner := range owners {
+		if owner = strings.ToLower(strings.TrimSpace(owner)); owner != "" {
+			normalized = append(normalized, owner)
+		}
+	}
+	slices.Sort(normalized)
+	return slices.Compact(normalized)
+}
+
+// NormalizeEmails applies NormalizeEmail to each address
+func NormalizeEmails(emails []string) ([]string, error) {
+	if emails == nil {
+		return nil, nil
+	}
+
+	normalized := make([]string, 0, len(emails))
+	for _, email := range emails {
+		n, err := NormalizeEmail(email)
+		if err != nil {
+			return nil, err
+		}
+		normalized = append(normalized, n)
+	}
+	return normalized, nil
+}
@@ -1,6 +1,9 @@
 package context
 
 import (
+	"math"
+	"slices"
+	"strings"
 	"testing"
 )
 
@@ -62,6 +65,50 @@ func TestValidateNamespace(t *testing.T) {
 	}
 }
 
+func TestValidateAllowedNamespace(t *testing.T) {
+	allowed := []string{"myorg", "payments"}
+
+	tests := []struct {
+		name      string
+		namespace string
+		allowed   []string
+		registry  string
+		wantErr   string
+	}{
+		{name: "allowed", namespace: "payments", allowed: allowed},
+		{name: "empty namespace", namespace: "", allowed: allowed},
+		{name: "no registry", namespace: "other"},
+		{
+			name:      "unknown with registry",
+			namespace: "other",
+			allowed:   allowed,
+			registry:  "https://wiki.example.com/namespaces",
+			wantErr:   "namespace other is not in the namespace registry, see https://wiki.example.com/namespaces",
+		},
+		{
+			name:      "unknown without registry",
+			namespace: "other",
+			allowed:   allowed,
+			wantErr:   "namespace other is not one of the allowed namespaces: myorg, payments",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateAllowedNamespace(tt.namespace, tt.allowed, tt.registry)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateAllowedNamespace() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("ValidateAllowedNamespace() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestValidateEnvironment(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -105,6 +152,274 @@ func TestValidateEnvironment(t *testing.T) {
 	}
 }
 
+func TestValidateTenant(t *testing.T) {
+	tests := []struct {
+		name    string
+		tenant  string
+		wantErr bool
+	}{
+		{
+			name:    "valid",
+			tenant:  "acme",
+			wantErr: false,
+		},
+		{
+			name:    "empty",
+			tenant:  "",
+			wantErr: false,
+		},
+		{
+			name:    "too long",
+			tenant:  "verylongtenant",
+			wantErr: true,
+		},
+		{
+			name:    "uppercase",
+			tenant:  "Acme",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateTenant(tt.tenant)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateTenant() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateTenantID(t *testing.T) {
+	tests := []struct {
+		name     string
+		tenantID string
+		wantErr  bool
+	}{
+		{
+			name:     "uuid",
+			tenantID: "3f2a9c1e-7b4d-4e1a-9c2b-5d6e7f809a1b",
+			wantErr:  false,
+		},
+		{
+			name:     "account number",
+			tenantID: "ACCT_00042.eu",
+			wantErr:  false,
+		},
+		{
+			name:     "empty",
+			tenantID: "",
+			wantErr:  false,
+		},
+		{
+			name:     "too long",
+			tenantID: strings.Repeat("a", 65),
+			wantErr:  true,
+		},
+		{
+			name:     "leading hyphen",
+			tenantID: "-42",
+			wantErr:  true,
+		},
+		{
+			name:     "spaces",
+			tenantID: "acct 42",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateTenantID(tt.tenantID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateTenantID() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateCustomer(t *testing.T) {
+	tests := []struct {
+		name     string
+		customer string
+		wantErr  bool
+	}{
+		{
+			name:     "valid",
+			customer: "globex",
+			wantErr:  false,
+		},
+		{
+			name:     "empty",
+			customer: "",
+			wantErr:  false,
+		},
+		{
+			name:     "too long",
+			customer: "globex-corporation",
+			wantErr:  true,
+		},
+		{
+			name:     "uppercase",
+			customer: "Globex",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCustomer(tt.customer)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCustomer() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateOrgHierarchy(t *testing.T) {
+	validators := map[string]func(string) error{
+		"business unit": ValidateBusinessUnit,
+		"division":      ValidateDivision,
+		"portfolio":     ValidatePortfolio,
+	}
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{
+			name:    "valid",
+			value:   "retail-banking",
+			wantErr: false,
+		},
+		{
+			name:    "empty",
+			value:   "",
+			wantErr: false,
+		},
+		{
+			name:    "single letter",
+			value:   "r",
+			wantErr: false,
+		},
+		{
+			name:    "too long",
+			value:   strings.Repeat("a", 33),
+			wantErr: true,
+		},
+		{
+			name:    "uppercase",
+			value:   "Retail",
+			wantErr: true,
+		},
+		{
+			name:    "spaces",
+			value:   "retail banking",
+			wantErr: true,
+		},
+		{
+			name:    "trailing hyphen",
+			value:   "retail-",
+			wantErr: true,
+		},
+	}
+
+	for level, validate := range validators {
+		for _, tt := range tests {
+			t.Run(level+"/"+tt.name, func(t *testing.T) {
+				err := validate(tt.value)
+				if (err != nil) != tt.wantErr {
+					t.Errorf("Validate(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+				}
+				if err != nil && !strings.HasPrefix(err.Error(), level+" ") {
+					t.Errorf("Validate(%q) error = %v, want it to name the %s", tt.value, err, level)
+				}
+			})
+		}
+	}
+}
+
+func TestValidateAttributes(t *testing.T) {
+	tests := []struct {
+		name       string
+		attributes []string
+		wantErr    bool
+	}{
+		{
+			name:       "valid",
+			attributes: []string{"blue", "1", "v2-a"},
+			wantErr:    false,
+		},
+		{
+			name:       "nil",
+			attributes: nil,
+			wantErr:    false,
+		},
+		{
+			name:       "empty token",
+			attributes: []string{""},
+			wantErr:    true,
+		},
+		{
+			name:       "too long",
+			attributes: []string{"verylongattribute"},
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateAttributes(tt.attributes)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateAttributes() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateLabelOrder(t *testing.T) {
+	tests := []struct {
+		name    string
+		order   []string
+		wantErr bool
+	}{
+		{
+			name:    "valid",
+			order:   []string{"tenant", "name", "environment", "attributes"},
+			wantErr: false,
+		},
+		{
+			name:    "empty",
+			order:   nil,
+			wantErr: false,
+		},
+		{
+			name:    "customer",
+			order:   []string{"namespace", "customer", "name"},
+			wantErr: false,
+		},
+		{
+			name:    "unknown label",
+			order:   []string{"name", "stage"},
+			wantErr: true,
+		},
+		{
+			name:    "duplicate label",
+			order:   []string{"name", "name"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateLabelOrder(tt.order)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateLabelOrder() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestValidateCloudProvider(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -186,92 +501,925 @@ func TestValidateEnvironmentType(t *testing.T) {
 	}
 }
 
-func TestValidateDeletionDate(t *testing.T) {
+func TestValidateMaintenanceWindow(t *testing.T) {
 	tests := []struct {
 		name    string
-		date    string
+		window  string
 		wantErr bool
 	}{
 		{
-			name:    "valid date",
-			date:    "2024-12-31",
+			name:    "empty",
+			window:  "",
 			wantErr: false,
 		},
 		{
-			name:    "empty date",
-			date:    "",
-			wantErr: false, // Optional field
+			name:    "day/time range",
+			window:  "sun:03:00-sun:05:00",
+			wantErr: false,
 		},
 		{
-			name:    "invalid format",
-			date:    "12/31/2024",
+			name:    "day/time range across days",
+			window:  "Sat:22:30-Sun:01:00",
+			wantErr: false,
+		},
+		{
+			name:    "cron",
+			window:  "0 3 * * SUN",
+			wantErr: false,
+		},
+		{
+			name:    "invalid hour in range",
+			window:  "sun:25:00-sun:05:00",
 			wantErr: true,
 		},
 		{
-			name:    "invalid date",
-			date:    "2024-13-45",
+			name:    "free text",
+			window:  "sundays",
 			wantErr: true,
 		},
 		{
-			name:    "not a date",
-			date:    "not-a-date",
+			name:    "invalid cron",
+			window:  "0 3 * *",
 			wantErr: true,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := ValidateDeletionDate(tt.date)
+			err := ValidateMaintenanceWindow(tt.window)
 			if (err != nil) != tt.wantErr {
-				t.Errorf("ValidateDeletionDate() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("ValidateMaintenanceWindow() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
 }
 
-func TestValidateEmail(t *testing.T) {
+func TestValidateTier(t *testing.T) {
 	tests := []struct {
 		name    string
-		email   string
+		tier    string
 		wantErr bool
 	}{
 		{
-			name:    "valid email",
-			email:   "user@example.com",
+			name:    "valid web",
+			tier:    "web",
 			wantErr: false,
 		},
 		{
-			name:    "empty email",
-			email:   "",
-			wantErr: false, // Optional field
-		},
-		{
-			name:    "complex valid email",
-			email:   "user.name+tag@sub.example.com",
+			name:    "valid data",
+			tier:    "data",
 			wantErr: false,
 		},
 		{
-			name:    "invalid no @",
-			email:   "userexample.com",
-			wantErr: true,
+			name:    "empty",
+			tier:    "",
+			wantErr: false,
 		},
 		{
-			name:    "invalid no domain",
-			email:   "user@",
+			name:    "invalid case",
+			tier:    "Web",
 			wantErr: true,
 		},
 		{
-			name:    "invalid no user",
-			email:   "@example.com",
+			name:    "invalid",
+			tier:    "cache",
 			wantErr: true,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := ValidateEmail(tt.email)
+			err := ValidateTier(tt.tier)
 			if (err != nil) != tt.wantErr {
-				t.Errorf("ValidateEmail() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("ValidateTier() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateDataRetention(t *testing.T) {
+	tests := []struct {
+		name      string
+		retention string
+		wantErr   bool
+	}{
+		{name: "years", retention: "P7Y"},
+		{name: "years and months", retention: "P1Y6M"},
+		{name: "days", retention: "P90D"},
+		{name: "weeks", retention: "P2W"},
+		{name: "time", retention: "PT36H"},
+		{name: "empty", retention: ""},
+		{name: "no components", retention: "P", wantErr: true},
+		{name: "empty time", retention: "P1DT", wantErr: true},
+		{name: "lowercase", retention: "p7y", wantErr: true},
+		{name: "words", retention: "7 years", wantErr: true},
+		{name: "out of order", retention: "P6M1Y", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateDataRetention(tt.retention)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateDataRetention() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateAdditionalTypedTags(t *testing.T) {
+	tests := []struct {
+		name    string
+		tags    map[string]TypedTag
+		wantErr bool
+	}{
+		{name: "nil", tags: nil},
+		{name: "typed", tags: map[string]TypedTag{"replicas": {Value: "3", Type: "number"}, "backup": {Value: "true", Type: "bool"}, "team": {Value: "core"}}},
+		{name: "not a number", tags: map[string]TypedTag{"replicas": {Value: "three", Type: "number"}}, wantErr: true},
+		{name: "not a bool", tags: map[string]TypedTag{"backup": {Value: "1", Type: "bool"}}, wantErr: true},
+		{name: "unknown type", tags: map[string]TypedTag{"team": {Value: "core", Type: "list"}}, wantErr: true},
+		{name: "empty key", tags: map[string]TypedTag{"": {Value: "core"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateAdditionalTypedTags(tt.tags)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateAdditionalTypedTags() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateDataResidency(t *testing.T) {
+	tests := []struct {
+		name    string
+		codes   []string
+		wantErr bool
+	}{
+		{name: "countries", codes: []string{"DE", "FR"}},
+		{name: "subdivision", codes: []string{"US-CA"}},
+		{name: "european union", codes: []string{"EU"}},
+		{name: "empty", codes: nil},
+		{name: "lowercase", codes: []string{"de"}, wantErr: true},
+		{name: "alpha-3", codes: []string{"DEU"}, wantErr: true},
+		{name: "unassigned", codes: []string{"XX"}, wantErr: true},
+		{name: "unassigned subdivision country", codes: []string{"XX-CA"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateDataResidency(tt.codes)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateDataResidency() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateRegion(t *testing.T) {
+	tests := []struct {
+		name    string
+		region  string
+		wantErr bool
+	}{
+		{name: "aws", region: "eu-west-1"},
+		{name: "azure", region: "westeurope"},
+		{name: "gcp", region: "europe-west3"},
+		{name: "empty", region: ""},
+		{name: "uppercase", region: "EU-WEST-1", wantErr: true},
+		{name: "spaces", region: "west europe", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateRegion(tt.region)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateRegion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateRegionResidency(t *testing.T) {
+	tests := []struct {
+		name      string
+		region    string
+		residency []string
+		wantErr   bool
+	}{
+		{name: "country", region: "eu-central-1", residency: []string{"DE"}},
+		{name: "european union", region: "westeurope", residency: []string{"EU"}},
+		{name: "subdivision", region: "us-west-1", residency: []string{"US-CA"}},
+		{name: "one of several", region: "europe-west9", residency: []string{"DE", "FR"}},
+		{name: "unknown region", region: "moon-base-1", residency: []string{"DE"}},
+		{name: "no residency", region: "us-east-1"},
+		{name: "no region", residency: []string{"DE"}},
+		{name: "outside country", region: "eu-west-1", residency: []string{"DE"}, wantErr: true},
+		{name: "outside european union", region: "uksouth", residency: []string{"EU"}, wantErr: true},
+		{name: "switzerland is not in the european union", region: "europe-west6", residency: []string{"EU"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateRegionResidency(tt.region, tt.residency)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateRegionResidency() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateComplianceProfile(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile string
+		wantErr bool
+	}{
+		{name: "pci", profile: "pci"},
+		{name: "fedramp moderate", profile: "fedramp-moderate"},
+		{name: "empty", profile: ""},
+		{name: "invalid case", profile: "PCI", wantErr: true},
+		{name: "unknown", profile: "sox", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateComplianceProfile(tt.profile)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateComplianceProfile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateLifecycleAction(t *testing.T) {
+	tests := []struct {
+		name    string
+		action  string
+		wantErr bool
+	}{
+		{
+			name:    "valid delete",
+			action:  "delete",
+			wantErr: false,
+		},
+		{
+			name:    "valid notify",
+			action:  "notify",
+			wantErr: false,
+		},
+		{
+			name:    "empty",
+			action:  "",
+			wantErr: false,
+		},
+		{
+			name:    "invalid case",
+			action:  "Delete",
+			wantErr: true,
+		},
+		{
+			name:    "invalid",
+			action:  "archive",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateLifecycleAction(tt.action)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateLifecycleAction() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateReservedWordAction(t *testing.T) {
+	tests := []struct {
+		name    string
+		action  string
+		wantErr bool
+	}{
+		{
+			name:    "valid error",
+			action:  "error",
+			wantErr: false,
+		},
+		{
+			name:    "valid remove",
+			action:  "remove",
+			wantErr: false,
+		},
+		{
+			name:    "empty",
+			action:  "",
+			wantErr: false,
+		},
+		{
+			name:    "invalid",
+			action:  "replace",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateReservedWordAction(tt.action)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateReservedWordAction() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateSanitizationMode(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    string
+		wantErr bool
+	}{
+		{
+			name:    "valid fix",
+			mode:    "fix",
+			wantErr: false,
+		},
+		{
+			name:    "valid warn",
+			mode:    "warn",
+			wantErr: false,
+		},
+		{
+			name:    "valid error",
+			mode:    "error",
+			wantErr: false,
+		},
+		{
+			name:    "empty",
+			mode:    "",
+			wantErr: false,
+		},
+		{
+			name:    "invalid",
+			mode:    "strict",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSanitizationMode(tt.mode)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateSanitizationMode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateLengthOverflow(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  string
+		wantErr bool
+	}{
+		{
+			name:    "valid truncate",
+			policy:  "truncate",
+			wantErr: false,
+		},
+		{
+			name:    "valid truncate_with_ellipsis_hash",
+			policy:  "truncate_with_ellipsis_hash",
+			wantErr: false,
+		},
+		{
+			name:    "valid error",
+			policy:  "error",
+			wantErr: false,
+		},
+		{
+			name:    "empty",
+			policy:  "",
+			wantErr: false,
+		},
+		{
+			name:    "invalid",
+			policy:  "ignore",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateLengthOverflow(tt.policy)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateLengthOverflow() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateNAFields(t *testing.T) {
+	tests := []struct {
+		name    string
+		fields  []string
+		wantErr bool
+	}{
+		{
+			name:    "allowed keys",
+			fields:  []string{"costcenter", "sensitivity"},
+			wantErr: false,
+		},
+		{
+			name:    "denied keys",
+			fields:  []string{"!sourcerepo", "!sourcecommit"},
+			wantErr: false,
+		},
+		{
+			name:    "empty",
+			fields:  nil,
+			wantErr: false,
+		},
+		{
+			name:    "unknown key",
+			fields:  []string{"tenant"},
+			wantErr: true,
+		},
+		{
+			name:    "tag prefix included",
+			fields:  []string{"!bc-costcenter"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateNAFields(tt.fields)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateNAFields() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateListDelimiter(t *testing.T) {
+	tests := []struct {
+		name      string
+		delimiter string
+		cloud     string
+		wantErr   bool
+	}{
+		{
+			name:      "empty",
+			delimiter: "",
+			cloud:     "aws",
+			wantErr:   false,
+		},
+		{
+			name:      "slash on aws",
+			delimiter: "/",
+			cloud:     "aws",
+			wantErr:   false,
+		},
+		{
+			name:      "pipe on dc",
+			delimiter: "|",
+			cloud:     "dc",
+			wantErr:   false,
+		},
+		{
+			name:      "semicolon sanitized on aws",
+			delimiter: ";",
+			cloud:     "aws",
+			wantErr:   true,
+		},
+		{
+			name:      "comma sanitized on gcp",
+			delimiter: ",",
+			cloud:     "gcp",
+			wantErr:   true,
+		},
+		{
+			name:      "letters",
+			delimiter: "and",
+			cloud:     "dc",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateListDelimiter(tt.delimiter, GetCloudProvider(tt.cloud))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateListDelimiter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidatePRNumber(t *testing.T) {
+	for _, n := range []int{0, 1, 123} {
+		if err := ValidatePRNumber(n); err != nil {
+			t.Errorf("ValidatePRNumber(%d) = %v, want nil", n, err)
+		}
+	}
+	if err := ValidatePRNumber(-1); err == nil {
+		t.Error("ValidatePRNumber(-1) = nil, want error")
+	}
+}
+
+func TestValidateEphemeralSuffix(t *testing.T) {
+	tests := []struct {
+		name    string
+		suffix  string
+		wantErr bool
+	}{
+		{
+			name:    "empty",
+			suffix:  "",
+			wantErr: false,
+		},
+		{
+			name:    "branch name",
+			suffix:  "feature/JIRA-123",
+			wantErr: false,
+		},
+		{
+			name:    "no letters or digits",
+			suffix:  "//",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateEphemeralSuffix(tt.suffix)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateEphemeralSuffix() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateMonthlyBudget(t *testing.T) {
+	for _, budget := range []float64{0, 100, 1500.5} {
+		if err := ValidateMonthlyBudget(budget); err != nil {
+			t.Errorf("ValidateMonthlyBudget(%v) = %v, want nil", budget, err)
+		}
+	}
+	for _, budget := range []float64{-1, math.NaN(), math.Inf(1)} {
+		if err := ValidateMonthlyBudget(budget); err == nil {
+			t.Errorf("ValidateMonthlyBudget(%v) = nil, want error", budget)
+		}
+	}
+}
+
+func TestValidateTagSchemaVersion(t *testing.T) {
+	for _, version := range []int{0, 1, LatestTagSchemaVersion} {
+		if err := ValidateTagSchemaVersion(version); err != nil {
+			t.Errorf("ValidateTagSchemaVersion(%d) = %v, want nil", version, err)
+		}
+	}
+	for _, version := range []int{-1, LatestTagSchemaVersion + 1} {
+		if err := ValidateTagSchemaVersion(version); err == nil {
+			t.Errorf("ValidateTagSchemaVersion(%d) = nil, want error", version)
+		}
+	}
+}
+
+func TestValidateLegacyTagMap(t *testing.T) {
+	valid := []map[string]string{
+		nil,
+		{"costcenter": "CostCenter", "environment": "Env"},
+	}
+	for _, legacyTagMap := range valid {
+		if err := ValidateLegacyTagMap(legacyTagMap); err != nil {
+			t.Errorf("ValidateLegacyTagMap(%v) = %v, want nil", legacyTagMap, err)
+		}
+	}
+	invalid := []map[string]string{
+		{"costcenter": ""},
+		{"": "CostCenter"},
+		{"costcenter": "Billing", "projectmgmtid": "Billing"},
+	}
+	for _, legacyTagMap := range invalid {
+		if err := ValidateLegacyTagMap(legacyTagMap); err == nil {
+			t.Errorf("ValidateLegacyTagMap(%v) = nil, want error", legacyTagMap)
+		}
+	}
+}
+
+func TestValidateLegacyTagsUntil(t *testing.T) {
+	for _, date := range []string{"", "2026-03-31"} {
+		if err := ValidateLegacyTagsUntil(date); err != nil {
+			t.Errorf("ValidateLegacyTagsUntil(%q) = %v, want nil", date, err)
+		}
+	}
+	for _, date := range []string{"31/03/2026", "2026-02-30"} {
+		if err := ValidateLegacyTagsUntil(date); err == nil {
+			t.Errorf("ValidateLegacyTagsUntil(%q) = nil, want error", date)
+		}
+	}
+}
+
+func TestValidateBudgetCurrency(t *testing.T) {
+	tests := []struct {
+		name     string
+		currency string
+		wantErr  bool
+	}{
+		{
+			name:     "empty",
+			currency: "",
+			wantErr:  false,
+		},
+		{
+			name:     "valid",
+			currency: "EUR",
+			wantErr:  false,
+		},
+		{
+			name:     "lowercase",
+			currency: "usd",
+			wantErr:  true,
+		},
+		{
+			name:     "symbol",
+			currency: "$",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateBudgetCurrency(tt.currency)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateBudgetCurrency() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateNameDelimiter(t *testing.T) {
+	tests := []struct {
+		name      string
+		delimiter string
+		wantErr   bool
+	}{
+		{
+			name:      "hyphen",
+			delimiter: "-",
+			wantErr:   false,
+		},
+		{
+			name:      "underscore",
+			delimiter: "_",
+			wantErr:   false,
+		},
+		{
+			name:      "empty",
+			delimiter: "",
+			wantErr:   false,
+		},
+		{
+			name:      "invalid",
+			delimiter: ".",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateNameDelimiter(tt.delimiter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateNameDelimiter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateDeletionDate(t *testing.T) {
+	tests := []struct {
+		name    string
+		date    string
+		wantErr bool
+	}{
+		{
+			name:    "valid date",
+			date:    "2024-12-31",
+			wantErr: false,
+		},
+		{
+			name:    "empty date",
+			date:    "",
+			wantErr: false, // Optional field
+		},
+		{
+			name:    "invalid format",
+			date:    "12/31/2024",
+			wantErr: true,
+		},
+		{
+			name:    "invalid date",
+			date:    "2024-13-45",
+			wantErr: true,
+		},
+		{
+			name:    "not a date",
+			date:    "not-a-date",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateDeletionDate(tt.date)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateDeletionDate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateDecommission(t *testing.T) {
+	tests := []struct {
+		name         string
+		envType      string
+		deletionDate string
+		approved     bool
+		wantErr      bool
+	}{
+		{name: "production without deletion date", envType: "Production"},
+		{name: "production deletion date", envType: "Production", deletionDate: "2026-12-31", wantErr: true},
+		{name: "mission critical deletion date", envType: "MissionCritical", deletionDate: "2026-12-31", wantErr: true},
+		{name: "approved production deletion date", envType: "Production", deletionDate: "2026-12-31", approved: true},
+		{name: "development deletion date", envType: "Development", deletionDate: "2026-12-31"},
+		{name: "no environment type", deletionDate: "2026-12-31"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateDecommission(tt.envType, tt.deletionDate, tt.approved)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateDecommission() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateEmail(t *testing.T) {
+	tests := []struct {
+		name    string
+		email   string
+		wantErr bool
+	}{
+		{
+			name:    "valid email",
+			email:   "user@example.com",
+			wantErr: false,
+		},
+		{
+			name:    "empty email",
+			email:   "",
+			wantErr: false, // Optional field
+		},
+		{
+			name:    "complex valid email",
+			email:   "user.name+tag@sub.example.com",
+			wantErr: false,
+		},
+		{
+			name:    "invalid no @",
+			email:   "userexample.com",
+			wantErr: true,
+		},
+		{
+			name:    "invalid no domain",
+			email:   "user@",
+			wantErr: true,
+		},
+		{
+			name:    "invalid no user",
+			email:   "@example.com",
+			wantErr: true,
+		},
+		{
+			name:    "internationalized domain",
+			email:   "user@bücher.example",
+			wantErr: false,
+		},
+		{
+			name:    "internationalized local part",
+			email:   "josé@example.com",
+			wantErr: false,
+		},
+		{
+			name:    "long top level domain",
+			email:   "user@example.photography",
+			wantErr: false,
+		},
+		{
+			name:    "punycode domain",
+			email:   "user@xn--bcher-kva.example",
+			wantErr: false,
+		},
+		{
+			name:    "display name",
+			email:   "User <user@example.com>",
+			wantErr: true,
+		},
+		{
+			name:    "single label domain",
+			email:   "user@localhost",
+			wantErr: true,
+		},
+		{
+			name:    "consecutive dots",
+			email:   "user..name@example.com",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateEmail(tt.email)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateEmail() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateEmailStrict(t *testing.T) {
+	tests := []struct {
+		name    string
+		email   string
+		wantErr bool
+	}{
+		{name: "valid email", email: "user.name+tag@sub.example.com"},
+		{name: "empty email", email: ""},
+		{name: "internationalized domain", email: "user@bücher.example", wantErr: true},
+		{name: "internationalized local part", email: "josé@example.com", wantErr: true},
+		{name: "single letter top level domain", email: "user@example.c", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateEmailStrict(tt.email)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateEmailStrict() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNormalizeEmail(t *testing.T) {
+	tests := []struct {
+		name    string
+		email   string
+		want    string
+		wantErr bool
+	}{
+		{name: "ascii unchanged", email: "user@example.com", want: "user@example.com"},
+		{name: "domain lowercased", email: "User@Example.COM", want: "User@example.com"},
+		{name: "internationalized domain", email: "user@bücher.example", want: "user@xn--bcher-kva.example"},
+		{name: "internationalized local part kept", email: "josé@münchen.example", want: "josé@xn--mnchen-3ya.example"},
+		{name: "invalid", email: "user@", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeEmail(tt.email)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NormalizeEmail() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeEmail() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeOwners(t *testing.T) {
+	tests := []struct {
+		name   string
+		owners []string
+		want   []string
+	}{
+		{name: "nil", owners: nil, want: nil},
+		{name: "sorted", owners: []string{"b@example.com", "a@example.com"}, want: []string{"a@example.com", "b@example.com"}},
+		{
+			name:   "casing and whitespace",
+			owners: []string{" Owner@Example.com", "owner@example.com ", "OWNER@EXAMPLE.COM"},
+			want:   []string{"owner@example.com"},
+		},
+		{name: "empty entries dropped", owners: []string{"", "  ", "a@example.com"}, want: []string{"a@example.com"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeOwners(tt.owners); !slices.Equal(got, tt.want) {
+				t.Errorf("NormalizeOwners() = %v, want %v", got, tt.want)
 			}
 		})
 	}
@@ -0,0 +1,43 @@
+// Package aws publishes contexts to AWS Systems Manager Parameter Store
+// and checks S3 bucket names.
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+)
+
+// requestTimeout bounds each request to AWS, including its retries
+const requestTimeout = 10 * time.Second
+
+// ErrNotConfigured is returned when no AWS credentials or region are found
+var ErrNotConfigured = errors.New("AWS credentials and region must be configured")
+
+// loadConfig loads the AWS configuration the way the AWS CLI does: the
+// credentials from the environment, the shared config and credentials files
+// with AWS_PROFILE (including SSO and assumed roles), web identity tokens or
+// the ECS task or EC2 instance role, and the region from AWS_REGION or the
+// profile. Endpoints are overridden with AWS_ENDPOINT_URL_<SERVICE> or
+// AWS_ENDPOINT_URL. The credentials are retrieved once so that missing
+// credentials are reported before the first request.
+func loadConfig(ctx context.Context) (awssdk.Config, error) {
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return awssdk.Config{}, fmt.Errorf("%w: %w", ErrNotConfigured, err)
+	}
+	if cfg.Region == "" {
+		return awssdk.Config{}, fmt.Errorf("%w: no region is set", ErrNotConfigured)
+	}
+	if cfg.Credentials == nil {
+		return awssdk.Config{}, fmt.Errorf("%w: no credentials are set", ErrNotConfigured)
+	}
+	if _, err := cfg.Credentials.Retrieve(ctx); err != nil {
+		return awssdk.Config{}, fmt.Errorf("%w: %w", ErrNotConfigured, err)
+	}
+	return cfg, nil
+}
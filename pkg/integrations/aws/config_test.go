@@ -0,0 +1,69 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// setTestConfig configures static credentials for region, ignoring the
+// shared files, profiles and instance roles of the machine running the
+// tests, and returns the directory of the shared files
+func setTestConfig(t *testing.T, region string) string {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("AWS_CONFIG_FILE", filepath.Join(dir, "config"))
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", filepath.Join(dir, "credentials"))
+	for _, env := range []string{"AWS_PROFILE", "AWS_DEFAULT_PROFILE", "AWS_SESSION_TOKEN", "AWS_DEFAULT_REGION",
+		"AWS_WEB_IDENTITY_TOKEN_FILE", "AWS_ROLE_ARN", "AWS_CONTAINER_CREDENTIALS_RELATIVE_URI", "AWS_CONTAINER_CREDENTIALS_FULL_URI"} {
+		t.Setenv(env, "")
+	}
+	t.Setenv("AWS_EC2_METADATA_DISABLED", "true")
+	t.Setenv("AWS_MAX_ATTEMPTS", "1")
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIDEXAMPLE")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	t.Setenv("AWS_REGION", region)
+	return dir
+}
+
+func TestLoadConfig(t *testing.T) {
+	ctx := context.Background()
+	dir := setTestConfig(t, "eu-west-1")
+
+	cfg, err := loadConfig(ctx)
+	if err != nil {
+		t.Fatalf("loadConfig() error = %v", err)
+	}
+	if cfg.Region != "eu-west-1" {
+		t.Errorf("loadConfig() region = %q, want eu-west-1", cfg.Region)
+	}
+
+	t.Setenv("AWS_REGION", "")
+	if _, err := loadConfig(ctx); !errors.Is(err, ErrNotConfigured) {
+		t.Errorf("loadConfig() without a region error = %v, want ErrNotConfigured", err)
+	}
+
+	t.Setenv("AWS_REGION", "eu-west-1")
+	t.Setenv("AWS_ACCESS_KEY_ID", "")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "")
+	if _, err := loadConfig(ctx); !errors.Is(err, ErrNotConfigured) {
+		t.Errorf("loadConfig() without credentials error = %v, want ErrNotConfigured", err)
+	}
+
+	profile := "[profile payments]\nregion = ap-southeast-2\naws_access_key_id = AKIDPROFILE\naws_secret_access_key = secret\n"
+	if err := os.WriteFile(filepath.Join(dir, "config"), []byte(profile), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("AWS_REGION", "")
+	t.Setenv("AWS_PROFILE", "payments")
+	cfg, err = loadConfig(ctx)
+	if err != nil {
+		t.Fatalf("loadConfig() with a profile error = %v", err)
+	}
+	creds, err := cfg.Credentials.Retrieve(ctx)
+	if err != nil || creds.AccessKeyID != "AKIDPROFILE" || cfg.Region != "ap-southeast-2" {
+		t.Errorf("loadConfig() with a profile = %s in %q, error %v, want the profile", creds.AccessKeyID, cfg.Region, err)
+	}
+}
@@ -0,0 +1,91 @@
+package aws
+
+import (
+	stdcontext "context"
+	"errors"
+	"net/http"
+	"regexp"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/kbrockhoff/terraform-provider-context/pkg/context"
+)
+
+// s3BucketNameRegex is the naming rule of S3 buckets. Dots are allowed, but
+// only up to 63 characters.
+var s3BucketNameRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]{1,61}[a-z0-9]$`)
+
+// NewS3BucketNameCheckerFromConfig returns an S3 bucket name checker
+// configured like NewSSMParametersFromConfig, with the endpoint overridden
+// by AWS_ENDPOINT_URL_S3. Overridden endpoints are called with path-style
+// URLs, which emulators such as LocalStack expect.
+func NewS3BucketNameCheckerFromConfig(ctx stdcontext.Context) (*S3BucketNameChecker, error) {
+	cfg, err := loadConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	client := s3.NewFromConfig(cfg, func(o *s3.Options) {
+		o.UsePathStyle = o.BaseEndpoint != nil
+	})
+	return &S3BucketNameChecker{Client: client}, nil
+}
+
+// S3BucketNameChecker checks S3 bucket names with HeadBucket. The
+// credentials need no permissions beyond their own buckets.
+type S3BucketNameChecker struct {
+	Client *s3.Client
+}
+
+// Resource returns S3 bucket
+func (c *S3BucketNameChecker) Resource() string {
+	return "S3 bucket"
+}
+
+// ValidName reports whether name is a valid S3 bucket name
+func (c *S3BucketNameChecker) ValidName(name string) bool {
+	return s3BucketNameRegex.MatchString(name)
+}
+
+// CheckName checks the bucket name. A bucket of another region is checked
+// again in its region, where HeadBucket tells whether the credentials can
+// access it.
+func (c *S3BucketNameChecker) CheckName(ctx stdcontext.Context, name string) (context.NameAvailability, error) {
+	availability, region, err := c.headBucket(ctx, name, c.Client.Options().Region)
+	if err == nil && region != "" {
+		availability, _, err = c.headBucket(ctx, name, region)
+	}
+	return availability, err
+}
+
+// headBucket sends HeadBucket to region. For a bucket of another region,
+// it returns the region of the bucket as reported by S3.
+func (c *S3BucketNameChecker) headBucket(ctx stdcontext.Context, name, region string) (context.NameAvailability, string, error) {
+	ctx, cancel := stdcontext.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	_, err := c.Client.HeadBucket(ctx, &s3.HeadBucketInput{Bucket: awssdk.String(name)}, func(o *s3.Options) {
+		o.Region = region
+	})
+	if err == nil {
+		return context.NameOwned, "", nil
+	}
+
+	var respErr *awshttp.ResponseError
+	if !errors.As(err, &respErr) {
+		return "", "", err
+	}
+	switch respErr.HTTPStatusCode() {
+	case http.StatusNotFound:
+		return context.NameAvailable, "", nil
+	case http.StatusForbidden:
+		return context.NameTaken, "", nil
+	case http.StatusMovedPermanently, http.StatusBadRequest:
+		// S3 answers requests signed for the wrong region with the region
+		// of the bucket
+		if bucketRegion := respErr.Response.Header.Get("X-Amz-Bucket-Region"); bucketRegion != "" && bucketRegion != region {
+			return context.NameTaken, bucketRegion, nil
+		}
+	}
+	return "", "", err
+}
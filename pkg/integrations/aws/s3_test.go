@@ -0,0 +1,75 @@
+package aws
+
+import (
+	stdcontext "context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kbrockhoff/terraform-provider-context/pkg/context"
+)
+
+func TestS3BucketNameChecker_CheckName(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead || r.Header.Get("X-Amz-Content-Sha256") == "" ||
+			!strings.Contains(r.Header.Get("Authorization"), "SignedHeaders=") {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		signedRegion := strings.Contains(r.Header.Get("Authorization"), "/eu-west-1/s3/")
+		switch r.URL.Path {
+		case "/myorg-logs":
+			w.WriteHeader(http.StatusOK)
+		case "/logs":
+			w.WriteHeader(http.StatusForbidden)
+		case "/myorg-eu-logs":
+			if !signedRegion {
+				w.Header().Set("X-Amz-Bucket-Region", "eu-west-1")
+				w.WriteHeader(http.StatusMovedPermanently)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		case "/broken":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	setTestConfig(t, "us-east-1")
+	t.Setenv("AWS_ENDPOINT_URL_S3", server.URL)
+	checker, err := NewS3BucketNameCheckerFromConfig(stdcontext.Background())
+	if err != nil {
+		t.Fatalf("NewS3BucketNameCheckerFromConfig() error = %v", err)
+	}
+	tests := map[string]context.NameAvailability{
+		"myorg-logs":    context.NameOwned,
+		"logs":          context.NameTaken,
+		"myorg-eu-logs": context.NameOwned,
+		"myorg-new":     context.NameAvailable,
+	}
+	for name, want := range tests {
+		got, err := checker.CheckName(stdcontext.Background(), name)
+		if err != nil {
+			t.Fatalf("CheckName(%q) error = %v", name, err)
+		}
+		if got != want {
+			t.Errorf("CheckName(%q) = %q, want %q", name, got, want)
+		}
+	}
+
+	if _, err := checker.CheckName(stdcontext.Background(), "broken"); err == nil {
+		t.Error("CheckName() expected an error for a server error")
+	}
+}
+
+func TestS3BucketNameChecker_ValidName(t *testing.T) {
+	checker := &S3BucketNameChecker{}
+	for name, want := range map[string]bool{"myorg-logs": true, "myorg.logs": true, "MyOrg-Logs": false, "ab": false} {
+		if got := checker.ValidName(name); got != want {
+			t.Errorf("ValidName(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package aws
+
+import (
+	"context"
+	"errors"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/aws-sdk-go-v2/service/ssm/types"
+)
+
+// SSMParameters reads and writes String parameters of AWS Systems Manager
+// Parameter Store
+type SSMParameters struct {
+	Client *ssm.Client
+}
+
+// NewSSMParametersFromConfig returns a Parameter Store client configured
+// from the shared AWS configuration, with the endpoint overridden by
+// AWS_ENDPOINT_URL_SSM, such as for LocalStack
+func NewSSMParametersFromConfig(ctx context.Context) (*SSMParameters, error) {
+	cfg, err := loadConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &SSMParameters{Client: ssm.NewFromConfig(cfg)}, nil
+}
+
+// Put writes value to the parameter name, creating or overwriting it, and
+// returns the parameter version. The Intelligent-Tiering tier switches the
+// parameter to the advanced tier when its value exceeds the 4 KB of the
+// standard tier.
+func (s *SSMParameters) Put(ctx context.Context, name, value string) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	out, err := s.Client.PutParameter(ctx, &ssm.PutParameterInput{
+		Name:      awssdk.String(name),
+		Value:     awssdk.String(value),
+		Type:      types.ParameterTypeString,
+		Overwrite: awssdk.Bool(true),
+		Tier:      types.ParameterTierIntelligentTiering,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return out.Version, nil
+}
+
+// Get returns the value and version of the parameter name. It returns false
+// without an error when the parameter does not exist.
+func (s *SSMParameters) Get(ctx context.Context, name string) (string, int64, bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	out, err := s.Client.GetParameter(ctx, &ssm.GetParameterInput{Name: awssdk.String(name)})
+	var notFound *types.ParameterNotFound
+	if errors.As(err, &notFound) {
+		return "", 0, false, nil
+	}
+	if err != nil {
+		return "", 0, false, err
+	}
+	return awssdk.ToString(out.Parameter.Value), out.Parameter.Version, true, nil
+}
+
+// Delete removes the parameter name. A parameter that does not exist is not
+// an error.
+func (s *SSMParameters) Delete(ctx context.Context, name string) error {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	_, err := s.Client.DeleteParameter(ctx, &ssm.DeleteParameterInput{Name: awssdk.String(name)})
+	var notFound *types.ParameterNotFound
+	if errors.As(err, &notFound) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,138 @@
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeSSM serves the PutParameter, GetParameter and DeleteParameter actions
+// from memory
+func fakeSSM(t *testing.T) *httptest.Server {
+	t.Helper()
+	var mu sync.Mutex
+	type parameter struct {
+		value   string
+		version int64
+	}
+	parameters := map[string]parameter{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/") {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"__type":"UnrecognizedClientException","message":"The security token included in the request is invalid."}`))
+			return
+		}
+		var input struct {
+			Name  string
+			Value string
+		}
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		p, exists := parameters[input.Name]
+		switch r.Header.Get("X-Amz-Target") {
+		case "AmazonSSM.PutParameter":
+			p = parameter{value: input.Value, version: p.version + 1}
+			parameters[input.Name] = p
+			_ = json.NewEncoder(w).Encode(map[string]any{"Version": p.version, "Tier": "Standard"})
+		case "AmazonSSM.GetParameter":
+			if !exists {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"__type":"ParameterNotFound"}`))
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"Parameter": map[string]any{"Name": input.Name, "Value": p.value, "Version": p.version}})
+		case "AmazonSSM.DeleteParameter":
+			if !exists {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"__type":"com.amazonaws.ssm#ParameterNotFound"}`))
+				return
+			}
+			delete(parameters, input.Name)
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestSSMParameters(t *testing.T) {
+	setTestConfig(t, "us-east-1")
+	t.Setenv("AWS_ENDPOINT_URL_SSM", fakeSSM(t).URL)
+	ctx := context.Background()
+	ssm, err := NewSSMParametersFromConfig(ctx)
+	if err != nil {
+		t.Fatalf("NewSSMParametersFromConfig() error = %v", err)
+	}
+
+	if _, _, found, err := ssm.Get(ctx, "/contexts/payments"); err != nil || found {
+		t.Fatalf("Get() of a missing parameter = found %v, error %v", found, err)
+	}
+
+	for want := int64(1); want <= 2; want++ {
+		version, err := ssm.Put(ctx, "/contexts/payments", `{"namespace":"ex"}`)
+		if err != nil {
+			t.Fatalf("Put() error = %v", err)
+		}
+		if version != want {
+			t.Errorf("Put() version = %d, want %d", version, want)
+		}
+	}
+
+	value, version, found, err := ssm.Get(ctx, "/contexts/payments")
+	if err != nil || !found {
+		t.Fatalf("Get() = found %v, error %v", found, err)
+	}
+	if value != `{"namespace":"ex"}` || version != 2 {
+		t.Errorf("Get() = %q version %d, want the put value version 2", value, version)
+	}
+
+	if err := ssm.Delete(ctx, "/contexts/payments"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if err := ssm.Delete(ctx, "/contexts/payments"); err != nil {
+		t.Errorf("Delete() of a missing parameter error = %v", err)
+	}
+
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIDOTHER")
+	other, err := NewSSMParametersFromConfig(ctx)
+	if err != nil {
+		t.Fatalf("NewSSMParametersFromConfig() error = %v", err)
+	}
+	_, err = other.Put(ctx, "/contexts/payments", "{}")
+	if err == nil || !strings.Contains(err.Error(), "UnrecognizedClientException") {
+		t.Errorf("Put() error = %v, want UnrecognizedClientException", err)
+	}
+}
+
+func TestNewSSMParametersFromConfig(t *testing.T) {
+	setTestConfig(t, "eu-west-1")
+	t.Setenv("AWS_ENDPOINT_URL_SSM", "http://localhost:4566")
+
+	ssm, err := NewSSMParametersFromConfig(context.Background())
+	if err != nil {
+		t.Fatalf("NewSSMParametersFromConfig() error = %v", err)
+	}
+	options := ssm.Client.Options()
+	if options.Region != "eu-west-1" || options.BaseEndpoint == nil || *options.BaseEndpoint != "http://localhost:4566" {
+		t.Errorf("NewSSMParametersFromConfig() region %q, endpoint %v, want eu-west-1 and the AWS_ENDPOINT_URL_SSM endpoint", options.Region, options.BaseEndpoint)
+	}
+
+	t.Setenv("AWS_ACCESS_KEY_ID", "")
+	if _, err := NewSSMParametersFromConfig(context.Background()); !errors.Is(err, ErrNotConfigured) {
+		t.Errorf("NewSSMParametersFromConfig() error = %v, want ErrNotConfigured", err)
+	}
+}
@@ -0,0 +1,154 @@
+// Package azure checks names of Azure resources through Azure Resource
+// Manager.
+package azure
+
+import (
+	"bytes"
+	stdcontext "context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/kbrockhoff/terraform-provider-context/pkg/context"
+)
+
+// requestTimeout bounds each request to Azure Resource Manager
+const requestTimeout = 10 * time.Second
+
+// Environment variables holding the Azure credentials, as used by the
+// azurerm Terraform provider. The token can be obtained with
+// az account get-access-token.
+const (
+	AccessTokenEnvVar    = "ARM_ACCESS_TOKEN"
+	SubscriptionIDEnvVar = "ARM_SUBSCRIPTION_ID"
+)
+
+// ErrNotConfigured is returned by NewStorageAccountNameCheckerFromEnv when
+// ARM_ACCESS_TOKEN or ARM_SUBSCRIPTION_ID is not set
+var ErrNotConfigured = errors.New(AccessTokenEnvVar + " and " + SubscriptionIDEnvVar + " must be set")
+
+// storageAccountNameRegex is the naming rule of storage accounts
+var storageAccountNameRegex = regexp.MustCompile(`^[a-z0-9]{3,24}$`)
+
+// NewStorageAccountNameCheckerFromEnv returns a storage account name checker
+// configured from ARM_ACCESS_TOKEN and ARM_SUBSCRIPTION_ID
+func NewStorageAccountNameCheckerFromEnv() (*StorageAccountNameChecker, error) {
+	checker := &StorageAccountNameChecker{
+		Token:          os.Getenv(AccessTokenEnvVar),
+		SubscriptionID: os.Getenv(SubscriptionIDEnvVar),
+	}
+	if checker.Token == "" || checker.SubscriptionID == "" {
+		return nil, ErrNotConfigured
+	}
+	return checker, nil
+}
+
+// StorageAccountNameChecker checks storage account names with the
+// checkNameAvailability API of Azure Resource Manager. A taken name is owned
+// when it is a storage account of the subscription. The token needs the
+// Microsoft.Storage/storageAccounts/read permission.
+type StorageAccountNameChecker struct {
+	Token          string
+	SubscriptionID string
+	// BaseURL defaults to https://management.azure.com
+	BaseURL string
+}
+
+// storageAPIVersion is the Microsoft.Storage API version used
+const storageAPIVersion = "2023-05-01"
+
+// Resource returns Azure storage account
+func (c *StorageAccountNameChecker) Resource() string {
+	return "Azure storage account"
+}
+
+// ValidName reports whether name is a valid storage account name: 3 to 24
+// lowercase letters and digits
+func (c *StorageAccountNameChecker) ValidName(name string) bool {
+	return storageAccountNameRegex.MatchString(name)
+}
+
+// CheckName checks the storage account name. Names that are not valid
+// storage account names are an error.
+func (c *StorageAccountNameChecker) CheckName(ctx stdcontext.Context, name string) (context.NameAvailability, error) {
+	base := strings.TrimSuffix(c.BaseURL, "/")
+	if base == "" {
+		base = "https://management.azure.com"
+	}
+	subscription := base + "/subscriptions/" + url.PathEscape(c.SubscriptionID) + "/providers/Microsoft.Storage"
+
+	body, err := json.Marshal(map[string]string{"name": name, "type": "Microsoft.Storage/storageAccounts"})
+	if err != nil {
+		return "", err
+	}
+	var result struct {
+		NameAvailable bool   `json:"nameAvailable"`
+		Reason        string `json:"reason"`
+		Message       string `json:"message"`
+	}
+	if err := c.do(ctx, http.MethodPost, subscription+"/checkNameAvailability?api-version="+storageAPIVersion, body, &result); err != nil {
+		return "", err
+	}
+	if result.NameAvailable {
+		return context.NameAvailable, nil
+	}
+	if result.Reason != "AlreadyExists" {
+		return "", fmt.Errorf("storage account name %s: %s", name, result.Message)
+	}
+
+	// The list is paged through nextLink
+	next := subscription + "/storageAccounts?api-version=" + storageAPIVersion
+	for next != "" {
+		var page struct {
+			Value []struct {
+				Name string `json:"name"`
+			} `json:"value"`
+			NextLink string `json:"nextLink"`
+		}
+		if err := c.do(ctx, http.MethodGet, next, nil, &page); err != nil {
+			return "", err
+		}
+		for _, account := range page.Value {
+			if account.Name == name {
+				return context.NameOwned, nil
+			}
+		}
+		next = page.NextLink
+	}
+	return context.NameTaken, nil
+}
+
+// do sends an authenticated request and decodes the response into result
+func (c *StorageAccountNameChecker) do(ctx stdcontext.Context, method, requestURL string, body []byte, result any) error {
+	ctx, cancel := stdcontext.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, method, requestURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.Token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s %s: %s", method, req.URL.Path, resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("decoding %s response: %w", req.URL.Path, err)
+	}
+	return nil
+}
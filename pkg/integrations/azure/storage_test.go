@@ -0,0 +1,78 @@
+package azure
+
+import (
+	stdcontext "context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kbrockhoff/terraform-provider-context/pkg/context"
+)
+
+func TestStorageAccountNameChecker_CheckName(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" || r.URL.Query().Get("api-version") == "" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/subscriptions/sub-1/providers/Microsoft.Storage/checkNameAvailability":
+			var input struct{ Name, Type string }
+			_ = json.NewDecoder(r.Body).Decode(&input)
+			switch {
+			case input.Type != "Microsoft.Storage/storageAccounts":
+				w.WriteHeader(http.StatusBadRequest)
+			case input.Name == "myorglogs" || input.Name == "logs":
+				_, _ = w.Write([]byte(`{"nameAvailable":false,"reason":"AlreadyExists","message":"The storage account named ` + input.Name + ` is already taken."}`))
+			case input.Name == "my-logs":
+				_, _ = w.Write([]byte(`{"nameAvailable":false,"reason":"AccountNameInvalid","message":"my-logs is not a valid storage account name."}`))
+			default:
+				_, _ = w.Write([]byte(`{"nameAvailable":true}`))
+			}
+		case "/subscriptions/sub-1/providers/Microsoft.Storage/storageAccounts":
+			if r.URL.Query().Get("page") == "" {
+				_, _ = w.Write([]byte(`{"value":[{"name":"myorgdata"}],"nextLink":"http://` + r.Host + r.URL.Path + `?api-version=2023-05-01&page=2"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"value":[{"name":"myorglogs"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	checker := &StorageAccountNameChecker{Token: "token", SubscriptionID: "sub-1", BaseURL: server.URL}
+	tests := map[string]context.NameAvailability{
+		"myorglogs": context.NameOwned,
+		"logs":      context.NameTaken,
+		"myorgnew":  context.NameAvailable,
+	}
+	for name, want := range tests {
+		got, err := checker.CheckName(stdcontext.Background(), name)
+		if err != nil {
+			t.Fatalf("CheckName(%q) error = %v", name, err)
+		}
+		if got != want {
+			t.Errorf("CheckName(%q) = %q, want %q", name, got, want)
+		}
+	}
+
+	if _, err := checker.CheckName(stdcontext.Background(), "my-logs"); err == nil || !strings.Contains(err.Error(), "not a valid storage account name") {
+		t.Errorf("CheckName() error = %v, want the invalid name message", err)
+	}
+	checker.Token = "expired"
+	if _, err := checker.CheckName(stdcontext.Background(), "myorgnew"); err == nil {
+		t.Error("CheckName() expected an error for a rejected token")
+	}
+}
+
+func TestStorageAccountNameChecker_ValidName(t *testing.T) {
+	checker := &StorageAccountNameChecker{}
+	for name, want := range map[string]bool{"myorglogs": true, "myorg-logs": false, "MyOrgLogs": false, "myorglogsforthewholeorganization": false} {
+		if got := checker.ValidName(name); got != want {
+			t.Errorf("ValidName(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
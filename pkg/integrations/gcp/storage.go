@@ -0,0 +1,95 @@
+// Package gcp checks names of Google Cloud resources.
+package gcp
+
+import (
+	stdcontext "context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/kbrockhoff/terraform-provider-context/pkg/context"
+)
+
+// requestTimeout bounds each request to Cloud Storage
+const requestTimeout = 10 * time.Second
+
+// AccessTokenEnvVar holds the Google Cloud access token, as used by the
+// google Terraform provider. The token can be obtained with
+// gcloud auth print-access-token.
+const AccessTokenEnvVar = "GOOGLE_OAUTH_ACCESS_TOKEN"
+
+// ErrNotConfigured is returned by NewBucketNameCheckerFromEnv when
+// GOOGLE_OAUTH_ACCESS_TOKEN is not set
+var ErrNotConfigured = errors.New(AccessTokenEnvVar + " must be set")
+
+// bucketNameRegex is the naming rule of Cloud Storage buckets. Dots are
+// allowed, but only up to 63 characters.
+var bucketNameRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{1,61}[a-z0-9]$`)
+
+// NewBucketNameCheckerFromEnv returns a Cloud Storage bucket name checker
+// configured from GOOGLE_OAUTH_ACCESS_TOKEN
+func NewBucketNameCheckerFromEnv() (*BucketNameChecker, error) {
+	checker := &BucketNameChecker{Token: os.Getenv(AccessTokenEnvVar)}
+	if checker.Token == "" {
+		return nil, ErrNotConfigured
+	}
+	return checker, nil
+}
+
+// BucketNameChecker checks Cloud Storage bucket names, which unlike most
+// Google Cloud resource names are global rather than project-scoped. The
+// token needs the storage.buckets.get permission on the project's buckets.
+type BucketNameChecker struct {
+	Token string
+	// BaseURL defaults to https://storage.googleapis.com
+	BaseURL string
+}
+
+// Resource returns Cloud Storage bucket
+func (c *BucketNameChecker) Resource() string {
+	return "Cloud Storage bucket"
+}
+
+// ValidName reports whether name is a valid Cloud Storage bucket name
+func (c *BucketNameChecker) ValidName(name string) bool {
+	return bucketNameRegex.MatchString(name)
+}
+
+// CheckName checks the bucket name
+func (c *BucketNameChecker) CheckName(ctx stdcontext.Context, name string) (context.NameAvailability, error) {
+	base := strings.TrimSuffix(c.BaseURL, "/")
+	if base == "" {
+		base = "https://storage.googleapis.com"
+	}
+
+	ctx, cancel := stdcontext.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, base+"/storage/v1/b/"+url.PathEscape(name)+"?fields=name", nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.Token)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return context.NameOwned, nil
+	case http.StatusNotFound:
+		return context.NameAvailable, nil
+	case http.StatusForbidden:
+		return context.NameTaken, nil
+	default:
+		return "", fmt.Errorf("fetching bucket %s: %s", name, resp.Status)
+	}
+}
@@ -0,0 +1,58 @@
+package gcp
+
+import (
+	stdcontext "context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kbrockhoff/terraform-provider-context/pkg/context"
+)
+
+func TestBucketNameChecker_CheckName(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/storage/v1/b/myorg-logs":
+			_, _ = w.Write([]byte(`{"name":"myorg-logs"}`))
+		case "/storage/v1/b/logs":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	checker := &BucketNameChecker{Token: "token", BaseURL: server.URL}
+	tests := map[string]context.NameAvailability{
+		"myorg-logs": context.NameOwned,
+		"logs":       context.NameTaken,
+		"myorg-new":  context.NameAvailable,
+	}
+	for name, want := range tests {
+		got, err := checker.CheckName(stdcontext.Background(), name)
+		if err != nil {
+			t.Fatalf("CheckName(%q) error = %v", name, err)
+		}
+		if got != want {
+			t.Errorf("CheckName(%q) = %q, want %q", name, got, want)
+		}
+	}
+
+	checker.Token = "expired"
+	if _, err := checker.CheckName(stdcontext.Background(), "myorg-new"); err == nil {
+		t.Error("CheckName() expected an error for a rejected token")
+	}
+}
+
+func TestBucketNameChecker_ValidName(t *testing.T) {
+	checker := &BucketNameChecker{}
+	for name, want := range map[string]bool{"myorg_logs": true, "myorg-logs": true, "-myorg": false, "MyOrg": false} {
+		if got := checker.ValidName(name); got != want {
+			t.Errorf("ValidName(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
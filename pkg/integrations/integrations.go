@@ -0,0 +1,39 @@
+// Package integrations holds the clients of the services the provider reads
+// from and publishes to, kept out of pkg/context so that the core library
+// does not depend on the cloud SDKs. The subpackages hold one cloud or
+// service each.
+package integrations
+
+import (
+	stdcontext "context"
+	"fmt"
+
+	"github.com/kbrockhoff/terraform-provider-context/pkg/context"
+	"github.com/kbrockhoff/terraform-provider-context/pkg/integrations/aws"
+	"github.com/kbrockhoff/terraform-provider-context/pkg/integrations/azure"
+	"github.com/kbrockhoff/terraform-provider-context/pkg/integrations/gcp"
+)
+
+// NewNameChecker returns the name checker of cloudProvider: S3 buckets with
+// the shared AWS configuration for aws, storage accounts with
+// ARM_ACCESS_TOKEN and ARM_SUBSCRIPTION_ID for az, and Cloud Storage buckets
+// with GOOGLE_OAUTH_ACCESS_TOKEN for gcp. It returns an error wrapping
+// context.ErrNameCheckNotConfigured when no credentials are found.
+func NewNameChecker(ctx stdcontext.Context, cloudProvider string) (context.NameChecker, error) {
+	var checker context.NameChecker
+	var err error
+	switch cloudProvider {
+	case "aws":
+		checker, err = aws.NewS3BucketNameCheckerFromConfig(ctx)
+	case "az":
+		checker, err = azure.NewStorageAccountNameCheckerFromEnv()
+	case "gcp":
+		checker, err = gcp.NewBucketNameCheckerFromEnv()
+	default:
+		return nil, fmt.Errorf("name availability checks are not supported for cloud provider '%s', must be one of: aws, az, gcp", cloudProvider)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", context.ErrNameCheckNotConfigured, err)
+	}
+	return checker, nil
+}
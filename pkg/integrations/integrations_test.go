@@ -0,0 +1,51 @@
+package integrations
+
+import (
+	stdcontext "context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/kbrockhoff/terraform-provider-context/pkg/context"
+	"github.com/kbrockhoff/terraform-provider-context/pkg/integrations/azure"
+	"github.com/kbrockhoff/terraform-provider-context/pkg/integrations/gcp"
+)
+
+func TestNewNameChecker(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("AWS_CONFIG_FILE", filepath.Join(dir, "config"))
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", filepath.Join(dir, "credentials"))
+	t.Setenv("AWS_EC2_METADATA_DISABLED", "true")
+	for _, env := range []string{"AWS_ACCESS_KEY_ID", "AWS_SECRET_ACCESS_KEY", "AWS_SESSION_TOKEN", "AWS_REGION", "AWS_DEFAULT_REGION",
+		"AWS_PROFILE", "AWS_WEB_IDENTITY_TOKEN_FILE", "AWS_CONTAINER_CREDENTIALS_RELATIVE_URI", "AWS_CONTAINER_CREDENTIALS_FULL_URI",
+		azure.AccessTokenEnvVar, azure.SubscriptionIDEnvVar, gcp.AccessTokenEnvVar} {
+		t.Setenv(env, "")
+	}
+	ctx := stdcontext.Background()
+
+	for _, provider := range []string{"aws", "az", "gcp"} {
+		if _, err := NewNameChecker(ctx, provider); !errors.Is(err, context.ErrNameCheckNotConfigured) {
+			t.Errorf("NewNameChecker(%q) error = %v, want context.ErrNameCheckNotConfigured", provider, err)
+		}
+	}
+	if _, err := NewNameChecker(ctx, "oci"); err == nil || errors.Is(err, context.ErrNameCheckNotConfigured) {
+		t.Errorf("NewNameChecker(oci) error = %v, want an unsupported provider error", err)
+	}
+
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIDEXAMPLE")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	t.Setenv("AWS_REGION", "us-west-2")
+	t.Setenv(azure.AccessTokenEnvVar, "token")
+	t.Setenv(azure.SubscriptionIDEnvVar, "sub-1")
+	t.Setenv(gcp.AccessTokenEnvVar, "token")
+	want := map[string]string{"aws": "S3 bucket", "az": "Azure storage account", "gcp": "Cloud Storage bucket"}
+	for provider, resource := range want {
+		checker, err := NewNameChecker(ctx, provider)
+		if err != nil {
+			t.Fatalf("NewNameChecker(%q) error = %v", provider, err)
+		}
+		if checker.Resource() != resource {
+			t.Errorf("NewNameChecker(%q).Resource() = %q, want %q", provider, checker.Resource(), resource)
+		}
+	}
+}
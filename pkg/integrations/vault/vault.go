@@ -0,0 +1,154 @@
+// Package vault publishes contexts to HashiCorp Vault.
+package vault
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// requestTimeout bounds each request to Vault
+const requestTimeout = 10 * time.Second
+
+// Environment variables configuring the Vault client, as used by the vault
+// CLI and the Vault Terraform provider
+const (
+	AddrEnvVar      = "VAULT_ADDR"
+	TokenEnvVar     = "VAULT_TOKEN"
+	NamespaceEnvVar = "VAULT_NAMESPACE"
+)
+
+// DefaultKVMount is the mount path of the KV version 2 secrets engine
+// enabled by Vault dev servers
+const DefaultKVMount = "secret"
+
+// ErrNotConfigured is returned by NewKVFromEnv when VAULT_ADDR or
+// VAULT_TOKEN is not set
+var ErrNotConfigured = errors.New("VAULT_ADDR and VAULT_TOKEN must be set")
+
+// KV reads and writes secrets of a Vault KV version 2 secrets engine
+// through the HTTP API
+type KV struct {
+	Address   string
+	Token     string
+	Namespace string
+	Mount     string
+}
+
+// NewKVFromEnv returns a client of the KV version 2 engine at mount,
+// configured from VAULT_ADDR, VAULT_TOKEN and VAULT_NAMESPACE
+func NewKVFromEnv(mount string) (*KV, error) {
+	kv := &KV{
+		Address:   os.Getenv(AddrEnvVar),
+		Token:     os.Getenv(TokenEnvVar),
+		Namespace: os.Getenv(NamespaceEnvVar),
+		Mount:     mount,
+	}
+	if kv.Address == "" || kv.Token == "" {
+		return nil, ErrNotConfigured
+	}
+	return kv, nil
+}
+
+// Put writes data as a new version of the secret at path and returns the
+// version number
+func (kv *KV) Put(ctx context.Context, path string, data map[string]any) (int64, error) {
+	body, err := json.Marshal(map[string]any{"data": data})
+	if err != nil {
+		return 0, err
+	}
+
+	var result struct {
+		Data struct {
+			Version int64 `json:"version"`
+		} `json:"data"`
+	}
+	if _, err := kv.do(ctx, http.MethodPost, "data", path, body, &result); err != nil {
+		return 0, err
+	}
+	return result.Data.Version, nil
+}
+
+// Get returns the current version of the secret at path and its number. It
+// returns false without an error when the secret does not exist or its
+// current version is deleted.
+func (kv *KV) Get(ctx context.Context, path string) (map[string]any, int64, bool, error) {
+	var result struct {
+		Data struct {
+			Data     map[string]any `json:"data"`
+			Metadata struct {
+				Version int64 `json:"version"`
+			} `json:"metadata"`
+		} `json:"data"`
+	}
+	found, err := kv.do(ctx, http.MethodGet, "data", path, nil, &result)
+	if err != nil || !found || result.Data.Data == nil {
+		return nil, 0, false, err
+	}
+	return result.Data.Data, result.Data.Metadata.Version, true, nil
+}
+
+// Delete removes the secret at path with all its versions
+func (kv *KV) Delete(ctx context.Context, path string) error {
+	_, err := kv.do(ctx, http.MethodDelete, "metadata", path, nil, nil)
+	return err
+}
+
+// do sends a request to the endpoint (data or metadata) of path and decodes
+// the response into result. It returns false without an error for a 404
+// response.
+func (kv *KV) do(ctx context.Context, method, endpoint, path string, body []byte, result any) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	requestURL, err := url.JoinPath(kv.Address, "v1", strings.Trim(kv.Mount, "/"), endpoint, strings.Trim(path, "/"))
+	if err != nil {
+		return false, err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, requestURL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("X-Vault-Token", kv.Token)
+	if kv.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", kv.Namespace)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var vaultErr struct {
+			Errors []string `json:"errors"`
+		}
+		if json.NewDecoder(resp.Body).Decode(&vaultErr) == nil && len(vaultErr.Errors) > 0 {
+			return false, fmt.Errorf("%s %s: %s: %s", method, req.URL.Path, resp.Status, strings.Join(vaultErr.Errors, "; "))
+		}
+		return false, fmt.Errorf("%s %s: %s", method, req.URL.Path, resp.Status)
+	}
+
+	if result == nil || resp.StatusCode == http.StatusNoContent {
+		return true, nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil && !errors.Is(err, io.EOF) {
+		return false, fmt.Errorf("decoding Vault response: %w", err)
+	}
+	return true, nil
+}
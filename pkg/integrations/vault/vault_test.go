@@ -0,0 +1,122 @@
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeVaultKV serves the KV version 2 data and metadata endpoints of the
+// mount "secret" from memory
+func fakeVaultKV(t *testing.T) *httptest.Server {
+	t.Helper()
+	var mu sync.Mutex
+	secrets := map[string][]map[string]any{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if r.Header.Get("X-Vault-Token") != "test-token" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+
+		dataPath, isData := strings.CutPrefix(r.URL.Path, "/v1/secret/data/")
+		metadataPath, isMetadata := strings.CutPrefix(r.URL.Path, "/v1/secret/metadata/")
+		switch {
+		case isData && r.Method == http.MethodPost:
+			var body struct {
+				Data map[string]any `json:"data"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			secrets[dataPath] = append(secrets[dataPath], body.Data)
+			_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"version": len(secrets[dataPath])}})
+		case isData && r.Method == http.MethodGet && len(secrets[dataPath]) > 0:
+			versions := secrets[dataPath]
+			_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{
+				"data":     versions[len(versions)-1],
+				"metadata": map[string]any{"version": len(versions)},
+			}})
+		case isMetadata && r.Method == http.MethodDelete:
+			delete(secrets, metadataPath)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errors":[]}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestKV(t *testing.T) {
+	server := fakeVaultKV(t)
+	kv := &KV{Address: server.URL, Token: "test-token", Mount: DefaultKVMount}
+	ctx := context.Background()
+
+	if _, _, found, err := kv.Get(ctx, "contexts/payments"); err != nil || found {
+		t.Fatalf("Get() of a missing secret = found %v, error %v", found, err)
+	}
+
+	data := map[string]any{"namespace": "ex", "enabled": true, "product_owners": []any{"a@example.com"}}
+	for want := int64(1); want <= 2; want++ {
+		version, err := kv.Put(ctx, "contexts/payments", data)
+		if err != nil {
+			t.Fatalf("Put() error = %v", err)
+		}
+		if version != want {
+			t.Errorf("Put() version = %d, want %d", version, want)
+		}
+	}
+
+	got, version, found, err := kv.Get(ctx, "/contexts/payments/")
+	if err != nil || !found {
+		t.Fatalf("Get() = found %v, error %v", found, err)
+	}
+	if version != 2 || !reflect.DeepEqual(got, data) {
+		t.Errorf("Get() = %v version %d, want %v version 2", got, version, data)
+	}
+
+	if err := kv.Delete(ctx, "contexts/payments"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, _, found, err := kv.Get(ctx, "contexts/payments"); err != nil || found {
+		t.Errorf("Get() after Delete() = found %v, error %v", found, err)
+	}
+
+	denied := &KV{Address: server.URL, Token: "wrong", Mount: DefaultKVMount}
+	if _, err := denied.Put(ctx, "contexts/payments", data); err == nil {
+		t.Error("Put() error = nil, want permission denied")
+	}
+}
+
+func TestNewKVFromEnv(t *testing.T) {
+	t.Setenv(AddrEnvVar, "")
+	t.Setenv(TokenEnvVar, "")
+	if _, err := NewKVFromEnv(DefaultKVMount); !errors.Is(err, ErrNotConfigured) {
+		t.Errorf("NewKVFromEnv() error = %v, want ErrNotConfigured", err)
+	}
+
+	t.Setenv(AddrEnvVar, "https://vault.example.com:8200")
+	t.Setenv(TokenEnvVar, "test-token")
+	t.Setenv(NamespaceEnvVar, "admin")
+	kv, err := NewKVFromEnv("kv")
+	if err != nil {
+		t.Fatalf("NewKVFromEnv() error = %v", err)
+	}
+	want := &KV{Address: "https://vault.example.com:8200", Token: "test-token", Namespace: "admin", Mount: "kv"}
+	if !reflect.DeepEqual(kv, want) {
+		t.Errorf("NewKVFromEnv() = %+v, want %+v", kv, want)
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Command gentagsupport generates the resource tag support table of
+// pkg/context from its CSV source.
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+func main() {
+	in := flag.String("in", "tagsupport.csv", "CSV source of the table")
+	out := flag.String("out", "tagsupport_gen.go", "generated Go file")
+	flag.Parse()
+
+	f, err := os.Open(*in)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+
+	records, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by gentagsupport from %s; DO NOT EDIT.\n\n", filepath.Base(*in))
+	fmt.Fprintln(&buf, "package context")
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "var tagSupportTable = map[string]TagSupport{")
+
+	seen := map[string]bool{}
+	for i, record := range records[1:] {
+		if len(record) != 4 {
+			log.Fatalf("line %d: want 4 fields, got %d", i+2, len(record))
+		}
+		resourceType, attribute, notes := record[0], record[1], record[3]
+		if seen[resourceType] {
+			log.Fatalf("line %d: duplicate resource type %s", i+2, resourceType)
+		}
+		seen[resourceType] = true
+
+		maxTags, err := strconv.Atoi(record[2])
+		if err != nil {
+			log.Fatalf("line %d: invalid max_tags: %v", i+2, err)
+		}
+
+		fmt.Fprintf(&buf, "\t%q: {Supported: %t, Attribute: %q, MaxTags: %d, Notes: %q},\n",
+			resourceType, attribute != "", attribute, maxTags, notes)
+	}
+	fmt.Fprintln(&buf, "}")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -13,10 +13,20 @@ import (
 // Generate copyright headers
 //go:generate go run github.com/hashicorp/copywrite headers -d .. --config ../.copywrite.hcl
 
+// Generate the resource tag support table.
+//go:generate go run ./gentagsupport -in ../pkg/context/tagsupport.csv -out ../pkg/context/tagsupport_gen.go
+
+// Generate the tag mapping and sanitization sections of the context data source documentation.
+//go:generate go -C .. run ./internal/gentagdocs templates/data-sources/context.md.tmpl docs/data-sources/context.md
+
+// Generate the JSON Schema of context documents.
+//go:generate go -C .. run ./internal/gencontextschema pkg/context/context.schema.json
+
 // Format Terraform code for use in documentation.
 // If you do not have Terraform installed, you can remove the formatting command, but it is suggested
 // to ensure the documentation is formatted properly.
 //go:generate terraform fmt -recursive ../examples/
 
-// Generate documentation.
-//go:generate go run github.com/hashicorp/terraform-plugin-docs/cmd/tfplugindocs generate --provider-dir .. -provider-name=brockhoff
+// Generate documentation, building the provider with the integrations tag so
+// the publish resources are documented.
+//go:generate env GOFLAGS=-tags=integrations go run github.com/hashicorp/terraform-plugin-docs/cmd/tfplugindocs generate --provider-dir .. -provider-name=brockhoff